@@ -0,0 +1,372 @@
+// Package cheatsheet 提供一个按键速查表组件。它以全屏、多列、可滚动的覆盖层
+// 显示 KeyMap 中的所有按键绑定，每组绑定一个带标题的分区，并可以按按键或说明搜索。
+// 与静态的完整帮助视图不同，它根据窗口大小决定列数，放不下时可以滚动。
+package cheatsheet
+
+import (
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/help"
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/textinput"
+	"github.com/purpose168/bubbles-cn/viewport"
+	tea "github.com/purpose168/bubbletea-cn"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// 分区之间的间距。
+const (
+	columnGap  = 4
+	sectionGap = 1
+)
+
+// KeyMap 是速查表的按键绑定。滚动使用视口的按键绑定。
+type KeyMap struct {
+	Toggle       key.Binding // 显示或隐藏速查表
+	Close        key.Binding // 隐藏速查表
+	Search       key.Binding // 开始输入搜索词
+	AcceptSearch key.Binding // 结束输入，保留搜索结果
+	ClearSearch  key.Binding // 结束输入并清除搜索词
+}
+
+// ShortHelp 实现 help.KeyMap 接口。
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Toggle, k.Search, k.Close}
+}
+
+// FullHelp 实现 help.KeyMap 接口。
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp(), {k.AcceptSearch, k.ClearSearch}}
+}
+
+// DefaultKeyMap 返回一组默认的按键绑定。
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Toggle: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "快捷键"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "关闭"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "搜索"),
+		),
+		AcceptSearch: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "确认搜索"),
+		),
+		ClearSearch: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "取消搜索"),
+		),
+	}
+}
+
+// Styles 包含速查表的样式。
+type Styles struct {
+	Title        lipgloss.Style // 顶部的标题
+	SectionTitle lipgloss.Style // 分区的标题
+	Key          lipgloss.Style // 绑定的按键
+	Desc         lipgloss.Style // 绑定的说明
+	NoMatches    lipgloss.Style // 没有匹配搜索词的绑定时显示的文本
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	return Styles{
+		Title:        lipgloss.NewStyle().Bold(true),
+		SectionTitle: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")),
+		Key: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
+			Light: "#909090",
+			Dark:  "#626262",
+		}),
+		Desc: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
+			Light: "#B2B2B2",
+			Dark:  "#4A4A4A",
+		}),
+		NoMatches: lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+	}
+}
+
+// Model 是速查表组件的 Bubble Tea 模型。隐藏时 View 返回空字符串；
+// 显示时它占满整个窗口，应用程序应显示它而不是自己的视图（参见 Visible）。
+type Model struct {
+	KeyMap KeyMap
+	Styles Styles
+
+	// Title 显示在速查表的顶部。
+	Title string
+
+	// Titles 是每个分区的标题，顺序与 FullHelp 返回的绑定组一致。
+	// 如果为空并且 KeyMap 实现了 help.TitledKeyMap，则使用它返回的标题。
+	Titles []string
+
+	// Viewport 显示分区并处理滚动。
+	Viewport viewport.Model
+
+	keyMap    help.KeyMap
+	input     textinput.Model
+	visible   bool
+	searching bool
+	width     int
+	height    int
+}
+
+// New 返回显示 k 的绑定的速查表。
+func New(k help.KeyMap) Model {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "搜索"
+
+	return Model{
+		KeyMap:   DefaultKeyMap(),
+		Styles:   DefaultStyles(),
+		Title:    "快捷键",
+		Viewport: viewport.New(0, 0),
+		keyMap:   k,
+		input:    input,
+	}
+}
+
+// SetKeyMap 设置速查表显示的绑定。
+func (m *Model) SetKeyMap(k help.KeyMap) {
+	m.keyMap = k
+	m.refresh()
+}
+
+// Visible 返回速查表是否正在显示。
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// Show 显示速查表，并滚动到顶部。
+func (m *Model) Show() {
+	m.visible = true
+	m.refresh()
+	m.Viewport.GotoTop()
+}
+
+// Hide 隐藏速查表，并清除搜索词。
+func (m *Model) Hide() {
+	m.visible = false
+	m.searching = false
+	m.input.Reset()
+	m.input.Blur()
+}
+
+// Query 返回当前的搜索词。
+func (m Model) Query() string {
+	return m.input.Value()
+}
+
+// SetQuery 设置搜索词，只显示按键或说明包含它的绑定（不区分大小写）。
+func (m *Model) SetQuery(q string) {
+	m.input.SetValue(q)
+	m.refresh()
+	m.Viewport.GotoTop()
+}
+
+// Searching 返回是否正在输入搜索词。此时所有按键都由搜索框处理。
+func (m Model) Searching() bool {
+	return m.searching
+}
+
+// SetSize 设置速查表的大小，通常是整个窗口的大小。
+func (m *Model) SetSize(width, height int) {
+	m.width, m.height = width, height
+	m.Viewport.Width = width
+	m.Viewport.Height = max(0, height-lipgloss.Height(m.headerView())-sectionGap)
+	m.input.Width = max(0, width-lipgloss.Width(m.Styles.Title.Render(m.Title))-columnGap)
+	m.refresh()
+}
+
+// Init 满足 tea.Model 接口。
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update 处理窗口大小变化和按键。隐藏时只处理 Toggle 键；
+// 显示时处理所有按键，应用程序不应再处理它们。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		if !m.visible {
+			if key.Matches(msg, m.KeyMap.Toggle) {
+				m.Show()
+			}
+			return m, nil
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+
+		switch {
+		case key.Matches(msg, m.KeyMap.Toggle, m.KeyMap.Close):
+			m.Hide()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Search):
+			m.searching = true
+			return m, m.input.Focus()
+		}
+	}
+
+	if !m.visible {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.Viewport, cmd = m.Viewport.Update(msg)
+	return m, cmd
+}
+
+// updateSearch 处理输入搜索词时的按键。
+func (m Model) updateSearch(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.KeyMap.AcceptSearch):
+		m.searching = false
+		m.input.Blur()
+		return m, nil
+	case key.Matches(msg, m.KeyMap.ClearSearch):
+		m.searching = false
+		m.input.Blur()
+		m.SetQuery("")
+		return m, nil
+	}
+
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.refresh()
+		m.Viewport.GotoTop()
+	}
+	return m, cmd
+}
+
+// View 渲染速查表。隐藏时返回空字符串。
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+	view := m.headerView() + strings.Repeat("\n", sectionGap+1) + m.Viewport.View()
+	if m.width > 0 && m.height > 0 {
+		view = lipgloss.NewStyle().MaxWidth(m.width).MaxHeight(m.height).Render(view)
+	}
+	return view
+}
+
+// headerView 渲染标题和搜索框。
+func (m Model) headerView() string {
+	header := m.Styles.Title.Render(m.Title)
+	if m.searching || m.input.Value() != "" {
+		header += strings.Repeat(" ", columnGap) + m.input.View()
+	}
+	return header
+}
+
+// refresh 重新渲染视口中的分区。
+func (m *Model) refresh() {
+	if !m.visible {
+		return
+	}
+	m.Viewport.SetContent(m.sectionsView())
+}
+
+// sectionsView 将分区排列成适合宽度的列：所有列宽度相同，每个分区依次放在
+// 当前最短的列中。
+func (m Model) sectionsView() string {
+	var groups [][]key.Binding
+	if m.keyMap != nil {
+		groups = m.keyMap.FullHelp()
+	}
+	var sections []string
+	for i, group := range groups {
+		if s := m.sectionView(m.title(i), group); s != "" {
+			sections = append(sections, s)
+		}
+	}
+	if len(sections) == 0 {
+		return m.Styles.NoMatches.Render("没有匹配的快捷键")
+	}
+
+	colWidth := 0
+	for _, s := range sections {
+		colWidth = max(colWidth, lipgloss.Width(s))
+	}
+	n := 1
+	if m.width > 0 {
+		n = max(1, (m.width+columnGap)/(colWidth+columnGap))
+	}
+	n = min(n, len(sections))
+
+	columns := make([][]string, n)
+	heights := make([]int, n)
+	for _, s := range sections {
+		shortest := 0
+		for i, h := range heights {
+			if h < heights[shortest] {
+				shortest = i
+			}
+		}
+		columns[shortest] = append(columns[shortest], s)
+		heights[shortest] += lipgloss.Height(s) + sectionGap
+	}
+
+	col := lipgloss.NewStyle().Width(colWidth)
+	var rendered []string
+	for i, c := range columns {
+		if i > 0 {
+			rendered = append(rendered, strings.Repeat(" ", columnGap))
+		}
+		rendered = append(rendered, col.Render(strings.Join(c, strings.Repeat("\n", sectionGap+1))))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+// sectionView 渲染一个分区中启用并且匹配搜索词的绑定。如果没有这样的绑定，返回空字符串。
+func (m Model) sectionView(title string, group []key.Binding) string {
+	query := strings.ToLower(m.input.Value())
+	var keys, descs []string
+	for _, b := range group {
+		if !b.Enabled() {
+			continue
+		}
+		h := b.Help()
+		if query != "" && !strings.Contains(strings.ToLower(h.Key+" "+h.Desc), query) {
+			continue
+		}
+		keys = append(keys, m.Styles.Key.Render(h.Key))
+		descs = append(descs, m.Styles.Desc.Render(h.Desc))
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		strings.Join(keys, "\n"),
+		" ",
+		strings.Join(descs, "\n"),
+	)
+	if title == "" {
+		return body
+	}
+	return m.Styles.SectionTitle.Render(title) + "\n" + body
+}
+
+// title 返回第 i 个分区的标题。
+func (m Model) title(i int) string {
+	titles := m.Titles
+	if tk, ok := m.keyMap.(help.TitledKeyMap); ok && len(titles) == 0 {
+		titles = tk.FullHelpTitles()
+	}
+	if i < len(titles) {
+		return titles[i]
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package cheatsheet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/purpose168/bubbles-cn/key"
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+type testKeyMap struct{}
+
+func binding(k, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(k), key.WithHelp(k, desc))
+}
+
+func (testKeyMap) ShortHelp() []key.Binding { return nil }
+
+func (testKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{binding("up", "move up"), binding("down", "move down")},
+		{binding("ctrl+s", "save"), binding("ctrl+o", "open")},
+		{binding("q", "quit")},
+	}
+}
+
+func (testKeyMap) FullHelpTitles() []string {
+	return []string{"Navigation", "Files", "General"}
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestCheatSheet(t *testing.T) {
+	m := New(testKeyMap{})
+	m.Title = "Keys"
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 60, Height: 12})
+
+	if m.View() != "" {
+		t.Fatal("expected an empty view while hidden")
+	}
+	m, _ = m.Update(runes("?"))
+	if !m.Visible() {
+		t.Fatal("expected ? to show the cheat sheet")
+	}
+
+	// 宽度足够时，分区并排显示
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	if len(lines) != 12 {
+		t.Fatalf("expected the cheat sheet to fill the window, got %d lines", len(lines))
+	}
+	for _, title := range []string{"Navigation", "Files", "General"} {
+		if !strings.Contains(lines[2], title) {
+			t.Fatalf("expected %q in the first row of sections, got %q", title, lines[2])
+		}
+	}
+
+	// 窄窗口中分区排成一列，可以滚动
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 20, Height: 6})
+	if got := strings.Split(ansi.Strip(m.View()), "\n")[2]; strings.TrimSpace(got) != "Navigation" {
+		t.Fatalf("expected a single column in a narrow window, got %q", got)
+	}
+	if m.Viewport.TotalLineCount() <= m.Viewport.Height {
+		t.Fatal("expected the sections to overflow and scroll")
+	}
+
+	// 搜索只显示匹配的绑定
+	m, _ = m.Update(runes("/"))
+	for _, r := range "save" {
+		m, _ = m.Update(runes(string(r)))
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view := ansi.Strip(m.View())
+	if m.Searching() || m.Query() != "save" {
+		t.Fatalf("expected the search to be accepted, got searching=%v query=%q", m.Searching(), m.Query())
+	}
+	if !strings.Contains(view, "ctrl+s save") || strings.Contains(view, "Navigation") || strings.Contains(view, "open") {
+		t.Fatalf("expected only the matching binding, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Visible() || m.Query() != "" {
+		t.Fatal("expected esc to hide the cheat sheet and clear the search")
+	}
+}
@@ -73,6 +73,12 @@ type Model struct {
 	blinkTag int
 	// mode 决定光标的行为
 	mode Mode
+	// shape 决定光标的形状
+	shape Shape
+
+	// TerminalCursor 表示宿主程序使用真实的终端光标（参见 Sequence）。
+	// 此时 View 只渲染光标下的字符，形状和闪烁由终端负责。
+	TerminalCursor bool
 }
 
 // New 创建一个具有默认设置的新模型。
@@ -214,8 +220,8 @@ func (m *Model) SetChar(char string) {
 
 // View 显示光标。
 func (m Model) View() string {
-	if m.Blink {
+	if m.Blink || m.TerminalCursor {
 		return m.TextStyle.Inline(true).Render(m.char) // 闪烁时显示正常文本
 	}
-	return m.Style.Inline(true).Reverse(true).Render(m.char) // 不闪烁时显示反转样式的光标
+	return m.shapeView() // 不闪烁时以当前形状显示光标
 }
@@ -1,6 +1,7 @@
 package cursor
 
 import (
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -47,3 +48,43 @@ func TestBlinkCmdDataRace(t *testing.T) {
 	}()
 	wg.Wait()
 }
+
+// TestShape 测试光标形状的渲染和 DECSCUSR 序列
+func TestShape(t *testing.T) {
+	for _, tc := range []struct {
+		shape         Shape
+		blink, steady string
+	}{
+		{ShapeBlock, "\x1b[1 q", "\x1b[2 q"},
+		{ShapeUnderline, "\x1b[3 q", "\x1b[4 q"},
+		{ShapeBar, "\x1b[5 q", "\x1b[6 q"},
+	} {
+		if got := tc.shape.Sequence(true); got != tc.blink {
+			t.Errorf("%s: 期望闪烁序列为 %q，但得到了 %q", tc.shape, tc.blink, got)
+		}
+		if got := tc.shape.Sequence(false); got != tc.steady {
+			t.Errorf("%s: 期望稳定序列为 %q，但得到了 %q", tc.shape, tc.steady, got)
+		}
+	}
+
+	m := New()
+	m.SetShape(ShapeBar)
+	m.SetChar(" ")
+	m.Blink = false
+	if got := m.View(); !strings.Contains(got, barGlyph) {
+		t.Errorf("期望空白单元格上的竖线光标渲染为 %q，但得到了 %q", barGlyph, got)
+	}
+	if got := m.Sequence(); got != "\x1b[5 q" {
+		t.Errorf("期望闪烁模式下的序列为闪烁竖线，但得到了 %q", got)
+	}
+
+	m.TerminalCursor = true
+	if got := m.View(); got != " " {
+		t.Errorf("期望使用终端光标时只渲染字符，但得到了 %q", got)
+	}
+
+	m.SetShape(Shape(42))
+	if m.Shape() != ShapeBar {
+		t.Errorf("期望忽略超出范围的形状")
+	}
+}
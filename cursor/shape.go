@@ -0,0 +1,88 @@
+package cursor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shape 描述光标的形状。
+type Shape int
+
+// 可用的光标形状。
+const (
+	ShapeBlock     Shape = iota // 块状光标
+	ShapeBar                    // 竖线光标
+	ShapeUnderline              // 下划线光标
+)
+
+// ResetShapeSequence 是将终端光标恢复为默认形状的 DECSCUSR 转义序列。
+const ResetShapeSequence = "\x1b[0 q"
+
+// barGlyph 是在空白单元格上渲染竖线光标时使用的字符。
+const barGlyph = "▏"
+
+// String 返回人类可读格式的光标形状。
+func (s Shape) String() string {
+	return [...]string{
+		"block",
+		"bar",
+		"underline",
+	}[s]
+}
+
+// Sequence 返回将终端光标设置为此形状的 DECSCUSR 转义序列。
+// blink 选择闪烁或稳定的变体。
+func (s Shape) Sequence(blink bool) string {
+	var n int
+	switch s {
+	case ShapeBar:
+		n = 6 //nolint:mnd
+	case ShapeUnderline:
+		n = 4 //nolint:mnd
+	default:
+		n = 2 //nolint:mnd
+	}
+	if blink {
+		n-- // 闪烁变体比稳定变体小 1
+	}
+	return fmt.Sprintf("\x1b[%d q", n)
+}
+
+// Shape 返回光标的形状。
+func (m Model) Shape() Shape {
+	return m.shape
+}
+
+// SetShape 设置光标的形状。如果形状值超出范围，则忽略。
+func (m *Model) SetShape(s Shape) {
+	if s < ShapeBlock || s > ShapeUnderline {
+		return
+	}
+	m.shape = s
+}
+
+// Sequence 返回将终端光标设置为当前形状的 DECSCUSR 转义序列。
+// 在 CursorBlink 模式下使用闪烁变体，否则使用稳定变体。
+//
+// 当 TerminalCursor 为 true 时，宿主程序负责将终端光标移动到光标位置，
+// 并将此序列写入终端；程序退出前应写入 ResetShapeSequence。
+func (m Model) Sequence() string {
+	return m.shape.Sequence(m.mode == CursorBlink)
+}
+
+// shapeView 以当前形状的字形样式渲染可见的光标。
+func (m Model) shapeView() string {
+	switch m.shape {
+	case ShapeBar:
+		// 竖线无法与字符共存于同一单元格中：在空白单元格上绘制竖线，
+		// 否则以下划线标出字符。
+		if strings.TrimSpace(m.char) == "" {
+			return m.Style.Inline(true).Render(barGlyph)
+		}
+		return m.Style.Inline(true).Underline(true).Render(m.char)
+	case ShapeUnderline:
+		return m.Style.Inline(true).Underline(true).Render(m.char)
+	default:
+		return m.Style.Inline(true).Reverse(true).Render(m.char)
+	}
+}
@@ -0,0 +1,381 @@
+// Package diffview 提供一个基于视口的差异查看组件。它显示两个字符串之间的差异，
+// 或者一段统一格式的差异，以不同的样式渲染新增、删除和上下文行，
+// 支持内联和并排两种模式、两边的行号，以及在区块之间跳转。
+package diffview
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/viewport"
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// Mode 是差异的显示模式。
+type Mode int
+
+const (
+	// Inline 将删除和新增的行上下排列在同一列中。
+	Inline Mode = iota
+	// SideBySide 将旧内容显示在左边，新内容显示在右边。
+	SideBySide
+)
+
+// KeyMap 是差异查看器的按键绑定。滚动使用视口的按键绑定。
+type KeyMap struct {
+	NextHunk   key.Binding // 跳转到下一个区块
+	PrevHunk   key.Binding // 跳转到上一个区块
+	ToggleMode key.Binding // 在内联和并排模式之间切换
+}
+
+// ShortHelp 实现 help.KeyMap 接口。
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.NextHunk, k.PrevHunk, k.ToggleMode}
+}
+
+// FullHelp 实现 help.KeyMap 接口。
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// DefaultKeyMap 返回一组默认的按键绑定。
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		NextHunk: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "下一个区块"),
+		),
+		PrevHunk: key.NewBinding(
+			key.WithKeys("p", "N"),
+			key.WithHelp("p", "上一个区块"),
+		),
+		ToggleMode: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "切换并排显示"),
+		),
+	}
+}
+
+// Styles 包含差异查看器的样式。
+type Styles struct {
+	Added      lipgloss.Style // 新增的行
+	Removed    lipgloss.Style // 删除的行
+	Context    lipgloss.Style // 上下文行
+	Hunk       lipgloss.Style // 区块头部
+	LineNumber lipgloss.Style // 行号
+	Filler     lipgloss.Style // 并排模式中另一边没有对应行的位置
+	Divider    lipgloss.Style // 并排模式中两边之间的分隔线，内容由 SetString 设置
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	return Styles{
+		Added:      lipgloss.NewStyle().Foreground(lipgloss.Color("42")),
+		Removed:    lipgloss.NewStyle().Foreground(lipgloss.Color("203")),
+		Context:    lipgloss.NewStyle(),
+		Hunk:       lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+		LineNumber: lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		Filler:     lipgloss.NewStyle().Foreground(lipgloss.Color("236")),
+		Divider:    lipgloss.NewStyle().Foreground(lipgloss.Color("240")).SetString("│"),
+	}
+}
+
+// Model 是差异查看器的 Bubble Tea 模型。
+type Model struct {
+	KeyMap KeyMap
+
+	// Styles、ShowLineNumbers 和 Context 应在 SetDiff 或 SetUnified 之前设置。
+	Styles Styles
+
+	// ShowLineNumbers 在每行前显示该行在两边的行号。默认为 true。
+	ShowLineNumbers bool
+
+	// Context 是 SetDiff 计算差异时每个区块包含的上下文行数。默认为 3。
+	Context int
+
+	// Viewport 是显示差异的视口。可以通过它配置滚动按键和样式。
+	// 每个区块都是视口中的一个锚点，因此视口的锚点按键同样可以在区块之间跳转。
+	Viewport viewport.Model
+
+	mode  Mode
+	hunks []hunk
+}
+
+// New 返回一个具有给定宽度和高度的差异查看器。
+func New(width, height int) Model {
+	return Model{
+		KeyMap:          DefaultKeyMap(),
+		Styles:          DefaultStyles(),
+		ShowLineNumbers: true,
+		Context:         3, //nolint:mnd
+		Viewport:        viewport.New(width, height),
+	}
+}
+
+// SetDiff 计算并显示 before 和 after 之间的差异，并滚动到顶部。
+func (m *Model) SetDiff(before, after string) {
+	m.setHunks(diffStrings(before, after, m.Context))
+}
+
+// SetUnified 解析并显示一段统一格式的差异（例如 git diff 的输出），并滚动到顶部。
+// 区块之外的行（例如文件头部）被忽略。
+func (m *Model) SetUnified(diff string) error {
+	hunks, err := parseUnified(diff)
+	if err != nil {
+		return err
+	}
+	m.setHunks(hunks)
+	return nil
+}
+
+// setHunks 显示给定的区块，并滚动到顶部。
+func (m *Model) setHunks(hunks []hunk) {
+	m.hunks = hunks
+	m.render()
+	m.Viewport.SetYOffset(0)
+	m.Viewport.ClearJumps()
+}
+
+// Mode 返回当前的显示模式。
+func (m Model) Mode() Mode {
+	return m.mode
+}
+
+// SetMode 设置显示模式。
+func (m *Model) SetMode(mode Mode) {
+	if mode == m.mode {
+		return
+	}
+	m.mode = mode
+	m.rerender()
+}
+
+// SetSize 设置差异查看器的宽度和高度。并排模式下宽度变化时重新渲染。
+func (m *Model) SetSize(width, height int) {
+	reflow := width != m.Viewport.Width && m.mode == SideBySide
+	m.Viewport.Width = width
+	m.Viewport.Height = height
+	if reflow {
+		m.rerender()
+	}
+}
+
+// HunkCount 返回差异中区块的数量。
+func (m Model) HunkCount() int {
+	return len(m.hunks)
+}
+
+// CurrentHunk 返回最近一次跳转到的区块的索引。如果没有，返回 -1。
+func (m Model) CurrentHunk() int {
+	i, err := strconv.Atoi(m.Viewport.CurrentAnchor())
+	if err != nil {
+		return -1
+	}
+	return i
+}
+
+// GotoHunk 滚动视口，使第 i 个区块显示在顶部。如果没有该区块，返回 false。
+func (m *Model) GotoHunk(i int) bool {
+	return m.Viewport.GotoAnchor(strconv.Itoa(i))
+}
+
+// NextHunk 跳转到下一个区块。如果没有下一个区块，返回 false。
+func (m *Model) NextHunk() bool {
+	return m.Viewport.NextAnchor()
+}
+
+// PrevHunk 跳转到上一个区块。如果没有上一个区块，返回 false。
+func (m *Model) PrevHunk() bool {
+	return m.Viewport.PrevAnchor()
+}
+
+// Init 存在以满足 tea.Model 接口。
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update 处理区块导航、模式切换和滚动。收到 tea.WindowSizeMsg 时，差异查看器填满整个窗口；
+// 如果它只占窗口的一部分，请不要转发该消息，而是调用 SetSize。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.NextHunk):
+			m.NextHunk()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.PrevHunk):
+			m.PrevHunk()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.ToggleMode):
+			if m.mode == Inline {
+				m.SetMode(SideBySide)
+			} else {
+				m.SetMode(Inline)
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.Viewport, cmd = m.Viewport.Update(msg)
+	return m, cmd
+}
+
+// View 渲染差异。
+func (m Model) View() string {
+	return m.Viewport.View()
+}
+
+// rerender 重新渲染差异。如果跳转过区块，则保持该区块可见，否则保持滚动位置。
+func (m *Model) rerender() {
+	current, yOffset := m.CurrentHunk(), m.Viewport.YOffset
+	m.render()
+	if line, ok := m.Viewport.Anchors()[strconv.Itoa(current)]; ok {
+		yOffset = line
+	}
+	m.Viewport.SetYOffset(yOffset)
+}
+
+// render 按当前模式将差异渲染到视口中，并将每个区块的头部设置为视口的锚点。
+func (m *Model) render() {
+	r := renderer{
+		styles: m.Styles,
+		width:  max(1, m.Viewport.Width-m.Viewport.Style.GetHorizontalFrameSize()),
+	}
+	if m.ShowLineNumbers {
+		r.numWidth = len(strconv.Itoa(maxLineNumber(m.hunks)))
+	}
+
+	anchors := make(map[string]int, len(m.hunks))
+	for i, h := range m.hunks {
+		anchors[strconv.Itoa(i)] = len(r.lines)
+		if m.mode == SideBySide {
+			r.sideBySide(h)
+		} else {
+			r.inline(h)
+		}
+	}
+	m.Viewport.SetContent(strings.Join(r.lines, "\n"))
+	m.Viewport.SetAnchors(anchors)
+}
+
+// maxLineNumber 返回区块中最大的行号。
+func maxLineNumber(hunks []hunk) int {
+	n := 1
+	for _, h := range hunks {
+		for _, l := range h.lines {
+			n = max(n, l.old, l.new)
+		}
+	}
+	return n
+}
+
+// renderer 渲染差异的区块。
+type renderer struct {
+	styles   Styles
+	width    int
+	numWidth int // 行号的宽度，0 表示不显示行号
+	lines    []string
+}
+
+// inline 以内联模式渲染一个区块。
+func (r *renderer) inline(h hunk) {
+	r.lines = append(r.lines, r.styles.Hunk.Render(h.header))
+	for _, l := range h.lines {
+		r.lines = append(r.lines, r.number(l.old)+r.number(l.new)+r.style(l.kind).Render(sign(l.kind)+expandTabs(l.text)))
+	}
+}
+
+// sideBySide 以并排模式渲染一个区块。连续的删除行和新增行逐行配对显示在两边。
+func (r *renderer) sideBySide(h hunk) {
+	divider := r.styles.Divider.String()
+	half := max(1, (r.width-ansi.StringWidth(divider))/2) //nolint:mnd
+	r.lines = append(r.lines, r.styles.Hunk.Render(ansi.Truncate(h.header, r.width, "")))
+
+	for i := 0; i < len(h.lines); {
+		if h.lines[i].kind == contextLine {
+			l := &h.lines[i]
+			r.lines = append(r.lines, r.cell(l, l.old, half)+divider+r.cell(l, l.new, half))
+			i++
+			continue
+		}
+
+		var removed, added []*diffLine
+		for ; i < len(h.lines) && h.lines[i].kind == removedLine; i++ {
+			removed = append(removed, &h.lines[i])
+		}
+		for ; i < len(h.lines) && h.lines[i].kind == addedLine; i++ {
+			added = append(added, &h.lines[i])
+		}
+		for j := range max(len(removed), len(added)) {
+			left, right := r.cell(nil, 0, half), r.cell(nil, 0, half)
+			if j < len(removed) {
+				left = r.cell(removed[j], removed[j].old, half)
+			}
+			if j < len(added) {
+				right = r.cell(added[j], added[j].new, half)
+			}
+			r.lines = append(r.lines, left+divider+right)
+		}
+	}
+}
+
+// cell 渲染并排模式中一边的一行，截断并填充到 width。l 为 nil 时渲染填充。
+func (r *renderer) cell(l *diffLine, n, width int) string {
+	if l == nil {
+		return r.styles.Filler.Render(strings.Repeat(" ", width))
+	}
+	num := r.number(n)
+	avail := max(0, width-ansi.StringWidth(num))
+	text := ansi.Truncate(sign(l.kind)+expandTabs(l.text), avail, "")
+	text += strings.Repeat(" ", avail-ansi.StringWidth(text))
+	return num + r.style(l.kind).Render(text)
+}
+
+// number 渲染行号及其后的空格。n 为 0 时渲染同样宽度的空白。不显示行号时返回空字符串。
+func (r *renderer) number(n int) string {
+	if r.numWidth == 0 {
+		return ""
+	}
+	s := strings.Repeat(" ", r.numWidth)
+	if n > 0 {
+		s = fmt.Sprintf("%*d", r.numWidth, n)
+	}
+	return r.styles.LineNumber.Render(s) + " "
+}
+
+// style 返回给定类型的行的样式。
+func (r *renderer) style(kind lineKind) lipgloss.Style {
+	switch kind {
+	case addedLine:
+		return r.styles.Added
+	case removedLine:
+		return r.styles.Removed
+	default:
+		return r.styles.Context
+	}
+}
+
+// sign 返回给定类型的行的标记。
+func sign(kind lineKind) string {
+	switch kind {
+	case addedLine:
+		return "+"
+	case removedLine:
+		return "-"
+	default:
+		return " "
+	}
+}
+
+// expandTabs 将制表符替换为空格，使宽度可以正确计算。
+func expandTabs(s string) string {
+	return strings.ReplaceAll(s, "\t", "    ")
+}
@@ -0,0 +1,99 @@
+package diffview
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+func plainView(m Model) []string {
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return lines
+}
+
+func TestInline(t *testing.T) {
+	m := New(40, 10)
+	m.SetDiff("a\nb\nc\n", "a\nB\nc\nd\n")
+
+	want := []string{
+		"@@ -1,3 +1,4 @@",
+		"1 1  a",
+		"2   -b",
+		"  2 +B",
+		"3 3  c",
+		"  4 +d",
+	}
+	got := plainView(m)
+	for i, w := range want {
+		if got[i] != w {
+			t.Fatalf("line %d: expected %q, got %q\n%s", i, w, got[i], strings.Join(got, "\n"))
+		}
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	m := New(21, 10)
+	m.SetDiff("a\nb\nc\n", "a\nB\nc\nd\n")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.Mode() != SideBySide {
+		t.Fatal("expected side-by-side mode")
+	}
+
+	want := []string{
+		"@@ -1,3 +1,4 @@",
+		"1  a      │1  a",
+		"2 -b      │2 +B",
+		"3  c      │3  c",
+		"          │4 +d",
+	}
+	got := plainView(m)
+	for i, w := range want {
+		if got[i] != w {
+			t.Fatalf("line %d: expected %q, got %q\n%s", i, w, got[i], strings.Join(got, "\n"))
+		}
+	}
+}
+
+func TestUnifiedHunks(t *testing.T) {
+	diff := "diff --git a/x b/x\n" +
+		"--- a/x\n" +
+		"+++ b/x\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		" one\n" +
+		"-two\n" +
+		"+TWO\n" +
+		"@@ -20 +20,2 @@ func main() {\n" +
+		" twenty\n" +
+		"+twenty-one\n" +
+		"--- a/y\n"
+
+	m := New(40, 3)
+	if err := m.SetUnified(diff); err != nil {
+		t.Fatal(err)
+	}
+	if m.HunkCount() != 2 {
+		t.Fatalf("expected 2 hunks, got %d", m.HunkCount())
+	}
+	if got := plainView(m)[2]; got != " 2    -two" {
+		t.Fatalf("expected padded line numbers, got %q", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.CurrentHunk() != 1 || plainView(m)[0] != "@@ -20 +20,2 @@ func main() {" {
+		t.Fatalf("expected to jump to the second hunk, got %d: %q", m.CurrentHunk(), plainView(m))
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if m.CurrentHunk() != 0 || m.Viewport.YOffset != 0 {
+		t.Fatalf("expected to jump back to the first hunk, got %d", m.CurrentHunk())
+	}
+
+	if err := m.SetUnified("@@ -1 +1 @@\n?what\n"); err == nil {
+		t.Fatal("expected an error for a malformed hunk")
+	}
+}
@@ -0,0 +1,141 @@
+package diffview
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aymanbagabas/go-udiff"
+	"github.com/aymanbagabas/go-udiff/lcs"
+)
+
+// lineKind 是差异中一行的类型。
+type lineKind int
+
+const (
+	contextLine lineKind = iota // 两边相同的上下文行
+	addedLine                   // 新增的行
+	removedLine                 // 删除的行
+)
+
+// diffLine 是差异中的一行。old 和 new 是该行在两边的行号（从 1 开始），
+// 不在某一边的行对应的行号为 0。
+type diffLine struct {
+	kind     lineKind
+	old, new int
+	text     string
+}
+
+// hunk 是差异中的一个区块。
+type hunk struct {
+	header string
+	lines  []diffLine
+}
+
+// hunkHeader 匹配区块的头部，例如 "@@ -1,3 +1,4 @@ func main() {"。
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// diffStrings 计算 before 和 after 之间的差异，每个区块包含 context 行上下文。
+func diffStrings(before, after string, context int) []hunk {
+	unified, err := udiff.ToUnified("", "", before, lineEdits(before, after), max(0, context))
+	if err != nil {
+		// lineEdits 计算的编辑总是有效的。
+		return nil
+	}
+	hunks, _ := parseUnified(unified)
+	return hunks
+}
+
+// lineEdits 按行计算将 before 变为 after 的编辑。与按字符比较的 udiff.Strings 不同，
+// 修改过的行总是整行删除再插入，不会把相邻的未修改行卷入编辑中。
+func lineEdits(before, after string) []udiff.Edit {
+	a, b := strings.SplitAfter(before, "\n"), strings.SplitAfter(after, "\n")
+
+	// 将每个不同的行映射为一个符号，然后比较符号序列。
+	ids := make(map[string]rune)
+	symbols := func(lines []string) []rune {
+		rs := make([]rune, len(lines))
+		for i, l := range lines {
+			id, ok := ids[l]
+			if !ok {
+				id = rune(len(ids))
+				ids[l] = id
+			}
+			rs[i] = id
+		}
+		return rs
+	}
+	diffs := lcs.DiffRunes(symbols(a), symbols(b))
+
+	offsets := make([]int, len(a)+1)
+	for i, l := range a {
+		offsets[i+1] = offsets[i] + len(l)
+	}
+	edits := make([]udiff.Edit, len(diffs))
+	for i, d := range diffs {
+		edits[i] = udiff.Edit{
+			Start: offsets[d.Start],
+			End:   offsets[d.End],
+			New:   strings.Join(b[d.ReplStart:d.ReplEnd], ""),
+		}
+	}
+	return edits
+}
+
+// parseUnified 解析统一格式的差异。区块之外的行（例如文件头部）被忽略；
+// 多个文件的差异中的所有区块按顺序返回。
+func parseUnified(s string) ([]hunk, error) {
+	var (
+		hunks            []hunk
+		old, new         int // 下一行在两边的行号
+		oldLeft, newLeft int // 当前区块在两边剩余的行数
+	)
+	for n, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if m := hunkHeader.FindStringSubmatch(line); m != nil {
+			old, oldLeft = atoi(m[1], 0), atoi(m[2], 1)
+			new, newLeft = atoi(m[3], 0), atoi(m[4], 1)
+			hunks = append(hunks, hunk{header: line})
+			continue
+		}
+		if oldLeft == 0 && newLeft == 0 {
+			continue
+		}
+
+		h := &hunks[len(hunks)-1]
+		switch {
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file"
+		case strings.HasPrefix(line, "+") && newLeft > 0:
+			h.lines = append(h.lines, diffLine{kind: addedLine, new: new, text: line[1:]})
+			new++
+			newLeft--
+		case strings.HasPrefix(line, "-") && oldLeft > 0:
+			h.lines = append(h.lines, diffLine{kind: removedLine, old: old, text: line[1:]})
+			old++
+			oldLeft--
+		case (line == "" || strings.HasPrefix(line, " ")) && oldLeft > 0 && newLeft > 0:
+			if line != "" {
+				line = line[1:]
+			}
+			h.lines = append(h.lines, diffLine{kind: contextLine, old: old, new: new, text: line})
+			old++
+			new++
+			oldLeft--
+			newLeft--
+		default:
+			return nil, fmt.Errorf("diffview: line %d: unexpected %q in hunk", n+1, line)
+		}
+	}
+	return hunks, nil
+}
+
+// atoi 解析区块头部中的数字。如果 s 为空，返回 def。
+func atoi(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
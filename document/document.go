@@ -0,0 +1,336 @@
+// Package document 提供一个基于视口的可滚动文档组件。它将类 Markdown 文本
+// （标题、粗体、斜体、行内代码、列表、引用、代码块和链接）使用 Lip Gloss 样式渲染，
+// 支持使用 tab/enter 在链接之间导航和激活链接，并在宽度变化时重新排版。
+//
+// 它只支持 Markdown 的一个小子集，不打算替代完整的 Markdown 渲染器。
+package document
+
+import (
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/viewport"
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// LinkActivatedMsg 在用户激活链接时发送。
+type LinkActivatedMsg struct {
+	Text string // 链接文本
+	URL  string // 链接目标
+}
+
+// KeyMap 是文档的链接导航按键绑定。滚动使用视口的按键绑定。
+type KeyMap struct {
+	NextLink key.Binding // 选择下一个链接
+	PrevLink key.Binding // 选择上一个链接
+	Activate key.Binding // 激活选中的链接
+}
+
+// ShortHelp 实现 help.KeyMap 接口。
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.NextLink, k.PrevLink, k.Activate}
+}
+
+// FullHelp 实现 help.KeyMap 接口。
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// DefaultKeyMap 返回一组默认的按键绑定。
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		NextLink: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "下一个链接"),
+		),
+		PrevLink: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "上一个链接"),
+		),
+		Activate: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "打开链接"),
+		),
+	}
+}
+
+// Styles 包含文档的样式。
+type Styles struct {
+	H1         lipgloss.Style // 一级标题
+	H2         lipgloss.Style // 二级标题
+	H3         lipgloss.Style // 三级及更低级别的标题
+	Text       lipgloss.Style // 普通文本
+	Bold       lipgloss.Style // 粗体
+	Italic     lipgloss.Style // 斜体
+	Code       lipgloss.Style // 行内代码
+	CodeBlock  lipgloss.Style // 代码块中的每一行
+	Bullet     lipgloss.Style // 列表标记
+	Quote      lipgloss.Style // 引用文本
+	QuoteBar   lipgloss.Style // 引用前的竖线
+	Link       lipgloss.Style // 链接
+	ActiveLink lipgloss.Style // 选中的链接
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	return Styles{
+		H1:         lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")),
+		H2:         lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")),
+		H3:         lipgloss.NewStyle().Bold(true),
+		Text:       lipgloss.NewStyle(),
+		Bold:       lipgloss.NewStyle().Bold(true),
+		Italic:     lipgloss.NewStyle().Italic(true),
+		Code:       lipgloss.NewStyle().Foreground(lipgloss.Color("203")),
+		CodeBlock:  lipgloss.NewStyle().Foreground(lipgloss.Color("250")).PaddingLeft(2), //nolint:mnd
+		Bullet:     lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		Quote:      lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Italic(true),
+		QuoteBar:   lipgloss.NewStyle().Foreground(lipgloss.Color("240")).SetString("│ "),
+		Link:       lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Underline(true),
+		ActiveLink: lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("62")),
+	}
+}
+
+// Model 是文档组件的 Bubble Tea 模型。
+type Model struct {
+	KeyMap KeyMap
+	Styles Styles
+
+	// Viewport 是显示渲染结果的视口。可以通过它配置滚动按键和样式。
+	Viewport viewport.Model
+
+	blocks     []block
+	links      []link // 按出现顺序排列的链接
+	activeLink int    // 选中的链接，-1 表示没有
+}
+
+// link 是文档中的一个链接。
+type link struct {
+	text, url string
+	line      int // 链接所在的渲染行
+}
+
+// New 返回一个具有给定宽度和高度的文档。
+func New(width, height int) Model {
+	return Model{
+		KeyMap:     DefaultKeyMap(),
+		Styles:     DefaultStyles(),
+		Viewport:   viewport.New(width, height),
+		activeLink: -1,
+	}
+}
+
+// SetContent 解析并渲染给定的类 Markdown 文本，并滚动到顶部。
+func (m *Model) SetContent(s string) {
+	m.blocks = parse(s)
+	m.activeLink = -1
+	m.render()
+	m.Viewport.SetYOffset(0)
+	m.Viewport.ClearJumps()
+}
+
+// SetSize 设置文档的宽度和高度。宽度变化时重新排版。
+func (m *Model) SetSize(width, height int) {
+	reflow := width != m.Viewport.Width
+	m.Viewport.Width = width
+	m.Viewport.Height = height
+	if reflow {
+		m.render()
+	}
+}
+
+// Links 返回文档中所有链接的目标，按出现顺序排列。
+func (m Model) Links() []string {
+	urls := make([]string, len(m.links))
+	for i, l := range m.links {
+		urls[i] = l.url
+	}
+	return urls
+}
+
+// ActiveLink 返回选中链接的文本和目标。如果没有选中的链接，ok 为 false。
+func (m Model) ActiveLink() (text, url string, ok bool) {
+	if m.activeLink < 0 || m.activeLink >= len(m.links) {
+		return "", "", false
+	}
+	l := m.links[m.activeLink]
+	return l.text, l.url, true
+}
+
+// Init 存在以满足 tea.Model 接口。
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update 处理链接导航和滚动。收到 tea.WindowSizeMsg 时，文档填满整个窗口；
+// 如果文档只占窗口的一部分，请不要转发该消息，而是调用 SetSize。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.NextLink):
+			m.selectLink(1)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.PrevLink):
+			m.selectLink(-1)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Activate):
+			text, url, ok := m.ActiveLink()
+			if !ok {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return LinkActivatedMsg{Text: text, URL: url}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.Viewport, cmd = m.Viewport.Update(msg)
+	return m, cmd
+}
+
+// View 渲染文档。
+func (m Model) View() string {
+	return m.Viewport.View()
+}
+
+// selectLink 选择下一个（dir 为 1）或上一个（dir 为 -1）链接，并滚动使其可见。
+func (m *Model) selectLink(dir int) {
+	if len(m.links) == 0 {
+		return
+	}
+	switch {
+	case m.activeLink < 0 && dir < 0:
+		m.activeLink = len(m.links) - 1
+	case m.activeLink < 0:
+		m.activeLink = 0
+	default:
+		m.activeLink = (m.activeLink + dir + len(m.links)) % len(m.links)
+	}
+
+	yOffset := m.Viewport.YOffset
+	m.render()
+	m.Viewport.SetYOffset(yOffset)
+
+	line := m.links[m.activeLink].line
+	if line < m.Viewport.YOffset || line >= m.Viewport.YOffset+m.Viewport.Height {
+		m.Viewport.SetYOffset(line - m.Viewport.Height/2) //nolint:mnd
+	}
+}
+
+// render 将解析后的文档按当前宽度渲染到视口中，并记录每个链接所在的行。
+func (m *Model) render() {
+	width := m.Viewport.Width - m.Viewport.Style.GetHorizontalFrameSize()
+	r := renderer{styles: m.Styles, width: max(1, width), active: m.activeLink}
+	for _, b := range m.blocks {
+		r.block(b)
+	}
+	m.links = r.links
+	m.Viewport.SetContent(strings.Join(r.lines, "\n"))
+}
+
+// renderer 渲染文档块。
+type renderer struct {
+	styles Styles
+	width  int
+	active int
+	lines  []string
+	links  []link
+}
+
+// block 渲染一个块。
+func (r *renderer) block(b block) {
+	switch b.kind {
+	case blankBlock:
+		r.lines = append(r.lines, "")
+	case codeBlock:
+		for _, l := range b.code {
+			r.lines = append(r.lines, r.styles.CodeBlock.Render(l))
+		}
+	case headingBlock:
+		style := r.styles.H3
+		switch b.level {
+		case 1:
+			style = r.styles.H1
+		case 2: //nolint:mnd
+			style = r.styles.H2
+		}
+		r.wrap(b.spans, "", "", style)
+	case itemBlock:
+		indent := strings.Repeat("  ", b.level)
+		marker := indent + r.styles.Bullet.Render(b.marker) + " "
+		r.wrap(b.spans, marker, strings.Repeat(" ", ansi.StringWidth(marker)), r.styles.Text)
+	case quoteBlock:
+		bar := r.styles.QuoteBar.String()
+		r.wrap(b.spans, bar, bar, r.styles.Quote)
+	default:
+		r.wrap(b.spans, "", "", r.styles.Text)
+	}
+}
+
+// wrap 将行内片段按单词换行。first 是第一行的前缀，rest 是后续行的前缀。
+func (r *renderer) wrap(spans []span, first, rest string, base lipgloss.Style) {
+	prefix := first
+	avail := max(1, r.width-ansi.StringWidth(prefix))
+
+	var (
+		line  strings.Builder
+		width int
+	)
+	flush := func() {
+		r.lines = append(r.lines, prefix+line.String())
+		line.Reset()
+		width = 0
+		prefix = rest
+		avail = max(1, r.width-ansi.StringWidth(prefix))
+	}
+
+	for _, w := range words(spans) {
+		ww := ansi.StringWidth(w.text)
+		if width > 0 && w.space && width+1+ww > avail {
+			flush()
+		} else if width > 0 && w.space {
+			line.WriteString(base.Render(" "))
+			width++
+		}
+		if w.span.kind == linkSpan {
+			r.recordLink(w.span)
+		}
+		line.WriteString(r.style(w.span, base).Render(w.text))
+		width += ww
+	}
+	flush()
+}
+
+// recordLink 记录链接所在的行。链接按出现顺序编号；一个链接可能跨越多行，
+// 只记录第一行。
+func (r *renderer) recordLink(s span) {
+	if s.link < len(r.links) {
+		return
+	}
+	r.links = append(r.links, link{text: s.text, url: s.url, line: len(r.lines)})
+}
+
+// style 返回给定片段的样式。
+func (r *renderer) style(s span, base lipgloss.Style) lipgloss.Style {
+	switch s.kind {
+	case boldSpan:
+		return r.styles.Bold.Inherit(base)
+	case italicSpan:
+		return r.styles.Italic.Inherit(base)
+	case codeSpan:
+		return r.styles.Code
+	case linkSpan:
+		if s.link == r.active {
+			return r.styles.ActiveLink
+		}
+		return r.styles.Link
+	default:
+		return base
+	}
+}
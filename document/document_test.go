@@ -0,0 +1,108 @@
+package document
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+const testDoc = "# Title\n\n" +
+	"Some **bold** and *italic* text with `code`\n" +
+	"continued on the next line.\n\n" +
+	"- first item\n" +
+	"- see [the docs](https://example.com/docs)\n" +
+	"  1. nested\n\n" +
+	"> quoted\n\n" +
+	"```\n" +
+	"func main() {}\n" +
+	"```\n\n" +
+	"Also [home](https://example.com)."
+
+func plainView(m Model) []string {
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return lines
+}
+
+func TestRender(t *testing.T) {
+	m := New(80, 20)
+	m.SetContent(testDoc)
+
+	want := []string{
+		"Title",
+		"",
+		"Some bold and italic text with code continued on the next line.",
+		"",
+		"• first item",
+		"• see the docs",
+		"  1. nested",
+		"",
+		"│ quoted",
+		"",
+		"  func main() {}",
+		"",
+		"Also home.",
+	}
+	got := plainView(m)
+	for i, w := range want {
+		if got[i] != w {
+			t.Fatalf("line %d: expected %q, got %q\n%s", i, w, got[i], strings.Join(got, "\n"))
+		}
+	}
+	if links := m.Links(); len(links) != 2 || links[1] != "https://example.com" {
+		t.Fatalf("expected two links, got %q", links)
+	}
+}
+
+func TestReflow(t *testing.T) {
+	m := New(80, 20)
+	m.SetContent("- one two three four five six")
+
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 14, Height: 20})
+	got := plainView(m)
+	want := []string{"• one two", "  three four", "  five six"}
+	for i, w := range want {
+		if got[i] != w {
+			t.Fatalf("line %d: expected %q, got %q", i, w, got[i])
+		}
+	}
+}
+
+func TestLinkNavigation(t *testing.T) {
+	m := New(80, 3)
+	m.SetContent(testDoc)
+
+	if _, _, ok := m.ActiveLink(); ok {
+		t.Fatal("expected no active link")
+	}
+
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	m, _ = m.Update(tab)
+	m, _ = m.Update(tab)
+	text, url, ok := m.ActiveLink()
+	if !ok || text != "home" || url != "https://example.com" {
+		t.Fatalf("expected the second link to be active, got %q %q", text, url)
+	}
+	if !strings.Contains(ansi.Strip(m.View()), "Also home.") {
+		t.Fatal("expected the active link to be scrolled into view")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	msg, ok := cmd().(LinkActivatedMsg)
+	if !ok || msg.URL != "https://example.com" {
+		t.Fatalf("expected LinkActivatedMsg, got %#v", msg)
+	}
+
+	// 回绕到第一个链接
+	m, _ = m.Update(tab)
+	if _, url, _ := m.ActiveLink(); url != "https://example.com/docs" {
+		t.Fatalf("expected to wrap around to the first link, got %q", url)
+	}
+}
@@ -0,0 +1,256 @@
+package document
+
+import (
+	"strings"
+	"unicode"
+)
+
+// blockKind 是块的类型。
+type blockKind int
+
+const (
+	paragraphBlock blockKind = iota
+	headingBlock
+	itemBlock
+	quoteBlock
+	codeBlock
+	blankBlock
+)
+
+// block 是文档中的一个块，例如段落、标题或列表项。
+type block struct {
+	kind   blockKind
+	level  int    // 标题级别或列表项的缩进级别
+	marker string // 列表项的标记，例如 "•" 或 "1."
+	spans  []span
+	code   []string // 代码块的行
+}
+
+// spanKind 是行内片段的类型。
+type spanKind int
+
+const (
+	textSpan spanKind = iota
+	boldSpan
+	italicSpan
+	codeSpan
+	linkSpan
+)
+
+// span 是一段具有相同样式的行内文本。
+type span struct {
+	kind spanKind
+	text string
+	url  string // 链接的目标
+	link int    // 链接在文档中的序号
+}
+
+// word 是换行的最小单位。
+type word struct {
+	text  string
+	span  span
+	space bool // 单词前是否有空格
+}
+
+// parse 将类 Markdown 文本解析为块。连续的文本行合并为一个段落，
+// 连续的空行合并为一个。
+func parse(s string) []block {
+	var (
+		blocks []block
+		para   []string
+		code   []string
+		fenced bool
+		links  int
+	)
+	inline := func(s string) []span {
+		spans := parseInline(s, links)
+		for _, sp := range spans {
+			if sp.kind == linkSpan {
+				links++
+			}
+		}
+		return spans
+	}
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, block{kind: paragraphBlock, spans: inline(strings.Join(para, " "))})
+			para = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			if fenced {
+				blocks = append(blocks, block{kind: codeBlock, code: code})
+				code = nil
+			} else {
+				flush()
+			}
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			code = append(code, strings.ReplaceAll(line, "\t", "    "))
+			continue
+		}
+
+		if trimmed == "" {
+			flush()
+			if len(blocks) > 0 && blocks[len(blocks)-1].kind != blankBlock {
+				blocks = append(blocks, block{kind: blankBlock})
+			}
+			continue
+		}
+
+		if level, text, ok := heading(trimmed); ok {
+			flush()
+			blocks = append(blocks, block{kind: headingBlock, level: level, spans: inline(text)})
+			continue
+		}
+		if marker, text, ok := listItem(trimmed); ok {
+			flush()
+			level := (len(line) - len(strings.TrimLeft(line, " \t"))) / 2 //nolint:mnd
+			blocks = append(blocks, block{kind: itemBlock, level: level, marker: marker, spans: inline(text)})
+			continue
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			flush()
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			blocks = append(blocks, block{kind: quoteBlock, spans: inline(text)})
+			continue
+		}
+		para = append(para, trimmed)
+	}
+
+	flush()
+	if fenced {
+		blocks = append(blocks, block{kind: codeBlock, code: code})
+	}
+	for len(blocks) > 0 && blocks[len(blocks)-1].kind == blankBlock {
+		blocks = blocks[:len(blocks)-1]
+	}
+	return blocks
+}
+
+// heading 解析 ATX 风格的标题，例如 "## 标题"。
+func heading(s string) (level int, text string, ok bool) {
+	for level < len(s) && s[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(s) || s[level] != ' ' { //nolint:mnd
+		return 0, "", false
+	}
+	return level, strings.TrimSpace(s[level:]), true
+}
+
+// listItem 解析无序列表项（"- "、"* "、"+ "）和有序列表项（"1. "）。
+func listItem(s string) (marker, text string, ok bool) {
+	if len(s) >= 2 && strings.ContainsRune("-*+", rune(s[0])) && s[1] == ' ' { //nolint:mnd
+		return "•", strings.TrimSpace(s[2:]), true
+	}
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i > 0 && i+1 < len(s) && s[i] == '.' && s[i+1] == ' ' {
+		return s[:i+1], strings.TrimSpace(s[i+2:]), true
+	}
+	return "", "", false
+}
+
+// parseInline 解析行内标记：**粗体**、*斜体*、_斜体_、`代码` 和 [链接](目标)。
+// 标记不能嵌套。firstLink 是第一个链接的序号。
+func parseInline(s string, firstLink int) []span {
+	var (
+		spans []span
+		text  strings.Builder
+	)
+	emit := func(sp span) {
+		if text.Len() > 0 {
+			spans = append(spans, span{kind: textSpan, text: text.String()})
+			text.Reset()
+		}
+		if sp.text != "" {
+			spans = append(spans, sp)
+		}
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case strings.HasPrefix(rest, "**"):
+			if end := strings.Index(rest[2:], "**"); end > 0 {
+				emit(span{kind: boldSpan, text: rest[2 : 2+end]})
+				i += end + 4 //nolint:mnd
+				continue
+			}
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				emit(span{kind: codeSpan, text: rest[1 : 1+end]})
+				i += end + 2 //nolint:mnd
+				continue
+			}
+		case rest[0] == '*' || rest[0] == '_':
+			// 下划线只在单词开头生效，避免误解析 snake_case。
+			if rest[0] == '_' && i > 0 && !unicode.IsSpace(rune(s[i-1])) {
+				break
+			}
+			if end := strings.IndexByte(rest[1:], rest[0]); end > 0 {
+				emit(span{kind: italicSpan, text: rest[1 : 1+end]})
+				i += end + 2 //nolint:mnd
+				continue
+			}
+		case rest[0] == '[':
+			if mid := strings.Index(rest, "]("); mid > 0 {
+				if end := strings.IndexByte(rest[mid:], ')'); end > 0 {
+					emit(span{
+						kind: linkSpan,
+						text: rest[1:mid],
+						url:  rest[mid+2 : mid+end],
+						link: firstLink + countLinks(spans),
+					})
+					i += mid + end + 1
+					continue
+				}
+			}
+		}
+		text.WriteByte(s[i])
+		i++
+	}
+	emit(span{})
+	return spans
+}
+
+// countLinks 返回片段中链接的数量。
+func countLinks(spans []span) int {
+	var n int
+	for _, s := range spans {
+		if s.kind == linkSpan {
+			n++
+		}
+	}
+	return n
+}
+
+// words 将片段拆分为单词。单词保留所属片段的样式；片段之间没有空格时，
+// 后一个单词紧跟在前一个单词之后。
+func words(spans []span) []word {
+	var ws []word
+	space := false
+	for _, s := range spans {
+		fields := strings.Split(s.text, " ")
+		for i, f := range fields {
+			if i > 0 {
+				space = true
+			}
+			if f == "" {
+				continue
+			}
+			ws = append(ws, word{text: f, span: s, space: space && len(ws) > 0})
+			space = false
+		}
+	}
+	return ws
+}
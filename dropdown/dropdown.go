@@ -0,0 +1,359 @@
+// Package dropdown 提供一个下拉选择组件：平时是显示当前选项的单行字段，
+// 激活后展开为可以导航的选项列表（基于 list 组件），选项较多时支持过滤。
+// 适用于表单等需要紧凑选择控件的界面；与完整的 list 组件不同，它只占用一行，
+// 直到被展开。
+//
+// 包名不能是 select，因为 select 是 Go 的关键字。
+package dropdown
+
+import (
+	"sync/atomic"
+
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/list"
+	tea "github.com/purpose168/bubbletea-cn"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// 默认配置。
+const (
+	defaultMaxHeight       = 8
+	defaultFilterThreshold = 8
+	defaultWidth           = 24
+)
+
+var lastID int64
+
+// nextID 生成下一个唯一的 ID。
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
+}
+
+// ChangedMsg 在用户从展开的列表中选择了与之前不同的选项后发送。
+type ChangedMsg struct {
+	ID    int    // 下拉选择的 ID
+	Index int    // 选中选项的索引
+	Value string // 选中的选项
+}
+
+// KeyMap 是下拉选择的按键绑定。展开后，列表中的导航和过滤使用 list 组件的按键绑定。
+type KeyMap struct {
+	Open   key.Binding // 展开选项列表
+	Accept key.Binding // 选择光标所在的选项并收起
+	Cancel key.Binding // 收起而不改变选择
+}
+
+// ShortHelp 实现 help.KeyMap 接口。
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Open, k.Accept, k.Cancel}
+}
+
+// FullHelp 实现 help.KeyMap 接口。
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+// DefaultKeyMap 返回一组默认的按键绑定。
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Open: key.NewBinding(
+			key.WithKeys("enter", " ", "down"),
+			key.WithHelp("enter", "展开"),
+		),
+		Accept: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "选择"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "取消"),
+		),
+	}
+}
+
+// Styles 包含下拉选择的样式。
+type Styles struct {
+	Field        lipgloss.Style // 收起时的字段
+	FocusedField lipgloss.Style // 聚焦时的字段
+	Placeholder  lipgloss.Style // 没有选择时的占位符
+	Indicator    lipgloss.Style // 字段右侧的展开指示符
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	return Styles{
+		Field:        lipgloss.NewStyle(),
+		FocusedField: lipgloss.NewStyle().Foreground(lipgloss.Color("212")),
+		Placeholder:  lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		Indicator:    lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+	}
+}
+
+// option 是列表中的一个选项。
+type option string
+
+func (o option) FilterValue() string { return string(o) }
+func (o option) Title() string       { return string(o) }
+func (o option) Description() string { return "" }
+
+// Model 是下拉选择组件的 Bubble Tea 模型。
+type Model struct {
+	KeyMap KeyMap
+	Styles Styles
+
+	// Placeholder 在没有选择任何选项时显示。
+	Placeholder string
+
+	// Indicator 显示在字段的右侧，提示字段可以展开。
+	Indicator string
+
+	// Width 是字段和展开的列表的宽度。
+	Width int
+
+	// MaxHeight 是展开的列表最多同时显示的选项数量，超出的选项分页显示。
+	MaxHeight int
+
+	// FilterThreshold 是启用过滤所需的选项数量：选项多于它时，
+	// 展开的列表可以按 / 过滤。如果为 0 或更小，则总是可以过滤。
+	FilterThreshold int
+
+	// List 是展开时显示的选项列表。可以通过它配置列表的样式和按键绑定。
+	List list.Model
+
+	id       int
+	options  []string
+	selected int // 选中的选项，-1 表示没有选择
+	open     bool
+	focus    bool
+}
+
+// New 返回一个带有给定选项的下拉选择，默认没有选择任何选项。
+func New(options []string) Model {
+	d := list.NewDefaultDelegate()
+	d.ShowDescription = false
+	d.SetSpacing(0)
+
+	l := list.New(nil, d, defaultWidth, defaultMaxHeight)
+	l.SetShowTitle(false)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetEmbedded(true)
+	l.Styles.TitleBar = lipgloss.NewStyle()
+	l.Styles.PaginationStyle = lipgloss.NewStyle().PaddingLeft(2) //nolint:mnd
+
+	m := Model{
+		KeyMap:          DefaultKeyMap(),
+		Styles:          DefaultStyles(),
+		Indicator:       "▾",
+		Width:           defaultWidth,
+		MaxHeight:       defaultMaxHeight,
+		FilterThreshold: defaultFilterThreshold,
+		List:            l,
+		id:              nextID(),
+		selected:        -1,
+	}
+	m.SetOptions(options)
+	return m
+}
+
+// ID 返回下拉选择的唯一 ID。
+func (m Model) ID() int {
+	return m.id
+}
+
+// SetOptions 设置选项。如果之前选中的选项仍然存在，则保持选中它。
+func (m *Model) SetOptions(options []string) {
+	value := m.Value()
+	m.options = append([]string(nil), options...)
+	m.selected = -1
+	for i, o := range m.options {
+		if o == value && value != "" {
+			m.selected = i
+			break
+		}
+	}
+
+	items := make([]list.Item, len(m.options))
+	for i, o := range m.options {
+		items[i] = option(o)
+	}
+	m.List.SetItems(items)
+}
+
+// Options 返回选项。
+func (m Model) Options() []string {
+	return m.options
+}
+
+// Value 返回选中的选项。如果没有选择任何选项，返回空字符串。
+func (m Model) Value() string {
+	if m.selected < 0 || m.selected >= len(m.options) {
+		return ""
+	}
+	return m.options[m.selected]
+}
+
+// Index 返回选中选项的索引。如果没有选择任何选项，返回 -1。
+func (m Model) Index() int {
+	return m.selected
+}
+
+// Select 选中给定索引的选项，不发送 ChangedMsg。传入 -1 清除选择。
+func (m *Model) Select(i int) {
+	if i < -1 || i >= len(m.options) {
+		return
+	}
+	m.selected = i
+}
+
+// SetValue 选中与 v 相同的选项。如果没有这样的选项，返回 false。
+func (m *Model) SetValue(v string) bool {
+	for i, o := range m.options {
+		if o == v {
+			m.selected = i
+			return true
+		}
+	}
+	return false
+}
+
+// Focus 聚焦下拉选择，使它响应按键。
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+// Blur 使下拉选择失去焦点，并收起选项列表。
+func (m *Model) Blur() {
+	m.focus = false
+	m.Close()
+}
+
+// Focused 返回下拉选择是否聚焦。
+func (m Model) Focused() bool {
+	return m.focus
+}
+
+// Open 展开选项列表，光标位于选中的选项上。
+func (m *Model) Open() {
+	if len(m.options) == 0 {
+		return
+	}
+	m.open = true
+	m.List.ResetFilter()
+	m.List.SetFilteringEnabled(len(m.options) > m.FilterThreshold)
+	m.List.Select(max(0, m.selected))
+	m.resize()
+}
+
+// Close 收起选项列表而不改变选择。
+func (m *Model) Close() {
+	m.open = false
+	m.List.ResetFilter()
+}
+
+// IsOpen 返回选项列表是否展开。
+func (m Model) IsOpen() bool {
+	return m.open
+}
+
+// resize 根据选项数量和过滤状态设置列表的大小：选项少于 MaxHeight 时列表不留空行，
+// 只在需要时显示过滤输入和分页器。
+func (m *Model) resize() {
+	rows := len(m.List.VisibleItems())
+	if m.List.FilterState() == list.Unfiltered {
+		rows = len(m.options)
+	}
+	maxHeight := m.MaxHeight
+	if maxHeight <= 0 {
+		maxHeight = defaultMaxHeight
+	}
+	paged := rows > maxHeight
+	rows = max(1, min(rows, maxHeight))
+
+	filtering := m.List.FilterState() != list.Unfiltered
+	m.List.SetShowFilter(filtering)
+	m.List.SetShowPagination(paged)
+
+	height := rows
+	if filtering {
+		height++
+	}
+	if paged {
+		height++
+	}
+	m.List.SetSize(m.Width, height)
+}
+
+// Update 处理按键：收起时 Open 键展开选项列表；展开后 Accept 键选择光标所在的选项，
+// Cancel 键收起，其他按键由列表处理（导航和过滤）。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if isKey && !m.focus {
+		return m, nil
+	}
+
+	if !m.open {
+		if isKey && key.Matches(keyMsg, m.KeyMap.Open) {
+			m.Open()
+			return m, nil
+		}
+		// 过滤的结果可能在收起后才到达。
+		if !isKey {
+			var cmd tea.Cmd
+			m.List, cmd = m.List.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if isKey && !m.List.SettingFilter() {
+		switch {
+		case key.Matches(keyMsg, m.KeyMap.Accept):
+			return m, m.accept()
+		case key.Matches(keyMsg, m.KeyMap.Cancel):
+			m.Close()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.List, cmd = m.List.Update(msg)
+	m.resize()
+	return m, cmd
+}
+
+// accept 选择光标所在的选项并收起列表。如果选择发生了变化，返回发送 ChangedMsg 的命令。
+func (m *Model) accept() tea.Cmd {
+	if m.List.SelectedItem() == nil {
+		return nil
+	}
+	i := m.List.GlobalIndex()
+	m.Close()
+	if i == m.selected {
+		return nil
+	}
+	m.selected = i
+	msg := ChangedMsg{ID: m.id, Index: i, Value: m.options[i]}
+	return func() tea.Msg { return msg }
+}
+
+// View 渲染字段，以及展开时字段下方的选项列表。
+func (m Model) View() string {
+	style := m.Styles.Field
+	if m.focus {
+		style = m.Styles.FocusedField
+	}
+
+	text := style.Inline(true).Render(m.Value())
+	if m.selected < 0 {
+		text = m.Styles.Placeholder.Inline(true).Render(m.Placeholder)
+	}
+	indicator := m.Styles.Indicator.Inline(true).Render(m.Indicator)
+
+	w := max(lipgloss.Width(text)+lipgloss.Width(indicator)+1, m.Width)
+	field := lipgloss.NewStyle().Width(w-lipgloss.Width(indicator)).Render(text) + indicator
+	if !m.open {
+		return field
+	}
+	return field + "\n" + m.List.View()
+}
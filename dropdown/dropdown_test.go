@@ -0,0 +1,126 @@
+package dropdown
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+func keyMsg(k tea.KeyType) tea.KeyMsg {
+	return tea.KeyMsg{Type: k}
+}
+
+func runeMsg(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestSelect(t *testing.T) {
+	m := New([]string{"red", "green", "blue"})
+	m.Placeholder = "pick a color"
+	m.Focus()
+
+	if got := ansi.Strip(m.View()); !strings.HasPrefix(got, "pick a color") {
+		t.Fatalf("expected placeholder, got %q", got)
+	}
+
+	m, _ = m.Update(keyMsg(tea.KeyEnter))
+	if !m.IsOpen() {
+		t.Fatal("expected dropdown to be open")
+	}
+	if got := ansi.Strip(m.View()); !strings.Contains(got, "green") {
+		t.Fatalf("expected options in view, got %q", got)
+	}
+
+	m, _ = m.Update(keyMsg(tea.KeyDown))
+	m, cmd := m.Update(keyMsg(tea.KeyEnter))
+	if m.IsOpen() {
+		t.Fatal("expected dropdown to be closed")
+	}
+	if cmd == nil {
+		t.Fatal("expected ChangedMsg command")
+	}
+	msg, ok := cmd().(ChangedMsg)
+	if !ok || msg.Index != 1 || msg.Value != "green" || msg.ID != m.ID() {
+		t.Fatalf("unexpected message %#v", msg)
+	}
+	if m.Value() != "green" {
+		t.Fatalf("expected value green, got %q", m.Value())
+	}
+
+	// 选择相同的选项不发送 ChangedMsg。
+	m, _ = m.Update(keyMsg(tea.KeyEnter))
+	m, cmd = m.Update(keyMsg(tea.KeyEnter))
+	if cmd != nil {
+		t.Fatal("expected no command when the choice is unchanged")
+	}
+
+	// 取消不改变选择。
+	m, _ = m.Update(keyMsg(tea.KeyEnter))
+	m, _ = m.Update(keyMsg(tea.KeyDown))
+	m, _ = m.Update(keyMsg(tea.KeyEsc))
+	if m.IsOpen() || m.Value() != "green" {
+		t.Fatalf("expected closed with value green, got open=%v value=%q", m.IsOpen(), m.Value())
+	}
+
+	if got := strings.Count(m.View(), "\n"); got != 0 {
+		t.Fatalf("expected a single line when closed, got %d newlines", got)
+	}
+}
+
+func TestUnfocused(t *testing.T) {
+	m := New([]string{"a", "b"})
+	m, _ = m.Update(keyMsg(tea.KeyEnter))
+	if m.IsOpen() {
+		t.Fatal("expected unfocused dropdown to ignore keys")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	options := make([]string, 20)
+	for i := range options {
+		options[i] = fmt.Sprintf("option %d", i)
+	}
+	options[13] = "needle"
+
+	m := New(options)
+	m.Focus()
+	m, _ = m.Update(keyMsg(tea.KeyEnter))
+	if !m.List.FilteringEnabled() {
+		t.Fatal("expected filtering to be enabled for long option sets")
+	}
+
+	m.List.SetFilterText("needle")
+	m, cmd := m.Update(keyMsg(tea.KeyEnter))
+	if cmd == nil {
+		t.Fatal("expected ChangedMsg command")
+	}
+	if msg := cmd().(ChangedMsg); msg.Index != 13 || msg.Value != "needle" {
+		t.Fatalf("unexpected message %#v", msg)
+	}
+
+	short := New([]string{"a", "b"})
+	short.Focus()
+	short, _ = short.Update(keyMsg(tea.KeyEnter))
+	if short.List.FilteringEnabled() {
+		t.Fatal("expected filtering to be disabled for short option sets")
+	}
+}
+
+func TestSetOptions(t *testing.T) {
+	m := New([]string{"a", "b", "c"})
+	m.Select(2)
+	m.SetOptions([]string{"c", "d"})
+	if m.Index() != 0 || m.Value() != "c" {
+		t.Fatalf("expected selection to follow value, got %d %q", m.Index(), m.Value())
+	}
+	m.SetOptions([]string{"x"})
+	if m.Index() != -1 {
+		t.Fatalf("expected selection to be cleared, got %d", m.Index())
+	}
+	if !m.SetValue("x") || m.Value() != "x" {
+		t.Fatal("expected SetValue to select x")
+	}
+}
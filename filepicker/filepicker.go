@@ -4,14 +4,16 @@ package filepicker
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/progress"
+	"github.com/purpose168/bubbles-cn/textinput"
 	tea "github.com/purpose168/bubbletea-cn"
 	lipgloss "github.com/purpose168/lipgloss-cn"
 )
@@ -29,6 +31,7 @@ func New() Model {
 		id:               nextID(),        // 生成唯一 ID
 		CurrentDirectory: ".",             // 当前目录默认为当前工作目录
 		Cursor:           ">",             // 光标默认样式
+		Marker:           "*",             // 标记默认样式
 		AllowedTypes:     []string{},      // 允许的文件类型，默认为空（允许所有文件）
 		selected:         0,               // 当前选中的文件索引
 		ShowPermissions:  true,            // 是否显示文件权限
@@ -45,6 +48,7 @@ func New() Model {
 		maxStack:         newStack(),      // 最大索引栈
 		KeyMap:           DefaultKeyMap(), // 默认键映射
 		Styles:           DefaultStyles(), // 默认样式
+		Progress:         newProgress(),   // 文件操作进度条
 	}
 }
 
@@ -57,12 +61,15 @@ type errorMsg struct {
 type readDirMsg struct {
 	id      int
 	entries []os.DirEntry
+	infos   []os.FileInfo
+	links   map[string]linkInfo
 }
 
 const (
-	marginBottom  = 5 // 底部边距
-	fileSizeWidth = 7 // 文件大小显示宽度
-	paddingLeft   = 2 // 左侧内边距
+	marginBottom  = 5  // 底部边距
+	fileSizeWidth = 7  // 文件大小显示宽度
+	paddingLeft   = 2  // 左侧内边距
+	progressWidth = 30 // 文件操作进度条宽度
 )
 
 // KeyMap 定义每个用户操作的键绑定。
@@ -76,20 +83,40 @@ type KeyMap struct {
 	Back     key.Binding // 返回上一级目录
 	Open     key.Binding // 打开文件或目录
 	Select   key.Binding // 选择文件
+	Mark     key.Binding // 标记或取消标记文件
+	Cancel   key.Binding // 取消正在进行的文件操作
+	Undo     key.Binding // 恢复最近一次删除的文件
+	NewDir   key.Binding // 新建目录
+	Rename   key.Binding // 重命名当前条目
+	Delete   key.Binding // 删除当前条目或已标记的条目
+	Confirm  key.Binding // 确认删除
+	GoTo     key.Binding // 输入路径并跳转到该目录，默认禁用，参见 SetGoToKey
+	Sort     key.Binding // 切换到下一种排序方式，默认禁用，参见 SetSortKeys
+	Reverse  key.Binding // 反转排序方向，默认禁用，参见 SetSortKeys
 }
 
 // DefaultKeyMap 定义默认键绑定。
 func DefaultKeyMap() KeyMap {
 	return KeyMap{
-		GoToTop:  key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "first")),                            // g 键跳转到顶部
-		GoToLast: key.NewBinding(key.WithKeys("G"), key.WithHelp("G", "last")),                             // G 键跳转到底部
-		Down:     key.NewBinding(key.WithKeys("j", "down", "ctrl+n"), key.WithHelp("j", "down")),           // j/下箭头/ctrl+n 向下移动
-		Up:       key.NewBinding(key.WithKeys("k", "up", "ctrl+p"), key.WithHelp("k", "up")),               // k/上箭头/ctrl+p 向上移动
-		PageUp:   key.NewBinding(key.WithKeys("K", "pgup"), key.WithHelp("pgup", "page up")),               // K/PageUp 向上翻页
-		PageDown: key.NewBinding(key.WithKeys("J", "pgdown"), key.WithHelp("pgdown", "page down")),         // J/PageDown 向下翻页
-		Back:     key.NewBinding(key.WithKeys("h", "backspace", "left", "esc"), key.WithHelp("h", "back")), // h/退格/左箭头/Esc 返回上一级
-		Open:     key.NewBinding(key.WithKeys("l", "right", "enter"), key.WithHelp("l", "open")),           // l/右箭头/Enter 打开
-		Select:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),                   // Enter 选择
+		GoToTop:  key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "first")),                               // g 键跳转到顶部
+		GoToLast: key.NewBinding(key.WithKeys("G"), key.WithHelp("G", "last")),                                // G 键跳转到底部
+		Down:     key.NewBinding(key.WithKeys("j", "down", "ctrl+n"), key.WithHelp("j", "down")),              // j/下箭头/ctrl+n 向下移动
+		Up:       key.NewBinding(key.WithKeys("k", "up", "ctrl+p"), key.WithHelp("k", "up")),                  // k/上箭头/ctrl+p 向上移动
+		PageUp:   key.NewBinding(key.WithKeys("K", "pgup"), key.WithHelp("pgup", "page up")),                  // K/PageUp 向上翻页
+		PageDown: key.NewBinding(key.WithKeys("J", "pgdown"), key.WithHelp("pgdown", "page down")),            // J/PageDown 向下翻页
+		Back:     key.NewBinding(key.WithKeys("h", "backspace", "left", "esc"), key.WithHelp("h", "back")),    // h/退格/左箭头/Esc 返回上一级
+		Open:     key.NewBinding(key.WithKeys("l", "right", "enter"), key.WithHelp("l", "open")),              // l/右箭头/Enter 打开
+		Select:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),                      // Enter 选择
+		Mark:     key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),                            // 空格标记
+		Cancel:   key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),                          // Esc 取消文件操作
+		Undo:     key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "undo")),                                // u 恢复最近一次删除的文件
+		NewDir:   key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new directory")),                       // n 新建目录
+		Rename:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "rename")),                              // r 重命名
+		Delete:   key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),                              // d 删除
+		Confirm:  key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "confirm")),                             // y 确认删除
+		GoTo:     key.NewBinding(key.WithKeys("ctrl+l"), key.WithHelp("ctrl+l", "go to"), key.WithDisabled()), // ctrl+l 输入路径跳转
+		Sort:     key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort"), key.WithDisabled()),            // s 切换排序方式
+		Reverse:  key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "reverse sort"), key.WithDisabled()),    // S 反转排序方向
 	}
 }
 
@@ -98,6 +125,7 @@ type Styles struct {
 	DisabledCursor   lipgloss.Style // 禁用状态的光标样式
 	Cursor           lipgloss.Style // 光标样式
 	Symlink          lipgloss.Style // 符号链接样式
+	BrokenSymlink    lipgloss.Style // 失效或陷入循环的符号链接样式
 	Directory        lipgloss.Style // 目录样式
 	File             lipgloss.Style // 文件样式
 	DisabledFile     lipgloss.Style // 禁用状态的文件样式
@@ -106,6 +134,10 @@ type Styles struct {
 	DisabledSelected lipgloss.Style // 禁用状态的选中项样式
 	FileSize         lipgloss.Style // 文件大小样式
 	EmptyDirectory   lipgloss.Style // 空目录样式
+	Marker           lipgloss.Style // 标记样式
+	OpStatus         lipgloss.Style // 文件操作进度和结果的样式
+	OpError          lipgloss.Style // 文件操作错误的样式
+	SortStatus       lipgloss.Style // 显示排序方式的页脚样式
 }
 
 // DefaultStyles 定义文件选择器的默认样式。
@@ -120,6 +152,7 @@ func DefaultStylesWithRenderer(r *lipgloss.Renderer) Styles {
 		DisabledCursor:   r.NewStyle().Foreground(lipgloss.Color("247")),                                                               // 禁用光标颜色
 		Cursor:           r.NewStyle().Foreground(lipgloss.Color("212")),                                                               // 光标颜色
 		Symlink:          r.NewStyle().Foreground(lipgloss.Color("36")),                                                                // 符号链接颜色
+		BrokenSymlink:    r.NewStyle().Foreground(lipgloss.Color("196")).Strikethrough(true),                                           // 失效符号链接颜色
 		Directory:        r.NewStyle().Foreground(lipgloss.Color("99")),                                                                // 目录颜色
 		File:             r.NewStyle(),                                                                                                 // 文件默认样式
 		DisabledFile:     r.NewStyle().Foreground(lipgloss.Color("243")),                                                               // 禁用文件颜色
@@ -128,6 +161,10 @@ func DefaultStylesWithRenderer(r *lipgloss.Renderer) Styles {
 		Selected:         r.NewStyle().Foreground(lipgloss.Color("212")).Bold(true),                                                    // 选中项颜色和样式
 		FileSize:         r.NewStyle().Foreground(lipgloss.Color("240")).Width(fileSizeWidth).Align(lipgloss.Right),                    // 文件大小样式
 		EmptyDirectory:   r.NewStyle().Foreground(lipgloss.Color("240")).PaddingLeft(paddingLeft).SetString("Bummer. No Files Found."), // 空目录提示
+		Marker:           r.NewStyle().Foreground(lipgloss.Color("212")),                                                               // 标记颜色
+		OpStatus:         r.NewStyle().Foreground(lipgloss.Color("240")),                                                               // 文件操作状态颜色
+		OpError:          r.NewStyle().Foreground(lipgloss.Color("196")),                                                               // 文件操作错误颜色
+		SortStatus:       r.NewStyle().Foreground(lipgloss.Color("240")),                                                               // 排序页脚颜色
 	}
 }
 
@@ -145,13 +182,32 @@ type Model struct {
 	// 如果为空，用户可以选择任何文件。
 	AllowedTypes []string
 
-	KeyMap          KeyMap        // 键绑定
-	files           []os.DirEntry // 文件列表
-	ShowPermissions bool          // 是否显示权限
-	ShowSize        bool          // 是否显示大小
-	ShowHidden      bool          // 是否显示隐藏文件
-	DirAllowed      bool          // 是否允许选择目录
-	FileAllowed     bool          // 是否允许选择文件
+	// FileSystem 是文件选择器浏览的文件系统。如果为 nil，则使用操作系统的文件系统。
+	// 使用非操作系统文件系统时，CurrentDirectory 应遵循 io/fs 的路径约定（例如 "."）。
+	FileSystem FS
+
+	// WatchInterval 启用监视模式：文件选择器每隔 WatchInterval 重新读取当前目录，
+	// 在文件被创建、删除或修改后更新文件列表并发送 DirectoryChangedMsg，
+	// 同时按名称保持选中的条目。如果为 0，则不监视。监视从 Init 开始。
+	WatchInterval time.Duration
+
+	KeyMap          KeyMap              // 键绑定
+	files           []os.DirEntry       // 文件列表
+	infos           []os.FileInfo       // 文件信息，与 files 一一对应
+	links           map[string]linkInfo // 符号链接的解析结果，以名称为键
+	ShowPermissions bool                // 是否显示权限
+	ShowSize        bool                // 是否显示大小
+	ShowHidden      bool                // 是否显示隐藏文件
+	DirAllowed      bool                // 是否允许选择目录
+	FileAllowed     bool                // 是否允许选择文件
+
+	// SortMode 和 SortDescending 决定目录条目的排序方式和方向。目录总是排在文件之前。
+	// 使用 SetSort 修改它们会立即重新排序当前目录；直接修改字段在下一次读取目录时生效。
+	SortMode       SortMode
+	SortDescending bool
+
+	// ShowSort 在列表下方显示当前排序方式的页脚。
+	ShowSort bool
 
 	FileSelected  string // 选中的文件
 	selected      int    // 当前选中的索引
@@ -170,6 +226,46 @@ type Model struct {
 
 	Cursor string // 光标样式
 	Styles Styles // 样式
+
+	// IconFunc 返回显示在条目名称之前的图标，例如按文件类型选择的 Nerd Font 图标。
+	// 如果为 nil 或返回空字符串，则不显示图标。
+	IconFunc func(os.DirEntry) string
+
+	// MultiSelect 启用标记模式：Mark 键切换当前条目的标记，
+	// 并在光标之后显示一列标记。
+	MultiSelect bool
+
+	// Marker 是标记列中已标记条目的指示符。
+	Marker string
+
+	marks map[string]struct{} // 已标记的路径
+
+	// Progress 是文件操作（参见 Copy 和 Delete）的进度条。
+	Progress progress.Model
+
+	// Trash 是 Delete 使用的回收站。如果为 nil，使用操作系统文件系统时会使用
+	// 平台默认的回收站（参见 DefaultTrash）；如果没有可用的回收站，删除是永久性的。
+	Trash Trash
+
+	// PermanentDelete 禁用回收站，使 Delete 永久删除文件。
+	PermanentDelete bool
+
+	// FileManagement 启用 NewDir、Rename 和 Delete 键。新建和重命名在列表下方
+	// 显示一个输入框，删除需要按 Confirm 键确认。操作的错误显示在同一行中。
+	// 文件系统必须实现 WritableFS。
+	FileManagement bool
+
+	prompt        promptKind      // 正在显示的提示
+	promptInput   textinput.Model // 新建和重命名的输入框
+	promptTargets []string        // 重命名或删除的路径
+	promptDir     string          // 跳转提示中已经读取了补全建议的目录
+
+	trashed []TrashedFile // 最近一次删除的文件，可以使用 Undo 恢复
+
+	op         *operation    // 正在进行的文件操作
+	opProgress OpProgressMsg // 正在进行的文件操作的进度
+	opStatus   string        // 最近一次文件操作的结果
+	opFailed   bool          // 最近一次文件操作是否失败
 }
 
 // stack 表示栈结构，用于存储目录导航历史。
@@ -212,39 +308,50 @@ func (m *Model) popView() (int, int, int) {
 // readDir 读取目录内容并返回命令。
 func (m Model) readDir(path string, showHidden bool) tea.Cmd {
 	return func() tea.Msg {
-		dirEntries, err := os.ReadDir(path)
+		entries, err := m.listDir(path, showHidden)
 		if err != nil {
 			return errorMsg{err}
 		}
+		infos := statEntries(entries)
+		entries, infos = m.sortEntries(entries, infos)
+		return readDirMsg{id: m.id, entries: entries, infos: infos, links: m.resolveLinks(path, entries)}
+	}
+}
 
-		// 排序目录项：目录在前，文件在后，然后按名称排序
-		sort.Slice(dirEntries, func(i, j int) bool {
-			if dirEntries[i].IsDir() == dirEntries[j].IsDir() {
-				return dirEntries[i].Name() < dirEntries[j].Name()
-			}
-			return dirEntries[i].IsDir()
-		})
+// listDir 读取目录内容：目录在前，文件在后，然后按名称排序，并按需过滤隐藏文件。
+func (m Model) listDir(path string, showHidden bool) ([]os.DirEntry, error) {
+	dirEntries, err := m.fsys().ReadDir(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
 
-		if showHidden {
-			return readDirMsg{id: m.id, entries: dirEntries}
+	// 排序目录项：目录在前，文件在后，然后按名称排序
+	sort.Slice(dirEntries, func(i, j int) bool {
+		if dirEntries[i].IsDir() == dirEntries[j].IsDir() {
+			return dirEntries[i].Name() < dirEntries[j].Name()
 		}
+		return dirEntries[i].IsDir()
+	})
 
-		// 过滤隐藏文件
-		var sanitizedDirEntries []os.DirEntry
-		for _, dirEntry := range dirEntries {
-			isHidden, _ := IsHidden(dirEntry.Name())
-			if isHidden {
-				continue
-			}
-			sanitizedDirEntries = append(sanitizedDirEntries, dirEntry)
+	if showHidden {
+		return dirEntries, nil
+	}
+
+	// 过滤隐藏文件
+	var sanitizedDirEntries []os.DirEntry
+	for _, dirEntry := range dirEntries {
+		isHidden, _ := IsHidden(dirEntry.Name())
+		if isHidden {
+			continue
 		}
-		return readDirMsg{id: m.id, entries: sanitizedDirEntries}
+		sanitizedDirEntries = append(sanitizedDirEntries, dirEntry)
 	}
+	return sanitizedDirEntries, nil
 }
 
 // Init 初始化文件选择器模型。
 func (m Model) Init() tea.Cmd {
-	return m.readDir(m.CurrentDirectory, m.ShowHidden)
+	return tea.Batch(m.readDir(m.CurrentDirectory, m.ShowHidden), m.watchTick())
 }
 
 // SetHeight 设置文件选择器的高度。
@@ -263,14 +370,57 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			break
 		}
 		m.files = msg.entries
+		m.infos = msg.infos
+		m.links = msg.links
 		m.max = max(m.max, m.Height-1)
+		// 重新读取目录（例如在删除之后）时，条目可能变少了。
+		if m.selected >= len(m.files) {
+			m.selected = max(0, len(m.files)-1)
+		}
 	case tea.WindowSizeMsg:
 		if m.AutoHeight {
 			m.Height = msg.Height - marginBottom
 		}
 		m.max = m.Height - 1
+	case watchTickMsg:
+		if msg.id != m.id {
+			break
+		}
+		return m, m.pollDir()
+	case pollDirMsg:
+		if msg.id != m.id {
+			break
+		}
+		return m, m.handlePoll(msg)
+	case OpProgressMsg:
+		return m, m.handleOpProgress(msg)
+	case OpDoneMsg:
+		return m, m.handleOpDone(msg)
 	case tea.KeyMsg:
+		if m.Prompting() {
+			return m, m.updatePrompt(msg)
+		}
+		// 按键清除最近一次文件操作的结果。
+		if m.op == nil {
+			m.opStatus = ""
+		}
 		switch {
+		case m.op != nil && key.Matches(msg, m.KeyMap.Cancel):
+			m.CancelOp()
+		case m.CanUndo() && key.Matches(msg, m.KeyMap.Undo):
+			return m, m.Undo()
+		case key.Matches(msg, m.KeyMap.GoTo):
+			return m, m.startPrompt(promptGoto)
+		case key.Matches(msg, m.KeyMap.Sort):
+			m.cycleSort()
+		case key.Matches(msg, m.KeyMap.Reverse):
+			m.SetSort(m.SortMode, !m.SortDescending)
+		case m.FileManagement && m.op == nil && key.Matches(msg, m.KeyMap.NewDir):
+			return m, m.startPrompt(promptMkdir)
+		case m.FileManagement && m.op == nil && key.Matches(msg, m.KeyMap.Rename):
+			return m, m.startPrompt(promptRename)
+		case m.FileManagement && m.op == nil && key.Matches(msg, m.KeyMap.Delete):
+			return m, m.startPrompt(promptDelete)
 		case key.Matches(msg, m.KeyMap.GoToTop):
 			m.selected = 0
 			m.min = 0
@@ -322,7 +472,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.max = m.min + m.Height
 			}
 		case key.Matches(msg, m.KeyMap.Back):
-			m.CurrentDirectory = filepath.Dir(m.CurrentDirectory)
+			m.CurrentDirectory = m.dir(m.CurrentDirectory)
 			if m.selectedStack.Length() > 0 {
 				m.selected, m.min, m.max = m.popView()
 			} else {
@@ -331,34 +481,45 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.max = m.Height - 1
 			}
 			return m, m.readDir(m.CurrentDirectory, m.ShowHidden)
+		case m.MultiSelect && key.Matches(msg, m.KeyMap.Mark):
+			if len(m.files) == 0 {
+				break
+			}
+			m.toggleMark(m.files[m.selected])
+			if m.selected < len(m.files)-1 {
+				m.selected++
+				if m.selected > m.max {
+					m.min++
+					m.max++
+				}
+			}
+		case m.MultiSelect && len(m.marks) > 0 && key.Matches(msg, m.KeyMap.Select):
+			// 已有标记时，Select 确认标记的条目，而不是打开当前条目。
+			// 参见 DidConfirmSelection。
 		case key.Matches(msg, m.KeyMap.Open):
 			if len(m.files) == 0 {
 				break
 			}
 
 			f := m.files[m.selected]
-			info, err := f.Info()
-			if err != nil {
-				break
-			}
-			isSymlink := info.Mode()&os.ModeSymlink != 0
+			isSymlink := f.Type()&os.ModeSymlink != 0
 			isDir := f.IsDir()
 
+			link := m.links[f.Name()]
 			if isSymlink {
-				symlinkPath, _ := filepath.EvalSymlinks(filepath.Join(m.CurrentDirectory, f.Name()))
-				info, err := os.Stat(symlinkPath)
-				if err != nil {
+				if link.state != linkOK {
+					m.reportLink(f.Name())
 					break
 				}
-				if info.IsDir() {
+				if link.dir {
 					isDir = true
 				}
 			}
 
 			if (!isDir && m.FileAllowed) || (isDir && m.DirAllowed) {
-				if key.Matches(msg, m.KeyMap.Select) {
+				if key.Check(msg, m.KeyMap.Select) {
 					// 选择当前路径作为选择结果
-					m.Path = filepath.Join(m.CurrentDirectory, f.Name())
+					m.Path = m.join(m.CurrentDirectory, f.Name())
 				}
 			}
 
@@ -366,19 +527,46 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				break
 			}
 
-			m.CurrentDirectory = filepath.Join(m.CurrentDirectory, f.Name())
+			// 不进入指向上级目录的符号链接，否则路径会无限加深。
+			if isSymlink && link.cycle {
+				m.reportLink(f.Name())
+				break
+			}
+
+			m.CurrentDirectory = m.join(m.CurrentDirectory, f.Name())
 			m.pushView(m.selected, m.min, m.max)
 			m.selected = 0
 			m.min = 0
 			m.max = m.Height - 1
 			return m, m.readDir(m.CurrentDirectory, m.ShowHidden)
 		}
+	default:
+		if m.Prompting() {
+			return m, m.updatePrompt(msg)
+		}
 	}
 	return m, nil
 }
 
-// View 返回文件选择器的视图。
+// View 返回文件选择器的视图。如果有正在进行的文件操作，其进度显示在列表下方；
+// 新建、重命名、删除和跳转的提示也显示在列表下方。启用 ShowSort 时，
+// 排序方式显示在它们之前。
 func (m Model) View() string {
+	view := m.filesView()
+	if footer := m.sortView(); footer != "" {
+		view += "\n" + footer
+	}
+	if m.Prompting() {
+		return view + "\n" + m.promptView()
+	}
+	if op := m.opView(); op != "" {
+		return view + "\n" + op
+	}
+	return view
+}
+
+// filesView 渲染文件列表。
+func (m Model) filesView() string {
 	if len(m.files) == 0 {
 		return m.Styles.EmptyDirectory.Height(m.Height).MaxHeight(m.Height).String()
 	}
@@ -390,13 +578,17 @@ func (m Model) View() string {
 		}
 
 		var symlinkPath string
-		info, _ := f.Info()
-		isSymlink := info.Mode()&os.ModeSymlink != 0
-		size := strings.Replace(humanize.Bytes(uint64(info.Size())), " ", "", 1) //nolint:gosec
+		link := m.links[f.Name()]
+		isSymlink := f.Type()&os.ModeSymlink != 0
+		mode, size := f.Type().String(), ""
+		if info, err := m.fileInfo(i); err == nil {
+			mode = info.Mode().String()
+			size = strings.Replace(humanize.Bytes(uint64(info.Size())), " ", "", 1) //nolint:gosec
+		}
 		name := f.Name()
 
 		if isSymlink {
-			symlinkPath, _ = filepath.EvalSymlinks(filepath.Join(m.CurrentDirectory, name))
+			symlinkPath = " → " + link.target + link.state.annotation()
 		}
 
 		disabled := !m.canSelect(name) && !f.IsDir()
@@ -404,19 +596,19 @@ func (m Model) View() string {
 		if m.selected == i { //nolint:nestif
 			selected := ""
 			if m.ShowPermissions {
-				selected += " " + info.Mode().String()
+				selected += " " + mode
 			}
 			if m.ShowSize {
 				selected += fmt.Sprintf("%"+strconv.Itoa(m.Styles.FileSize.GetWidth())+"s", size)
 			}
-			selected += " " + name
+			selected += " " + m.iconView(f) + name
 			if isSymlink {
-				selected += " → " + symlinkPath
+				selected += symlinkPath
 			}
 			if disabled {
-				s.WriteString(m.Styles.DisabledSelected.Render(m.Cursor) + m.Styles.DisabledSelected.Render(selected))
+				s.WriteString(m.Styles.DisabledSelected.Render(m.Cursor) + m.markerView(name) + m.Styles.DisabledSelected.Render(selected))
 			} else {
-				s.WriteString(m.Styles.Cursor.Render(m.Cursor) + m.Styles.Selected.Render(selected))
+				s.WriteString(m.Styles.Cursor.Render(m.Cursor) + m.markerView(name) + m.Styles.Selected.Render(selected))
 			}
 			s.WriteRune('\n')
 			continue
@@ -425,19 +617,22 @@ func (m Model) View() string {
 		style := m.Styles.File
 		if f.IsDir() {
 			style = m.Styles.Directory
+		} else if isSymlink && link.state != linkOK {
+			style = m.Styles.BrokenSymlink
 		} else if isSymlink {
 			style = m.Styles.Symlink
 		} else if disabled {
 			style = m.Styles.DisabledFile
 		}
 
-		fileName := style.Render(name)
+		fileName := m.iconView(f) + style.Render(name)
 		s.WriteString(m.Styles.Cursor.Render(" "))
+		s.WriteString(m.markerView(name))
 		if isSymlink {
-			fileName += " → " + symlinkPath
+			fileName += symlinkPath
 		}
 		if m.ShowPermissions {
-			s.WriteString(" " + m.Styles.Permission.Render(info.Mode().String()))
+			s.WriteString(" " + m.Styles.Permission.Render(mode))
 		}
 		if m.ShowSize {
 			s.WriteString(m.Styles.FileSize.Render(size))
@@ -481,23 +676,23 @@ func (m Model) didSelectFile(msg tea.Msg) (bool, string) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		// 如果消息与 Select 键映射不匹配，则这不可能是选择操作。
-		if !key.Matches(msg, m.KeyMap.Select) {
+		if !key.Check(msg, m.KeyMap.Select) {
+			return false, ""
+		}
+
+		// 已有标记时，Select 确认的是标记的条目，参见 DidConfirmSelection。
+		if m.MultiSelect && len(m.marks) > 0 {
 			return false, ""
 		}
 
 		// 按键是选择操作，让我们确认当前文件是否可以
 		// 被选择或用于导航到更深层次的堆栈。
 		f := m.files[m.selected]
-		info, err := f.Info()
-		if err != nil {
-			return false, ""
-		}
-		isSymlink := info.Mode()&os.ModeSymlink != 0
+		isSymlink := f.Type()&os.ModeSymlink != 0
 		isDir := f.IsDir()
 
 		if isSymlink {
-			symlinkPath, _ := filepath.EvalSymlinks(filepath.Join(m.CurrentDirectory, f.Name()))
-			info, err := os.Stat(symlinkPath)
+			info, err := m.fsys().Stat(m.join(m.CurrentDirectory, f.Name()))
 			if err != nil {
 				break
 			}
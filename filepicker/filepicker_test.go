@@ -0,0 +1,67 @@
+package filepicker
+
+import (
+	"testing"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+func keyPress(k string) tea.Msg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "ctrl+l":
+		return tea.KeyMsg{Type: tea.KeyCtrlL}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// load 读取文件选择器的当前目录并返回更新后的模型。
+func load(t *testing.T, m Model) Model {
+	t.Helper()
+	msg := m.readDir(m.CurrentDirectory, m.ShowHidden)()
+	if err, ok := msg.(errorMsg); ok {
+		t.Fatalf("failed to read %s: %v", m.CurrentDirectory, err.err)
+	}
+	m, _ = m.Update(msg)
+	return m
+}
+
+// newPicker 返回一个显示目录 dir 的文件选择器。
+func newPicker(t *testing.T, dir string) Model {
+	t.Helper()
+	m := New()
+	m.CurrentDirectory = dir
+	m.Height = 10
+	m.ShowPermissions, m.ShowSize = false, false
+	return load(t, m)
+}
+
+// names 返回文件列表中条目的名称。
+func names(m Model) []string {
+	s := make([]string, len(m.files))
+	for i, f := range m.files {
+		s[i] = f.Name()
+	}
+	return s
+}
+
+// selectName 选中给定名称的条目。
+func selectName(t *testing.T, m *Model, name string) {
+	t.Helper()
+	for i, f := range m.files {
+		if f.Name() == name {
+			m.selected = i
+			return
+		}
+	}
+	t.Fatalf("no entry named %q in %v", name, names(*m))
+}
@@ -0,0 +1,191 @@
+package filepicker
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// FS 是文件选择器用来浏览文件系统的接口。它与 io/fs 兼容：
+// fstest.MapFS 等实现了 fs.ReadDirFS 和 fs.StatFS 的文件系统可以直接使用，
+// 任何其他 fs.FS（例如 embed.FS）都可以通过 FromFS 进行包装。
+//
+// 除操作系统文件系统外，路径均使用 io/fs 的约定：以斜杠分隔，
+// 不以斜杠开头，根目录为 "."。
+type FS interface {
+	// ReadDir 读取给定目录并返回其中的目录项。
+	ReadDir(name string) ([]fs.DirEntry, error)
+
+	// Stat 返回给定文件的信息。如果文件系统支持符号链接，应跟随符号链接。
+	Stat(name string) (fs.FileInfo, error)
+}
+
+// SymlinkFS 是一个可选接口。实现了它的文件系统可以解析符号链接的目标，
+// 文件选择器会在符号链接旁边显示其目标。
+type SymlinkFS interface {
+	FS
+
+	// EvalSymlinks 返回解析所有符号链接后的路径。
+	EvalSymlinks(name string) (string, error)
+}
+
+// WritableFS 是 FS 的可写扩展，供需要修改文件系统的操作使用。
+type WritableFS interface {
+	FS
+
+	// Open 打开给定文件以供读取。
+	Open(name string) (fs.File, error)
+
+	// Create 创建或截断给定文件并打开它以供写入。
+	Create(name string) (io.WriteCloser, error)
+
+	// Mkdir 使用给定的权限创建一个新目录。
+	Mkdir(name string, perm fs.FileMode) error
+
+	// Rename 重命名（移动）文件或目录。
+	Rename(oldname, newname string) error
+
+	// RemoveAll 删除给定的文件或目录及其包含的所有内容。
+	RemoveAll(name string) error
+}
+
+// LinkFS 是 WritableFS 的一个可选扩展。文件操作用它识别和复制符号链接：
+// 符号链接总是作为链接本身被复制或删除，而不是其指向的文件或目录。
+type LinkFS interface {
+	WritableFS
+
+	// Lstat 返回给定文件的信息。如果文件是符号链接，返回链接本身的信息。
+	Lstat(name string) (fs.FileInfo, error)
+
+	// ReadLink 返回符号链接的目标。
+	ReadLink(name string) (string, error)
+
+	// Symlink 创建指向 oldname 的符号链接 newname。
+	Symlink(oldname, newname string) error
+}
+
+// OSFS 返回由操作系统支持的文件系统。这是文件选择器的默认文件系统。
+func OSFS() WritableFS {
+	return osFS{}
+}
+
+// FromFS 将任意 fs.FS 包装为 FS。
+func FromFS(fsys fs.FS) FS {
+	return ioFS{fsys}
+}
+
+// osFS 是由操作系统支持的文件系统。
+type osFS struct{}
+
+// ReadDir 实现 FS 接口。
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name) //nolint:wrapcheck
+}
+
+// Stat 实现 FS 接口。
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name) //nolint:wrapcheck
+}
+
+// Lstat 实现 LinkFS 接口。
+func (osFS) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name) //nolint:wrapcheck
+}
+
+// Symlink 实现 LinkFS 接口。
+func (osFS) Symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname) //nolint:wrapcheck
+}
+
+// EvalSymlinks 实现 SymlinkFS 接口。
+func (osFS) EvalSymlinks(name string) (string, error) {
+	return filepath.EvalSymlinks(name) //nolint:wrapcheck
+}
+
+// Open 实现 WritableFS 接口。
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name) //nolint:wrapcheck
+}
+
+// Create 实现 WritableFS 接口。
+func (osFS) Create(name string) (io.WriteCloser, error) {
+	return os.Create(name) //nolint:wrapcheck
+}
+
+// Mkdir 实现 WritableFS 接口。
+func (osFS) Mkdir(name string, perm fs.FileMode) error {
+	return os.Mkdir(name, perm) //nolint:wrapcheck
+}
+
+// Rename 实现 WritableFS 接口。
+func (osFS) Rename(oldname, newname string) error {
+	return os.Rename(oldname, newname) //nolint:wrapcheck
+}
+
+// RemoveAll 实现 WritableFS 接口。
+func (osFS) RemoveAll(name string) error {
+	return os.RemoveAll(name) //nolint:wrapcheck
+}
+
+// ioFS 将 fs.FS 适配为 FS。
+type ioFS struct {
+	fsys fs.FS
+}
+
+// ReadDir 实现 FS 接口。
+func (f ioFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(f.fsys, name) //nolint:wrapcheck
+}
+
+// Stat 实现 FS 接口。
+func (f ioFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(f.fsys, name) //nolint:wrapcheck
+}
+
+// fsys 返回文件选择器使用的文件系统。
+func (m Model) fsys() FS {
+	if m.FileSystem == nil {
+		return osFS{}
+	}
+	return m.FileSystem
+}
+
+// isOS 返回文件选择器是否使用操作系统文件系统，这决定了路径的拼接方式。
+func (m Model) isOS() bool {
+	_, ok := m.fsys().(osFS)
+	return ok
+}
+
+// join 按照文件系统的约定拼接路径。
+func (m Model) join(elem ...string) string {
+	if m.isOS() {
+		return filepath.Join(elem...)
+	}
+	return path.Join(elem...)
+}
+
+// dir 按照文件系统的约定返回路径的父目录。
+func (m Model) dir(p string) string {
+	if m.isOS() {
+		return filepath.Dir(p)
+	}
+	return path.Dir(p)
+}
+
+// base 按照文件系统的约定返回路径的最后一个元素。
+func (m Model) base(p string) string {
+	if m.isOS() {
+		return filepath.Base(p)
+	}
+	return path.Base(p)
+}
+
+// evalSymlinks 解析符号链接的目标。如果文件系统不支持符号链接，则返回错误。
+func (m Model) evalSymlinks(name string) (string, error) {
+	if s, ok := m.fsys().(SymlinkFS); ok {
+		return s.EvalSymlinks(name) //nolint:wrapcheck
+	}
+	return "", fs.ErrInvalid
+}
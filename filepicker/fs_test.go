@@ -0,0 +1,62 @@
+package filepicker
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestMapFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/guide.md": {Data: []byte("guide")},
+		"readme.md":     {Data: []byte("readme")},
+	}
+
+	for name, fsys := range map[string]FS{"MapFS": fsys, "FromFS": FromFS(fsys)} {
+		t.Run(name, func(t *testing.T) {
+			m := New()
+			m.FileSystem = fsys
+			m.Height = 10
+			m = load(t, m)
+			if got, want := names(m), []string{"docs", "readme.md"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+
+			// 路径使用 io/fs 的约定。
+			m, _ = m.Update(keyPress("l"))
+			if m.CurrentDirectory != "docs" {
+				t.Fatalf("expected to open docs, got %q", m.CurrentDirectory)
+			}
+			m = load(t, m)
+			m, _ = m.Update(keyPress("enter"))
+			if m.Path != "docs/guide.md" {
+				t.Fatalf("expected docs/guide.md to be selected, got %q", m.Path)
+			}
+			m, _ = m.Update(keyPress("h"))
+			if m.CurrentDirectory != "." {
+				t.Fatalf("expected to go back to the root, got %q", m.CurrentDirectory)
+			}
+		})
+	}
+}
+
+func TestMapFSWatch(t *testing.T) {
+	fsys := fstest.MapFS{"b.txt": {}}
+	m := New()
+	m.FileSystem = fsys
+	m.WatchInterval = time.Millisecond
+	m = load(t, m)
+
+	fsys["a.txt"] = &fstest.MapFile{}
+	m, changed := poll(t, m)
+	if len(changed) != 1 || !reflect.DeepEqual(names(m), []string{"a.txt", "b.txt"}) {
+		t.Fatalf("expected the created file to be picked up, got %v", names(m))
+	}
+
+	delete(fsys, "b.txt")
+	m, changed = poll(t, m)
+	if len(changed) != 1 || !reflect.DeepEqual(names(m), []string{"a.txt"}) {
+		t.Fatalf("expected the removed file to be picked up, got %v", names(m))
+	}
+}
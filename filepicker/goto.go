@@ -0,0 +1,139 @@
+package filepicker
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/textinput"
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// ErrNotDirectory 表示 GoTo 的路径不是目录。
+var ErrNotDirectory = errors.New("filepicker: not a directory")
+
+// GoTo 跳转到目录 p，并返回读取它的命令。使用操作系统文件系统时，相对路径相对于
+// CurrentDirectory，开头的 "~" 表示用户的主目录。如果 p 不存在或不是目录，
+// 错误显示在列表下方，当前目录保持不变，并返回 nil。
+func (m *Model) GoTo(p string) tea.Cmd {
+	target, err := m.resolveGoto(p)
+	if err == nil {
+		var info fs.FileInfo
+		info, err = m.fsys().Stat(target)
+		if err == nil && !info.IsDir() {
+			err = fmt.Errorf("%s: %w", target, ErrNotDirectory)
+		}
+	}
+	if err != nil {
+		m.opStatus, m.opFailed = err.Error(), true
+		return nil
+	}
+
+	m.CurrentDirectory = target
+	m.selectedStack, m.minStack, m.maxStack = newStack(), newStack(), newStack()
+	m.selected, m.min, m.max = 0, 0, m.Height-1
+	return m.readDir(m.CurrentDirectory, m.ShowHidden)
+}
+
+// SetGoToKey 启用或禁用 GoTo 键。它默认是禁用的，以免占用嵌入文件选择器的程序
+// 可能使用的 ctrl+l 键。无论是否启用，都可以直接调用 GoTo。
+func (m *Model) SetGoToKey(on bool) {
+	m.KeyMap.GoTo.SetEnabled(on)
+}
+
+// resolveGoto 将输入的路径转换为文件系统中的路径。
+func (m Model) resolveGoto(p string) (string, error) {
+	if !m.isOS() {
+		p = strings.Trim(p, "/")
+		if p == "" {
+			p = "."
+		}
+		p = strings.TrimPrefix(p, "./")
+		if !fs.ValidPath(p) {
+			return "", fmt.Errorf("%s: %w", p, fs.ErrInvalid)
+		}
+		return p, nil
+	}
+
+	if p == "~" || strings.HasPrefix(p, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+		p = home + p[1:]
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(m.CurrentDirectory, p)
+	}
+	return filepath.Clean(p), nil
+}
+
+// startGoto 显示跳转提示，输入框中预先填入当前目录。提示中 tab 补全路径中的目录名。
+func (m *Model) startGoto() tea.Cmd {
+	m.prompt = promptGoto
+	m.promptTargets = nil
+	m.opStatus, m.opFailed = "", false
+
+	m.promptInput = textinput.New()
+	m.promptInput.Prompt = "go to: "
+	m.promptInput.Placeholder = "path"
+	m.promptInput.ShowSuggestions = true
+	m.promptInput.SetValue(m.dirPrefix(m.CurrentDirectory))
+	m.promptInput.CursorEnd()
+	m.loadSuggestions(m.promptInput.Value())
+	return m.promptInput.Focus()
+}
+
+// suggestDirs 在输入框中最后一个分隔符之前的目录变化时，重新读取补全建议。
+func (m *Model) suggestDirs() {
+	value := m.promptInput.Value()
+	if prefix := value[:strings.LastIndex(value, m.separator())+1]; prefix != m.promptDir {
+		m.loadSuggestions(prefix)
+	}
+}
+
+// loadSuggestions 将目录 prefix（以分隔符结尾）中的子目录设置为补全建议。
+func (m *Model) loadSuggestions(prefix string) {
+	m.promptDir = prefix
+
+	dir, err := m.resolveGoto(prefix)
+	if err != nil {
+		m.promptInput.SetSuggestions(nil)
+		return
+	}
+	entries, err := m.listDir(dir, m.ShowHidden)
+	if err != nil {
+		m.promptInput.SetSuggestions(nil)
+		return
+	}
+	suggestions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			suggestions = append(suggestions, prefix+e.Name()+m.separator())
+		}
+	}
+	m.promptInput.SetSuggestions(suggestions)
+}
+
+// dirPrefix 返回目录 p 以分隔符结尾的形式，以便直接输入其中的条目名称。
+// 非操作系统文件系统的根目录返回空字符串。
+func (m Model) dirPrefix(p string) string {
+	if !m.isOS() && (p == "." || p == "") {
+		return ""
+	}
+	if strings.HasSuffix(p, m.separator()) {
+		return p
+	}
+	return p + m.separator()
+}
+
+// separator 返回文件系统的路径分隔符。
+func (m Model) separator() string {
+	if m.isOS() {
+		return string(filepath.Separator)
+	}
+	return "/"
+}
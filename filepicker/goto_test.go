@@ -0,0 +1,95 @@
+package filepicker
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGoToPrompt(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"sub/deep/a.txt": "a", "sibling/b.txt": "b"})
+	m := newPicker(t, dir)
+
+	// GoTo 键默认禁用。
+	if m, _ = m.Update(keyPress("ctrl+l")); m.Prompting() {
+		t.Fatal("expected the GoTo key to be disabled by default")
+	}
+
+	m.SetGoToKey(true)
+	m, _ = m.Update(keyPress("ctrl+l"))
+	if !m.Prompting() || m.promptInput.Value() != dir+string(filepath.Separator) {
+		t.Fatalf("expected a prompt prefilled with the current directory, got %q", m.promptInput.Value())
+	}
+
+	// tab 补全目录名，之后的补全来自新的目录。
+	m, _ = m.Update(keyPress("su"))
+	m, _ = m.Update(keyPress("tab"))
+	m, _ = m.Update(keyPress("d"))
+	m, _ = m.Update(keyPress("tab"))
+	want := filepath.Join(dir, "sub", "deep")
+	if got := m.promptInput.Value(); got != want+string(filepath.Separator) {
+		t.Fatalf("expected the path to be completed to %q, got %q", want, got)
+	}
+
+	m, cmd := m.Update(keyPress("enter"))
+	if m.Prompting() || m.CurrentDirectory != want || cmd == nil {
+		t.Fatalf("expected to go to %s, got %q", want, m.CurrentDirectory)
+	}
+	if m = load(t, m); len(m.files) != 1 || m.files[0].Name() != "a.txt" {
+		t.Fatalf("expected the contents of %s, got %v", want, names(m))
+	}
+}
+
+func TestGoTo(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"sub/a.txt": "a", "file.txt": "f"})
+
+	tests := []struct {
+		name   string
+		path   string
+		want   string // 为空时期望失败
+		status string // 失败时显示的错误
+	}{
+		{"absolute", filepath.Join(dir, "sub"), filepath.Join(dir, "sub"), ""},
+		{"relative", "sub", filepath.Join(dir, "sub"), ""},
+		{"parent", "sub/..", dir, ""},
+		{"missing", "missing", "", filepath.Join(dir, "missing")},
+		{"file", "file.txt", "", filepath.Join(dir, "file.txt") + ": " + ErrNotDirectory.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newPicker(t, dir)
+			cmd := m.GoTo(tt.path)
+			if tt.want != "" {
+				if cmd == nil || m.CurrentDirectory != tt.want || m.opFailed {
+					t.Fatalf("expected to go to %s, got %q", tt.want, m.CurrentDirectory)
+				}
+				return
+			}
+			if cmd != nil || m.CurrentDirectory != dir {
+				t.Fatalf("expected to stay in %s, got %q", dir, m.CurrentDirectory)
+			}
+			if !m.opFailed || !strings.Contains(m.View(), tt.status) {
+				t.Fatalf("expected the error %q to be shown, got:\n%s", tt.status, m.View())
+			}
+		})
+	}
+}
+
+func TestGoToFS(t *testing.T) {
+	m := New()
+	m.FileSystem = fstest.MapFS{"docs/guide/intro.md": {}, "readme.md": {}}
+
+	if cmd := m.GoTo("/docs/guide/"); cmd == nil || m.CurrentDirectory != "docs/guide" {
+		t.Fatalf("expected to go to docs/guide, got %q", m.CurrentDirectory)
+	}
+	if cmd := m.GoTo("/"); cmd == nil || m.CurrentDirectory != "." {
+		t.Fatalf("expected to go to the root, got %q", m.CurrentDirectory)
+	}
+	if cmd := m.GoTo("../etc"); cmd != nil || !m.opFailed {
+		t.Fatal("expected a path outside the file system to be rejected")
+	}
+}
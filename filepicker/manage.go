@@ -0,0 +1,195 @@
+package filepicker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/textinput"
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// ErrInvalidName 表示新建或重命名时输入的名称为空或包含路径分隔符。
+var ErrInvalidName = errors.New("filepicker: invalid file name")
+
+// promptKind 是正在显示的提示的类型。
+type promptKind int
+
+const (
+	promptNone   promptKind = iota
+	promptMkdir             // 输入新目录的名称
+	promptRename            // 输入新的名称
+	promptDelete            // 确认删除
+	promptGoto              // 输入要跳转到的目录
+)
+
+// Mkdir 在当前目录中创建名为 name 的目录，并返回执行操作的命令。
+// 结果以 OpKind 为 OpMkdir 的 OpDoneMsg 报告，完成后重新读取当前目录。
+// 文件系统必须实现 WritableFS。
+func (m *Model) Mkdir(name string) tea.Cmd {
+	if !validName(name) {
+		return m.failOp(OpMkdir, ErrInvalidName)
+	}
+	return m.startOp(OpMkdir, []string{m.join(m.CurrentDirectory, name)}, "")
+}
+
+// Rename 将路径 p 重命名为同一目录中的 name，并返回执行操作的命令。
+// 结果以 OpKind 为 OpRename 的 OpDoneMsg 报告。参见 Mkdir。
+func (m *Model) Rename(p, name string) tea.Cmd {
+	if !validName(name) {
+		return m.failOp(OpRename, ErrInvalidName)
+	}
+	return m.startOp(OpRename, []string{p}, m.join(m.dir(p), name))
+}
+
+// Prompting 返回是否正在显示新建、重命名、删除或跳转的提示。提示显示时，
+// 所有按键都由提示处理。
+func (m Model) Prompting() bool {
+	return m.prompt != promptNone
+}
+
+// validName 返回 name 是否可以用作当前目录中的条目名称。
+func validName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// failOp 返回报告操作未能启动的命令。
+func (m Model) failOp(kind OpKind, err error) tea.Cmd {
+	msg := OpDoneMsg{Kind: kind, Err: err, pickerID: m.id}
+	return func() tea.Msg { return msg }
+}
+
+// runManageOp 执行新建目录（OpMkdir）或重命名（OpRename），并返回最终的 OpDoneMsg。
+func (m Model) runManageOp(ctx context.Context, op *operation, fsys WritableFS, p, dest string) OpDoneMsg {
+	done := OpDoneMsg{ID: op.id, Kind: op.kind, pickerID: m.id}
+	if err := ctx.Err(); err != nil {
+		done.Canceled = true
+		return done
+	}
+
+	if op.kind == OpMkdir {
+		done.Path = p
+		done.Err = fsys.Mkdir(p, 0o755) //nolint:mnd
+	} else {
+		// 不覆盖已有的条目。
+		if _, err := fsys.Stat(dest); err == nil {
+			done.Err = fmt.Errorf("%s: %w", m.base(dest), fs.ErrExist)
+			return done
+		}
+		done.Path = dest
+		done.Err = fsys.Rename(p, dest)
+	}
+	if done.Err == nil {
+		done.Files = 1
+	}
+	return done
+}
+
+// startPrompt 显示给定类型的提示。
+func (m *Model) startPrompt(kind promptKind) tea.Cmd {
+	var targets []string
+	switch {
+	case kind == promptMkdir:
+	case kind == promptGoto:
+		return m.startGoto()
+	case kind == promptDelete && m.MultiSelect && len(m.marks) > 0:
+		targets = m.MarkedPaths()
+	case len(m.files) == 0:
+		return nil
+	default:
+		targets = []string{m.join(m.CurrentDirectory, m.files[m.selected].Name())}
+	}
+
+	m.prompt = kind
+	m.promptTargets = targets
+	m.opStatus, m.opFailed = "", false
+	if kind == promptDelete {
+		return nil
+	}
+
+	m.promptInput = textinput.New()
+	m.promptInput.Prompt = "new directory: "
+	m.promptInput.Placeholder = "name"
+	if kind == promptRename {
+		m.promptInput.Prompt = "rename to: "
+		m.promptInput.SetValue(m.base(targets[0]))
+		m.promptInput.CursorEnd()
+	}
+	return m.promptInput.Focus()
+}
+
+// updatePrompt 处理提示显示时的消息。输入名称或路径时 enter 确认、esc 取消；
+// 确认删除时 Confirm 键确认，其他按键取消。
+func (m *Model) updatePrompt(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if m.prompt == promptDelete {
+		if !ok {
+			return nil
+		}
+		targets := m.promptTargets
+		m.endPrompt()
+		if !key.Matches(keyMsg, m.KeyMap.Confirm) {
+			return nil
+		}
+		if len(m.marks) > 0 {
+			m.ClearMarks()
+		}
+		return m.Delete(targets)
+	}
+
+	if ok {
+		switch keyMsg.Type { //nolint:exhaustive
+		case tea.KeyEsc:
+			m.endPrompt()
+			return nil
+		case tea.KeyEnter:
+			name := strings.TrimSpace(m.promptInput.Value())
+			kind, targets := m.prompt, m.promptTargets
+			m.endPrompt()
+			if kind == promptGoto {
+				return m.GoTo(name)
+			}
+			if kind == promptMkdir {
+				return m.Mkdir(name)
+			}
+			if name == m.base(targets[0]) {
+				return nil
+			}
+			return m.Rename(targets[0], name)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.promptInput, cmd = m.promptInput.Update(msg)
+	if m.prompt == promptGoto {
+		m.suggestDirs()
+	}
+	return cmd
+}
+
+// endPrompt 关闭提示。
+func (m *Model) endPrompt() {
+	m.prompt = promptNone
+	m.promptTargets = nil
+	m.promptInput.Blur()
+}
+
+// promptView 渲染提示行。
+func (m Model) promptView() string {
+	if m.prompt != promptDelete {
+		return m.promptInput.View()
+	}
+
+	verb := "delete"
+	if m.trash() != nil {
+		verb = "move to trash"
+	}
+	what := m.base(m.promptTargets[0])
+	if n := len(m.promptTargets); n > 1 {
+		what = fmt.Sprintf("%d items", n)
+	}
+	return m.Styles.OpError.Render(fmt.Sprintf("%s %s? (%s/n)", verb, what, m.KeyMap.Confirm.Help().Key))
+}
@@ -0,0 +1,162 @@
+package filepicker
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newManagedPicker 返回启用了文件管理、永久删除文件的文件选择器。
+func newManagedPicker(t *testing.T, dir string) Model {
+	t.Helper()
+	m := newPicker(t, dir)
+	m.FileManagement = true
+	m.PermanentDelete = true
+	return m
+}
+
+func TestManageDisabled(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a"})
+	m := newPicker(t, dir)
+
+	for _, k := range []string{"n", "r", "d"} {
+		if m, _ = m.Update(keyPress(k)); m.Prompting() {
+			t.Fatalf("expected %q not to prompt without FileManagement", k)
+		}
+	}
+}
+
+func TestMkdirPrompt(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a"})
+	m := newManagedPicker(t, dir)
+
+	m, _ = m.Update(keyPress("n"))
+	if !m.Prompting() || !strings.Contains(m.View(), "new directory: ") {
+		t.Fatalf("expected a prompt for the directory name, got:\n%s", m.View())
+	}
+	m, _ = m.Update(keyPress("docs"))
+	m, cmd := m.Update(keyPress("enter"))
+	m, done := runOpCmd(t, m, cmd)
+	if done.Kind != OpMkdir || done.Err != nil || done.Path != filepath.Join(dir, "docs") {
+		t.Fatalf("expected the directory to be created, got %+v", done)
+	}
+
+	m = load(t, m)
+	if got := names(m); !reflect.DeepEqual(got, []string{"docs", "a.txt"}) {
+		t.Fatalf("expected the new directory to be listed, got %v", got)
+	}
+	if !strings.Contains(m.View(), "created docs") {
+		t.Fatalf("expected a status line, got:\n%s", m.View())
+	}
+
+	// 已有的目录和无效的名称都报告错误。
+	for _, tt := range []struct {
+		name string
+		err  error
+	}{
+		{"docs", fs.ErrExist},
+		{"a/b", ErrInvalidName},
+	} {
+		m, done = runOpCmd(t, m, m.Mkdir(tt.name))
+		if !errors.Is(done.Err, tt.err) || !m.opFailed {
+			t.Fatalf("%s: expected %v, got %+v", tt.name, tt.err, done)
+		}
+	}
+}
+
+func TestRenamePrompt(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a", "b.txt": "b"})
+	m := newManagedPicker(t, dir)
+	selectName(t, &m, "a.txt")
+
+	m, _ = m.Update(keyPress("r"))
+	if m.promptInput.Value() != "a.txt" {
+		t.Fatalf("expected the prompt to start with the current name, got %q", m.promptInput.Value())
+	}
+
+	// 不覆盖已有的条目。
+	m.promptInput.SetValue("b.txt")
+	m, cmd := m.Update(keyPress("enter"))
+	m, done := runOpCmd(t, m, cmd)
+	if !errors.Is(done.Err, fs.ErrExist) {
+		t.Fatalf("expected %v, got %+v", fs.ErrExist, done)
+	}
+
+	m, _ = m.Update(keyPress("r"))
+	m.promptInput.SetValue("c.txt")
+	m, cmd = m.Update(keyPress("enter"))
+	m, done = runOpCmd(t, m, cmd)
+	if done.Err != nil || done.Path != filepath.Join(dir, "c.txt") {
+		t.Fatalf("expected the file to be renamed, got %+v", done)
+	}
+	if m = load(t, m); !reflect.DeepEqual(names(m), []string{"b.txt", "c.txt"}) {
+		t.Fatalf("expected the renamed file to be listed, got %v", names(m))
+	}
+
+	// esc 取消提示。
+	m, _ = m.Update(keyPress("r"))
+	if m, cmd = m.Update(keyPress("esc")); m.Prompting() || cmd != nil {
+		t.Fatal("expected esc to cancel the prompt")
+	}
+}
+
+func TestDeletePrompt(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a", "b.txt": "b"})
+	m := newManagedPicker(t, dir)
+	selectName(t, &m, "a.txt")
+
+	m, _ = m.Update(keyPress("d"))
+	if !strings.Contains(m.View(), "delete a.txt? (y/n)") {
+		t.Fatalf("expected a confirmation, got:\n%s", m.View())
+	}
+	// 其他按键取消删除。
+	m, cmd := m.Update(keyPress("n"))
+	if m.Prompting() || cmd != nil {
+		t.Fatal("expected any other key to cancel the deletion")
+	}
+
+	m, _ = m.Update(keyPress("d"))
+	m, cmd = m.Update(keyPress("y"))
+	m, done := runOpCmd(t, m, cmd)
+	if done.Kind != OpDelete || done.Err != nil || done.Files != 1 {
+		t.Fatalf("expected the file to be deleted, got %+v", done)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected the file to be deleted, got %v", err)
+	}
+	if m = load(t, m); !reflect.DeepEqual(names(m), []string{"b.txt"}) {
+		t.Fatalf("expected the deleted file to disappear, got %v", names(m))
+	}
+}
+
+func TestManageRefreshesLinks(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Symlink(filepath.Join(dir, "target"), filepath.Join(dir, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	m := newManagedPicker(t, dir)
+	if m.links["link"].state != linkBroken {
+		t.Fatalf("expected a broken link, got %+v", m.links["link"])
+	}
+
+	// 链接的状态在读取目录时缓存，创建目标后重新读取目录会更新它。
+	m, done := runOpCmd(t, m, m.Mkdir("target"))
+	if done.Err != nil {
+		t.Fatal(done.Err)
+	}
+	m = load(t, m)
+	if l := m.links["link"]; l.state != linkOK || !l.dir {
+		t.Fatalf("expected the link to point to a directory, got %+v", l)
+	}
+	if strings.Contains(m.View(), "(broken)") {
+		t.Fatalf("expected the link not to be shown as broken, got:\n%s", m.View())
+	}
+}
@@ -0,0 +1,97 @@
+package filepicker
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	tea "github.com/purpose168/bubbletea-cn"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// MarkedPaths 返回所有已标记条目的路径，按字母顺序排序。
+// 标记在切换目录时保留。
+func (m Model) MarkedPaths() []string {
+	paths := make([]string, 0, len(m.marks))
+	for p := range m.marks {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// IsMarked 返回给定路径是否已被标记。
+func (m Model) IsMarked(path string) bool {
+	_, ok := m.marks[path]
+	return ok
+}
+
+// ClearMarks 清除所有标记。
+func (m *Model) ClearMarks() {
+	m.marks = nil
+}
+
+// DidConfirmSelection 返回用户是否在至少标记了一个条目时按下了 Select 键
+// （在此消息上），以及已标记的路径。
+func (m Model) DidConfirmSelection(msg tea.Msg) (bool, []string) {
+	if !m.MultiSelect || len(m.marks) == 0 {
+		return false, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !key.Check(keyMsg, m.KeyMap.Select) {
+		return false, nil
+	}
+	return true, m.MarkedPaths()
+}
+
+// toggleMark 切换给定条目的标记。无法选择的条目不能被标记。
+func (m *Model) toggleMark(f os.DirEntry) {
+	path := m.join(m.CurrentDirectory, f.Name())
+	if _, ok := m.marks[path]; ok {
+		// 复制后再修改，避免影响模型的其他副本。
+		marks := make(map[string]struct{}, len(m.marks))
+		for p := range m.marks {
+			if p != path {
+				marks[p] = struct{}{}
+			}
+		}
+		m.marks = marks
+		return
+	}
+
+	if !m.canMark(f) {
+		return
+	}
+	marks := make(map[string]struct{}, len(m.marks)+1)
+	for p := range m.marks {
+		marks[p] = struct{}{}
+	}
+	marks[path] = struct{}{}
+	m.marks = marks
+}
+
+// canMark 返回给定条目是否可以被标记，规则与选择相同。
+func (m Model) canMark(f os.DirEntry) bool {
+	isDir := f.IsDir()
+	if f.Type()&os.ModeSymlink != 0 {
+		if target, err := m.fsys().Stat(m.join(m.CurrentDirectory, f.Name())); err == nil {
+			isDir = target.IsDir()
+		}
+	}
+	if isDir {
+		return m.DirAllowed
+	}
+	return m.FileAllowed && m.canSelect(f.Name())
+}
+
+// markerView 渲染给定条目的标记列。如果未启用标记模式，则返回空字符串。
+func (m Model) markerView(name string) string {
+	if !m.MultiSelect {
+		return ""
+	}
+	if m.IsMarked(m.join(m.CurrentDirectory, name)) {
+		return " " + m.Styles.Marker.Render(m.Marker)
+	}
+	return " " + strings.Repeat(" ", lipgloss.Width(m.Marker))
+}
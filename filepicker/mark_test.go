@@ -0,0 +1,98 @@
+package filepicker
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMark(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a", "b.txt": "b", "c.md": "c", "sub/d.txt": "d"})
+	m := newPicker(t, dir)
+	m.AllowedTypes = []string{".txt"}
+
+	// 没有启用标记模式时，Mark 键不起作用。
+	selectName(t, &m, "a.txt")
+	if m, _ = m.Update(keyPress(" ")); len(m.MarkedPaths()) != 0 {
+		t.Fatalf("expected no marks without MultiSelect, got %v", m.MarkedPaths())
+	}
+
+	m.MultiSelect = true
+	// 标记后光标移到下一项；不允许选择的文件和目录不能被标记。
+	for _, name := range []string{"sub", "a.txt", "b.txt", "c.md"} {
+		selectName(t, &m, name)
+		m, _ = m.Update(keyPress(" "))
+	}
+	want := []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")}
+	if got := m.MarkedPaths(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v to be marked, got %v", want, got)
+	}
+	selectName(t, &m, "a.txt")
+	m, _ = m.Update(keyPress(" "))
+	if m.files[m.selected].Name() != "b.txt" {
+		t.Fatalf("expected the cursor to move down after marking, got %s", m.files[m.selected].Name())
+	}
+	if m.IsMarked(want[0]) || !m.IsMarked(want[1]) {
+		t.Fatalf("expected marking again to unmark a.txt, got %v", m.MarkedPaths())
+	}
+
+	lines := strings.Split(m.View(), "\n")
+	for i, want := range []string{"    sub", "    a.txt", "> * b.txt", "    c.md"} {
+		if lines[i] != want {
+			t.Errorf("line %d: expected %q, got %q", i, want, lines[i])
+		}
+	}
+
+	// 标记在切换目录时保留。
+	selectName(t, &m, "sub")
+	m, _ = m.Update(keyPress("l"))
+	m = load(t, m)
+	m, _ = m.Update(keyPress(" "))
+	m, _ = m.Update(keyPress("h"))
+	m = load(t, m)
+	want = []string{filepath.Join(dir, "b.txt"), filepath.Join(dir, "sub", "d.txt")}
+	if got := m.MarkedPaths(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v to be marked, got %v", want, got)
+	}
+
+	m.ClearMarks()
+	if len(m.MarkedPaths()) != 0 {
+		t.Fatalf("expected the marks to be cleared, got %v", m.MarkedPaths())
+	}
+}
+
+func TestDidConfirmSelection(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a", "b.txt": "b"})
+	m := newPicker(t, dir)
+	m.MultiSelect = true
+	enter := keyPress("enter")
+
+	// 没有标记时，Select 选择当前文件。
+	if ok, _ := m.DidConfirmSelection(enter); ok {
+		t.Fatal("expected no confirmation without marks")
+	}
+	if selected, _ := m.Update(enter); selected.Path != filepath.Join(dir, "a.txt") {
+		t.Fatalf("expected a.txt to be selected, got %q", selected.Path)
+	}
+
+	m, _ = m.Update(keyPress(" "))
+	m, _ = m.Update(keyPress(" "))
+
+	// 有标记时，Select 确认标记的条目，而不是选择当前文件。
+	ok, paths := m.DidConfirmSelection(enter)
+	if want := []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")}; !ok || !reflect.DeepEqual(paths, want) {
+		t.Fatalf("expected %v to be confirmed, got %v, %v", want, ok, paths)
+	}
+	if ok, _ := m.DidSelectFile(enter); ok {
+		t.Fatal("expected no single file selection while marks exist")
+	}
+	if m, _ = m.Update(enter); m.Path != "" {
+		t.Fatalf("expected Select not to set Path while marks exist, got %q", m.Path)
+	}
+	if ok, _ := m.DidConfirmSelection(keyPress("j")); ok {
+		t.Fatal("expected only the Select key to confirm")
+	}
+}
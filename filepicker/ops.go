@@ -0,0 +1,616 @@
+package filepicker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/purpose168/bubbles-cn/progress"
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// OpKind 是文件操作的类型。
+type OpKind int
+
+const (
+	// OpCopy 复制文件和目录。
+	OpCopy OpKind = iota
+
+	// OpDelete 永久删除文件和目录。
+	OpDelete
+
+	// OpTrash 将文件和目录移到回收站。
+	OpTrash
+
+	// OpRestore 从回收站恢复最近一次删除的文件和目录。
+	OpRestore
+
+	// OpMkdir 创建一个新目录。
+	OpMkdir
+
+	// OpRename 重命名文件或目录。
+	OpRename
+)
+
+// String 返回操作类型的名称。
+func (k OpKind) String() string {
+	switch k {
+	case OpDelete:
+		return "delete"
+	case OpTrash:
+		return "trash"
+	case OpRestore:
+		return "restore"
+	case OpMkdir:
+		return "mkdir"
+	case OpRename:
+		return "rename"
+	default:
+		return "copy"
+	}
+}
+
+// progressive 返回操作进行时显示的名称。
+func (k OpKind) progressive() string {
+	switch k {
+	case OpDelete:
+		return "deleting"
+	case OpTrash:
+		return "trashing"
+	case OpRestore:
+		return "restoring"
+	case OpMkdir:
+		return "creating"
+	case OpRename:
+		return "renaming"
+	default:
+		return "copying"
+	}
+}
+
+var (
+	// ErrNotWritable 表示文件系统没有实现 WritableFS，无法执行文件操作。
+	ErrNotWritable = errors.New("filepicker: file system is not writable")
+
+	// ErrBusy 表示已有另一个文件操作正在进行。
+	ErrBusy = errors.New("filepicker: another operation is in progress")
+
+	// ErrSymlink 表示文件系统没有实现 LinkFS，无法复制符号链接。
+	ErrSymlink = errors.New("filepicker: file system cannot copy symlinks")
+
+	// ErrCopyIntoSelf 表示复制的目标是源文件本身，或位于源目录之中。
+	ErrCopyIntoSelf = errors.New("filepicker: cannot copy into itself")
+)
+
+// progressInterval 是两次进度报告之间的最短间隔。
+const progressInterval = 50 * time.Millisecond
+
+// copyBufferSize 是复制文件时使用的缓冲区大小。
+const copyBufferSize = 32 * 1024
+
+// OpProgressMsg 报告正在进行的文件操作的进度。
+type OpProgressMsg struct {
+	ID         int    // 操作的 ID
+	Kind       OpKind // 操作的类型
+	FilesDone  int    // 已处理的文件数量
+	FilesTotal int    // 文件总数
+	BytesDone  int64  // 已复制的字节数
+	BytesTotal int64  // 字节总数
+
+	pickerID int
+}
+
+// Percent 返回操作完成的百分比，范围为 0 到 1。复制按字节计算，删除按文件计算。
+func (p OpProgressMsg) Percent() float64 {
+	if p.Kind == OpCopy && p.BytesTotal > 0 {
+		return float64(p.BytesDone) / float64(p.BytesTotal)
+	}
+	if p.FilesTotal > 0 {
+		return float64(p.FilesDone) / float64(p.FilesTotal)
+	}
+	return 0
+}
+
+// OpDoneMsg 在文件操作完成、被取消或失败时发送。
+type OpDoneMsg struct {
+	ID       int    // 操作的 ID
+	Kind     OpKind // 操作的类型
+	Files    int    // 已处理的文件数量
+	Bytes    int64  // 已复制的字节数
+	Canceled bool   // 操作是否被取消
+	Err      error  // 操作失败时的错误
+
+	// Trashed 是被移到回收站的文件（OpTrash），或未能恢复的文件（OpRestore）。
+	Trashed []TrashedFile
+
+	// Path 是新建的目录（OpMkdir）或重命名后的路径（OpRename）。
+	Path string
+
+	pickerID int
+}
+
+// operation 是正在进行的文件操作。
+type operation struct {
+	id     int
+	kind   OpKind
+	ch     chan tea.Msg
+	cancel context.CancelFunc
+}
+
+// wait 等待操作的下一条消息。
+func (op *operation) wait() tea.Cmd {
+	return func() tea.Msg {
+		return <-op.ch
+	}
+}
+
+// newProgress 返回文件操作的默认进度条。
+func newProgress() progress.Model {
+	return progress.New(progress.WithDefaultGradient(), progress.WithWidth(progressWidth), progress.WithoutPercentage())
+}
+
+// opEntry 是操作涉及的一个文件、目录或符号链接。
+type opEntry struct {
+	src, dst string
+	isDir    bool
+	isLink   bool // 符号链接作为链接本身处理，不进入其指向的目录
+	size     int64
+}
+
+// Copy 将给定的文件和目录（递归地）复制到目录 dest 中，并返回执行操作的命令。
+// 操作在后台进行：进度以 OpProgressMsg 报告并显示在列表下方，
+// 完成后发送 OpDoneMsg 并重新读取当前目录。在操作进行时按下 Cancel 键可以取消它。
+// 文件系统必须实现 WritableFS。
+//
+// Copy 不会覆盖或合并已有的文件：如果 dest 中已有同名的文件或目录，操作在复制
+// 任何内容之前失败，错误包装 fs.ErrExist；如果 dest 就是源文件所在的目录或位于
+// 源目录之中，错误为 ErrCopyIntoSelf。
+func (m *Model) Copy(paths []string, dest string) tea.Cmd {
+	return m.startOp(OpCopy, paths, dest)
+}
+
+// Delete 删除给定的文件和目录，并返回执行操作的命令。参见 Copy。
+//
+// 如果有可用的回收站（参见 Model.Trash），文件会被移到回收站，之后可以使用
+// Undo 恢复；否则文件和目录会被递归地永久删除。
+func (m *Model) Delete(paths []string) tea.Cmd {
+	if m.trash() != nil {
+		return m.startOp(OpTrash, paths, "")
+	}
+	return m.startOp(OpDelete, paths, "")
+}
+
+// CanUndo 返回是否有可以恢复的删除。
+func (m Model) CanUndo() bool {
+	return m.op == nil && len(m.trashed) > 0
+}
+
+// Undo 从回收站恢复最近一次删除的文件，并返回执行操作的命令。结果以
+// OpKind 为 OpRestore 的 OpDoneMsg 报告。按下 Undo 键也会执行它。
+func (m *Model) Undo() tea.Cmd {
+	paths := make([]string, len(m.trashed))
+	for i, f := range m.trashed {
+		paths[i] = f.Path
+	}
+	return m.startOp(OpRestore, paths, "")
+}
+
+// Busy 返回是否有文件操作正在进行。
+func (m Model) Busy() bool {
+	return m.op != nil
+}
+
+// CancelOp 取消正在进行的文件操作。操作停止后仍会发送 OpDoneMsg。
+func (m *Model) CancelOp() {
+	if m.op != nil {
+		m.op.cancel()
+	}
+}
+
+// startOp 在后台启动文件操作。
+func (m *Model) startOp(kind OpKind, paths []string, dest string) tea.Cmd {
+	if m.op != nil {
+		return m.failOp(kind, ErrBusy)
+	}
+
+	picker := *m
+	paths = append([]string(nil), paths...)
+
+	var run func(ctx context.Context, op *operation) OpDoneMsg
+	switch kind {
+	case OpTrash, OpRestore:
+		trash := m.trash()
+		if trash == nil {
+			return m.failOp(kind, ErrNoTrash)
+		}
+		run = func(ctx context.Context, op *operation) OpDoneMsg {
+			return picker.runTrashOp(ctx, op, trash, paths)
+		}
+	case OpMkdir, OpRename:
+		fsys, ok := m.fsys().(WritableFS)
+		if !ok {
+			return m.failOp(kind, ErrNotWritable)
+		}
+		run = func(ctx context.Context, op *operation) OpDoneMsg {
+			return picker.runManageOp(ctx, op, fsys, paths[0], dest)
+		}
+	default:
+		fsys, ok := m.fsys().(WritableFS)
+		if !ok {
+			return m.failOp(kind, ErrNotWritable)
+		}
+		run = func(ctx context.Context, op *operation) OpDoneMsg {
+			return picker.runOp(ctx, op, fsys, paths, dest)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// 缓冲的通道使后台的 goroutine 在界面不再等待时仍能发送最终结果并退出。
+	op := &operation{id: nextID(), kind: kind, ch: make(chan tea.Msg, 1), cancel: cancel}
+	m.op = op
+	m.opProgress = OpProgressMsg{ID: op.id, Kind: kind, FilesTotal: len(paths), pickerID: m.id}
+	m.opStatus, m.opFailed = "", false
+
+	return func() tea.Msg {
+		go func() {
+			defer cancel()
+			done := run(ctx, op)
+			for {
+				select {
+				case op.ch <- done:
+					return
+				case <-op.ch:
+					// 丢弃没有被接收的进度报告，为最终结果腾出位置。
+				}
+			}
+		}()
+		return <-op.ch
+	}
+}
+
+// handleOpProgress 记录操作的进度并等待下一条消息。
+func (m *Model) handleOpProgress(msg OpProgressMsg) tea.Cmd {
+	if m.op == nil || msg.pickerID != m.id || msg.ID != m.op.id {
+		return nil
+	}
+	m.opProgress = msg
+	return m.op.wait()
+}
+
+// handleOpDone 结束操作，记录结果并重新读取当前目录。
+func (m *Model) handleOpDone(msg OpDoneMsg) tea.Cmd {
+	if msg.pickerID != m.id {
+		return nil
+	}
+	if msg.ID == 0 {
+		// 操作未能启动。
+		m.opStatus, m.opFailed = fmt.Sprintf("%s failed: %v", msg.Kind, msg.Err), true
+		return nil
+	}
+	if m.op == nil || msg.ID != m.op.id {
+		return nil
+	}
+	m.op = nil
+	m.opFailed = msg.Err != nil
+
+	// 记录可以恢复的文件。部分完成的删除也可以恢复。
+	switch msg.Kind {
+	case OpTrash:
+		if len(msg.Trashed) > 0 {
+			m.trashed = msg.Trashed
+		}
+	case OpRestore:
+		m.trashed = msg.Trashed
+	}
+
+	switch {
+	case msg.Err != nil:
+		m.opStatus = fmt.Sprintf("%s failed: %v", msg.Kind, msg.Err)
+	case msg.Canceled:
+		m.opStatus = fmt.Sprintf("%s canceled after %d of %d files", msg.Kind, msg.Files, m.opProgress.FilesTotal)
+	case msg.Kind == OpDelete:
+		m.opStatus = fmt.Sprintf("deleted %d files", msg.Files)
+	case msg.Kind == OpTrash:
+		m.opStatus = fmt.Sprintf("moved %d files to trash · %s to undo", msg.Files, m.KeyMap.Undo.Help().Key)
+	case msg.Kind == OpRestore:
+		m.opStatus = fmt.Sprintf("restored %d files", msg.Files)
+	case msg.Kind == OpMkdir:
+		m.opStatus = "created " + m.base(msg.Path)
+	case msg.Kind == OpRename:
+		m.opStatus = "renamed to " + m.base(msg.Path)
+	default:
+		m.opStatus = fmt.Sprintf("copied %d files (%s)", msg.Files, humanize.Bytes(uint64(msg.Bytes))) //nolint:gosec
+	}
+	return m.readDir(m.CurrentDirectory, m.ShowHidden)
+}
+
+// opView 渲染正在进行的操作的进度行，或最近一次操作的结果。
+func (m Model) opView() string {
+	if m.op != nil {
+		p := m.opProgress
+		info := fmt.Sprintf("%d/%d files", p.FilesDone, p.FilesTotal)
+		if p.Kind == OpCopy {
+			info += fmt.Sprintf(" · %s/%s", humanize.Bytes(uint64(p.BytesDone)), humanize.Bytes(uint64(p.BytesTotal))) //nolint:gosec
+		}
+		return m.Styles.OpStatus.Render(p.Kind.progressive()) + " " +
+			m.Progress.ViewAs(p.Percent()) + " " +
+			m.Styles.OpStatus.Render(info)
+	}
+	if m.opStatus == "" {
+		return ""
+	}
+	if m.opFailed {
+		return m.Styles.OpError.Render(m.opStatus)
+	}
+	return m.Styles.OpStatus.Render(m.opStatus)
+}
+
+// runOp 执行文件操作，并返回最终的 OpDoneMsg。它在自己的 goroutine 中运行。
+func (m Model) runOp(ctx context.Context, op *operation, fsys WritableFS, paths []string, dest string) OpDoneMsg {
+	done := OpDoneMsg{ID: op.id, Kind: op.kind, pickerID: m.id}
+	state := OpProgressMsg{ID: op.id, Kind: op.kind, pickerID: m.id}
+
+	// 首先统计所有涉及的文件，以便报告总数。
+	var entries []opEntry
+	for _, p := range paths {
+		dst := ""
+		if op.kind == OpCopy {
+			dst = m.join(dest, m.base(p))
+			if err := m.checkCopyDest(fsys, p, dst); err != nil {
+				done.Err = err
+				return done
+			}
+		}
+		if err := m.planOp(fsys, p, dst, &entries); err != nil {
+			done.Err = err
+			return done
+		}
+	}
+	for _, e := range entries {
+		if !e.isDir {
+			state.FilesTotal++
+			state.BytesTotal += e.size
+		}
+	}
+
+	var last time.Time
+	report := func(force bool) {
+		if !force && time.Since(last) < progressInterval {
+			return
+		}
+		last = time.Now()
+		// 如果界面还没有准备好接收，则跳过这次报告。
+		select {
+		case op.ch <- state:
+		default:
+		}
+	}
+	report(true)
+
+	finish := func(err error) OpDoneMsg {
+		done.Files = state.FilesDone
+		done.Bytes = state.BytesDone
+		if errors.Is(err, context.Canceled) {
+			done.Canceled = true
+		} else {
+			done.Err = err
+		}
+		return done
+	}
+
+	if op.kind == OpDelete {
+		// 逆序删除，使目录中的内容先于目录本身被删除。
+		for i := len(entries) - 1; i >= 0; i-- {
+			if err := ctx.Err(); err != nil {
+				return finish(err)
+			}
+			if err := fsys.RemoveAll(entries[i].src); err != nil {
+				return finish(err)
+			}
+			if !entries[i].isDir {
+				state.FilesDone++
+				report(false)
+			}
+		}
+		return finish(nil)
+	}
+
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return finish(err)
+		}
+		if e.isDir {
+			if err := fsys.Mkdir(e.dst, 0o755); err != nil { //nolint:mnd
+				return finish(err)
+			}
+			continue
+		}
+		if e.isLink {
+			if err := copyLink(fsys, e); err != nil {
+				return finish(err)
+			}
+			state.FilesDone++
+			report(false)
+			continue
+		}
+		if err := copyFile(ctx, fsys, e, func(n int) {
+			state.BytesDone += int64(n)
+			report(false)
+		}); err != nil {
+			return finish(err)
+		}
+		state.FilesDone++
+		report(false)
+	}
+	return finish(nil)
+}
+
+// runTrashOp 将文件移到回收站（OpTrash），或从回收站恢复最近一次删除的文件
+// （OpRestore），并返回最终的 OpDoneMsg。它在自己的 goroutine 中运行。
+func (m Model) runTrashOp(ctx context.Context, op *operation, trash Trash, paths []string) OpDoneMsg {
+	done := OpDoneMsg{ID: op.id, Kind: op.kind, pickerID: m.id}
+	state := OpProgressMsg{ID: op.id, Kind: op.kind, FilesTotal: len(paths), pickerID: m.id}
+
+	var last time.Time
+	for i, p := range paths {
+		if err := ctx.Err(); err != nil {
+			done.Canceled = true
+			break
+		}
+
+		var err error
+		if op.kind == OpRestore {
+			if err = trash.Restore(m.trashed[i]); err != nil {
+				// 未能恢复的文件留在回收站中，之后可以再次尝试恢复。
+				done.Trashed = append(done.Trashed, m.trashed[i:]...)
+			}
+		} else {
+			var f TrashedFile
+			if f, err = trash.Trash(p); err == nil {
+				done.Trashed = append(done.Trashed, f)
+			}
+		}
+		if err != nil {
+			done.Err = err
+			break
+		}
+
+		state.FilesDone++
+		if time.Since(last) >= progressInterval {
+			last = time.Now()
+			select {
+			case op.ch <- state:
+			default:
+			}
+		}
+	}
+	if done.Canceled && op.kind == OpRestore {
+		done.Trashed = append(done.Trashed, m.trashed[state.FilesDone:]...)
+	}
+	done.Files = state.FilesDone
+	return done
+}
+
+// planOp 递归地收集给定路径下的所有文件和目录，目录位于其内容之前。
+// 符号链接不被跟随：它们作为单独的条目被收集，因此删除链接不会删除其目标中的
+// 内容，复制指向上级目录的链接也不会无限递归。
+func (m Model) planOp(fsys FS, src, dst string, entries *[]opEntry) error {
+	info, err := lstat(fsys, src)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if info.Mode()&fs.ModeSymlink != 0 {
+		*entries = append(*entries, opEntry{src: src, dst: dst, isLink: true})
+		return nil
+	}
+	if !info.IsDir() {
+		*entries = append(*entries, opEntry{src: src, dst: dst, size: info.Size()})
+		return nil
+	}
+
+	*entries = append(*entries, opEntry{src: src, dst: dst, isDir: true})
+	children, err := fsys.ReadDir(src)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	for _, c := range children {
+		childSrc, childDst := m.join(src, c.Name()), ""
+		if dst != "" {
+			childDst = m.join(dst, c.Name())
+		}
+		if c.Type()&fs.ModeSymlink != 0 {
+			*entries = append(*entries, opEntry{src: childSrc, dst: childDst, isLink: true})
+			continue
+		}
+		if err := m.planOp(fsys, childSrc, childDst, entries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCopyDest 检查 src 能否被复制到 dst：dst 不能是 src 本身或位于 src 之中，
+// 也不能已经存在。
+func (m Model) checkCopyDest(fsys FS, src, dst string) error {
+	if isWithin(dst, src, m.separator()) {
+		return fmt.Errorf("%s: %w", src, ErrCopyIntoSelf)
+	}
+	_, err := lstat(fsys, dst)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%s: %w", dst, fs.ErrExist)
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	default:
+		return err
+	}
+}
+
+// lstat 返回给定文件的信息，不跟随符号链接。如果文件系统没有实现 LinkFS，
+// 它无法区分符号链接，此时使用 Stat。
+func lstat(fsys FS, name string) (fs.FileInfo, error) {
+	if l, ok := fsys.(LinkFS); ok {
+		return l.Lstat(name) //nolint:wrapcheck
+	}
+	return fsys.Stat(name) //nolint:wrapcheck
+}
+
+// copyLink 复制符号链接本身：在目标位置创建指向相同目标的链接。
+func copyLink(fsys WritableFS, e opEntry) error {
+	l, ok := fsys.(LinkFS)
+	if !ok {
+		return ErrSymlink
+	}
+	target, err := l.ReadLink(e.src)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	return l.Symlink(target, e.dst) //nolint:wrapcheck
+}
+
+// copyFile 复制单个文件，每写入一块数据就调用 onWrite。
+// 如果复制被取消或失败，则删除不完整的目标文件。
+func copyFile(ctx context.Context, fsys WritableFS, e opEntry, onWrite func(n int)) (err error) {
+	src, err := fsys.Open(e.src)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer src.Close() //nolint:errcheck
+
+	dst, err := fsys.Create(e.dst)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer func() {
+		if cerr := dst.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			_ = fsys.RemoveAll(e.dst)
+		}
+	}()
+
+	buf := make([]byte, copyBufferSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err //nolint:wrapcheck
+		}
+		n, rerr := src.Read(buf)
+		if n > 0 {
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return err //nolint:wrapcheck
+			}
+			onWrite(n)
+		}
+		if errors.Is(rerr, io.EOF) {
+			return nil
+		}
+		if rerr != nil {
+			return rerr //nolint:wrapcheck
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package filepicker
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// newTestOp 返回一个在测试中直接传给 runOp 的操作。
+func newTestOp(kind OpKind) *operation {
+	return &operation{id: nextID(), kind: kind, ch: make(chan tea.Msg, 1), cancel: func() {}}
+}
+
+// writeTree 在 dir 中创建文件，键为相对路径，值为内容。
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	src, dest := t.TempDir(), t.TempDir()
+	writeTree(t, src, map[string]string{"a.txt": "hello", "sub/b.txt": "world!"})
+
+	m := New()
+	done := m.runOp(context.Background(), newTestOp(OpCopy), osFS{}, []string{src}, dest)
+	if done.Err != nil || done.Canceled {
+		t.Fatalf("expected copy to succeed, got %+v", done)
+	}
+	if done.Files != 2 || done.Bytes != 11 {
+		t.Fatalf("expected 2 files and 11 bytes, got %d files and %d bytes", done.Files, done.Bytes)
+	}
+	b, err := os.ReadFile(filepath.Join(dest, filepath.Base(src), "sub", "b.txt"))
+	if err != nil || string(b) != "world!" {
+		t.Fatalf("expected copied file content, got %q, %v", b, err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"x/a.txt": "a", "x/y/b.txt": "b", "keep.txt": "k"})
+
+	m := New()
+	done := m.runOp(context.Background(), newTestOp(OpDelete), osFS{}, []string{filepath.Join(dir, "x")}, "")
+	if done.Err != nil || done.Files != 2 {
+		t.Fatalf("expected 2 files to be deleted, got %+v", done)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "x")); !os.IsNotExist(err) {
+		t.Fatalf("expected directory to be deleted, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "keep.txt")); err != nil {
+		t.Fatalf("expected other files to be kept, got %v", err)
+	}
+}
+
+func TestOpCancel(t *testing.T) {
+	src, dest := t.TempDir(), t.TempDir()
+	writeTree(t, src, map[string]string{"a.txt": "a", "b.txt": "b"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m := New()
+	done := m.runOp(ctx, newTestOp(OpCopy), osFS{}, []string{src}, dest)
+	if !done.Canceled || done.Err != nil || done.Files != 0 {
+		t.Fatalf("expected a canceled copy with no files, got %+v", done)
+	}
+}
+
+func TestOpSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	writeTree(t, target, map[string]string{"precious.txt": "keep me"})
+	tree := filepath.Join(dir, "tree")
+	writeTree(t, tree, map[string]string{"file.txt": "f"})
+	// 指向外部目录的链接，以及指向上级目录的循环链接。
+	if err := os.Symlink(target, filepath.Join(tree, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink(tree, filepath.Join(tree, "loop")); err != nil {
+		t.Fatal(err)
+	}
+
+	m := New()
+
+	// 复制链接本身，循环链接不会无限递归。
+	dest := t.TempDir()
+	done := m.runOp(context.Background(), newTestOp(OpCopy), osFS{}, []string{tree}, dest)
+	if done.Err != nil || done.Files != 3 {
+		t.Fatalf("expected 1 file and 2 links to be copied, got %+v", done)
+	}
+	if got, err := os.Readlink(filepath.Join(dest, "tree", "link")); err != nil || got != target {
+		t.Fatalf("expected the copied link to point to %q, got %q, %v", target, got, err)
+	}
+
+	// 删除指向目录的链接不会删除目标中的内容。
+	done = m.runOp(context.Background(), newTestOp(OpDelete), osFS{}, []string{filepath.Join(tree, "link")}, "")
+	if done.Err != nil || done.Files != 1 {
+		t.Fatalf("expected the link to be deleted, got %+v", done)
+	}
+	if _, err := os.Stat(filepath.Join(target, "precious.txt")); err != nil {
+		t.Fatalf("expected the link target to be kept, got %v", err)
+	}
+
+	// 删除包含链接的目录也不会跟随链接。
+	done = m.runOp(context.Background(), newTestOp(OpDelete), osFS{}, []string{tree}, "")
+	if done.Err != nil {
+		t.Fatalf("expected the tree to be deleted, got %+v", done)
+	}
+	if _, err := os.Stat(filepath.Join(target, "precious.txt")); err != nil {
+		t.Fatalf("expected the link target to be kept, got %v", err)
+	}
+}
+
+func TestOpResultNotLost(t *testing.T) {
+	src, dest := t.TempDir(), t.TempDir()
+	writeTree(t, src, map[string]string{"a.txt": "a"})
+
+	m := New()
+	cmd := m.Copy([]string{src}, dest)
+	op := m.op
+	// 只接收第一条消息（通常是进度报告），不再等待后续消息。
+	if msg := cmd(); msg == nil {
+		t.Fatal("expected a message")
+	}
+	// 后台的 goroutine 仍然能发送最终结果并退出。
+	select {
+	case msg := <-op.ch:
+		if _, ok := msg.(OpDoneMsg); !ok {
+			t.Fatalf("expected the final result, got %T", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the final result to be delivered")
+	}
+}
+
+func TestCopyConflicts(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "original", "sub/b.txt": "b", "other/a.txt": "other", "other/sub/c.txt": "c"})
+	m := New()
+
+	tests := []struct {
+		name string
+		src  string
+		dest string
+		err  error
+	}{
+		{"file into its own directory", filepath.Join(dir, "a.txt"), dir, ErrCopyIntoSelf},
+		{"directory into itself", filepath.Join(dir, "sub"), filepath.Join(dir, "sub"), ErrCopyIntoSelf},
+		{"existing file", filepath.Join(dir, "a.txt"), filepath.Join(dir, "other"), fs.ErrExist},
+		{"existing directory", filepath.Join(dir, "sub"), filepath.Join(dir, "other"), fs.ErrExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := m.runOp(context.Background(), newTestOp(OpCopy), osFS{}, []string{tt.src}, tt.dest)
+			if !errors.Is(done.Err, tt.err) || done.Files != 0 {
+				t.Fatalf("expected %v before copying any file, got %+v", tt.err, done)
+			}
+		})
+	}
+
+	// 源文件和目标中已有的文件都保持不变，已有的目录没有被合并。
+	for name, want := range map[string]string{"a.txt": "original", "other/a.txt": "other"} {
+		if b, err := os.ReadFile(filepath.Join(dir, name)); err != nil || string(b) != want {
+			t.Fatalf("expected %s to be kept as %q, got %q, %v", name, want, b, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "other", "sub", "b.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected the existing directory not to be merged into, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package filepicker
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SortMode 是目录条目的排序方式。无论使用哪种方式，目录总是排在文件之前，
+// 排序键相同的条目按名称排序。
+type SortMode int
+
+const (
+	SortByName      SortMode = iota // 按名称排序
+	SortBySize                      // 按大小排序
+	SortByModTime                   // 按修改时间排序
+	SortByExtension                 // 按扩展名排序（不区分大小写）
+)
+
+// sortModes 是 Sort 键循环切换的顺序。
+var sortModes = []SortMode{SortByName, SortBySize, SortByModTime, SortByExtension}
+
+// String 返回排序方式的名称。
+func (s SortMode) String() string {
+	switch s {
+	case SortBySize:
+		return "size"
+	case SortByModTime:
+		return "modified"
+	case SortByExtension:
+		return "extension"
+	default:
+		return "name"
+	}
+}
+
+// SetSort 设置排序方式和方向，并立即重新排序当前目录的条目，无需重新读取目录。
+// 选中的条目保持不变。
+func (m *Model) SetSort(mode SortMode, descending bool) {
+	m.SortMode, m.SortDescending = mode, descending
+
+	var name string
+	if m.selected < len(m.files) {
+		name = m.files[m.selected].Name()
+	}
+	m.files, m.infos = m.sortEntries(m.files, m.infos)
+	m.reselect(name)
+}
+
+// SetSortKeys 启用或禁用 Sort 和 Reverse 键。它们默认是禁用的，
+// 以免占用嵌入文件选择器的程序可能使用的 s 和 S 键。
+func (m *Model) SetSortKeys(on bool) {
+	m.KeyMap.Sort.SetEnabled(on)
+	m.KeyMap.Reverse.SetEnabled(on)
+}
+
+// cycleSort 切换到下一种排序方式。
+func (m *Model) cycleSort() {
+	next := SortByName
+	for i, s := range sortModes {
+		if s == m.SortMode {
+			next = sortModes[(i+1)%len(sortModes)]
+		}
+	}
+	m.SetSort(next, m.SortDescending)
+}
+
+// sortEntries 按照 SortMode 和 SortDescending 对条目及其对应的文件信息排序，
+// 并返回排序后的新切片。
+func (m Model) sortEntries(entries []os.DirEntry, infos []os.FileInfo) ([]os.DirEntry, []os.FileInfo) {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if entries[i].IsDir() != entries[j].IsDir() {
+			return entries[i].IsDir()
+		}
+		if c := m.compareEntries(entries[i], infoAt(infos, i), entries[j], infoAt(infos, j)); c != 0 {
+			return c < 0
+		}
+		return false
+	})
+
+	sortedEntries := make([]os.DirEntry, len(entries))
+	sortedInfos := make([]os.FileInfo, len(entries))
+	for k, i := range order {
+		sortedEntries[k], sortedInfos[k] = entries[i], infoAt(infos, i)
+	}
+	return sortedEntries, sortedInfos
+}
+
+// compareEntries 按照排序方式比较两个条目，返回负数、0 或正数。
+func (m Model) compareEntries(a os.DirEntry, ai os.FileInfo, b os.DirEntry, bi os.FileInfo) int {
+	var c int
+	switch m.SortMode {
+	case SortBySize:
+		c = compare(infoSize(ai), infoSize(bi))
+	case SortByModTime:
+		if ai != nil && bi != nil {
+			c = ai.ModTime().Compare(bi.ModTime())
+		}
+	case SortByExtension:
+		c = strings.Compare(strings.ToLower(filepath.Ext(a.Name())), strings.ToLower(filepath.Ext(b.Name())))
+	}
+	if c == 0 {
+		c = strings.Compare(a.Name(), b.Name())
+	}
+	if m.SortDescending {
+		c = -c
+	}
+	return c
+}
+
+// compare 比较两个整数。
+func compare(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// infoSize 返回文件的大小。如果没有文件信息，返回 0。
+func infoSize(info os.FileInfo) int64 {
+	if info == nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// sortView 渲染显示当前排序方式的页脚。如果没有启用 ShowSort，返回空字符串。
+func (m Model) sortView() string {
+	if !m.ShowSort {
+		return ""
+	}
+	arrow := "↑"
+	if m.SortDescending {
+		arrow = "↓"
+	}
+	return m.Styles.SortStatus.Render("sort: " + m.SortMode.String() + " " + arrow)
+}
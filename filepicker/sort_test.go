@@ -0,0 +1,84 @@
+package filepicker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// newSortPicker 返回一个显示内存文件系统的文件选择器，其中的文件有不同的大小和修改时间。
+func newSortPicker(t *testing.T) Model {
+	t.Helper()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := New()
+	m.FileSystem = fstest.MapFS{
+		"dir/x": {},
+		"a.go":  {Data: make([]byte, 10), ModTime: t0.Add(3 * time.Hour)},
+		"b.TXT": {Data: make([]byte, 3), ModTime: t0.Add(2 * time.Hour)},
+		"c":     {Data: make([]byte, 1), ModTime: t0.Add(time.Hour)},
+	}
+	m.Height = 10
+	return load(t, m)
+}
+
+func TestSortKeys(t *testing.T) {
+	m := newSortPicker(t)
+	selectName(t, &m, "b.TXT")
+
+	// Sort 和 Reverse 键默认禁用。
+	m, _ = m.Update(keyPress("s"))
+	m, _ = m.Update(keyPress("S"))
+	if m.SortMode != SortByName || m.SortDescending {
+		t.Fatalf("expected the sort keys to be disabled by default, got %v, %v", m.SortMode, m.SortDescending)
+	}
+
+	m.SetSortKeys(true)
+	m.ShowSort = true
+	tests := []struct {
+		key    string
+		mode   SortMode
+		desc   bool
+		footer string
+		want   []string
+	}{
+		{"s", SortBySize, false, "sort: size ↑", []string{"dir", "c", "b.TXT", "a.go"}},
+		{"s", SortByModTime, false, "sort: modified ↑", []string{"dir", "c", "b.TXT", "a.go"}},
+		{"S", SortByModTime, true, "sort: modified ↓", []string{"dir", "a.go", "b.TXT", "c"}},
+		{"s", SortByExtension, true, "sort: extension ↓", []string{"dir", "b.TXT", "a.go", "c"}},
+		{"s", SortByName, true, "sort: name ↓", []string{"dir", "c", "b.TXT", "a.go"}},
+		{"S", SortByName, false, "sort: name ↑", []string{"dir", "a.go", "b.TXT", "c"}},
+	}
+
+	for _, tt := range tests {
+		m, _ = m.Update(keyPress(tt.key))
+		if m.SortMode != tt.mode || m.SortDescending != tt.desc {
+			t.Fatalf("%s: expected %v (descending %v), got %v (descending %v)", tt.footer, tt.mode, tt.desc, m.SortMode, m.SortDescending)
+		}
+		// 目录总是在前，选中的条目保持不变。
+		if got := names(m); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.footer, tt.want, got)
+		}
+		if m.files[m.selected].Name() != "b.TXT" {
+			t.Fatalf("%s: expected b.TXT to stay selected, got %s", tt.footer, m.files[m.selected].Name())
+		}
+		if !strings.Contains(m.View(), tt.footer) {
+			t.Fatalf("expected the footer %q, got:\n%s", tt.footer, m.View())
+		}
+	}
+}
+
+func TestSortReadDir(t *testing.T) {
+	m := newSortPicker(t)
+	m.SetSort(SortBySize, true)
+
+	// 重新读取目录时使用当前的排序方式。
+	m = load(t, m)
+	if got, want := names(m), []string{"dir", "a.go", "b.TXT", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if m.infos[1].Size() != 10 {
+		t.Fatalf("expected the file info to be sorted with the entries, got %d", m.infos[1].Size())
+	}
+}
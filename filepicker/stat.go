@@ -0,0 +1,57 @@
+package filepicker
+
+import (
+	"os"
+	"sync"
+)
+
+// maxStatWorkers 是读取目录时并发获取文件信息的最大数量。
+const maxStatWorkers = 16
+
+// statEntries 并发获取目录项的文件信息，并发数量不超过 maxStatWorkers。
+// 获取失败的目录项的信息为 nil。
+func statEntries(entries []os.DirEntry) []os.FileInfo {
+	infos := make([]os.FileInfo, len(entries))
+	if len(entries) == 0 {
+		return infos
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(maxStatWorkers, len(entries)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// 每个 goroutine 只写入自己的索引，无需加锁。
+				infos[i], _ = entries[i].Info()
+			}
+		}()
+	}
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return infos
+}
+
+// fileInfo 返回第 i 个文件的信息。信息在读取目录时获取并缓存，
+// 如果没有缓存（例如获取失败），则再次获取。
+func (m Model) fileInfo(i int) (os.FileInfo, error) {
+	if i < len(m.infos) && m.infos[i] != nil {
+		return m.infos[i], nil
+	}
+	return m.files[i].Info() //nolint:wrapcheck
+}
+
+// iconView 渲染给定条目的图标。如果没有设置 IconFunc 或图标为空，则返回空字符串。
+func (m Model) iconView(f os.DirEntry) string {
+	if m.IconFunc == nil {
+		return ""
+	}
+	if icon := m.IconFunc(f); icon != "" {
+		return icon + " "
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package filepicker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStatCache(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"big.txt": strings.Repeat("x", 2000), "small.txt": "x"})
+	m := newPicker(t, dir)
+	m.ShowSize = true
+
+	if len(m.infos) != len(m.files) {
+		t.Fatalf("expected file info for each of %d entries, got %d", len(m.files), len(m.infos))
+	}
+	// 渲染使用读取目录时获取的信息，不再访问文件系统。
+	if err := os.Remove(filepath.Join(dir, "big.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if view := m.View(); !strings.Contains(view, "2.0kB big.txt") {
+		t.Fatalf("expected the cached size, got:\n%s", view)
+	}
+}
+
+func TestIconFunc(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"sub/a.txt": "a", "a.txt": "a", "main.go": "package main"})
+	m := newPicker(t, dir)
+	m.IconFunc = func(e os.DirEntry) string {
+		switch {
+		case e.IsDir():
+			return "D"
+		case filepath.Ext(e.Name()) == ".go":
+			return "G"
+		default:
+			return ""
+		}
+	}
+
+	lines := strings.Split(m.View(), "\n")
+	for i, want := range []string{"> D sub", "  a.txt", "  G main.go"} {
+		if lines[i] != want {
+			t.Errorf("line %d: expected %q, got %q", i, want, lines[i])
+		}
+	}
+}
+
+// failingEntry 是获取文件信息失败的目录项。
+type failingEntry struct {
+	os.DirEntry
+}
+
+func (failingEntry) Info() (os.FileInfo, error) {
+	return nil, os.ErrNotExist
+}
+
+func TestStatEntries(t *testing.T) {
+	dir := t.TempDir()
+	files := make(map[string]string)
+	for i := range 3 * maxStatWorkers {
+		files[fmt.Sprintf("f%03d.txt", i)] = strings.Repeat("x", i)
+	}
+	writeTree(t, dir, files)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries[1] = failingEntry{entries[1]}
+
+	// 信息与目录项的顺序一致，获取失败的为 nil。
+	infos := statEntries(entries)
+	for i, info := range infos {
+		switch {
+		case i == 1:
+			if info != nil {
+				t.Errorf("expected no info for a failing entry, got %v", info.Name())
+			}
+		case info == nil || info.Name() != entries[i].Name() || info.Size() != int64(i):
+			t.Errorf("entry %d: expected info for %s", i, entries[i].Name())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package filepicker
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// ReadLinkFS 是一个可选接口。实现了它的文件系统可以读取符号链接本身的目标，
+// 文件选择器用它在失效的符号链接旁边显示其指向的路径。
+type ReadLinkFS interface {
+	FS
+
+	// ReadLink 返回符号链接的目标，不跟随目标中的符号链接。
+	ReadLink(name string) (string, error)
+}
+
+// ReadLink 实现 ReadLinkFS 接口。
+func (osFS) ReadLink(name string) (string, error) {
+	return os.Readlink(name) //nolint:wrapcheck
+}
+
+// linkState 是符号链接的解析结果。
+type linkState int
+
+const (
+	linkOK     linkState = iota // 目标存在
+	linkBroken                  // 目标不存在
+	linkLoop                    // 解析时陷入符号链接循环
+)
+
+// annotation 返回显示在符号链接目标之后的说明。
+func (s linkState) annotation() string {
+	switch s {
+	case linkBroken:
+		return " (broken)"
+	case linkLoop:
+		return " (cycle)"
+	default:
+		return ""
+	}
+}
+
+// linkInfo 是符号链接的解析结果，在读取目录时获取并缓存，参见 resolveLinks。
+type linkInfo struct {
+	target string    // 用于显示的目标
+	state  linkState // 链接的状态
+	dir    bool      // 目标是否为目录
+	cycle  bool      // 打开它是否会进入循环，参见 resolveLinks
+}
+
+// resolveLinks 解析目录 dir 中所有符号链接的目标，返回以名称为键的结果。
+// 它在读取目录的命令中调用，这样渲染和导航时都无需访问文件系统。
+// 如果没有符号链接，返回 nil。
+func (m Model) resolveLinks(dir string, entries []os.DirEntry) map[string]linkInfo {
+	var (
+		links   map[string]linkInfo
+		current string
+	)
+	for _, e := range entries {
+		if e.Type()&os.ModeSymlink == 0 {
+			continue
+		}
+		if links == nil {
+			links = make(map[string]linkInfo)
+			current, _ = m.evalSymlinks(dir)
+		}
+		links[e.Name()] = m.resolveLink(m.join(dir, e.Name()), current)
+	}
+	return links
+}
+
+// resolveLink 解析给定路径的符号链接。目标存在时，用于显示的目标是解析所有符号链接后的路径，
+// 否则是链接本身记录的目标。current 是解析后的当前目录，用于判断打开目录链接是否会进入循环，
+// 即链接指向当前目录本身或它的某个上级目录。
+func (m Model) resolveLink(p, current string) linkInfo {
+	var l linkInfo
+	info, err := m.fsys().Stat(p)
+	switch {
+	case err == nil:
+		l.dir = info.IsDir()
+	case errors.Is(err, syscall.ELOOP):
+		l.state = linkLoop
+	default:
+		l.state = linkBroken
+	}
+
+	if target, err := m.evalSymlinks(p); err == nil {
+		l.target = target
+		l.cycle = l.dir && current != "" && isWithin(current, target, m.separator())
+		return l
+	}
+	if r, ok := m.fsys().(ReadLinkFS); ok {
+		l.target, _ = r.ReadLink(p)
+	}
+	return l
+}
+
+// isWithin 返回路径 p 是否为 dir 本身或位于 dir 之下。
+func isWithin(p, dir, sep string) bool {
+	return p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, sep)+sep)
+}
+
+// reportLink 为无法打开的符号链接设置状态信息：链接已失效、陷入循环，
+// 或者指向当前目录的上级目录。
+func (m *Model) reportLink(name string) {
+	l := m.links[name]
+	switch l.state {
+	case linkBroken:
+		m.opStatus = "broken symlink: " + name + " → " + l.target
+	case linkLoop:
+		m.opStatus = "symlink cycle: " + name + " → " + l.target
+	default:
+		m.opStatus = "symlink cycle: " + name + " → " + l.target + " contains the current directory"
+	}
+	m.opFailed = true
+}
@@ -0,0 +1,92 @@
+package filepicker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// symlinkTree 创建包含各种符号链接的目录并返回其解析后的路径。
+func symlinkTree(t *testing.T) string {
+	t.Helper()
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTree(t, dir, map[string]string{"file.txt": "f", "sub/inner.txt": "i"})
+	links := map[string]string{
+		"broken": filepath.Join(dir, "missing"),
+		"up":     dir,
+		"tosub":  filepath.Join(dir, "sub"),
+		"loop1":  "loop2",
+		"loop2":  "loop1",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestSymlinkView(t *testing.T) {
+	dir := symlinkTree(t)
+	m := newPicker(t, dir)
+
+	want := []string{"sub", "broken", "file.txt", "loop1", "loop2", "tosub", "up"}
+	if got := names(m); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	view := m.View()
+	for _, s := range []string{
+		"broken → " + filepath.Join(dir, "missing") + " (broken)\n",
+		"loop1 → loop2 (cycle)\n",
+		"tosub → " + filepath.Join(dir, "sub") + "\n",
+		"up → " + dir + "\n",
+	} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected the view to contain %q, got:\n%s", s, view)
+		}
+	}
+}
+
+func TestSymlinkOpen(t *testing.T) {
+	dir := symlinkTree(t)
+
+	tests := []struct {
+		name   string
+		status string // 为空时期望进入链接指向的目录
+	}{
+		{"broken", "broken symlink: broken → " + filepath.Join(dir, "missing")},
+		{"loop1", "symlink cycle: loop1 → loop2"},
+		{"up", "symlink cycle: up → " + dir + " contains the current directory"},
+		{"tosub", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newPicker(t, dir)
+			selectName(t, &m, tt.name)
+			m, cmd := m.Update(keyPress("l"))
+
+			if tt.status == "" {
+				if m.CurrentDirectory != filepath.Join(dir, tt.name) || cmd == nil {
+					t.Fatalf("expected to open %s, got %q", tt.name, m.CurrentDirectory)
+				}
+				if m = load(t, m); !reflect.DeepEqual(names(m), []string{"inner.txt"}) {
+					t.Fatalf("expected the link target's contents, got %v", names(m))
+				}
+				return
+			}
+			if m.CurrentDirectory != dir || cmd != nil {
+				t.Fatalf("expected to stay in %s, got %q", dir, m.CurrentDirectory)
+			}
+			if !m.opFailed || !strings.Contains(m.View(), tt.status) {
+				t.Fatalf("expected the status %q, got:\n%s", tt.status, m.View())
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package filepicker
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Trash 是回收站的接口。设置了回收站时，Delete 将文件移到回收站而不是永久删除，
+// 并且可以使用 Undo 恢复最近一次删除的文件。
+type Trash interface {
+	// Trash 将给定文件或目录移到回收站，并返回恢复它所需的记录。
+	Trash(name string) (TrashedFile, error)
+
+	// Restore 将文件从回收站恢复到原来的位置。
+	Restore(f TrashedFile) error
+}
+
+// TrashedFile 是被移到回收站的文件的记录。
+type TrashedFile struct {
+	Path      string    // 文件原来的路径
+	TrashPath string    // 文件在回收站中的路径
+	DeletedAt time.Time // 删除的时间
+}
+
+// ErrNoTrash 表示没有可用的回收站。
+var ErrNoTrash = errors.New("filepicker: no trash available")
+
+// trashInfoDateFormat 是 .trashinfo 文件中删除时间的格式。
+const trashInfoDateFormat = "2006-01-02T15:04:05"
+
+// XDGTrash 返回遵循 freedesktop.org 回收站规范的回收站，位于给定目录中。
+// 如果 dir 为空，则使用用户的主回收站 $XDG_DATA_HOME/Trash
+// （默认为 ~/.local/share/Trash）。
+//
+// 只支持与回收站位于同一文件系统上的文件；移动其他文件会返回错误。
+func XDGTrash(dir string) Trash {
+	return xdgTrash{dir: dir}
+}
+
+// xdgTrash 实现 freedesktop.org 回收站规范。
+type xdgTrash struct {
+	dir string
+}
+
+// root 返回回收站的目录。
+func (t xdgTrash) root() (string, error) {
+	if t.dir != "" {
+		return t.dir, nil
+	}
+	if data := os.Getenv("XDG_DATA_HOME"); data != "" {
+		return filepath.Join(data, "Trash"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	return filepath.Join(home, ".local", "share", "Trash"), nil
+}
+
+// Trash 实现 Trash 接口。
+func (t xdgTrash) Trash(name string) (TrashedFile, error) {
+	root, err := t.root()
+	if err != nil {
+		return TrashedFile{}, err
+	}
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return TrashedFile{}, err //nolint:wrapcheck
+	}
+	files, info := filepath.Join(root, "files"), filepath.Join(root, "info")
+	for _, dir := range []string{files, info} {
+		if err := os.MkdirAll(dir, 0o700); err != nil { //nolint:mnd
+			return TrashedFile{}, err //nolint:wrapcheck
+		}
+	}
+
+	now := time.Now()
+	content := fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n",
+		(&url.URL{Path: abs}).EscapedPath(), now.Format(trashInfoDateFormat))
+
+	// 通过独占地创建 .trashinfo 文件来占用回收站中的名称。
+	base := filepath.Base(abs)
+	for n := 1; ; n++ {
+		trashName := base
+		if n > 1 {
+			trashName += "." + strconv.Itoa(n)
+		}
+		infoPath := filepath.Join(info, trashName+".trashinfo")
+		f, err := os.OpenFile(infoPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) //nolint:mnd
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return TrashedFile{}, err //nolint:wrapcheck
+		}
+		_, werr := f.WriteString(content)
+		if cerr := f.Close(); werr == nil {
+			werr = cerr
+		}
+
+		trashPath := filepath.Join(files, trashName)
+		if werr == nil {
+			werr = os.Rename(abs, trashPath)
+		}
+		if werr != nil {
+			_ = os.Remove(infoPath)
+			return TrashedFile{}, werr //nolint:wrapcheck
+		}
+		return TrashedFile{Path: abs, TrashPath: trashPath, DeletedAt: now}, nil
+	}
+}
+
+// Restore 实现 Trash 接口。如果原来的位置已存在文件，则返回错误。
+func (t xdgTrash) Restore(f TrashedFile) error {
+	if _, err := os.Lstat(f.Path); err == nil {
+		return fmt.Errorf("%s: %w", f.Path, os.ErrExist)
+	}
+	if err := os.Rename(f.TrashPath, f.Path); err != nil {
+		return err //nolint:wrapcheck
+	}
+	root := filepath.Dir(filepath.Dir(f.TrashPath))
+	_ = os.Remove(filepath.Join(root, "info", filepath.Base(f.TrashPath)+".trashinfo"))
+	return nil
+}
+
+// trash 返回删除操作使用的回收站。使用操作系统文件系统时，如果没有设置
+// Model.Trash，则使用平台默认的回收站。
+func (m Model) trash() Trash {
+	switch {
+	case m.PermanentDelete:
+		return nil
+	case m.Trash != nil:
+		return m.Trash
+	case m.isOS():
+		return DefaultTrash()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,10 @@
+//go:build windows || darwin
+// +build windows darwin
+
+package filepicker
+
+// DefaultTrash 返回平台默认的回收站。这个平台上没有内置的回收站实现，
+// 因此返回 nil；可以通过设置 Model.Trash 提供自己的实现。
+func DefaultTrash() Trash {
+	return nil
+}
@@ -0,0 +1,147 @@
+package filepicker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// runOpCmd 执行文件操作的命令，将进度报告交给模型，直到收到最终结果。
+func runOpCmd(t *testing.T, m Model, cmd tea.Cmd) (Model, OpDoneMsg) {
+	t.Helper()
+	for cmd != nil {
+		msg := cmd()
+		m, cmd = m.Update(msg)
+		if done, ok := msg.(OpDoneMsg); ok {
+			return m, done
+		}
+	}
+	t.Fatal("expected an OpDoneMsg")
+	return m, OpDoneMsg{}
+}
+
+func TestXDGTrash(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a/note.txt": "first", "b/note.txt": "second"})
+	trash := XDGTrash(filepath.Join(dir, "Trash"))
+
+	first, err := trash.Trash(filepath.Join(dir, "a", "note.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 同名的文件使用不同的名称放入回收站。
+	second, err := trash.Trash(filepath.Join(dir, "b", "note.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(first.TrashPath) != "note.txt" || filepath.Base(second.TrashPath) != "note.txt.2" {
+		t.Fatalf("expected distinct names in the trash, got %q and %q", first.TrashPath, second.TrashPath)
+	}
+
+	info, err := os.ReadFile(filepath.Join(dir, "Trash", "info", "note.txt.2.trashinfo"))
+	if err != nil || !strings.Contains(string(info), "Path="+filepath.ToSlash(second.Path)+"\n") {
+		t.Fatalf("expected a trashinfo file recording the original path, got %q, %v", info, err)
+	}
+	if _, err := os.Stat(second.Path); !os.IsNotExist(err) {
+		t.Fatalf("expected the file to be moved out of place, got %v", err)
+	}
+
+	if err := trash.Restore(second); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(second.Path); err != nil || string(b) != "second" {
+		t.Fatalf("expected the file to be restored, got %q, %v", b, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Trash", "info", "note.txt.2.trashinfo")); !os.IsNotExist(err) {
+		t.Fatalf("expected the trashinfo file to be removed, got %v", err)
+	}
+
+	// 不覆盖原来位置上的新文件。
+	writeTree(t, dir, map[string]string{"a/note.txt": "new"})
+	if err := trash.Restore(first); !errors.Is(err, os.ErrExist) {
+		t.Fatalf("expected %v, got %v", os.ErrExist, err)
+	}
+	if _, err := os.Stat(first.TrashPath); err != nil {
+		t.Fatalf("expected the file to stay in the trash, got %v", err)
+	}
+}
+
+func TestXDGTrashRenameFails(t *testing.T) {
+	dir := t.TempDir()
+	trash := XDGTrash(filepath.Join(dir, "Trash"))
+
+	// 文件不存在时移动失败，已经写入的 .trashinfo 文件被删除。
+	if _, err := trash.Trash(filepath.Join(dir, "missing.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected %v, got %v", os.ErrNotExist, err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "Trash", "info"))
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected no trashinfo files to be left behind, got %v, %v", entries, err)
+	}
+}
+
+func TestTrashUndo(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a", "b.txt": "b"})
+
+	m := New()
+	m.CurrentDirectory = dir
+	m.Trash = XDGTrash(filepath.Join(t.TempDir(), "Trash"))
+	paths := []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")}
+
+	m, done := runOpCmd(t, m, m.Delete(paths))
+	if done.Kind != OpTrash || done.Err != nil || done.Files != 2 || !m.CanUndo() {
+		t.Fatalf("expected 2 files to be moved to the trash, got %+v", done)
+	}
+	for _, p := range paths {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be moved to the trash, got %v", p, err)
+		}
+	}
+
+	// 按下 Undo 键恢复。
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m, done = runOpCmd(t, m, cmd)
+	if done.Kind != OpRestore || done.Err != nil || done.Files != 2 || m.CanUndo() {
+		t.Fatalf("expected 2 files to be restored, got %+v", done)
+	}
+	for _, p := range paths {
+		if _, err := os.Stat(p); err != nil {
+			t.Fatalf("expected %s to be restored, got %v", p, err)
+		}
+	}
+}
+
+func TestTrashUndoFails(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a"})
+
+	m := New()
+	m.CurrentDirectory = dir
+	m.Trash = XDGTrash(filepath.Join(t.TempDir(), "Trash"))
+	p := filepath.Join(dir, "a.txt")
+
+	m, _ = runOpCmd(t, m, m.Delete([]string{p}))
+	writeTree(t, dir, map[string]string{"a.txt": "new"})
+
+	// 原来的位置被占用时恢复失败，文件留在回收站中，之后可以再次尝试。
+	m, done := runOpCmd(t, m, m.Undo())
+	if !errors.Is(done.Err, os.ErrExist) || !m.CanUndo() {
+		t.Fatalf("expected the restore to fail and remain undoable, got %+v", done)
+	}
+
+	if err := os.Remove(p); err != nil {
+		t.Fatal(err)
+	}
+	m, done = runOpCmd(t, m, m.Undo())
+	if done.Err != nil || done.Files != 1 || m.CanUndo() {
+		t.Fatalf("expected the retry to restore the file, got %+v", done)
+	}
+	if b, err := os.ReadFile(p); err != nil || string(b) != "a" {
+		t.Fatalf("expected the trashed file to be restored, got %q, %v", b, err)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !windows && !darwin
+// +build !windows,!darwin
+
+package filepicker
+
+// DefaultTrash 返回平台默认的回收站：在 Linux 和其他类 Unix 系统上，
+// 这是用户的 XDG 主回收站。
+func DefaultTrash() Trash {
+	return XDGTrash("")
+}
@@ -0,0 +1,136 @@
+package filepicker
+
+import (
+	"maps"
+	"os"
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// DirectoryChangedMsg 在监视模式（参见 WatchInterval）下发现当前目录的内容发生变化，
+// 并更新了文件列表之后发送。
+type DirectoryChangedMsg struct {
+	ID   int    // 文件选择器的 ID
+	Path string // 发生变化的目录
+}
+
+// watchTickMsg 触发一次对当前目录的轮询。
+type watchTickMsg struct {
+	id int
+}
+
+// pollDirMsg 是轮询读取当前目录的结果。
+type pollDirMsg struct {
+	id      int
+	path    string
+	entries []os.DirEntry
+	infos   []os.FileInfo
+	links   map[string]linkInfo
+	err     error
+}
+
+// ID 返回文件选择器的唯一 ID。
+func (m Model) ID() int {
+	return m.id
+}
+
+// watchTick 返回在 WatchInterval 之后触发下一次轮询的命令。如果没有启用监视模式，返回 nil。
+func (m Model) watchTick() tea.Cmd {
+	if m.WatchInterval <= 0 {
+		return nil
+	}
+	id := m.id
+	return tea.Tick(m.WatchInterval, func(time.Time) tea.Msg {
+		return watchTickMsg{id: id}
+	})
+}
+
+// pollDir 返回重新读取当前目录的命令。
+func (m Model) pollDir() tea.Cmd {
+	path, showHidden := m.CurrentDirectory, m.ShowHidden
+	return func() tea.Msg {
+		entries, err := m.listDir(path, showHidden)
+		if err != nil {
+			return pollDirMsg{id: m.id, path: path, err: err}
+		}
+		infos := statEntries(entries)
+		entries, infos = m.sortEntries(entries, infos)
+		return pollDirMsg{id: m.id, path: path, entries: entries, infos: infos, links: m.resolveLinks(path, entries)}
+	}
+}
+
+// handlePoll 在当前目录的内容发生变化时更新文件列表，并安排下一次轮询。
+// 读取失败（例如目录被删除）或用户在轮询期间离开了该目录时，只安排下一次轮询。
+func (m *Model) handlePoll(msg pollDirMsg) tea.Cmd {
+	next := m.watchTick()
+	if msg.err != nil || msg.path != m.CurrentDirectory || sameEntries(m.files, m.infos, msg.entries, msg.infos) && maps.Equal(m.links, msg.links) {
+		return next
+	}
+
+	var name string
+	if m.selected < len(m.files) {
+		name = m.files[m.selected].Name()
+	}
+	m.files, m.infos, m.links = msg.entries, msg.infos, msg.links
+	m.reselect(name)
+
+	changed := DirectoryChangedMsg{ID: m.id, Path: msg.path}
+	return tea.Batch(next, func() tea.Msg { return changed })
+}
+
+// reselect 选中给定名称的条目并使它可见。如果它已经不存在，则保持选中的位置。
+func (m *Model) reselect(name string) {
+	for i, f := range m.files {
+		if f.Name() == name {
+			m.selected = i
+			break
+		}
+	}
+	m.selected = clamp(m.selected, 0, max(0, len(m.files)-1))
+
+	if m.Height <= 0 {
+		return
+	}
+	switch {
+	case m.selected < m.min:
+		m.min = m.selected
+		m.max = m.min + m.Height - 1
+	case m.selected > m.max:
+		m.max = m.selected
+		m.min = m.max - m.Height + 1
+	}
+}
+
+// sameEntries 返回两次读取的目录内容是否相同：条目的名称、类型、大小和修改时间都相同。
+func sameEntries(a []os.DirEntry, ai []os.FileInfo, b []os.DirEntry, bi []os.FileInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name() != b[i].Name() || a[i].Type() != b[i].Type() {
+			return false
+		}
+		x, y := infoAt(ai, i), infoAt(bi, i)
+		if (x == nil) != (y == nil) {
+			return false
+		}
+		if x != nil && (x.Size() != y.Size() || !x.ModTime().Equal(y.ModTime())) {
+			return false
+		}
+	}
+	return true
+}
+
+// infoAt 返回第 i 个文件的信息。如果没有，返回 nil。
+func infoAt(infos []os.FileInfo, i int) os.FileInfo {
+	if i < len(infos) {
+		return infos[i]
+	}
+	return nil
+}
+
+// clamp 将 v 限制在 low 和 high 之间。
+func clamp(v, low, high int) int {
+	return min(max(v, low), high)
+}
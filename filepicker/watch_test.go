@@ -0,0 +1,108 @@
+package filepicker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// poll 执行一次轮询，返回更新后的模型和随后发送的 DirectoryChangedMsg（如果有）。
+func poll(t *testing.T, m Model) (Model, []DirectoryChangedMsg) {
+	t.Helper()
+	m, cmd := m.Update(watchTickMsg{id: m.id})
+	if cmd == nil {
+		t.Fatal("expected a command to poll the directory")
+	}
+	m, cmd = m.Update(cmd())
+
+	var changed []DirectoryChangedMsg
+	var hasTick bool
+	var run func(cmd tea.Cmd)
+	run = func(cmd tea.Cmd) {
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, c := range msg {
+				run(c)
+			}
+		case DirectoryChangedMsg:
+			changed = append(changed, msg)
+		case watchTickMsg:
+			hasTick = true
+		}
+	}
+	if cmd != nil {
+		run(cmd)
+	}
+	if !hasTick {
+		t.Fatal("expected the next poll to be scheduled")
+	}
+	return m, changed
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"b.txt": "b", "c.txt": "c"})
+	m := newPicker(t, dir)
+	m.WatchInterval = time.Millisecond
+	selectName(t, &m, "c.txt")
+
+	// 没有变化时不发送 DirectoryChangedMsg。
+	m, changed := poll(t, m)
+	if len(changed) != 0 {
+		t.Fatalf("expected no change, got %v", changed)
+	}
+
+	// 新建的文件出现在列表中，选中的条目保持不变。
+	writeTree(t, dir, map[string]string{"a.txt": "a"})
+	m, changed = poll(t, m)
+	if len(changed) != 1 || changed[0].ID != m.ID() || changed[0].Path != dir {
+		t.Fatalf("expected a DirectoryChangedMsg for %s, got %v", dir, changed)
+	}
+	if len(m.files) != 3 || m.files[m.selected].Name() != "c.txt" {
+		t.Fatalf("expected c.txt to stay selected, got %v at %d", names(m), m.selected)
+	}
+
+	// 修改文件的内容也算作变化。
+	writeTree(t, dir, map[string]string{"b.txt": "bigger"})
+	if _, changed = poll(t, m); len(changed) != 1 {
+		t.Fatalf("expected a modified file to be noticed, got %v", changed)
+	}
+
+	// 选中的条目被删除时，保持选中的位置。
+	if err := os.Remove(filepath.Join(dir, "c.txt")); err != nil {
+		t.Fatal(err)
+	}
+	m, _ = poll(t, m)
+	if len(m.files) != 2 || m.files[m.selected].Name() != "b.txt" {
+		t.Fatalf("expected the last entry to be selected, got %v at %d", names(m), m.selected)
+	}
+}
+
+func TestWatchStale(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "a", "sub/b.txt": "b"})
+	m := newPicker(t, dir)
+	m.WatchInterval = time.Millisecond
+
+	// 轮询期间离开了该目录时，结果被丢弃。
+	msg := m.pollDir()()
+	m.CurrentDirectory = filepath.Join(dir, "sub")
+	m = load(t, m)
+	if m, _ = m.Update(msg); len(m.files) != 1 || m.files[0].Name() != "b.txt" {
+		t.Fatalf("expected the poll of the previous directory to be ignored, got %v", names(m))
+	}
+
+	// 属于其他文件选择器的触发被忽略。
+	if _, cmd := m.Update(watchTickMsg{id: m.id + 1}); cmd != nil {
+		t.Fatal("expected a tick for another picker to be ignored")
+	}
+
+	// 没有启用监视模式时不安排轮询。
+	m.WatchInterval = 0
+	if m.watchTick() != nil {
+		t.Fatal("expected no polling without a WatchInterval")
+	}
+}
@@ -0,0 +1,425 @@
+// Package form 提供一个由带标签的字段（单行输入、多行文本、选择）垂直排列而成的
+// 表单组件。它负责字段之间的焦点切换、逐字段校验、错误信息的显示，
+// 并在提交时以一条消息返回所有字段的值。
+package form
+
+import (
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/textarea"
+	"github.com/purpose168/bubbles-cn/textinput"
+	tea "github.com/purpose168/bubbletea-cn"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// Kind 是字段的类型。
+type Kind int
+
+const (
+	// Input 是单行文本输入字段。
+	Input Kind = iota
+
+	// Text 是多行文本输入字段。
+	Text
+
+	// Select 是从一组选项中选择一项的字段。
+	Select
+)
+
+// Field 是表单中的一个字段。使用 NewInput、NewText 或 NewSelect 创建字段，
+// 然后可以通过 Input 和 Area 字段进一步配置底层组件（例如占位符和字符限制）。
+type Field struct {
+	// Key 用于在 SubmitMsg 中识别字段的值。
+	Key string
+
+	// Label 是显示在字段上方的标签。
+	Label string
+
+	// Kind 是字段的类型。
+	Kind Kind
+
+	// Validate 校验字段的值。如果返回错误，错误信息会显示在字段下方，
+	// 并且表单无法提交。
+	Validate func(value string) error
+
+	// Input 是 Input 字段的底层组件。
+	Input textinput.Model
+
+	// Area 是 Text 字段的底层组件。
+	Area textarea.Model
+
+	// Options 是 Select 字段的选项。
+	Options []string
+
+	selected int   // Select 字段当前选中的选项
+	err      error // 最近一次校验的错误
+}
+
+// NewInput 返回一个单行文本输入字段。
+func NewInput(key, label string) Field {
+	input := textinput.New()
+	input.Prompt = ""
+	return Field{Key: key, Label: label, Kind: Input, Input: input}
+}
+
+// NewText 返回一个多行文本输入字段。
+func NewText(key, label string) Field {
+	area := textarea.New()
+	area.ShowLineNumbers = false
+	area.SetHeight(3) //nolint:mnd
+	return Field{Key: key, Label: label, Kind: Text, Area: area}
+}
+
+// NewSelect 返回一个从给定选项中选择一项的字段。
+func NewSelect(key, label string, options []string) Field {
+	return Field{Key: key, Label: label, Kind: Select, Options: options}
+}
+
+// Value 返回字段的值。
+func (f Field) Value() string {
+	switch f.Kind {
+	case Text:
+		return f.Area.Value()
+	case Select:
+		if f.selected < 0 || f.selected >= len(f.Options) {
+			return ""
+		}
+		return f.Options[f.selected]
+	default:
+		return f.Input.Value()
+	}
+}
+
+// SetValue 设置字段的值。对于 Select 字段，值必须是其中一个选项，否则忽略。
+func (f *Field) SetValue(v string) {
+	switch f.Kind {
+	case Text:
+		f.Area.SetValue(v)
+	case Select:
+		for i, o := range f.Options {
+			if o == v {
+				f.selected = i
+				return
+			}
+		}
+	default:
+		f.Input.SetValue(v)
+	}
+}
+
+// Err 返回字段最近一次校验的错误。
+func (f Field) Err() error {
+	return f.err
+}
+
+// validate 校验字段的值并记录错误。
+func (f *Field) validate() error {
+	f.err = nil
+	if f.Validate != nil {
+		f.err = f.Validate(f.Value())
+	}
+	return f.err
+}
+
+// focus 聚焦字段。
+func (f *Field) focus() tea.Cmd {
+	switch f.Kind {
+	case Text:
+		return f.Area.Focus()
+	case Input:
+		return f.Input.Focus()
+	default:
+		return nil
+	}
+}
+
+// blur 使字段失去焦点。
+func (f *Field) blur() {
+	switch f.Kind {
+	case Text:
+		f.Area.Blur()
+	case Input:
+		f.Input.Blur()
+	default:
+	}
+}
+
+// SubmitMsg 在表单通过校验并被提交时发送。
+type SubmitMsg struct {
+	Values map[string]string // 以字段 Key 为键的值
+}
+
+// KeyMap 是表单的按键绑定。它满足 help.KeyMap 接口。
+type KeyMap struct {
+	Next       key.Binding // 聚焦下一个字段
+	Prev       key.Binding // 聚焦上一个字段
+	Submit     key.Binding // 提交表单
+	OptionNext key.Binding // 选择下一个选项
+	OptionPrev key.Binding // 选择上一个选项
+}
+
+// ShortHelp 实现 help.KeyMap 接口。
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Next, k.Prev, k.Submit}
+}
+
+// FullHelp 实现 help.KeyMap 接口。
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Next, k.Prev, k.Submit},
+		{k.OptionNext, k.OptionPrev},
+	}
+}
+
+// DefaultKeyMap 返回一组默认的按键绑定。
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Next: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "下一项"),
+		),
+		Prev: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "上一项"),
+		),
+		Submit: key.NewBinding(
+			key.WithKeys("enter", "ctrl+s"),
+			key.WithHelp("enter", "提交"),
+		),
+		OptionNext: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "下一个选项"),
+		),
+		OptionPrev: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "上一个选项"),
+		),
+	}
+}
+
+// Styles 包含表单的样式。
+type Styles struct {
+	Label          lipgloss.Style // 标签样式
+	FocusedLabel   lipgloss.Style // 聚焦字段的标签样式
+	Error          lipgloss.Style // 错误信息样式
+	Option         lipgloss.Style // 选项样式
+	SelectedOption lipgloss.Style // 选中选项的样式
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	return Styles{
+		Label:          lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"}),
+		FocusedLabel:   lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true),
+		Error:          lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Option:         lipgloss.NewStyle().Padding(0, 1),
+		SelectedOption: lipgloss.NewStyle().Padding(0, 1).Reverse(true),
+	}
+}
+
+// Model 是表单组件的 Bubble Tea 模型。
+type Model struct {
+	KeyMap KeyMap
+	Styles Styles
+
+	fields []Field
+	focus  int
+}
+
+// New 返回一个由给定字段组成的表单，第一个字段获得焦点。
+func New(fields ...Field) Model {
+	m := Model{
+		KeyMap: DefaultKeyMap(),
+		Styles: DefaultStyles(),
+		fields: append([]Field(nil), fields...),
+	}
+	m.FocusField(0)
+	return m
+}
+
+// Fields 返回表单的字段。
+func (m Model) Fields() []Field {
+	return m.fields
+}
+
+// Field 返回具有给定 Key 的字段。如果没有这样的字段，ok 为 false。
+func (m Model) Field(key string) (f Field, ok bool) {
+	for _, f := range m.fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Focused 返回当前聚焦字段的索引。
+func (m Model) Focused() int {
+	return m.focus
+}
+
+// Values 返回以字段 Key 为键的所有字段的值。
+func (m Model) Values() map[string]string {
+	values := make(map[string]string, len(m.fields))
+	for _, f := range m.fields {
+		values[f.Key] = f.Value()
+	}
+	return values
+}
+
+// SetWidth 设置所有输入字段的宽度。
+func (m *Model) SetWidth(w int) {
+	for i := range m.fields {
+		m.fields[i].Input.Width = w
+		if m.fields[i].Kind == Text {
+			m.fields[i].Area.SetWidth(w)
+		}
+	}
+}
+
+// Init 启动光标闪烁。
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// FocusField 聚焦给定索引的字段。
+func (m *Model) FocusField(i int) tea.Cmd {
+	if len(m.fields) == 0 {
+		return nil
+	}
+	m.fields[m.focus].blur()
+	m.focus = clamp(i, 0, len(m.fields)-1)
+	return m.fields[m.focus].focus()
+}
+
+// Validate 校验所有字段，并返回是否全部通过。
+func (m *Model) Validate() bool {
+	ok := true
+	for i := range m.fields {
+		if m.fields[i].validate() != nil {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Update 处理焦点切换、提交以及当前聚焦字段的输入。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if len(m.fields) == 0 {
+		return m, nil
+	}
+
+	// 复制字段，避免修改模型的其他副本。
+	m.fields = append([]Field(nil), m.fields...)
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		f := &m.fields[m.focus]
+		switch {
+		case key.Matches(msg, m.KeyMap.Next):
+			_ = f.validate()
+			return m, m.FocusField((m.focus + 1) % len(m.fields))
+		case key.Matches(msg, m.KeyMap.Prev):
+			_ = f.validate()
+			return m, m.FocusField((m.focus - 1 + len(m.fields)) % len(m.fields))
+		case f.Kind == Text && key.Matches(msg, f.Area.KeyMap.InsertNewline):
+			// 多行文本字段中的换行优先于提交。
+		case key.Matches(msg, m.KeyMap.Submit):
+			return m, m.submit()
+		case f.Kind == Select && key.Matches(msg, m.KeyMap.OptionNext):
+			if len(f.Options) > 0 {
+				f.selected = (f.selected + 1) % len(f.Options)
+				m.revalidate()
+			}
+			return m, nil
+		case f.Kind == Select && key.Matches(msg, m.KeyMap.OptionPrev):
+			if len(f.Options) > 0 {
+				f.selected = (f.selected - 1 + len(f.Options)) % len(f.Options)
+				m.revalidate()
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	f := &m.fields[m.focus]
+	switch f.Kind {
+	case Text:
+		f.Area, cmd = f.Area.Update(msg)
+	case Input:
+		f.Input, cmd = f.Input.Update(msg)
+	default:
+	}
+	m.revalidate()
+	return m, cmd
+}
+
+// View 渲染表单。
+func (m Model) View() string {
+	var b strings.Builder
+	for i, f := range m.fields {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		if i == m.focus {
+			b.WriteString(m.Styles.FocusedLabel.Render(f.Label))
+		} else {
+			b.WriteString(m.Styles.Label.Render(f.Label))
+		}
+		b.WriteString("\n")
+
+		switch f.Kind {
+		case Text:
+			b.WriteString(f.Area.View())
+		case Select:
+			b.WriteString(m.optionsView(f))
+		default:
+			b.WriteString(f.Input.View())
+		}
+
+		if f.err != nil {
+			b.WriteString("\n")
+			b.WriteString(m.Styles.Error.Render(f.err.Error()))
+		}
+	}
+	return b.String()
+}
+
+// optionsView 渲染 Select 字段的选项。
+func (m Model) optionsView(f Field) string {
+	opts := make([]string, len(f.Options))
+	for i, o := range f.Options {
+		if i == f.selected {
+			opts[i] = m.Styles.SelectedOption.Render(o)
+		} else {
+			opts[i] = m.Styles.Option.Render(o)
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, opts...)
+}
+
+// revalidate 在聚焦字段已有错误时重新校验它，使错误在修正后立即消失。
+func (m *Model) revalidate() {
+	if f := &m.fields[m.focus]; f.err != nil {
+		_ = f.validate()
+	}
+}
+
+// submit 校验所有字段。如果全部通过，返回发送 SubmitMsg 的命令；
+// 否则聚焦第一个未通过校验的字段。
+func (m *Model) submit() tea.Cmd {
+	if !m.Validate() {
+		for i, f := range m.fields {
+			if f.err != nil {
+				return m.FocusField(i)
+			}
+		}
+	}
+	values := m.Values()
+	return func() tea.Msg {
+		return SubmitMsg{Values: values}
+	}
+}
+
+func clamp(v, low, high int) int {
+	return min(max(v, low), high)
+}
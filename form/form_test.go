@@ -0,0 +1,113 @@
+package form
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+func keyPress(k string) tea.Msg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyMsg{Type: tea.KeyShiftTab}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func sendString(m Model, s string) Model {
+	for _, r := range s {
+		m, _ = m.Update(keyPress(string(r)))
+	}
+	return m
+}
+
+func required(v string) error {
+	if v == "" {
+		return errors.New("必填")
+	}
+	return nil
+}
+
+func newTestForm() Model {
+	name := NewInput("name", "名称")
+	name.Validate = required
+	return New(
+		name,
+		NewText("bio", "简介"),
+		NewSelect("lang", "语言", []string{"Go", "Rust"}),
+	)
+}
+
+func TestFocusCycling(t *testing.T) {
+	m := newTestForm()
+	if m.Focused() != 0 || !m.Fields()[0].Input.Focused() {
+		t.Fatal("expected first field to be focused")
+	}
+
+	m, _ = m.Update(keyPress("tab"))
+	if m.Focused() != 1 || !m.Fields()[1].Area.Focused() || m.Fields()[0].Input.Focused() {
+		t.Fatalf("expected second field to be focused, got %d", m.Focused())
+	}
+
+	m, _ = m.Update(keyPress("shift+tab"))
+	m, _ = m.Update(keyPress("shift+tab"))
+	if m.Focused() != 2 {
+		t.Fatalf("expected focus to wrap around to the last field, got %d", m.Focused())
+	}
+}
+
+func TestValidationAndSubmit(t *testing.T) {
+	m := newTestForm()
+
+	// 空名称无法提交，错误显示在字段下方
+	m, cmd := m.Update(keyPress("enter"))
+	if cmd != nil {
+		if _, ok := cmd().(SubmitMsg); ok {
+			t.Fatal("expected invalid form not to be submitted")
+		}
+	}
+	if f, _ := m.Field("name"); f.Err() == nil {
+		t.Fatal("expected validation error on name")
+	}
+	if !strings.Contains(m.View(), "必填") {
+		t.Fatal("expected error message in view")
+	}
+
+	// 修正后错误立即消失
+	m = sendString(m, "bubbles")
+	if f, _ := m.Field("name"); f.Err() != nil {
+		t.Fatalf("expected error to clear, got %v", f.Err())
+	}
+
+	// 多行文本字段中 enter 插入换行
+	m, _ = m.Update(keyPress("tab"))
+	m = sendString(m, "a")
+	m, _ = m.Update(keyPress("enter"))
+	m = sendString(m, "b")
+
+	m, _ = m.Update(keyPress("tab"))
+	m, _ = m.Update(keyPress("right"))
+
+	m, cmd = m.Update(keyPress("enter"))
+	if cmd == nil {
+		t.Fatal("expected submit command")
+	}
+	msg, ok := cmd().(SubmitMsg)
+	if !ok {
+		t.Fatalf("expected SubmitMsg, got %T", cmd())
+	}
+	want := map[string]string{"name": "bubbles", "bio": "a\nb", "lang": "Rust"}
+	for k, v := range want {
+		if msg.Values[k] != v {
+			t.Errorf("expected %s=%q, got %q", k, v, msg.Values[k])
+		}
+	}
+}
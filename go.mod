@@ -18,7 +18,6 @@ require (
 	github.com/charmbracelet/harmonica v0.2.0
 	github.com/dustin/go-humanize v1.0.1
 	github.com/lucasb-eyer/go-colorful v1.3.0
-	github.com/mattn/go-runewidth v0.0.19
 	github.com/muesli/termenv v0.16.0
 	github.com/purpose168/bubbletea-cn v0.0.0-00010101000000-000000000000
 	github.com/purpose168/charm-experimental-packages-cn/ansi v0.10.2
@@ -26,6 +25,7 @@ require (
 	github.com/purpose168/lipgloss-cn v0.0.0-00010101000000-000000000000
 	github.com/rivo/uniseg v0.4.7
 	github.com/sahilm/fuzzy v0.1.1
+	golang.org/x/text v0.24.0
 )
 
 require (
@@ -38,11 +38,11 @@ require (
 	github.com/erikgeiser/coninput v0.0.0-20211004153227-1c3628e74d0f // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-localereader v0.0.1 // indirect
+	github.com/mattn/go-runewidth v0.0.19 // indirect
 	github.com/muesli/ansi v0.0.0-20230316100256-276c6243b2f6 // indirect
 	github.com/muesli/cancelreader v0.2.2 // indirect
 	github.com/purpose168/charm-experimental-packages-cn/cellbuf v0.0.13 // indirect
 	github.com/purpose168/charm-experimental-packages-cn/term v0.2.1 // indirect
 	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
 	golang.org/x/sys v0.41.0 // indirect
-	golang.org/x/text v0.24.0 // indirect
 )
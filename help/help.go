@@ -24,6 +24,15 @@ type KeyMap interface {
 	FullHelp() [][]key.Binding
 }
 
+// TitledKeyMap 是可选的接口。如果 KeyMap 实现了它，并且 Model.Titles 为空，
+// 完整帮助视图将使用它返回的标题作为各列的标题。
+type TitledKeyMap interface {
+	KeyMap
+
+	// FullHelpTitles 返回完整帮助中每一列的标题，顺序与 FullHelp 返回的列一致。
+	FullHelpTitles() []string
+}
+
 // Styles 是帮助组件可用的样式定义集合。
 type Styles struct {
 	Ellipsis lipgloss.Style
@@ -37,6 +46,10 @@ type Styles struct {
 	FullKey       lipgloss.Style
 	FullDesc      lipgloss.Style
 	FullSeparator lipgloss.Style
+	FullTitle     lipgloss.Style
+
+	// 交互式完整帮助中高亮的绑定的样式
+	Highlight lipgloss.Style
 }
 
 // Model 包含帮助视图的状态。
@@ -50,7 +63,37 @@ type Model struct {
 	// 在简短帮助中，当帮助项因宽度而被截断时使用的符号。默认为省略号。
 	Ellipsis string
 
+	// ShortHelpLines 是简短帮助在宽度不足时最多换行到的行数。大于 1 时，简短帮助先换行，
+	// 仍然放不下时按优先级（参见 key.Binding.Priority）从低到高省略帮助项。
+	// 为 0 或 1 时，简短帮助只有一行，放不下的帮助项被截断。需要设置 Width。
+	ShortHelpLines int
+
+	// Reflow 使完整帮助在各列并排的总宽度超过 Width 时，将绑定重新分配到更少、更高的列中，
+	// 而不是截断放不下的列，使所有绑定都能显示。需要设置 Width。
+	Reflow bool
+
+	// MaxHeight 是 Reflow 重新分配后每列的最大行数（包括标题）。达到上限后仍然放不下的
+	// 列被截断。如果为 0 或更小，则没有限制。
+	MaxHeight int
+
+	// Titles 是完整帮助中每一列的标题（例如"导航"、"编辑"），
+	// 按列的顺序排列。空字符串表示该列没有标题。
+	Titles []string
+
+	// Interactive 使完整帮助可以交互：方向键在绑定之间移动高亮，
+	// enter 按下高亮的绑定，使帮助界面成为可发现的操作菜单。
+	// 参见 UpdateInteractive。
+	Interactive bool
+
+	// SynthesizeKeys 使按下绑定时发送该绑定的按键，而不是 ChosenMsg。
+	SynthesizeKeys bool
+
+	// Nav 是交互式完整帮助中使用的按键。
+	Nav NavKeyMap
+
 	Styles Styles
+
+	hlCol, hlRow int // 高亮的绑定所在的列和行
 }
 
 // New 创建一个带有一些有用默认值的新帮助视图。
@@ -74,6 +117,7 @@ func New() Model {
 		ShortSeparator: " • ",
 		FullSeparator:  "    ",
 		Ellipsis:       "…",
+		Nav:            DefaultNavKeyMap(),
 		Styles: Styles{
 			ShortKey:       keyStyle,
 			ShortDesc:      descStyle,
@@ -82,6 +126,8 @@ func New() Model {
 			FullKey:        keyStyle,
 			FullDesc:       descStyle,
 			FullSeparator:  sepStyle,
+			FullTitle:      keyStyle.Bold(true),
+			Highlight:      lipgloss.NewStyle().Reverse(true),
 		},
 	}
 }
@@ -99,6 +145,9 @@ func (m Model) Update(_ tea.Msg) (Model, tea.Cmd) {
 // View 渲染帮助视图的当前状态。
 func (m Model) View(k KeyMap) string {
 	if m.ShowAll {
+		if tk, ok := k.(TitledKeyMap); ok && len(m.Titles) == 0 {
+			m.Titles = tk.FullHelpTitles()
+		}
 		return m.FullHelpView(k.FullHelp())
 	}
 	return m.ShortHelpView(k.ShortHelp())
@@ -106,10 +155,14 @@ func (m Model) View(k KeyMap) string {
 
 // ShortHelpView 从按键绑定切片渲染单行帮助视图。
 // 如果行长度超过最大宽度，它会被优雅地截断，只显示尽可能多的帮助项。
+// 设置了 ShortHelpLines 时，改为换行到多行，参见 ShortHelpLines。
 func (m Model) ShortHelpView(bindings []key.Binding) string {
 	if len(bindings) == 0 {
 		return ""
 	}
+	if m.ShortHelpLines > 1 && m.Width > 0 {
+		return m.wrappedShortHelpView(bindings)
+	}
 
 	var b strings.Builder
 	var totalWidth int
@@ -148,10 +201,16 @@ func (m Model) ShortHelpView(bindings []key.Binding) string {
 }
 
 // FullHelpView 从按键绑定切片的切片渲染帮助列。每个顶层切片条目渲染为一列。
+// 设置了 Reflow 时，宽度不足时重新分配列，参见 Reflow。
 func (m Model) FullHelpView(groups [][]key.Binding) string {
 	if len(groups) == 0 {
 		return ""
 	}
+	if m.Reflow && m.Width > 0 {
+		if v, ok := m.reflowedFullHelpView(groups); ok {
+			return v
+		}
+	}
 
 	// 代码注释：此时我们认为预分配此切片的额外代码复杂性不值得。
 	//nolint:prealloc
@@ -163,6 +222,7 @@ func (m Model) FullHelpView(groups [][]key.Binding) string {
 	)
 
 	// 遍历组以构建列
+	var c int // 渲染的列的索引
 	for i, group := range groups {
 		if group == nil || !shouldRenderColumn(group) {
 			continue
@@ -183,17 +243,26 @@ func (m Model) FullHelpView(groups [][]key.Binding) string {
 			if !kb.Enabled() {
 				continue
 			}
-			keys = append(keys, kb.Help().Key)
-			descriptions = append(descriptions, kb.Help().Desc)
+			k, d := kb.Help().Key, kb.Help().Desc
+			if m.highlighted(c, len(keys)) {
+				k = m.Styles.Highlight.Inline(true).Render(k)
+				d = m.Styles.Highlight.Inline(true).Render(d)
+			}
+			keys = append(keys, k)
+			descriptions = append(descriptions, d)
 		}
+		c++
 
 		// 列
 		col := lipgloss.JoinHorizontal(lipgloss.Top,
-			sep,
 			m.Styles.FullKey.Render(strings.Join(keys, "\n")),
 			" ",
 			m.Styles.FullDesc.Render(strings.Join(descriptions, "\n")),
 		)
+		if len(m.Titles) > 0 {
+			col = lipgloss.JoinVertical(lipgloss.Left, m.title(i), col)
+		}
+		col = lipgloss.JoinHorizontal(lipgloss.Top, sep, col)
 		w := lipgloss.Width(col)
 
 		// 尾部处理
@@ -211,6 +280,14 @@ func (m Model) FullHelpView(groups [][]key.Binding) string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, out...)
 }
 
+// title 返回给定列的渲染后标题。没有标题的列返回空行，以便各列对齐。
+func (m Model) title(i int) string {
+	if i >= len(m.Titles) || m.Titles[i] == "" {
+		return ""
+	}
+	return m.Styles.FullTitle.Inline(true).Render(m.Titles[i])
+}
+
 // shouldAddItem 检查是否应该添加新项，考虑当前总宽度和新项宽度。
 // 返回值：
 // - tail: 如果空间不足，返回要添加的尾部字符串（通常是省略号）
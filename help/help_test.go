@@ -2,9 +2,12 @@ package help
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	tea "github.com/purpose168/bubbletea-cn"
 	"github.com/purpose168/charm-experimental-packages-cn/exp/golden"
+	lipgloss "github.com/purpose168/lipgloss-cn"
 
 	"github.com/purpose168/bubbles-cn/key"
 )
@@ -49,3 +52,189 @@ func TestFullHelp(t *testing.T) {
 		})
 	}
 }
+
+// TestFullHelpReflow 测试宽度不足时将绑定重新分配到更少、更高的列中。
+func TestFullHelpReflow(t *testing.T) {
+	m := New()
+	m.FullSeparator = " | "
+	m.Reflow = true
+
+	k := key.WithKeys("x")
+	kb := [][]key.Binding{
+		{key.NewBinding(k, key.WithHelp("enter", "continue"))},
+		{
+			key.NewBinding(k, key.WithHelp("esc", "back")),
+			key.NewBinding(k, key.WithHelp("?", "help")),
+		},
+		{
+			key.NewBinding(k, key.WithHelp("H", "home")),
+			key.NewBinding(k, key.WithHelp("ctrl+c", "quit")),
+			key.NewBinding(k, key.WithHelp("ctrl+l", "log")),
+		},
+	}
+
+	m.Width = 30
+	got := m.FullHelpView(kb)
+	for _, want := range []string{"continue", "back", "help", "home", "quit", "log"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in reflowed help:\n%s", want, got)
+		}
+	}
+	if w := lipgloss.Width(got); w > m.Width {
+		t.Errorf("expected width <= %d, got %d:\n%s", m.Width, w, got)
+	}
+
+	// 宽度足够时与不重新分配的结果一致。
+	m.Width = 80
+	plain := m
+	plain.Reflow = false
+	if got, want := m.FullHelpView(kb), plain.FullHelpView(kb); got != want {
+		t.Errorf("expected unchanged layout:\n%s\ngot:\n%s", want, got)
+	}
+
+	// MaxHeight 限制列高，放不下的列被截断。
+	m.Width = 30
+	m.MaxHeight = 2
+	got = m.FullHelpView(kb)
+	if h := lipgloss.Height(got); h > 2 {
+		t.Errorf("expected height <= 2, got %d:\n%s", h, got)
+	}
+	if !strings.Contains(got, m.Ellipsis) {
+		t.Errorf("expected ellipsis in truncated help:\n%s", got)
+	}
+}
+
+// TestFullHelpTitles 测试带列标题的完整帮助视图。
+func TestFullHelpTitles(t *testing.T) {
+	m := New()
+	m.FullSeparator = " | "
+	m.Titles = []string{"导航", "", "其他"}
+
+	k := key.WithKeys("x")
+	kb := [][]key.Binding{
+		{key.NewBinding(k, key.WithHelp("↑", "up"))},
+		{key.NewBinding(k, key.WithHelp("esc", "back"))},
+		{key.NewBinding(k, key.WithHelp("q", "quit"))},
+	}
+
+	lines := strings.Split(m.FullHelpView(kb), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("期望 2 行，但得到了 %d 行：%q", len(lines), lines)
+	}
+	if !strings.HasPrefix(lines[0], "导航") || !strings.Contains(lines[0], "其他") {
+		t.Errorf("期望第一行包含列标题，但得到了 %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "esc back") {
+		t.Errorf("期望第二行包含按键，但得到了 %q", lines[1])
+	}
+}
+
+type conflictKeyMap struct {
+	Up, Down, Quit key.Binding
+}
+
+func (k conflictKeyMap) ShortHelp() []key.Binding { return []key.Binding{k.Up, k.Quit} }
+
+func (k conflictKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down}, {k.Quit}}
+}
+
+// TestValidate 测试按键冲突检测。
+func TestValidate(t *testing.T) {
+	km := conflictKeyMap{
+		Up:   key.NewBinding(key.WithKeys("k", "up"), key.WithHelp("↑/k", "up")),
+		Down: key.NewBinding(key.WithKeys("j", "down"), key.WithHelp("↓/j", "down")),
+		Quit: key.NewBinding(key.WithKeys("q", "k"), key.WithHelp("q", "quit")),
+	}
+
+	conflicts := Validate(km)
+	if len(conflicts) != 1 {
+		t.Fatalf("期望 1 个冲突，但得到了 %d 个：%v", len(conflicts), conflicts)
+	}
+	if conflicts[0].Key != "k" || len(conflicts[0].Bindings) != 2 {
+		t.Errorf("期望按键 k 在 2 个绑定间冲突，但得到了 %v", conflicts[0])
+	}
+
+	km.Quit.SetEnabled(false)
+	if conflicts := Validate(km); len(conflicts) != 0 {
+		t.Errorf("期望禁用的绑定不参与冲突检测，但得到了 %v", conflicts)
+	}
+
+	// 按键和帮助都相同的两个绑定也是冲突
+	km.Down = km.Up
+	conflicts = Validate(km)
+	if len(conflicts) != 2 || conflicts[0].Key != "k" || conflicts[1].Key != "up" || len(conflicts[0].Bindings) != 2 {
+		t.Errorf("期望按键 k 和 up 在 2 个相同的绑定间冲突，但得到了 %v", conflicts)
+	}
+}
+
+// TestInteractive 测试交互式完整帮助中的高亮移动和按下绑定。
+func TestInteractive(t *testing.T) {
+	km := conflictKeyMap{
+		Up:   key.NewBinding(key.WithKeys("k", "up"), key.WithHelp("↑/k", "up")),
+		Down: key.NewBinding(key.WithKeys("j", "down"), key.WithHelp("↓/j", "down")),
+		Quit: key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit")),
+	}
+	m := New()
+	m.ShowAll = true
+	m.Interactive = true
+
+	m, _ = m.UpdateInteractive(tea.KeyMsg{Type: tea.KeyDown}, km)
+	if b, _ := m.Highlighted(km); b.Help().Desc != "down" {
+		t.Fatalf("期望高亮 down，但得到了 %q", b.Help().Desc)
+	}
+
+	// 移动到只有一行的列时，行被限制在该列中
+	m, _ = m.UpdateInteractive(tea.KeyMsg{Type: tea.KeyRight}, km)
+	m, cmd := m.UpdateInteractive(tea.KeyMsg{Type: tea.KeyEnter}, km)
+	if msg, ok := cmd().(ChosenMsg); !ok || msg.Binding.Help().Desc != "quit" {
+		t.Fatalf("期望按下 quit，但得到了 %#v", cmd())
+	}
+
+	m.SynthesizeKeys = true
+	_, cmd = m.UpdateInteractive(tea.KeyMsg{Type: tea.KeyEnter}, km)
+	if msg, ok := cmd().(tea.KeyMsg); !ok || msg.Type != tea.KeyCtrlC {
+		t.Fatalf("期望合成 ctrl+c 按键，但得到了 %#v", cmd())
+	}
+
+	if view := m.View(km); !strings.Contains(view, "ctrl+c") {
+		t.Fatalf("期望完整帮助包含高亮的绑定，但得到了 %q", view)
+	}
+	if m.highlighted(0, 0) || !m.highlighted(1, 0) {
+		t.Fatal("期望只有第二列的第一行被高亮")
+	}
+}
+
+// TestShortHelpLines 测试简短帮助换行到多行，以及按优先级省略帮助项。
+func TestShortHelpLines(t *testing.T) {
+	k := key.WithKeys("x")
+	bindings := []key.Binding{
+		key.NewBinding(k, key.WithHelp("↑", "up"), key.WithPriority(1)),
+		key.NewBinding(k, key.WithHelp("↓", "down"), key.WithPriority(1)),
+		key.NewBinding(k, key.WithHelp("/", "filter")),
+		key.NewBinding(k, key.WithHelp("q", "quit"), key.WithPriority(2)),
+	}
+
+	m := New()
+	m.Width = 20
+	m.ShortHelpLines = 2
+
+	lines := strings.Split(m.ShortHelpView(bindings), "\n")
+	want := []string{"↑ up • ↓ down", "/ filter • q quit"}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("期望 %q，但得到了 %q", want, lines)
+	}
+
+	// 放不下时先省略优先级最低的 filter
+	m.Width = 13
+	lines = strings.Split(m.ShortHelpView(bindings), "\n")
+	want = []string{"↑ up • ↓ down", "q quit …"}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("期望 %q，但得到了 %q", want, lines)
+	}
+
+	m.ShortHelpLines = 0
+	if view := m.ShortHelpView(bindings); strings.Contains(view, "\n") {
+		t.Fatalf("期望没有设置 ShortHelpLines 时只有一行，但得到了 %q", view)
+	}
+}
@@ -0,0 +1,130 @@
+package help
+
+import (
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// ChosenMsg 在交互式完整帮助中按下高亮的绑定时发送（参见 Model.Interactive）。
+type ChosenMsg struct {
+	Binding key.Binding
+}
+
+// NavKeyMap 是交互式完整帮助中移动高亮和按下绑定的按键。
+type NavKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Left   key.Binding
+	Right  key.Binding
+	Choose key.Binding
+}
+
+// DefaultNavKeyMap 返回交互式完整帮助的默认按键。
+func DefaultNavKeyMap() NavKeyMap {
+	return NavKeyMap{
+		Up:     key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "up")),
+		Down:   key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "down")),
+		Left:   key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "left")),
+		Right:  key.NewBinding(key.WithKeys("right"), key.WithHelp("→", "right")),
+		Choose: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "choose")),
+	}
+}
+
+// UpdateInteractive 在交互式完整帮助（Interactive 和 ShowAll 都为 true）中处理按键：
+// Nav 中的方向键在 k 的完整帮助中移动高亮，Choose 按下高亮的绑定。
+// 按下绑定时，返回的命令发送 ChosenMsg；如果设置了 SynthesizeKeys，
+// 则改为发送该绑定的第一个按键对应的 tea.KeyMsg，就像用户按下了它一样。
+func (m Model) UpdateInteractive(msg tea.Msg, k KeyMap) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !m.Interactive || !m.ShowAll {
+		return m, nil
+	}
+	cols := enabledColumns(k.FullHelp())
+	if len(cols) == 0 {
+		return m, nil
+	}
+	m.clampHighlight(cols)
+
+	switch {
+	case key.Matches(keyMsg, m.Nav.Up):
+		m.hlRow = max(0, m.hlRow-1)
+	case key.Matches(keyMsg, m.Nav.Down):
+		m.hlRow = min(len(cols[m.hlCol])-1, m.hlRow+1)
+	case key.Matches(keyMsg, m.Nav.Left):
+		m.hlCol = max(0, m.hlCol-1)
+		m.hlRow = min(len(cols[m.hlCol])-1, m.hlRow)
+	case key.Matches(keyMsg, m.Nav.Right):
+		m.hlCol = min(len(cols)-1, m.hlCol+1)
+		m.hlRow = min(len(cols[m.hlCol])-1, m.hlRow)
+	case key.Matches(keyMsg, m.Nav.Choose):
+		return m, m.choose(cols[m.hlCol][m.hlRow])
+	}
+	return m, nil
+}
+
+// Highlighted 返回交互式完整帮助中高亮的绑定，以及是否有高亮的绑定。
+func (m Model) Highlighted(k KeyMap) (key.Binding, bool) {
+	cols := enabledColumns(k.FullHelp())
+	if len(cols) == 0 {
+		return key.Binding{}, false
+	}
+	m.clampHighlight(cols)
+	return cols[m.hlCol][m.hlRow], true
+}
+
+// choose 返回按下给定绑定的命令。
+func (m Model) choose(b key.Binding) tea.Cmd {
+	if m.SynthesizeKeys && len(b.Keys()) > 0 {
+		if msg, ok := keyMsg(b.Keys()[0]); ok {
+			return func() tea.Msg { return msg }
+		}
+	}
+	return func() tea.Msg { return ChosenMsg{Binding: b} }
+}
+
+// clampHighlight 使高亮位置落在给定的列中。
+func (m *Model) clampHighlight(cols [][]key.Binding) {
+	m.hlCol = max(0, min(len(cols)-1, m.hlCol))
+	m.hlRow = max(0, min(len(cols[m.hlCol])-1, m.hlRow))
+}
+
+// highlighted 返回完整帮助中第 col 个渲染的列的第 row 个启用的绑定是否高亮。
+func (m Model) highlighted(col, row int) bool {
+	return m.Interactive && col == m.hlCol && row == m.hlRow
+}
+
+// enabledColumns 返回完整帮助中会被渲染的列，每列只包含启用的绑定。
+func enabledColumns(groups [][]key.Binding) [][]key.Binding {
+	var cols [][]key.Binding
+	for _, group := range groups {
+		var col []key.Binding
+		for _, b := range group {
+			if b.Enabled() {
+				col = append(col, b)
+			}
+		}
+		if len(col) > 0 {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// keyMsg 将按键名称（例如 "a"、"enter"、"ctrl+c"、"alt+x"）转换为对应的 tea.KeyMsg。
+func keyMsg(s string) (tea.KeyMsg, bool) {
+	var alt bool
+	if rest, ok := strings.CutPrefix(s, "alt+"); ok && rest != "" {
+		s, alt = rest, true
+	}
+	for t := tea.KeyF20; t <= tea.KeyCtrlQuestionMark; t++ {
+		if t != tea.KeyRunes && t.String() == s {
+			return tea.KeyMsg{Type: t, Alt: alt}, true
+		}
+	}
+	if r := []rune(s); len(r) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: r, Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
@@ -0,0 +1,143 @@
+package help
+
+import (
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// helpItem 是完整帮助中一列的一行：一个绑定或一个列标题。
+type helpItem struct {
+	key, desc string
+	title     bool
+}
+
+// reflowedFullHelpView 在各列并排的总宽度超过 Width 时，将所有绑定按顺序重新分配到
+// 更少、更高的列中，使它们都能显示。列的高度从最高的原始列开始逐渐增加，直到放得下
+// 或达到 MaxHeight；仍然放不下时，超出宽度的列以省略号截断。如果原始的列放得下，
+// 返回 false。
+func (m Model) reflowedFullHelpView(groups [][]key.Binding) (string, bool) {
+	var natural [][]helpItem
+	c := 0 // 渲染的列的索引
+	for i, group := range groups {
+		if group == nil || !shouldRenderColumn(group) {
+			continue
+		}
+		var col []helpItem
+		if len(m.Titles) > 0 {
+			col = append(col, helpItem{key: m.title(i), title: true})
+		}
+		row := 0
+		for _, kb := range group {
+			if !kb.Enabled() {
+				continue
+			}
+			k, d := kb.Help().Key, kb.Help().Desc
+			if m.highlighted(c, row) {
+				k = m.Styles.Highlight.Inline(true).Render(k)
+				d = m.Styles.Highlight.Inline(true).Render(d)
+			}
+			col = append(col, helpItem{key: k, desc: d})
+			row++
+		}
+		c++
+		natural = append(natural, col)
+	}
+	if len(natural) == 0 || lipgloss.Width(m.joinColumns(natural, false)) <= m.Width {
+		return "", false
+	}
+
+	// 重新分配时省略空的标题，它们只用于对齐并排的列。
+	var items []helpItem
+	start := 0
+	for _, col := range natural {
+		start = max(start, len(col))
+		for _, it := range col {
+			if !it.title || it.key != "" {
+				items = append(items, it)
+			}
+		}
+	}
+	limit := len(items)
+	if m.MaxHeight > 0 {
+		limit = min(limit, m.MaxHeight)
+	}
+
+	for h := start; h <= limit; h++ {
+		cols := flowColumns(items, h)
+		if lipgloss.Width(m.joinColumns(cols, false)) <= m.Width {
+			return m.joinColumns(cols, false), true
+		}
+	}
+	return m.joinColumns(flowColumns(items, limit), true), true
+}
+
+// flowColumns 将帮助项按顺序排列到高度为 h 的列中。标题不会单独留在一列的末尾。
+func flowColumns(items []helpItem, h int) [][]helpItem {
+	var (
+		cols [][]helpItem
+		cur  []helpItem
+	)
+	for _, it := range items {
+		orphan := it.title && len(cur) > 0 && len(cur) == h-1
+		if len(cur) == h || orphan {
+			cols = append(cols, cur)
+			cur = nil
+		}
+		cur = append(cur, it)
+	}
+	if len(cur) > 0 {
+		cols = append(cols, cur)
+	}
+	return cols
+}
+
+// joinColumns 渲染各列并以 FullSeparator 分隔。如果 truncate 为 true，
+// 超出 Width 的列被省略，并在空间允许时显示省略号。
+func (m Model) joinColumns(cols [][]helpItem, truncate bool) string {
+	var (
+		out        []string
+		totalWidth int
+		separator  = m.Styles.FullSeparator.Inline(true).Render(m.FullSeparator)
+	)
+	for _, items := range cols {
+		col := m.columnView(items)
+		if totalWidth > 0 {
+			col = lipgloss.JoinHorizontal(lipgloss.Top, separator, col)
+		}
+		w := lipgloss.Width(col)
+		if truncate {
+			if tail, ok := m.shouldAddItem(totalWidth, w); !ok {
+				if tail != "" {
+					out = append(out, tail)
+				}
+				break
+			}
+		}
+		totalWidth += w
+		out = append(out, col)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, out...)
+}
+
+// columnView 渲染一列帮助项，按键左对齐到同一宽度。
+func (m Model) columnView(items []helpItem) string {
+	keyWidth := 0
+	for _, it := range items {
+		if !it.title {
+			keyWidth = max(keyWidth, lipgloss.Width(it.key))
+		}
+	}
+	lines := make([]string, len(items))
+	for i, it := range items {
+		if it.title {
+			lines[i] = it.key
+			continue
+		}
+		pad := strings.Repeat(" ", keyWidth-lipgloss.Width(it.key))
+		lines[i] = m.Styles.FullKey.Inline(true).Render(it.key+pad) + " " +
+			m.Styles.FullDesc.Inline(true).Render(it.desc)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,79 @@
+package help
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+)
+
+// Conflict 描述了一个被多个按键绑定同时使用的按键。
+type Conflict struct {
+	Key      string        // 冲突的按键
+	Bindings []key.Binding // 使用该按键的绑定
+}
+
+// String 返回冲突的可读描述。
+func (c Conflict) String() string {
+	descs := make([]string, len(c.Bindings))
+	for i, b := range c.Bindings {
+		descs[i] = b.Help().Desc
+		if descs[i] == "" {
+			descs[i] = strings.Join(b.Keys(), "/")
+		}
+	}
+	return fmt.Sprintf("按键 %q 被多个绑定使用：%s", c.Key, strings.Join(descs, ", "))
+}
+
+// Validate 检查按键映射中是否存在分配给多个绑定的按键，并按按键排序返回所有冲突。
+// 检查范围包括 ShortHelp 和 FullHelp 返回的所有已启用绑定。
+//
+// 绑定是值类型，无法区分两个完全相同的绑定是否来自同一个字段，因此 FullHelp 中的
+// 每一项都被视为不同的绑定：即使两个绑定的按键和帮助完全相同，也会被报告。
+// ShortHelp 中的绑定通常也出现在 FullHelp 中，只有没有在 FullHelp 中出现的才被额外计入。
+func Validate(k KeyMap) []Conflict {
+	var bindings []key.Binding
+	inFull := map[string]int{}
+	for _, group := range k.FullHelp() {
+		for _, b := range group {
+			if b.Enabled() {
+				bindings = append(bindings, b)
+				inFull[bindingID(b)]++
+			}
+		}
+	}
+	for _, b := range k.ShortHelp() {
+		if !b.Enabled() {
+			continue
+		}
+		if id := bindingID(b); inFull[id] > 0 {
+			inFull[id]--
+			continue
+		}
+		bindings = append(bindings, b)
+	}
+
+	byKey := map[string][]key.Binding{}
+	for _, b := range bindings {
+		for _, k := range b.Keys() {
+			byKey[k] = append(byKey[k], b)
+		}
+	}
+
+	var conflicts []Conflict
+	for k, bs := range byKey {
+		if len(bs) > 1 {
+			conflicts = append(conflicts, Conflict{Key: k, Bindings: bs})
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Key < conflicts[j].Key
+	})
+	return conflicts
+}
+
+// bindingID 返回用于匹配 ShortHelp 和 FullHelp 中同一绑定的字符串。
+func bindingID(b key.Binding) string {
+	return strings.Join(b.Keys(), "\x00") + "\x01" + b.Help().Key + "\x01" + b.Help().Desc
+}
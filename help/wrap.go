@@ -0,0 +1,96 @@
+package help
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// wrappedShortHelpView 渲染换行到最多 ShortHelpLines 行的简短帮助。放不下所有帮助项时，
+// 按优先级从低到高（优先级相同时从后往前）省略帮助项，并在最后一行末尾显示省略号。
+func (m Model) wrappedShortHelpView(bindings []key.Binding) string {
+	var items []string
+	var priorities []int
+	for _, kb := range bindings {
+		if !kb.Enabled() {
+			continue
+		}
+		items = append(items,
+			m.Styles.ShortKey.Inline(true).Render(kb.Help().Key)+" "+
+				m.Styles.ShortDesc.Inline(true).Render(kb.Help().Desc))
+		priorities = append(priorities, kb.Priority())
+	}
+
+	// 省略的顺序：优先级低的先省略，优先级相同时后面的先省略。
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = len(order) - 1 - i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return priorities[order[a]] < priorities[order[b]]
+	})
+
+	tail := " " + m.Styles.Ellipsis.Inline(true).Render(m.Ellipsis)
+	dropped := make([]bool, len(items))
+	for n := 0; n <= len(items); n++ {
+		if n > 0 {
+			dropped[order[n-1]] = true
+		}
+		var kept []string
+		for i, item := range items {
+			if !dropped[i] {
+				kept = append(kept, item)
+			}
+		}
+		var t string
+		if n > 0 {
+			t = tail
+		}
+		if lines, ok := m.wrapShortHelp(kept, t); ok {
+			return strings.Join(lines, "\n")
+		}
+	}
+	return ""
+}
+
+// wrapShortHelp 将帮助项依次排列到宽度为 Width 的行中，并在最后一行末尾添加 tail。
+// 如果需要的行数超过 ShortHelpLines，或者有帮助项比宽度还宽，返回 false。
+func (m Model) wrapShortHelp(items []string, tail string) ([]string, bool) {
+	separator := m.Styles.ShortSeparator.Inline(true).Render(m.ShortSeparator)
+	sepWidth := lipgloss.Width(separator)
+
+	var (
+		lines []string
+		line  strings.Builder
+		width int
+	)
+	for _, item := range items {
+		w := lipgloss.Width(item)
+		if width > 0 && width+sepWidth+w > m.Width {
+			lines = append(lines, line.String())
+			line.Reset()
+			width = 0
+		}
+		if w > m.Width {
+			return nil, false
+		}
+		if width > 0 {
+			line.WriteString(separator)
+			width += sepWidth
+		}
+		line.WriteString(item)
+		width += w
+	}
+
+	if tail != "" {
+		if width > 0 && width+lipgloss.Width(tail) > m.Width {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		line.WriteString(tail)
+	}
+	lines = append(lines, line.String())
+	return lines, len(lines) <= m.ShortHelpLines
+}
@@ -0,0 +1,174 @@
+package key
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Config 将动作名称映射到按键，用于从配置文件读取用户自定义的按键绑定。
+// 动作名称是 KeyMap 结构体中 Binding 字段的名称（可以用 `key:"name"` 标签覆盖，
+// 标签为 "-" 的字段被忽略），嵌套结构体中的字段使用 "Outer.Inner" 形式的名称。
+// 空的按键列表表示解除绑定。
+//
+// Config 可以直接使用 encoding/json 等编码，也可以使用 ParseConfig 和
+// Config.WriteTo 读写简单的文本格式：
+//
+//	# 注释
+//	LineUp = "up" "k"
+//	LineDown = "down" "j"
+//	Quit =
+type Config map[string][]string
+
+// ErrUnknownAction 表示配置中的动作名称在按键映射中不存在。
+var ErrUnknownAction = errors.New("key: unknown action")
+
+// Extract 返回按键映射中所有按键绑定的配置。keymap 必须是结构体或指向结构体的指针。
+func Extract(keymap any) Config {
+	cfg := Config{}
+	v := reflect.Indirect(reflect.ValueOf(keymap))
+	if v.Kind() != reflect.Struct {
+		return cfg
+	}
+	walkBindings(v, "", func(name string, b *Binding) {
+		cfg[name] = append([]string{}, b.keys...)
+	})
+	return cfg
+}
+
+// Apply 将配置中的按键应用到按键映射上，通常用于在默认按键映射上覆盖用户的设置。
+// keymap 必须是指向结构体的指针。配置中没有的动作保持不变。
+//
+// 重新绑定的按键绑定的帮助按键被设置为以 "/" 连接的新按键，帮助描述保持不变。
+// 如果配置中有按键映射中不存在的动作，Apply 返回包装了 ErrUnknownAction
+// 的错误，并且不修改按键映射。
+func Apply(keymap any, cfg Config) error {
+	v := reflect.ValueOf(keymap)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("key: Apply requires a pointer to a struct, got %T", keymap)
+	}
+
+	bindings := map[string]*Binding{}
+	walkBindings(v.Elem(), "", func(name string, b *Binding) {
+		bindings[name] = b
+	})
+
+	var unknown []string
+	for name := range cfg {
+		if _, ok := bindings[name]; !ok {
+			unknown = append(unknown, strconv.Quote(name))
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %s", ErrUnknownAction, strings.Join(unknown, ", "))
+	}
+
+	for name, keys := range cfg {
+		b := bindings[name]
+		if len(keys) == 0 {
+			b.Unbind()
+			continue
+		}
+		b.SetKeys(append([]string{}, keys...)...)
+		if b.help != (Help{}) {
+			b.help.Key = strings.Join(keys, "/")
+		}
+	}
+	return nil
+}
+
+// bindingType 是 Binding 的反射类型。
+var bindingType = reflect.TypeOf(Binding{})
+
+// walkBindings 以动作名称调用 fn，遍历结构体中所有可设置的 Binding 字段。
+func walkBindings(v reflect.Value, prefix string, fn func(name string, b *Binding)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("key"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		name = prefix + name
+
+		fv := v.Field(i)
+		switch {
+		case f.Type == bindingType:
+			if fv.CanAddr() {
+				fn(name, fv.Addr().Interface().(*Binding)) //nolint:forcetypeassert
+			} else {
+				b := fv.Interface().(Binding) //nolint:forcetypeassert
+				fn(name, &b)
+			}
+		case f.Type.Kind() == reflect.Struct:
+			walkBindings(fv, name+".", fn)
+		}
+	}
+}
+
+// ParseConfig 读取文本格式的配置（参见 Config）。每行是一个动作名称、一个等号
+// 和任意数量的带引号的按键（使用 Go 的字符串语法，因此空格键写作 " "）。
+// 空行和以 # 开头的行被忽略。
+func ParseConfig(r io.Reader) (Config, error) {
+	cfg := Config{}
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, rest, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("key: line %d: expected action = keys", n)
+		}
+
+		keys := []string{}
+		for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("key: line %d: invalid key %s", n, rest)
+			}
+			k, _ := strconv.Unquote(quoted)
+			keys = append(keys, k)
+			rest = rest[len(quoted):]
+		}
+		cfg[name] = keys
+	}
+	if err := s.Err(); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	return cfg, nil
+}
+
+// WriteTo 以文本格式写出配置，动作按名称排序。它实现 io.WriterTo 接口。
+func (c Config) WriteTo(w io.Writer) (int64, error) {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + " =")
+		for _, k := range c[name] {
+			b.WriteString(" " + strconv.Quote(k))
+		}
+		b.WriteByte('\n')
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err //nolint:wrapcheck
+}
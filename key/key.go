@@ -35,13 +35,17 @@
 // 上面示例中未使用的帮助信息可用于在视图中渲染按键的帮助文本。
 package key
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+)
 
 // Binding 描述了一组按键绑定以及可选的相关帮助文本。
 type Binding struct {
 	keys     []string // 按键列表
 	help     Help     // 帮助信息
 	disabled bool     // 是否禁用
+	priority int      // 在帮助中的优先级
 }
 
 // BindingOpt 是按键绑定的初始化选项。它用作 NewBinding 的参数。
@@ -77,6 +81,13 @@ func WithDisabled() BindingOpt {
 	}
 }
 
+// WithPriority 使用给定的优先级初始化按键绑定，参见 Binding.Priority。
+func WithPriority(p int) BindingOpt {
+	return func(b *Binding) {
+		b.priority = p
+	}
+}
+
 // SetKeys 设置按键绑定的按键。
 func (b *Binding) SetKeys(keys ...string) {
 	b.keys = keys
@@ -97,6 +108,17 @@ func (b Binding) Help() Help {
 	return b.help
 }
 
+// SetPriority 设置按键绑定在帮助中的优先级。
+func (b *Binding) SetPriority(p int) {
+	b.priority = p
+}
+
+// Priority 返回按键绑定在帮助中的优先级。空间不足时，帮助视图先省略优先级低的绑定。
+// 默认为 0。
+func (b Binding) Priority() int {
+	return b.priority
+}
+
 // Enabled 返回按键绑定是否启用。禁用的按键绑定不会被激活，也不会在帮助中显示。
 // 按键绑定默认是启用的。
 func (b Binding) Enabled() bool {
@@ -121,15 +143,66 @@ type Help struct {
 	Desc string // 描述
 }
 
-// Matches 检查给定的按键是否匹配给定的绑定。
+// Matches 检查给定的按键是否匹配给定的绑定。如果设置了观察者，
+// 匹配成功时会以按键和匹配的绑定调用它。
+//
+// 组件内部只用于预先判断、随后还会分发同一按键的检查应当使用 Check，
+// 以免同一次按键被观察者记录多次。
 func Matches[Key fmt.Stringer](k Key, b ...Binding) bool {
 	keys := k.String()
+	binding, ok := match(keys, b)
+	if ok {
+		notify(keys, binding)
+	}
+	return ok
+}
+
+// Check 与 Matches 相同，但不通知观察者。
+func Check[Key fmt.Stringer](k Key, b ...Binding) bool {
+	_, ok := match(k.String(), b)
+	return ok
+}
+
+// match 返回第一个匹配按键的可用绑定。
+func match(keys string, b []Binding) (Binding, bool) {
 	for _, binding := range b {
 		for _, v := range binding.keys {
 			if keys == v && binding.Enabled() {
-				return true
+				return binding, true
 			}
 		}
 	}
-	return false
+	return Binding{}, false
+}
+
+// Observer 在 Matches 匹配成功时被调用，参数为按下的按键和匹配的绑定。
+// 应用程序可以用它统计用户实际使用的快捷键，例如用于提示尚未使用的功能，
+// 而无需包装 Update 中的每个分支。绑定可以通过其帮助文本识别。
+//
+// 观察者在调用 Matches 的 goroutine 中同步执行，因此应当快速返回。
+type Observer func(key string, b Binding)
+
+var (
+	observerMu sync.RWMutex
+	observer   Observer
+)
+
+// SetObserver 设置全局观察者并返回之前的观察者，以便恢复或链式调用。
+// 传入 nil 以移除观察者。
+func SetObserver(o Observer) Observer {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+	prev := observer
+	observer = o
+	return prev
+}
+
+// notify 通知观察者（如果有）按键匹配成功。
+func notify(key string, b Binding) {
+	observerMu.RLock()
+	o := observer
+	observerMu.RUnlock()
+	if o != nil {
+		o(key, b)
+	}
 }
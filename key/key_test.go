@@ -1,6 +1,9 @@
 package key
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -33,3 +36,89 @@ func TestBinding_Enabled(t *testing.T) {
 		t.Errorf("expected key not to be Enabled")
 	}
 }
+
+// keyString 是用于测试的按键。
+type keyString string
+
+func (k keyString) String() string { return string(k) }
+
+// TestObserver 测试 Matches 匹配成功时调用观察者，而 Check 不调用。
+func TestObserver(t *testing.T) {
+	up := NewBinding(WithKeys("k", "up"), WithHelp("↑/k", "move up"))
+	down := NewBinding(WithKeys("j", "down"), WithHelp("↓/j", "move down"))
+
+	var got []string
+	prev := SetObserver(func(key string, b Binding) {
+		got = append(got, key+":"+b.Help().Desc)
+	})
+	defer SetObserver(prev)
+
+	Matches(keyString("up"), down, up)
+	Matches(keyString("x"), down, up)
+	if !Check(keyString("k"), up) {
+		t.Error("expected Check to match")
+	}
+	down.SetEnabled(false)
+	Matches(keyString("j"), down)
+
+	if len(got) != 1 || got[0] != "up:move up" {
+		t.Errorf("expected only the successful match to be observed, got %v", got)
+	}
+}
+
+type testKeyMap struct {
+	Up     Binding
+	Down   Binding `key:"down"`
+	Ignore Binding `key:"-"`
+	Nested struct {
+		Quit Binding
+	}
+}
+
+func TestConfig(t *testing.T) {
+	km := testKeyMap{
+		Up:   NewBinding(WithKeys("k", "up"), WithHelp("↑/k", "move up")),
+		Down: NewBinding(WithKeys("j", "down"), WithHelp("↓/j", "move down")),
+	}
+	km.Nested.Quit = NewBinding(WithKeys("q"))
+
+	want := Config{"Up": {"k", "up"}, "down": {"j", "down"}, "Nested.Quit": {"q"}}
+	if got := Extract(km); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	cfg, err := ParseConfig(strings.NewReader("# overrides\nUp = \"w\" \" \"\n\nNested.Quit =\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Apply(&km, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(km.Up.Keys(), []string{"w", " "}) || km.Up.Help() != (Help{"w/ ", "move up"}) {
+		t.Errorf("expected Up to be rebound, got %v %v", km.Up.Keys(), km.Up.Help())
+	}
+	if km.Nested.Quit.Enabled() {
+		t.Error("expected Nested.Quit to be unbound")
+	}
+	if !reflect.DeepEqual(km.Down.Keys(), []string{"j", "down"}) {
+		t.Errorf("expected Down to be unchanged, got %v", km.Down.Keys())
+	}
+
+	if err := Apply(&km, Config{"Ignore": {"x"}, "Up": {"i"}}); !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("expected ErrUnknownAction, got %v", err)
+	}
+	if km.Up.Keys()[0] != "w" {
+		t.Error("expected keymap not to be modified when the config is invalid")
+	}
+
+	var b strings.Builder
+	if _, err := Extract(km).WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "Nested.Quit =\nUp = \"w\" \" \"\ndown = \"j\" \"down\"\n"; got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+	if _, err := ParseConfig(strings.NewReader("Up = k")); err == nil {
+		t.Error("expected unquoted keys to be rejected")
+	}
+}
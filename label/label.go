@@ -0,0 +1,284 @@
+// Package label 提供一个不可编辑的带样式文本块组件。当内容超出宽度时，
+// 它可以截断、自动换行，或者以跑马灯的方式滚动显示，适用于显示很长的
+// 分支名或 URL 的状态行。
+package label
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// 内部 ID 管理。在动画过程中使用，以确保滚动消息仅由发送它们的标签接收。
+var lastID int64
+
+// nextID 生成下一个唯一的 ID
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
+}
+
+// Overflow 决定文本超出宽度时如何显示。
+type Overflow int
+
+// 可用的溢出处理方式。
+const (
+	// Truncate 截断超出宽度的行，并在末尾添加省略号。
+	Truncate Overflow = iota
+	// Wrap 将超出宽度的行折为多行。
+	Wrap
+	// Marquee 使超出宽度的行循环滚动。
+	Marquee
+)
+
+// 默认的跑马灯配置。
+const (
+	defaultSpeed = time.Second / 8 //nolint:mnd
+	defaultPause = time.Second
+	defaultGap   = "   "
+)
+
+// TickMsg 推动跑马灯滚动一列。
+type TickMsg struct {
+	ID  int // 标签 ID
+	tag int // 标签，用于防止消息过多
+}
+
+// Model 是标签组件的状态。
+type Model struct {
+	// Width 是标签的宽度（包括样式的边框和内边距）。为 0 时不限制宽度。
+	Width int
+
+	// MaxHeight 是 Wrap 模式下最多显示的行数，超出的部分被截断，
+	// 最后一行以省略号结尾。为 0 时不限制。
+	MaxHeight int
+
+	// Overflow 决定文本超出宽度时如何显示。
+	Overflow Overflow
+
+	// Style 是整个标签的样式。
+	Style lipgloss.Style
+
+	// Ellipsis 是截断的行末尾的省略号。
+	Ellipsis string
+
+	// Speed 是跑马灯每滚动一列的时间间隔。
+	Speed time.Duration
+
+	// Pause 是跑马灯每次回到开头时停顿的时间。
+	Pause time.Duration
+
+	// Gap 是跑马灯中文本结尾与下一次重复开头之间的分隔。
+	Gap string
+
+	text    string
+	offset  int  // 跑马灯的当前偏移量
+	running bool // 跑马灯是否正在滚动
+	id      int  // 唯一标识符
+	tag     int  // 标签，用于防止消息过多
+}
+
+// Option 用于在 New 中设置选项，例如 label.New(label.WithWidth(40))。
+type Option func(*Model)
+
+// WithWidth 设置标签的宽度。
+func WithWidth(w int) Option {
+	return func(m *Model) {
+		m.Width = w
+	}
+}
+
+// WithOverflow 设置文本超出宽度时的显示方式。
+func WithOverflow(o Overflow) Option {
+	return func(m *Model) {
+		m.Overflow = o
+	}
+}
+
+// WithStyle 设置标签的样式。
+func WithStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.Style = s
+	}
+}
+
+// WithSpeed 设置跑马灯每滚动一列的时间间隔。
+func WithSpeed(d time.Duration) Option {
+	return func(m *Model) {
+		m.Speed = d
+	}
+}
+
+// WithPause 设置跑马灯每次回到开头时停顿的时间。
+func WithPause(d time.Duration) Option {
+	return func(m *Model) {
+		m.Pause = d
+	}
+}
+
+// New 使用给定的文本创建一个新的标签。
+func New(text string, opts ...Option) Model {
+	m := Model{
+		Ellipsis: "…",
+		Speed:    defaultSpeed,
+		Pause:    defaultPause,
+		Gap:      defaultGap,
+		text:     text,
+		id:       nextID(),
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
+}
+
+// ID 返回标签的唯一 ID。
+func (m Model) ID() int {
+	return m.id
+}
+
+// Text 返回标签的文本。
+func (m Model) Text() string {
+	return m.text
+}
+
+// SetText 设置标签的文本，并使跑马灯回到开头。
+func (m *Model) SetText(s string) {
+	if s == m.text {
+		return
+	}
+	m.text = s
+	m.offset = 0
+}
+
+// Running 返回跑马灯是否正在滚动。
+func (m Model) Running() bool {
+	return m.running
+}
+
+// Start 开始滚动跑马灯。只在 Marquee 模式下有效；文本没有超出宽度时，
+// 跑马灯保持静止，直到文本或宽度发生变化。
+func (m *Model) Start() tea.Cmd {
+	if m.running || m.Overflow != Marquee {
+		return nil
+	}
+	m.running = true
+	m.tag++
+	return m.tick(m.Pause)
+}
+
+// Stop 停止滚动跑马灯并使其回到开头。
+func (m *Model) Stop() {
+	m.running = false
+	m.offset = 0
+	m.tag++
+}
+
+// Update 处理跑马灯的滚动消息。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	tick, ok := msg.(TickMsg)
+	if !ok || tick.ID != m.id || tick.tag != m.tag || !m.running {
+		return m, nil
+	}
+
+	cycle := m.cycle()
+	if cycle == 0 {
+		// 没有需要滚动的行。以停顿的间隔检查文本或宽度是否发生了变化。
+		m.offset = 0
+		m.tag++
+		return m, m.tick(m.Pause)
+	}
+
+	m.offset = (m.offset + 1) % cycle
+	m.tag++
+	if m.offset == 0 {
+		return m, m.tick(m.Pause)
+	}
+	return m, m.tick(m.Speed)
+}
+
+// View 渲染标签。
+func (m Model) View() string {
+	w := m.contentWidth()
+	if w <= 0 {
+		return m.Style.Render(m.text)
+	}
+
+	lines := strings.Split(m.text, "\n")
+	switch m.Overflow {
+	case Wrap:
+		lines = m.wrap(lines, w)
+	case Marquee:
+		for i, line := range lines {
+			lines[i] = m.scroll(line, w)
+		}
+	default:
+		for i, line := range lines {
+			lines[i] = ansi.Truncate(line, w, m.Ellipsis)
+		}
+	}
+	return m.Style.Width(w).Render(strings.Join(lines, "\n"))
+}
+
+// contentWidth 返回去掉样式的边框和内边距后可用于文本的宽度。
+func (m Model) contentWidth() int {
+	if m.Width <= 0 {
+		return 0
+	}
+	return max(1, m.Width-m.Style.GetHorizontalFrameSize())
+}
+
+// wrap 将行折为宽度为 w 的多行，并按 MaxHeight 截断。
+func (m Model) wrap(lines []string, w int) []string {
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, strings.Split(ansi.Wrap(line, w, ""), "\n")...)
+	}
+	if m.MaxHeight <= 0 || len(wrapped) <= m.MaxHeight {
+		return wrapped
+	}
+	wrapped = wrapped[:m.MaxHeight]
+	last := wrapped[len(wrapped)-1]
+	tail := ansi.StringWidth(m.Ellipsis)
+	wrapped[len(wrapped)-1] = ansi.Truncate(last, w-tail, "") + m.Ellipsis
+	return wrapped
+}
+
+// scroll 返回跑马灯在当前偏移量下显示的行的部分。
+func (m Model) scroll(line string, w int) string {
+	lw := ansi.StringWidth(line)
+	if lw <= w {
+		return line
+	}
+	loop := line + m.Gap
+	n := lw + ansi.StringWidth(m.Gap)
+	o := m.offset % n
+	return ansi.Cut(loop+loop, o, o+w)
+}
+
+// cycle 返回跑马灯滚动一周所需的列数，即最长的超出宽度的行加上分隔的宽度。
+// 没有需要滚动的行时返回 0。
+func (m Model) cycle() int {
+	w := m.contentWidth()
+	if w <= 0 {
+		return 0
+	}
+	cycle := 0
+	for _, line := range strings.Split(m.text, "\n") {
+		if lw := ansi.StringWidth(line); lw > w {
+			cycle = max(cycle, lw+ansi.StringWidth(m.Gap))
+		}
+	}
+	return cycle
+}
+
+// tick 返回在 d 之后发送 TickMsg 的命令。
+func (m Model) tick(d time.Duration) tea.Cmd {
+	id, tag := m.id, m.tag
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return TickMsg{ID: id, tag: tag}
+	})
+}
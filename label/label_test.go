@@ -0,0 +1,61 @@
+package label
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	m := New("feature/very-long-branch-name\nmain", WithWidth(10))
+	want := "feature/v…\nmain      "
+	if got := m.View(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	m := New("one two three four five", WithWidth(9), WithOverflow(Wrap))
+	want := []string{"one two  ", "three    ", "four five"}
+	if got := strings.Split(m.View(), "\n"); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	m.MaxHeight = 2
+	want = []string{"one two  ", "three…   "}
+	if got := strings.Split(m.View(), "\n"); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestMarquee(t *testing.T) {
+	m := New("abcdef", WithWidth(4), WithOverflow(Marquee))
+	m.Gap = "-"
+
+	if m.Start() == nil {
+		t.Fatal("expected Start to return a tick command")
+	}
+	if m.Start() != nil {
+		t.Fatal("expected a second Start to be a no-op")
+	}
+
+	// 一周是文本加上分隔的 7 列，之后回到开头。
+	want := []string{"abcd", "bcde", "cdef", "def-", "ef-a", "f-ab", "-abc", "abcd"}
+	for i, w := range want {
+		if got := m.View(); got != w {
+			t.Fatalf("frame %d: expected %q, got %q", i, w, got)
+		}
+		m, _ = m.Update(TickMsg{ID: m.id, tag: m.tag})
+	}
+
+	// 过期的消息被忽略。
+	offset := m.offset
+	m, _ = m.Update(TickMsg{ID: m.id, tag: m.tag - 1})
+	if m.offset != offset {
+		t.Fatal("expected a stale tick to be ignored")
+	}
+
+	m.Stop()
+	if m.Running() || m.View() != "abcd" {
+		t.Fatalf("expected the marquee to stop at the start, got %q", m.View())
+	}
+}
@@ -0,0 +1,90 @@
+package list
+
+import (
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// filterDebounceMsg 在防抖延迟结束后发送，此时如果过滤词没有再次变化，就开始过滤。
+type filterDebounceMsg struct {
+	id  int // 列表的 ID（即 spinner 的 ID），用于区分多个列表
+	gen int
+}
+
+// filterResultMsg 包含异步过滤的结果。只有最新一次过滤的结果会被应用。
+type filterResultMsg struct {
+	id      int
+	gen     int
+	matches FilterMatchesMsg
+}
+
+// SetFilterDebounce 设置过滤的防抖延迟。如果 d 大于 0，输入过滤词时列表会等待
+// 过滤词在 d 内不再变化后才调用 Filter，过滤期间在标题栏显示 spinner，并丢弃
+// 被更新的过滤词取代的过滤结果。这适用于项目很多或 FilterFunc 很慢的列表。
+// 如果 d 为 0（默认），每次按键都立即过滤。
+func (m *Model) SetFilterDebounce(d time.Duration) {
+	m.filterDebounce = max(0, d)
+}
+
+// FilterDebounce 返回过滤的防抖延迟。
+func (m Model) FilterDebounce() time.Duration {
+	return m.filterDebounce
+}
+
+// FilterPending 返回是否有尚未返回结果的异步过滤，参见 SetFilterDebounce。
+func (m Model) FilterPending() bool {
+	return m.filterPending
+}
+
+// requestFilter 返回按照当前过滤词过滤项目的命令。如果设置了防抖延迟，命令先等待
+// 延迟结束，并取代所有尚未返回结果的过滤。
+func (m *Model) requestFilter() tea.Cmd {
+	if m.filterDebounce <= 0 {
+		return filterItems(*m)
+	}
+
+	m.filterGen++
+	msg := filterDebounceMsg{id: m.spinner.ID(), gen: m.filterGen}
+	cmds := []tea.Cmd{tea.Tick(m.filterDebounce, func(time.Time) tea.Msg { return msg })}
+	m.filterPending = true
+	if !m.showSpinner {
+		m.filterSpinner = true
+		cmds = append(cmds, m.StartSpinner())
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleFilterDebounce 在防抖延迟结束后开始过滤，除非过滤词在此期间再次变化。
+func (m Model) handleFilterDebounce(msg filterDebounceMsg) tea.Cmd {
+	if msg.id != m.spinner.ID() || msg.gen != m.filterGen {
+		return nil
+	}
+	filter := filterItems(m)
+	return func() tea.Msg {
+		matches, _ := filter().(FilterMatchesMsg)
+		return filterResultMsg{id: msg.id, gen: msg.gen, matches: matches}
+	}
+}
+
+// acceptFilterResult 返回异步过滤的结果是否仍是最新的。如果是，结束等待状态。
+func (m *Model) acceptFilterResult(msg filterResultMsg) bool {
+	if msg.id != m.spinner.ID() || msg.gen != m.filterGen {
+		return false
+	}
+	m.cancelFilter()
+	return true
+}
+
+// cancelFilter 丢弃所有尚未返回结果的异步过滤，并停止因等待过滤而显示的 spinner。
+func (m *Model) cancelFilter() {
+	if !m.filterPending {
+		return
+	}
+	m.filterGen++
+	m.filterPending = false
+	if m.filterSpinner {
+		m.filterSpinner = false
+		m.StopSpinner()
+	}
+}
@@ -0,0 +1,56 @@
+package list
+
+import tea "github.com/purpose168/bubbletea-cn"
+
+// AutoSize 描述列表如何根据 tea.WindowSizeMsg 自动调整自身大小。
+// 参见 [Model.SetAutoSize]。
+type AutoSize struct {
+	// MarginX 和 MarginY 是从窗口宽度和高度中减去的列数和行数，
+	// 用于为列表周围的其他内容（例如边框和状态栏）留出空间。
+	MarginX int
+	MarginY int
+
+	// MaxWidth 和 MaxHeight 限制列表的最大尺寸。如果为 0 或更小，则不限制。
+	MaxWidth  int
+	MaxHeight int
+}
+
+// WithAutoSize 启用对 tea.WindowSizeMsg 的自动处理。参见 [Model.SetAutoSize]。
+func WithAutoSize(s AutoSize) Option {
+	return func(m *Model) {
+		m.SetAutoSize(s)
+	}
+}
+
+// SetAutoSize 启用对 tea.WindowSizeMsg 的自动处理：收到消息时，列表按照给定的
+// 约束调用 SetSize，重新计算分页、过滤输入框和帮助的宽度，
+// 因此不需要在父模型中手动调用 SetSize。
+func (m *Model) SetAutoSize(s AutoSize) {
+	m.autoSize = s
+	m.autoSizeEnabled = true
+}
+
+// DisableAutoSize 禁用对 tea.WindowSizeMsg 的自动处理。
+func (m *Model) DisableAutoSize() {
+	m.autoSize = AutoSize{}
+	m.autoSizeEnabled = false
+}
+
+// AutoSizeEnabled 返回是否启用了对 tea.WindowSizeMsg 的自动处理。
+func (m Model) AutoSizeEnabled() bool {
+	return m.autoSizeEnabled
+}
+
+// handleWindowSize 根据窗口大小和自动调整约束调整列表大小。
+func (m *Model) handleWindowSize(msg tea.WindowSizeMsg) {
+	s := m.autoSize
+	w := max(0, msg.Width-s.MarginX)
+	h := max(0, msg.Height-s.MarginY)
+	if s.MaxWidth > 0 {
+		w = min(w, s.MaxWidth)
+	}
+	if s.MaxHeight > 0 {
+		h = min(h, s.MaxHeight)
+	}
+	m.SetSize(w, h)
+}
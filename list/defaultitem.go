@@ -22,10 +22,18 @@ type DefaultItemStyles struct {
 	SelectedTitle lipgloss.Style
 	SelectedDesc  lipgloss.Style
 
+	// 重新排序时拿起的项目。
+	MovingTitle lipgloss.Style
+	MovingDesc  lipgloss.Style
+
 	// 暗淡状态，用于过滤器输入最初激活时。
 	DimmedTitle lipgloss.Style
 	DimmedDesc  lipgloss.Style
 
+	// 被禁用的项目（参见 DisabledItem）。
+	DisabledTitle lipgloss.Style
+	DisabledDesc  lipgloss.Style
+
 	// 匹配当前过滤器的字符（如果有）。
 	FilterMatch lipgloss.Style
 }
@@ -49,6 +57,13 @@ func NewDefaultItemStyles() (s DefaultItemStyles) {
 	s.SelectedDesc = s.SelectedTitle.
 		Foreground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"})
 
+	s.MovingTitle = s.SelectedTitle.
+		Border(lipgloss.ThickBorder(), false, false, false, true).
+		Bold(true)
+
+	s.MovingDesc = s.SelectedDesc.
+		Border(lipgloss.ThickBorder(), false, false, false, true)
+
 	s.DimmedTitle = lipgloss.NewStyle().
 		Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"}).
 		Padding(0, 0, 0, 2) //nolint:mnd
@@ -56,6 +71,9 @@ func NewDefaultItemStyles() (s DefaultItemStyles) {
 	s.DimmedDesc = s.DimmedTitle.
 		Foreground(lipgloss.AdaptiveColor{Light: "#C2B8C2", Dark: "#4D4D4D"})
 
+	s.DisabledTitle = s.DimmedTitle.Faint(true)
+	s.DisabledDesc = s.DimmedDesc.Faint(true)
+
 	s.FilterMatch = lipgloss.NewStyle().Underline(true)
 
 	return s
@@ -78,12 +96,14 @@ type DefaultItem interface {
 // 而 ItemDelegate 是在列表的 Update 函数被调用时被调用的。
 //
 // 设置 ShortHelpFunc 和 FullHelpFunc 是可选的。它们可以设置为在列表的默认简短和完整帮助菜单中包含项目。
+// ItemHelpFunc 同样是可选的，它返回只适用于选中项目的按键绑定（参见 ItemKeyMapper）。
 type DefaultDelegate struct {
 	ShowDescription bool
 	Styles          DefaultItemStyles
 	UpdateFunc      func(tea.Msg, *Model) tea.Cmd
 	ShortHelpFunc   func() []key.Binding
 	FullHelpFunc    func() [][]key.Binding
+	ItemHelpFunc    func(index int, item Item) []key.Binding
 	height          int
 	spacing         int
 }
@@ -184,6 +204,14 @@ func (d DefaultDelegate) Render(w io.Writer, m Model, index int, item Item) {
 		// 空过滤器状态
 		title = s.DimmedTitle.Render(title)
 		desc = s.DimmedDesc.Render(desc)
+	} else if itemDisabled(item) {
+		// 被禁用的项目
+		title = s.DisabledTitle.Render(title)
+		desc = s.DisabledDesc.Render(desc)
+	} else if isSelected && m.Reordering() {
+		// 正在移动的项目
+		title = s.MovingTitle.Render(title)
+		desc = s.MovingDesc.Render(desc)
 	} else if isSelected && m.FilterState() != Filtering {
 		// 选中状态
 		if isFiltered {
@@ -229,3 +257,11 @@ func (d DefaultDelegate) FullHelp() [][]key.Binding {
 	}
 	return nil
 }
+
+// ItemKeyMap 返回选中项目的帮助，实现 ItemKeyMapper 接口。
+func (d DefaultDelegate) ItemKeyMap(index int, item Item) []key.Binding {
+	if d.ItemHelpFunc != nil {
+		return d.ItemHelpFunc(index, item)
+	}
+	return nil
+}
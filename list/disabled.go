@@ -0,0 +1,36 @@
+package list
+
+// DisabledItem 是项目可选实现的接口。Disabled 返回 true 的项目不可选择：
+// CursorUp 和 CursorDown 跳过它们，DefaultDelegate 以暗淡的样式渲染它们，
+// 并且光标位于它们上面时按键不会交给委托的 Update，因此不会对它们执行操作。
+type DisabledItem interface {
+	Item
+
+	// Disabled 返回项目是否被禁用。
+	Disabled() bool
+}
+
+// itemDisabled 返回项目是否被禁用。
+func itemDisabled(item Item) bool {
+	d, ok := item.(DisabledItem)
+	return ok && d.Disabled()
+}
+
+// skipDisabled 使用 move 移动光标，直到光标位于未禁用的项目上。
+// 如果在该方向上没有未禁用的项目，光标保持不动。
+func (m *Model) skipDisabled(move func()) {
+	page, cursor := m.Paginator.Page, m.cursor
+	prev := m.Index()
+	for range len(m.VisibleItems()) {
+		move()
+		if !itemDisabled(m.SelectedItem()) {
+			return
+		}
+		if m.Index() == prev {
+			// 光标已经到达边界
+			break
+		}
+		prev = m.Index()
+	}
+	m.Paginator.Page, m.cursor = page, cursor
+}
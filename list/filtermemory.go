@@ -0,0 +1,73 @@
+package list
+
+// defaultFilterMemory 是默认记住选中项目的过滤词数量。
+const defaultFilterMemory = 10
+
+// filterMemory 记录使用某个过滤词时最后选中的项目。
+type filterMemory struct {
+	term string
+	sel  selection // 选中项目的标识，或它在所有项目中的索引
+}
+
+// WithFilterMemory 设置记住选中项目的过滤词数量（参见 SetFilterMemory）。
+func WithFilterMemory(n int) Option {
+	return func(m *Model) {
+		m.SetFilterMemory(n)
+	}
+}
+
+// SetFilterMemory 设置记住选中项目的最近过滤词的数量，默认为 10。
+// 清除过滤器时，列表记住该过滤词下选中的项目；之后再次应用相同的过滤词时，
+// 重新选中该项目（如果它仍然匹配）。设置了 ItemIdentity 时按标识查找项目，
+// 否则按它在所有项目中的索引查找。如果 n 为 0 或更小，则禁用此功能。
+func (m *Model) SetFilterMemory(n int) {
+	m.filterMemorySize = max(0, n)
+	if len(m.filterMemory) > m.filterMemorySize {
+		m.filterMemory = m.filterMemory[:m.filterMemorySize]
+	}
+}
+
+// rememberFilterSelection 记住当前过滤词下选中的项目。最近使用的过滤词排在最前，
+// 超出数量上限时丢弃最久未使用的过滤词。
+func (m *Model) rememberFilterSelection() {
+	term := m.FilterInput.Value()
+	if m.filterMemorySize <= 0 || m.filterState == Unfiltered || term == "" {
+		return
+	}
+	item := m.SelectedItem()
+	if item == nil {
+		return
+	}
+
+	sel := selection{index: m.GlobalIndex()}
+	if m.ItemIdentity != nil {
+		sel.id = m.ItemIdentity(item)
+	}
+
+	entries := []filterMemory{{term: term, sel: sel}}
+	for _, e := range m.filterMemory {
+		if e.term != term && len(entries) < m.filterMemorySize {
+			entries = append(entries, e)
+		}
+	}
+	m.filterMemory = entries
+}
+
+// recallFilterSelection 如果记住了当前过滤词下选中的项目，并且该项目在过滤结果中，
+// 则重新选中它。
+func (m *Model) recallFilterSelection() {
+	term := m.FilterInput.Value()
+	for _, e := range m.filterMemory {
+		if e.term != term {
+			continue
+		}
+		for i, match := range m.filteredItems {
+			if m.ItemIdentity != nil && m.ItemIdentity(match.item) == e.sel.id ||
+				m.ItemIdentity == nil && match.index == e.sel.index {
+				m.Select(i)
+				return
+			}
+		}
+		return
+	}
+}
@@ -0,0 +1,48 @@
+package list
+
+// ItemIdentityFunc 返回项目的唯一标识，用于在项目变化后重新定位选中的项目。
+type ItemIdentityFunc func(Item) string
+
+// WithItemIdentity 设置项目标识函数（参见 Model.ItemIdentity）。
+func WithItemIdentity(fn ItemIdentityFunc) Option {
+	return func(m *Model) {
+		m.ItemIdentity = fn
+	}
+}
+
+// selection 记录 SetItems 之前选中的项目，以便之后重新定位。
+type selection struct {
+	id    string
+	index int
+}
+
+// rememberSelection 记录当前选中项目的标识和索引。
+func (m Model) rememberSelection() *selection {
+	if m.ItemIdentity == nil {
+		return nil
+	}
+	item := m.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	return &selection{id: m.ItemIdentity(item), index: m.Index()}
+}
+
+// restoreSelection 在可见项目中重新定位之前选中的项目。如果没有设置 ItemIdentity
+// 或找不到该项目，则选择最接近之前索引的项目。
+func (m *Model) restoreSelection(sel *selection) {
+	if sel == nil {
+		return
+	}
+	items := m.VisibleItems()
+	if len(items) == 0 {
+		return
+	}
+	for i, item := range items {
+		if m.ItemIdentity != nil && m.ItemIdentity(item) == sel.id {
+			m.Select(i)
+			return
+		}
+	}
+	m.Select(clamp(sel.index, 0, len(items)-1))
+}
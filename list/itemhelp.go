@@ -0,0 +1,25 @@
+package list
+
+import "github.com/purpose168/bubbles-cn/key"
+
+// ItemKeyMapper 是委托可以选择实现的接口，用于报告只适用于某个项目的按键绑定，
+// 例如只对可删除的项目显示 "x: delete"。列表将选中项目的绑定合并到简短帮助和
+// 完整帮助中，帮助随光标的移动而变化。
+type ItemKeyMapper interface {
+	// ItemKeyMap 返回第 index 个可见项目的按键绑定。
+	ItemKeyMap(index int, item Item) []key.Binding
+}
+
+// itemKeyMap 返回选中项目的上下文按键绑定。如果委托没有实现 ItemKeyMapper、
+// 没有选中的项目或正在设置过滤器，则返回 nil。
+func (m Model) itemKeyMap() []key.Binding {
+	km, ok := m.delegate.(ItemKeyMapper)
+	if !ok || m.filterState == Filtering {
+		return nil
+	}
+	item := m.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	return km.ItemKeyMap(m.Index(), item)
+}
@@ -18,6 +18,12 @@ type KeyMap struct {
 	CancelWhileFiltering key.Binding // 取消过滤
 	AcceptWhileFiltering key.Binding // 接受过滤
 
+	// 重新排序项目时使用的按键绑定（参见 Model.SetReorderingEnabled）。
+	// 拿起项目之后，CursorUp 和 CursorDown 移动它。
+	Reorder       key.Binding // 拿起选中的项目
+	DropItem      key.Binding // 放下项目
+	CancelReorder key.Binding // 取消移动，将项目放回原处
+
 	// 帮助切换按键绑定。
 	ShowFullHelp  key.Binding // 显示完整帮助
 	CloseFullHelp key.Binding // 关闭完整帮助
@@ -76,6 +82,20 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("enter", "apply filter"),
 		),
 
+		// 重新排序。
+		Reorder: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move"),
+		),
+		DropItem: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "drop"),
+		),
+		CancelReorder: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel move"),
+		),
+
 		// 切换帮助。
 		ShowFullHelp: key.NewBinding(
 			key.WithKeys("?"),
@@ -0,0 +1,128 @@
+package list
+
+import tea "github.com/purpose168/bubbletea-cn"
+
+// defaultLazyLoadThreshold 是默认的预加载阈值。
+const defaultLazyLoadThreshold = 5
+
+// FetchFunc 返回一个加载更多项目的命令。offset 是已加载的项目数量。
+// 命令的结果应为 ItemsLoadedMsg，列表收到后会将其中的项目追加到末尾。
+type FetchFunc func(offset int) tea.Cmd
+
+// NeedMoreItemsMsg 在延迟加载模式下，列表开始加载更多项目时发送。
+// 父模型可以监听它来显示额外的加载状态。
+type NeedMoreItemsMsg struct {
+	Offset int // 已加载的项目数量
+}
+
+// ItemsLoadedMsg 是 FetchFunc 返回的命令的结果。
+type ItemsLoadedMsg struct {
+	// Items 是新加载的项目，将被追加到列表末尾。
+	Items []Item
+
+	// Done 表示没有更多项目可以加载。
+	Done bool
+
+	// Total 是项目的总数（如果已知）。如果为 0 或更小，则视为未知，
+	// 状态栏会显示为 "50+ items"。
+	Total int
+
+	// Err 是加载过程中发生的错误。如果不为 nil，错误会显示为状态消息。
+	// 之后按下移动光标的键会重试加载，即使光标已经在最后一项上而无法移动；
+	// 也可以调用 FetchMore 重试。
+	Err error
+
+	id int // 发起加载的列表的 ID，参见 FetchMore
+}
+
+// WithLazyLoading 启用延迟加载模式：当光标距离已加载项目的末尾少于
+// threshold 个项目时，列表发送 NeedMoreItemsMsg 并调用 fetch 加载更多项目。
+// 如果 threshold 为 0 或更小，则使用默认值 5。
+func WithLazyLoading(fetch FetchFunc, threshold int) Option {
+	return func(m *Model) {
+		m.SetLazyLoading(fetch, threshold)
+	}
+}
+
+// SetLazyLoading 启用延迟加载模式（参见 WithLazyLoading）。传入 nil 以禁用。
+func (m *Model) SetLazyLoading(fetch FetchFunc, threshold int) {
+	if threshold <= 0 {
+		threshold = defaultLazyLoadThreshold
+	}
+	m.fetch = fetch
+	m.fetchThreshold = threshold
+	m.fetching = false
+	m.fetchFailed = false
+	m.fetchDone = false
+	m.totalItems = 0
+}
+
+// Fetching 返回是否正在加载更多项目。
+func (m Model) Fetching() bool {
+	return m.fetching
+}
+
+// HasMoreItems 返回在延迟加载模式下是否还有更多项目可以加载。
+func (m Model) HasMoreItems() bool {
+	return m.fetch != nil && !m.fetchDone
+}
+
+// FetchMore 立即开始加载更多项目，例如用于加载第一页。
+// 如果没有启用延迟加载、正在加载或没有更多项目，则返回 nil。
+func (m *Model) FetchMore() tea.Cmd {
+	if !m.HasMoreItems() || m.fetching {
+		return nil
+	}
+	m.fetching = true
+	m.fetchFailed = false
+	offset := len(m.items)
+	return tea.Batch(
+		func() tea.Msg { return NeedMoreItemsMsg{Offset: offset} },
+		m.stampLoaded(m.fetch(offset)),
+	)
+}
+
+// stampLoaded 在 fetch 命令的结果中记录列表的 ID，
+// 这样多个延迟加载的列表不会收到彼此加载的项目。
+func (m Model) stampLoaded(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	id := m.spinner.ID()
+	return func() tea.Msg {
+		msg := cmd()
+		if loaded, ok := msg.(ItemsLoadedMsg); ok {
+			loaded.id = id
+			return loaded
+		}
+		return msg
+	}
+}
+
+// maybeFetch 在光标接近已加载项目的末尾时开始加载更多项目。
+// 过滤时不加载，因为可见项目并不对应已加载项目的末尾。
+func (m *Model) maybeFetch() tea.Cmd {
+	if m.filterState != Unfiltered || len(m.VisibleItems())-m.Index()-1 >= m.fetchThreshold {
+		return nil
+	}
+	return m.FetchMore()
+}
+
+// handleItemsLoaded 将加载的项目追加到列表末尾。
+func (m *Model) handleItemsLoaded(msg ItemsLoadedMsg) tea.Cmd {
+	if m.fetch == nil || msg.id != m.spinner.ID() {
+		return nil
+	}
+	m.fetching = false
+	if msg.Err != nil {
+		m.fetchFailed = true
+		return m.NewStatusMessage(m.Styles.StatusEmpty.Render(msg.Err.Error()))
+	}
+
+	m.fetchDone = msg.Done
+	m.totalItems = msg.Total
+	items := make([]Item, 0, len(m.items)+len(msg.Items))
+	items = append(items, m.items...)
+	items = append(items, msg.Items...)
+	return m.SetItems(items)
+}
@@ -38,7 +38,8 @@ type Item interface {
 // ItemDelegate 封装了所有列表项的通用功能。将此逻辑与项目本身分离的好处是，
 // 您可以更改项目的功能而无需更改实际项目本身。
 //
-// 注意，如果委托还实现了 help.KeyMap 接口，与委托相关的帮助项将被添加到帮助视图中。
+// 注意，如果委托还实现了 help.KeyMap 接口，与委托相关的帮助项将被添加到帮助视图中；
+// 如果委托实现了 ItemKeyMapper 接口，选中项目的帮助项也会被添加到帮助视图中。
 type ItemDelegate interface {
 	// Render 渲染项目的视图。
 	Render(w io.Writer, m Model, index int, item Item)
@@ -145,6 +146,9 @@ type Model struct {
 	itemNameSingular string
 	itemNamePlural   string
 
+	// 固定的每页项目数量。如果为 0，则根据可用高度计算。
+	itemsPerPage int
+
 	Title             string
 	Styles            Styles
 	InfiniteScrolling bool
@@ -155,23 +159,64 @@ type Model struct {
 	// Filter 用于过滤列表。
 	Filter FilterFunc
 
+	// ItemIdentity 返回项目的唯一标识。如果设置了它，SetItems 会在项目变化后
+	// 按标识重新定位之前选中的项目（例如轮询刷新之后），找不到时选择最接近
+	// 之前索引的项目。如果为 nil，则保留光标所在的索引。
+	ItemIdentity ItemIdentityFunc
+
+	// 正在过滤时，等待过滤结果后重新定位的选中项目
+	pendingSelection *selection
+
+	// 最近的过滤词下最后选中的项目，最近使用的在前（参见 SetFilterMemory）
+	filterMemory     []filterMemory
+	filterMemorySize int
+
+	// 在文本过滤之前应用的谓词，以及通过它的项目
+	predicate        PredicateFunc
+	predicateMatches filteredItems
+
+	// 键盘重新排序的状态（参见 SetReorderingEnabled）
+	reorderingEnabled bool
+	reordering        bool
+	reorderOrigin     int // 拿起的项目原来的索引
+
 	disableQuitKeybindings bool
 
+	// 嵌入模式下，列表从不返回 tea.Quit。
+	embedded bool
+
+	// 进入嵌入模式之前退出按键绑定的状态，离开嵌入模式时恢复
+	quitBeforeEmbed quitState
+
+	// 根据窗口大小自动调整列表大小的约束
+	autoSize        AutoSize
+	autoSizeEnabled bool
+
 	// 简短和完整帮助视图的附加按键映射。这允许您在不重新实现帮助组件的情况下
 	// 向帮助菜单添加附加按键映射。当然，如果您需要更多灵活性，
 	// 也可以禁用列表的帮助组件并实现一个新的。
 	AdditionalShortHelpKeys func() []key.Binding
 	AdditionalFullHelpKeys  func() []key.Binding
 
+	// StatusFunc 如果设置，返回代替默认内容显示在状态栏中的文本，例如本地化的项目计数。
+	// 返回的文本仍使用 Styles.StatusBar 渲染。StatusCounts 提供默认状态栏使用的计数。
+	StatusFunc StatusFunc
+
 	spinner     spinner.Model
 	showSpinner bool
-	width       int
-	height      int
-	Paginator   paginator.Model
-	cursor      int
-	Help        help.Model
-	FilterInput textinput.Model
-	filterState FilterState
+
+	// 异步过滤的状态（参见 SetFilterDebounce）
+	filterDebounce time.Duration
+	filterGen      int  // 最新一次过滤请求的编号，较旧的结果被丢弃
+	filterPending  bool // 是否有尚未返回结果的过滤
+	filterSpinner  bool // spinner 是否因等待过滤而显示
+	width          int
+	height         int
+	Paginator      paginator.Model
+	cursor         int
+	Help           help.Model
+	FilterInput    textinput.Model
+	filterState    FilterState
 
 	// 状态消息应保持可见的时间。默认情况下为 1 秒。
 	StatusMessageLifetime time.Duration
@@ -187,10 +232,38 @@ type Model struct {
 	filteredItems filteredItems
 
 	delegate ItemDelegate
+
+	// 延迟加载状态
+	fetch          FetchFunc
+	fetchThreshold int
+	fetching       bool
+	fetchFailed    bool // 上一次加载是否失败，此时移动光标的键总会重试
+	fetchDone      bool
+	totalItems     int
+}
+
+// Option 用于在 New 中设置选项。例如：
+//
+//	l := list.New(items, delegate, width, height, list.WithoutQuit())
+type Option func(*Model)
+
+// WithoutQuit 将列表设置为嵌入模式（参见 Model.SetEmbedded）。当列表与其他组件
+// 组合使用，并且退出应由应用程序处理时，请使用此选项。
+func WithoutQuit() Option {
+	return func(m *Model) {
+		m.SetEmbedded(true)
+	}
+}
+
+// WithItemsPerPage 固定每页显示的项目数量（参见 SetItemsPerPage）。
+func WithItemsPerPage(n int) Option {
+	return func(m *Model) {
+		m.SetItemsPerPage(n)
+	}
 }
 
 // New 返回一个具有合理默认值的新模型。
-func New(items []Item, delegate ItemDelegate, width, height int) Model {
+func New(items []Item, delegate ItemDelegate, width, height int, opts ...Option) Model {
 	styles := DefaultStyles()
 
 	// 创建一个新的 spinner 模型
@@ -228,6 +301,7 @@ func New(items []Item, delegate ItemDelegate, width, height int) Model {
 		Title:                 "List",
 		FilterInput:           filterInput,
 		StatusMessageLifetime: time.Second,
+		filterMemorySize:      defaultFilterMemory,
 
 		width:     width,
 		height:    height,
@@ -238,6 +312,10 @@ func New(items []Item, delegate ItemDelegate, width, height int) Model {
 		Help:      help.New(),
 	}
 
+	for _, opt := range opts {
+		opt(&m)
+	}
+
 	// 更新分页和按键绑定
 	m.updatePagination()
 	m.updateKeybindings()
@@ -282,6 +360,7 @@ func (m *Model) SetFilterText(filter string) {
 	m.filteredItems = filteredItems(fmm)
 	m.filterState = FilterApplied
 	m.GoToStart()
+	m.recallFilterSelection()
 	m.FilterInput.CursorEnd()
 	m.updatePagination()
 	m.updateKeybindings()
@@ -371,15 +450,21 @@ func (m Model) Items() []Item {
 // SetItems 设置列表中可用的项目。这返回一个命令。
 func (m *Model) SetItems(i []Item) tea.Cmd {
 	var cmd tea.Cmd
+	sel := m.rememberSelection()
 	m.items = i
+	m.applyPredicate()
 
-	// 如果当前处于过滤状态，则重新过滤项目
+	// 如果当前处于过滤状态，则重新过滤项目，并在过滤结果返回后重新定位选中的项目
 	if m.filterState != Unfiltered {
 		m.filteredItems = nil
+		m.pendingSelection = sel
 		cmd = filterItems(*m)
 	}
 
 	m.updatePagination()
+	if m.filterState == Unfiltered {
+		m.restoreSelection(sel)
+	}
 	m.updateKeybindings()
 	return cmd
 }
@@ -404,6 +489,7 @@ func (m *Model) ResetFilter() {
 func (m *Model) SetItem(index int, item Item) tea.Cmd {
 	var cmd tea.Cmd
 	m.items[index] = item
+	m.applyPredicate()
 
 	// 如果当前处于过滤状态，则重新过滤项目
 	if m.filterState != Unfiltered {
@@ -419,6 +505,7 @@ func (m *Model) SetItem(index int, item Item) tea.Cmd {
 func (m *Model) InsertItem(index int, item Item) tea.Cmd {
 	var cmd tea.Cmd
 	m.items = insertItemIntoSlice(m.items, item, index)
+	m.applyPredicate()
 
 	// 如果当前处于过滤状态，则重新过滤项目
 	if m.filterState != Unfiltered {
@@ -434,6 +521,7 @@ func (m *Model) InsertItem(index int, item Item) tea.Cmd {
 // 这将是空操作。O(n) 复杂度，在 TUI 的情况下可能不会成为问题。
 func (m *Model) RemoveItem(index int) {
 	m.items = removeItemFromSlice(m.items, index)
+	m.applyPredicate()
 	// 如果当前处于过滤状态，则从过滤结果中移除该项目
 	if m.filterState != Unfiltered {
 		m.filteredItems = removeFilterMatchFromSlice(m.filteredItems, index)
@@ -455,6 +543,9 @@ func (m Model) VisibleItems() []Item {
 	if m.filterState != Unfiltered {
 		return m.filteredItems.items()
 	}
+	if m.predicate != nil {
+		return m.predicateMatches.items()
+	}
 	return m.items
 }
 
@@ -494,11 +585,15 @@ func (m Model) Index() int {
 func (m Model) GlobalIndex() int {
 	index := m.Index()
 
-	if m.filteredItems == nil || index >= len(m.filteredItems) {
+	matches := m.filteredItems
+	if m.filterState == Unfiltered {
+		matches = m.predicateMatches
+	}
+	if matches == nil || index >= len(matches) {
 		return index
 	}
 
-	return m.filteredItems[index].index
+	return matches[index].index
 }
 
 // Cursor 返回当前页面上光标的索引。
@@ -506,8 +601,13 @@ func (m Model) Cursor() int {
 	return m.cursor
 }
 
-// CursorUp 向上移动光标。这也可以将状态移动到上一页。
+// CursorUp 向上移动光标，跳过被禁用的项目（参见 DisabledItem）。这也可以将状态移动到上一页。
 func (m *Model) CursorUp() {
+	m.skipDisabled(m.cursorUp)
+}
+
+// cursorUp 将光标向上移动一个项目，不跳过被禁用的项目。
+func (m *Model) cursorUp() {
 	m.cursor--
 
 	// 如果我们在开始处，停止
@@ -531,8 +631,13 @@ func (m *Model) CursorUp() {
 	m.cursor = m.maxCursorIndex()
 }
 
-// CursorDown 向下移动光标。这也可以将状态推进到下一页。
+// CursorDown 向下移动光标，跳过被禁用的项目（参见 DisabledItem）。这也可以将状态推进到下一页。
 func (m *Model) CursorDown() {
+	m.skipDisabled(m.cursorDown)
+}
+
+// cursorDown 将光标向下移动一个项目，不跳过被禁用的项目。
+func (m *Model) cursorDown() {
 	maxCursorIndex := m.maxCursorIndex()
 
 	m.cursor++
@@ -656,6 +761,41 @@ func (m *Model) DisableQuitKeybindings() {
 	m.KeyMap.ForceQuit.SetEnabled(false)
 }
 
+// quitState 是退出按键绑定的状态。
+type quitState struct {
+	disabled  bool // 是否禁用了退出按键绑定，参见 DisableQuitKeybindings
+	forceQuit bool // 强制退出按键绑定是否启用
+}
+
+// SetEmbedded 启用或禁用嵌入模式。在嵌入模式下，退出和强制退出按键绑定都被禁用，
+// 并且列表永远不会返回 tea.Quit，因此将列表嵌入到更大的程序中时，
+// 按下 q、esc 或 ctrl+c 不会意外退出整个程序。
+//
+// 禁用嵌入模式时，退出按键绑定恢复到启用嵌入模式之前的状态：
+// 如果之前调用过 DisableQuitKeybindings，它们仍然保持禁用。
+func (m *Model) SetEmbedded(v bool) {
+	if v == m.embedded {
+		return
+	}
+	m.embedded = v
+	if v {
+		m.quitBeforeEmbed = quitState{disabled: m.disableQuitKeybindings, forceQuit: m.KeyMap.ForceQuit.Enabled()}
+		m.disableQuitKeybindings = true
+		m.KeyMap.Quit.SetEnabled(false)
+		m.KeyMap.ForceQuit.SetEnabled(false)
+	} else {
+		m.disableQuitKeybindings = m.quitBeforeEmbed.disabled
+		m.KeyMap.Quit.SetEnabled(!m.disableQuitKeybindings)
+		m.KeyMap.ForceQuit.SetEnabled(m.quitBeforeEmbed.forceQuit)
+	}
+	m.updateKeybindings()
+}
+
+// Embedded 返回列表是否处于嵌入模式。
+func (m Model) Embedded() bool {
+	return m.embedded
+}
+
 // NewStatusMessage 设置一个新的状态消息，该消息将显示有限的时间。
 // 注意这也返回一个命令。
 func (m *Model) NewStatusMessage(s string) tea.Cmd {
@@ -683,6 +823,21 @@ func (m *Model) SetHeight(v int) {
 	m.SetSize(m.width, v)
 }
 
+// SetItemsPerPage 固定每页显示的项目数量，而不管可用高度是多少，
+// 适用于每页需要恰好 N 行的界面（例如使用 1-9 选择的快捷菜单）。
+// 每页总是占用 N 个项目的高度，不足的部分用空行填充；超出可用高度的
+// 部分会被裁剪。传入 0 或更小的值以恢复根据可用高度计算。
+func (m *Model) SetItemsPerPage(n int) {
+	m.itemsPerPage = max(0, n)
+	m.updatePagination()
+}
+
+// ItemsPerPage 返回通过 SetItemsPerPage 固定的每页项目数量。
+// 如果未固定，则返回 0。
+func (m Model) ItemsPerPage() int {
+	return m.itemsPerPage
+}
+
 // SetSize 设置此组件的宽度和高度。
 func (m *Model) SetSize(width, height int) {
 	promptWidth := lipgloss.Width(m.Styles.Title.Render(m.FilterInput.Prompt))
@@ -700,6 +855,8 @@ func (m *Model) resetFiltering() {
 		return
 	}
 
+	m.rememberFilterSelection()
+	m.cancelFilter()
 	m.filterState = Unfiltered
 	m.FilterInput.Reset()
 	m.filteredItems = nil
@@ -711,7 +868,8 @@ func (m Model) itemsAsFilterItems() filteredItems {
 	fi := make([]filteredItem, len(m.items))
 	for i, item := range m.items {
 		fi[i] = filteredItem{
-			item: item,
+			index: i,
+			item:  item,
 		}
 	}
 	return fi
@@ -719,6 +877,11 @@ func (m Model) itemsAsFilterItems() filteredItems {
 
 // 根据过滤状态设置按键绑定。
 func (m *Model) updateKeybindings() {
+	if m.reordering {
+		m.updateReorderKeybindings()
+		return
+	}
+
 	switch m.filterState { //nolint:exhaustive
 	case Filtering:
 		// 在过滤状态下禁用导航按键
@@ -735,6 +898,9 @@ func (m *Model) updateKeybindings() {
 		m.KeyMap.Quit.SetEnabled(false)
 		m.KeyMap.ShowFullHelp.SetEnabled(false)
 		m.KeyMap.CloseFullHelp.SetEnabled(false)
+		m.KeyMap.Reorder.SetEnabled(false)
+		m.KeyMap.DropItem.SetEnabled(false)
+		m.KeyMap.CancelReorder.SetEnabled(false)
 
 	default:
 		// 默认状态下的按键绑定
@@ -754,6 +920,9 @@ func (m *Model) updateKeybindings() {
 		m.KeyMap.CancelWhileFiltering.SetEnabled(false)
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(false)
 		m.KeyMap.Quit.SetEnabled(!m.disableQuitKeybindings)
+		m.KeyMap.Reorder.SetEnabled(m.canReorder())
+		m.KeyMap.DropItem.SetEnabled(false)
+		m.KeyMap.CancelReorder.SetEnabled(false)
 
 		if m.Help.ShowAll {
 			m.KeyMap.ShowFullHelp.SetEnabled(true)
@@ -788,8 +957,12 @@ func (m *Model) updatePagination() {
 		availHeight -= lipgloss.Height(m.helpView())
 	}
 
-	// 计算每页可以显示的项目数量
-	m.Paginator.PerPage = max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
+	// 计算每页可以显示的项目数量，除非已被固定
+	if m.itemsPerPage > 0 {
+		m.Paginator.PerPage = m.itemsPerPage
+	} else {
+		m.Paginator.SetPerPageFromHeight(availHeight, m.delegate.Height(), m.delegate.Spacing())
+	}
 
 	// 设置总页数
 	if pages := len(m.VisibleItems()); pages < 1 {
@@ -822,15 +995,34 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		// 处理强制退出按键
-		if key.Matches(msg, m.KeyMap.ForceQuit) {
+		if !m.embedded && key.Matches(msg, m.KeyMap.ForceQuit) {
 			return m, tea.Quit
 		}
 
 	case FilterMatchesMsg:
 		// 处理过滤匹配消息
 		m.filteredItems = filteredItems(msg)
+		if m.pendingSelection != nil {
+			m.restoreSelection(m.pendingSelection)
+			m.pendingSelection = nil
+		} else if m.filterState == Filtering {
+			m.recallFilterSelection()
+		}
 		return m, nil
 
+	case filterDebounceMsg:
+		return m, m.handleFilterDebounce(msg)
+
+	case filterResultMsg:
+		if !m.acceptFilterResult(msg) {
+			return m, nil
+		}
+		return m.Update(msg.matches)
+
+	case ItemsLoadedMsg:
+		// 处理延迟加载的项目
+		return m, m.handleItemsLoaded(msg)
+
 	case spinner.TickMsg:
 		// 处理 spinner 滴答消息
 		newSpinnerModel, cmd := m.spinner.Update(msg)
@@ -842,12 +1034,21 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case statusMessageTimeoutMsg:
 		// 处理状态消息超时
 		m.hideStatusMessage()
+
+	case tea.WindowSizeMsg:
+		// 处理窗口大小变化（仅在启用自动调整大小时）
+		if m.autoSizeEnabled {
+			m.handleWindowSize(msg)
+		}
 	}
 
-	// 根据过滤状态处理消息
-	if m.filterState == Filtering {
+	// 根据过滤和重新排序状态处理消息
+	switch {
+	case m.filterState == Filtering:
 		cmds = append(cmds, m.handleFiltering(msg))
-	} else {
+	case m.reordering:
+		cmds = append(cmds, m.handleReordering(msg))
+	default:
 		cmds = append(cmds, m.handleBrowsing(msg))
 	}
 
@@ -857,6 +1058,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 // 当用户浏览列表时的更新。
 func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
+	index := m.Index()
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -866,7 +1068,7 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 		case key.Matches(msg, m.KeyMap.ClearFilter):
 			m.resetFiltering()
 
-		case key.Matches(msg, m.KeyMap.Quit):
+		case !m.embedded && key.Matches(msg, m.KeyMap.Quit):
 			return tea.Quit
 
 		case key.Matches(msg, m.KeyMap.CursorUp):
@@ -889,9 +1091,10 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 		case key.Matches(msg, m.KeyMap.Filter):
 			m.hideStatusMessage()
+			m.rememberFilterSelection()
 			// 仅当过滤器为空时，才用所有项目填充过滤器。
 			if m.FilterInput.Value() == "" {
-				m.filteredItems = m.itemsAsFilterItems()
+				m.filteredItems = append(filteredItems(nil), m.candidates()...)
 			}
 			m.GoToStart()
 			m.filterState = Filtering
@@ -900,6 +1103,9 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.updateKeybindings()
 			return textinput.Blink
 
+		case key.Matches(msg, m.KeyMap.Reorder):
+			m.StartReordering()
+
 		case key.Matches(msg, m.KeyMap.ShowFullHelp):
 			fallthrough
 		case key.Matches(msg, m.KeyMap.CloseFullHelp):
@@ -908,16 +1114,31 @@ func (m *Model) handleBrowsing(msg tea.Msg) tea.Cmd {
 		}
 	}
 
-	// 调用委托的更新方法
-	cmd := m.delegate.Update(msg, m)
-	cmds = append(cmds, cmd)
+	// 调用委托的更新方法。光标位于被禁用的项目上时，按键不交给委托，以免对它执行操作
+	if _, ok := msg.(tea.KeyMsg); !ok || !itemDisabled(m.SelectedItem()) {
+		cmds = append(cmds, m.delegate.Update(msg, m))
+	}
 
 	// 确保光标在有效范围内
 	m.cursor = clamp(m.cursor, 0, m.maxCursorIndex())
 
+	// 光标移动后接近末尾时加载更多项目。只在光标真正移动时检查，
+	// 以免加载失败后无关的消息（例如状态消息超时）不断触发重试。
+	// 加载失败后，即使光标在最后一项上无法移动，移动光标的键也会重试
+	if m.fetch != nil && (m.Index() != index || m.fetchFailed && m.isCursorKey(msg)) {
+		cmds = append(cmds, m.maybeFetch())
+	}
+
 	return tea.Batch(cmds...)
 }
 
+// isCursorKey 返回消息是否为移动光标或翻页的按键。
+func (m Model) isCursorKey(msg tea.Msg) bool {
+	k, ok := msg.(tea.KeyMsg)
+	return ok && key.Check(k, m.KeyMap.CursorUp, m.KeyMap.CursorDown,
+		m.KeyMap.PrevPage, m.KeyMap.NextPage, m.KeyMap.GoToStart, m.KeyMap.GoToEnd)
+}
+
 // 当用户在过滤编辑界面中时的更新。
 func (m *Model) handleFiltering(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
@@ -963,7 +1184,7 @@ func (m *Model) handleFiltering(msg tea.Msg) tea.Cmd {
 
 	// 如果过滤输入已更改，则请求更新的过滤
 	if filterChanged {
-		cmds = append(cmds, filterItems(*m))
+		cmds = append(cmds, m.requestFilter())
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(m.FilterInput.Value() != "")
 	}
 
@@ -989,6 +1210,7 @@ func (m Model) ShortHelp() []key.Binding {
 		if b, ok := m.delegate.(help.KeyMap); ok {
 			kb = append(kb, b.ShortHelp()...)
 		}
+		kb = append(kb, m.itemKeyMap()...)
 	}
 
 	kb = append(kb,
@@ -996,6 +1218,9 @@ func (m Model) ShortHelp() []key.Binding {
 		m.KeyMap.ClearFilter,
 		m.KeyMap.AcceptWhileFiltering,
 		m.KeyMap.CancelWhileFiltering,
+		m.KeyMap.Reorder,
+		m.KeyMap.DropItem,
+		m.KeyMap.CancelReorder,
 	)
 
 	if !filtering && m.AdditionalShortHelpKeys != nil {
@@ -1028,6 +1253,9 @@ func (m Model) FullHelp() [][]key.Binding {
 		if b, ok := m.delegate.(help.KeyMap); ok {
 			kb = append(kb, b.FullHelp()...)
 		}
+		if ikm := m.itemKeyMap(); len(ikm) > 0 {
+			kb = append(kb, ikm)
+		}
 	}
 
 	listLevelBindings := []key.Binding{
@@ -1035,6 +1263,9 @@ func (m Model) FullHelp() [][]key.Binding {
 		m.KeyMap.ClearFilter,
 		m.KeyMap.AcceptWhileFiltering,
 		m.KeyMap.CancelWhileFiltering,
+		m.KeyMap.Reorder,
+		m.KeyMap.DropItem,
+		m.KeyMap.CancelReorder,
 	}
 
 	if !filtering && m.AdditionalFullHelpKeys != nil {
@@ -1085,7 +1316,12 @@ func (m Model) View() string {
 	}
 
 	// 渲染主要内容
-	content := lipgloss.NewStyle().Height(availHeight).Render(m.populatedView())
+	contentStyle := lipgloss.NewStyle().Height(availHeight)
+	if m.itemsPerPage > 0 {
+		// 固定每页项目数量时，裁剪超出可用高度的部分
+		contentStyle = contentStyle.MaxHeight(max(0, availHeight))
+	}
+	content := contentStyle.Render(m.populatedView())
 	sections = append(sections, content)
 
 	// 添加分页器
@@ -1150,10 +1386,14 @@ func (m Model) titleView() string {
 }
 
 func (m Model) statusView() string {
+	if m.StatusFunc != nil {
+		return m.Styles.StatusBar.Render(m.StatusFunc(m))
+	}
+
 	var status string
 
-	totalItems := len(m.items)
-	visibleItems := len(m.VisibleItems())
+	counts := m.StatusCounts()
+	visibleItems := counts.Visible
 
 	var itemName string
 	if visibleItems != 1 {
@@ -1164,6 +1404,15 @@ func (m Model) statusView() string {
 
 	itemsDisplay := fmt.Sprintf("%d %s", visibleItems, itemName)
 
+	// 延迟加载时，项目总数可能未知
+	if m.HasMoreItems() && m.filterState == Unfiltered {
+		if m.totalItems > 0 {
+			itemsDisplay = fmt.Sprintf("%d/%d %s", visibleItems, m.totalItems, m.itemNamePlural)
+		} else {
+			itemsDisplay = fmt.Sprintf("%d+ %s", visibleItems, m.itemNamePlural)
+		}
+	}
+
 	if m.filterState == Filtering { //nolint:nestif
 		// 过滤结果
 		if visibleItems == 0 {
@@ -1171,6 +1420,9 @@ func (m Model) statusView() string {
 		} else {
 			status = itemsDisplay
 		}
+	} else if len(m.items) == 0 && m.fetching {
+		// 未过滤：正在加载第一批项目。
+		status = m.Styles.StatusEmpty.Render(m.Styles.LoadingMore.Value())
 	} else if len(m.items) == 0 {
 		// 未过滤：没有项目。
 		status = m.Styles.StatusEmpty.Render("No " + m.itemNamePlural)
@@ -1187,10 +1439,20 @@ func (m Model) statusView() string {
 		status += itemsDisplay
 	}
 
-	numFiltered := totalItems - visibleItems
-	if numFiltered > 0 {
+	// 被谓词隐藏的项目和被过滤器隐藏的项目分别计数
+	if counts.Hidden > 0 {
+		status += m.Styles.DividerDot.String()
+		status += m.Styles.StatusBarFilterCount.Render(fmt.Sprintf("%d hidden", counts.Hidden))
+	}
+
+	if counts.Filtered > 0 {
 		status += m.Styles.DividerDot.String()
-		status += m.Styles.StatusBarFilterCount.Render(fmt.Sprintf("%d filtered", numFiltered))
+		status += m.Styles.StatusBarFilterCount.Render(fmt.Sprintf("%d filtered", counts.Filtered))
+	}
+
+	if m.itemsPerPage > 0 && visibleItems > 0 {
+		status += m.Styles.DividerDot.String()
+		status += fmt.Sprintf("%d per page", m.itemsPerPage)
 	}
 
 	return m.Styles.StatusBar.Render(status)
@@ -1228,6 +1490,9 @@ func (m Model) populatedView() string {
 		if m.filterState == Filtering {
 			return ""
 		}
+		if m.fetching {
+			return m.Styles.LoadingMore.String()
+		}
 		return m.Styles.NoItems.Render("No " + m.itemNamePlural + ".")
 	}
 
@@ -1251,7 +1516,15 @@ func (m Model) populatedView() string {
 		if len(items) == 0 {
 			n -= m.delegate.Height() - 1
 		}
-		fmt.Fprint(&b, strings.Repeat("\n", n))
+
+		// 延迟加载时，在最后一个项目之后显示加载指示器。
+		if m.fetching && m.filterState == Unfiltered {
+			sep := strings.Repeat("\n", m.delegate.Spacing()+1)
+			fmt.Fprint(&b, sep+m.Styles.LoadingMore.String())
+			n -= len(sep)
+		}
+
+		fmt.Fprint(&b, strings.Repeat("\n", max(0, n)))
 	}
 
 	return b.String()
@@ -1269,23 +1542,24 @@ func filterItems(m Model) tea.Cmd {
 	return func() tea.Msg {
 		// 如果过滤器为空或未处于过滤状态，则返回所有项目
 		if m.FilterInput.Value() == "" || m.filterState == Unfiltered {
-			return FilterMatchesMsg(m.itemsAsFilterItems()) // return nothing
+			return FilterMatchesMsg(append(filteredItems(nil), m.candidates()...)) // return nothing
 		}
 
-		items := m.items
+		// 只过滤通过谓词的项目
+		items := m.candidates()
 		targets := make([]string, len(items))
 
 		// 获取所有项目的过滤值
 		for i, t := range items {
-			targets[i] = t.FilterValue()
+			targets[i] = t.item.FilterValue()
 		}
 
 		// 使用过滤器过滤项目
 		filterMatches := []filteredItem{}
 		for _, r := range m.Filter(m.FilterInput.Value(), targets) {
 			filterMatches = append(filterMatches, filteredItem{
-				index:   r.Index,
-				item:    items[r.Index],
+				index:   items[r.Index].index,
+				item:    items[r.Index].item,
 				matches: r.MatchedIndexes,
 			})
 		}
@@ -1,12 +1,16 @@
 package list
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/spinner"
 	tea "github.com/purpose168/bubbletea-cn"
 )
 
@@ -165,3 +169,624 @@ func TestSetFilterState(t *testing.T) {
 		t.Fatalf("Error: expected view to contain '%s'", expected)
 	}
 }
+
+// TestWithoutQuit 测试嵌入模式下列表不会返回 tea.Quit
+func TestWithoutQuit(t *testing.T) {
+	list := New([]Item{item("foo"), item("bar")}, itemDelegate{}, 10, 10, WithoutQuit())
+
+	if !list.Embedded() {
+		t.Fatal("Error: expected list to be in embedded mode")
+	}
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("q")},
+		{Type: tea.KeyEsc},
+		{Type: tea.KeyCtrlC},
+	} {
+		// 即使应用程序重新启用了按键绑定，也不应退出
+		list.KeyMap.Quit.SetEnabled(true)
+		list.KeyMap.ForceQuit.SetEnabled(true)
+
+		_, cmd := list.Update(msg)
+		if cmd == nil {
+			continue
+		}
+		if _, ok := cmd().(tea.QuitMsg); ok {
+			t.Fatalf("Error: expected %q not to quit in embedded mode", msg)
+		}
+	}
+}
+
+// TestSetEmbedded 测试离开嵌入模式时恢复之前退出按键绑定的状态
+func TestSetEmbedded(t *testing.T) {
+	tests := []struct {
+		name    string
+		disable bool
+	}{
+		{"quit enabled", false},
+		{"quit disabled", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := New([]Item{item("foo")}, itemDelegate{}, 10, 10)
+			if tt.disable {
+				list.DisableQuitKeybindings()
+			}
+
+			list.SetEmbedded(true)
+			list.SetEmbedded(true)
+			if list.KeyMap.Quit.Enabled() || list.KeyMap.ForceQuit.Enabled() {
+				t.Fatal("expected the quit keybindings to be disabled in embedded mode")
+			}
+
+			list.SetEmbedded(false)
+			if list.Embedded() {
+				t.Fatal("expected the list to leave embedded mode")
+			}
+			if list.KeyMap.Quit.Enabled() == tt.disable || list.KeyMap.ForceQuit.Enabled() == tt.disable {
+				t.Fatalf("expected the quit keybindings to be enabled = %v again", !tt.disable)
+			}
+		})
+	}
+}
+
+// TestLazyLoading 测试光标接近末尾时加载更多项目
+func TestLazyLoading(t *testing.T) {
+	var offsets []int
+	fetch := func(offset int) tea.Cmd {
+		offsets = append(offsets, offset)
+		return func() tea.Msg {
+			return ItemsLoadedMsg{Items: []Item{item("baz"), item("qux")}, Done: true}
+		}
+	}
+	list := New([]Item{item("foo"), item("bar")}, itemDelegate{}, 10, 10, WithLazyLoading(fetch, 2))
+
+	if expected := "2+ items"; !strings.Contains(list.statusView(), expected) {
+		t.Fatalf("Error: expected status to contain %q, got %q", expected, list.statusView())
+	}
+
+	list, cmd := list.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if !list.Fetching() || len(offsets) != 1 || offsets[0] != 2 {
+		t.Fatalf("Error: expected fetch at offset 2, got %v", offsets)
+	}
+	if cmd == nil {
+		t.Fatal("Error: expected a fetch command")
+	}
+	if !strings.Contains(list.View(), "Loading more…") {
+		t.Fatal("Error: expected view to contain the loading indicator")
+	}
+
+	// 加载期间不会重复加载
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if len(offsets) != 1 {
+		t.Fatalf("Error: expected a single fetch, got %v", offsets)
+	}
+
+	list, _ = list.Update(ItemsLoadedMsg{Items: []Item{item("baz"), item("qux")}, Done: true, id: list.spinner.ID()})
+	if list.Fetching() || list.HasMoreItems() || len(list.Items()) != 4 {
+		t.Fatalf("Error: expected 4 loaded items, got %d", len(list.Items()))
+	}
+	if expected := "4 items"; !strings.Contains(list.statusView(), expected) {
+		t.Fatalf("Error: expected status to contain %q, got %q", expected, list.statusView())
+	}
+}
+
+// TestLazyLoadingError 测试加载失败后，只有移动光标的键才会重试
+func TestLazyLoadingError(t *testing.T) {
+	var offsets []int
+	fetch := func(offset int) tea.Cmd {
+		offsets = append(offsets, offset)
+		return nil
+	}
+	list := New([]Item{item("foo"), item("bar"), item("baz")}, itemDelegate{}, 10, 10, WithLazyLoading(fetch, 2))
+
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if len(offsets) != 1 {
+		t.Fatalf("Error: expected a single fetch, got %v", offsets)
+	}
+
+	list, _ = list.Update(ItemsLoadedMsg{Err: errors.New("network down"), id: list.spinner.ID()})
+	if list.Fetching() {
+		t.Fatal("Error: expected fetching to stop after an error")
+	}
+
+	// 无关的消息不会触发重试
+	for _, msg := range []tea.Msg{statusMessageTimeoutMsg{}, spinner.TickMsg{}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}} {
+		list, _ = list.Update(msg)
+		if len(offsets) != 1 || list.Fetching() {
+			t.Fatalf("Error: expected no fetch after %T, got %v", msg, offsets)
+		}
+	}
+
+	// 移动光标会重试
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if len(offsets) != 2 || offsets[1] != 3 {
+		t.Fatalf("Error: expected a retry at offset 3, got %v", offsets)
+	}
+
+	// 光标在最后一项上无法移动时，移动光标的键仍然会重试
+	list, _ = list.Update(ItemsLoadedMsg{Err: errors.New("network down"), id: list.spinner.ID()})
+	if list.Index() != 2 {
+		t.Fatalf("Error: expected the cursor on the last item, got %d", list.Index())
+	}
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if len(offsets) != 3 || !list.Fetching() {
+		t.Fatalf("Error: expected a retry from the last item, got %v", offsets)
+	}
+
+	// 加载成功后，停在最后一项上的按键不会再次加载
+	list, _ = list.Update(ItemsLoadedMsg{id: list.spinner.ID()})
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if len(offsets) != 3 {
+		t.Fatalf("Error: expected no fetch without a failure, got %v", offsets)
+	}
+}
+
+// TestLazyLoadingTwoLists 测试延迟加载的列表只接受自己加载的项目
+func TestLazyLoadingTwoLists(t *testing.T) {
+	fetch := func(name string) FetchFunc {
+		return func(int) tea.Cmd {
+			return func() tea.Msg {
+				return ItemsLoadedMsg{Items: []Item{item(name)}, Done: true}
+			}
+		}
+	}
+	a := New([]Item{item("a")}, itemDelegate{}, 10, 10, WithLazyLoading(fetch("from a"), 2))
+	b := New([]Item{item("b")}, itemDelegate{}, 10, 10, WithLazyLoading(fetch("from b"), 2))
+
+	// 执行 a 的加载命令，得到它的结果。
+	var loaded tea.Msg
+	for _, cmd := range a.FetchMore()().(tea.BatchMsg) {
+		if msg, ok := cmd().(ItemsLoadedMsg); ok {
+			loaded = msg
+		}
+	}
+	if loaded == nil {
+		t.Fatal("Error: expected an ItemsLoadedMsg")
+	}
+
+	b.FetchMore()
+	b, _ = b.Update(loaded)
+	if len(b.Items()) != 1 || !b.Fetching() {
+		t.Fatalf("Error: expected b to ignore items loaded by a, got %v", b.Items())
+	}
+	a, _ = a.Update(loaded)
+	if len(a.Items()) != 2 || a.Items()[1] != item("from a") {
+		t.Fatalf("Error: expected a to append its items, got %v", a.Items())
+	}
+}
+
+// TestSetItemsPerPage 测试固定每页项目数量
+func TestSetItemsPerPage(t *testing.T) {
+	items := make([]Item, 20)
+	for i := range items {
+		items[i] = item(fmt.Sprintf("item %d", i))
+	}
+	list := New(items, itemDelegate{}, 10, 40, WithItemsPerPage(9))
+
+	if list.Paginator.PerPage != 9 || list.Paginator.TotalPages != 3 {
+		t.Fatalf("Error: expected 9 items per page over 3 pages, got %d over %d",
+			list.Paginator.PerPage, list.Paginator.TotalPages)
+	}
+	if expected := "9 per page"; !strings.Contains(list.statusView(), expected) {
+		t.Fatalf("Error: expected status to contain %q, got %q", expected, list.statusView())
+	}
+
+	// 高度变化不影响固定的每页项目数量
+	list.SetHeight(12)
+	if list.Paginator.PerPage != 9 {
+		t.Fatalf("Error: expected 9 items per page, got %d", list.Paginator.PerPage)
+	}
+	if h := strings.Count(list.View(), "\n") + 1; h > 12 {
+		t.Fatalf("Error: expected view to be clipped to 12 lines, got %d", h)
+	}
+
+	list.SetItemsPerPage(0)
+	if list.Paginator.PerPage == 9 || strings.Contains(list.statusView(), "per page") {
+		t.Fatal("Error: expected items per page to follow the available height")
+	}
+}
+
+func TestItemIdentity(t *testing.T) {
+	identity := func(i Item) string { return string(i.(item)) }
+	list := New([]Item{item("a"), item("b"), item("c"), item("d")}, itemDelegate{}, 10, 20, WithItemIdentity(identity))
+	list.Select(2)
+
+	// 刷新后选中的项目移动到了新的位置
+	list.SetItems([]Item{item("new"), item("a"), item("b"), item("c"), item("d")})
+	if sel := list.SelectedItem(); sel != item("c") {
+		t.Fatalf("Error: expected selection to follow item c, got %v", sel)
+	}
+
+	// 选中的项目被删除时，选择最接近之前索引的项目
+	list.SetItems([]Item{item("a"), item("b")})
+	if sel := list.SelectedItem(); sel != item("b") {
+		t.Fatalf("Error: expected selection to fall back to item b, got %v", sel)
+	}
+
+	// 过滤时在过滤结果返回后重新定位
+	list.SetFilterText("b")
+	cmd := list.SetItems([]Item{item("ab"), item("b"), item("c")})
+	list, _ = list.Update(cmd())
+	if sel := list.SelectedItem(); sel != item("b") {
+		t.Fatalf("Error: expected selection to follow filtered item b, got %v", sel)
+	}
+}
+
+func TestFilterMemory(t *testing.T) {
+	items := []Item{item("apple"), item("banana"), item("apricot"), item("grape")}
+	list := New(items, itemDelegate{}, 10, 20)
+
+	list.SetFilterText("ap")
+	list.Select(1)
+	if sel := list.SelectedItem(); sel != item("apricot") {
+		t.Fatalf("Error: expected apricot to be selected, got %v", sel)
+	}
+	list.ResetFilter()
+
+	// 重新应用相同的过滤词时恢复选中的项目
+	list.SetFilterText("ap")
+	if sel := list.SelectedItem(); sel != item("apricot") {
+		t.Fatalf("Error: expected apricot to be restored, got %v", sel)
+	}
+	list.ResetFilter()
+
+	// 逐字输入过滤词时，过滤结果返回后恢复
+	list.SetFilterState(Filtering)
+	list.FilterInput.SetValue("ap")
+	list, _ = list.Update(filterItems(list)())
+	if sel := list.SelectedItem(); sel != item("apricot") {
+		t.Fatalf("Error: expected apricot to be restored while filtering, got %v", sel)
+	}
+
+	// 其他过滤词不受影响，禁用后不再恢复
+	list.SetFilterText("gr")
+	if list.Index() != 0 {
+		t.Fatalf("Error: expected a new term to start at the top, got %d", list.Index())
+	}
+	list.SetFilterMemory(0)
+	list.SetFilterText("ap")
+	if list.Index() != 0 {
+		t.Fatalf("Error: expected no restore when disabled, got %d", list.Index())
+	}
+}
+
+func TestPredicate(t *testing.T) {
+	items := []Item{item("ok a"), item("failed b"), item("ok c"), item("failed d"), item("failed e")}
+	list := New(items, itemDelegate{}, 10, 20)
+	list.Select(3)
+
+	failed := func(i Item) bool { return strings.HasPrefix(string(i.(item)), "failed") }
+	cmd := list.SetPredicate(failed)
+	if msg, ok := cmd().(PredicateChangedMsg); !ok || msg.Shown != 3 || msg.Hidden != 2 {
+		t.Fatalf("Error: expected PredicateChangedMsg{3, 2}, got %#v", msg)
+	}
+	if n := len(list.VisibleItems()); n != 3 {
+		t.Fatalf("Error: expected 3 visible items, got %d", n)
+	}
+	if expected := "2 hidden"; !strings.Contains(list.statusView(), expected) {
+		t.Fatalf("Error: expected status to contain %q, got %q", expected, list.statusView())
+	}
+
+	// 光标停留在最接近之前索引的项目上，GlobalIndex 指向未过滤列表
+	list.Select(1)
+	if sel := list.SelectedItem(); sel != item("failed d") || list.GlobalIndex() != 3 {
+		t.Fatalf("Error: expected failed d at global index 3, got %v at %d", sel, list.GlobalIndex())
+	}
+
+	// 文本过滤只作用于通过谓词的项目
+	list.SetFilterText("ed e")
+	if visible := list.VisibleItems(); len(visible) != 1 || visible[0] != item("failed e") {
+		t.Fatalf("Error: expected only failed e to match, got %v", visible)
+	}
+	status := list.statusView()
+	if !strings.Contains(status, "2 hidden") || !strings.Contains(status, "2 filtered") {
+		t.Fatalf("Error: expected hidden and filtered counts, got %q", status)
+	}
+
+	list.ResetFilter()
+	list.SetPredicate(nil)
+	if n := len(list.VisibleItems()); n != 5 || strings.Contains(list.statusView(), "hidden") {
+		t.Fatalf("Error: expected all items to be visible, got %d", n)
+	}
+}
+
+func TestAutoSize(t *testing.T) {
+	items := make([]Item, 20)
+	for i := range items {
+		items[i] = item(fmt.Sprintf("item %d", i))
+	}
+	list := New(items, itemDelegate{}, 10, 10)
+
+	// 默认不处理窗口大小
+	list, _ = list.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	if list.Width() != 10 || list.Height() != 10 {
+		t.Fatalf("Error: expected size 10x10 without auto-size, got %dx%d", list.Width(), list.Height())
+	}
+
+	list.SetAutoSize(AutoSize{MarginX: 4, MarginY: 2, MaxWidth: 60})
+	perPage := list.Paginator.PerPage
+	list, _ = list.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	if list.Width() != 60 || list.Height() != 28 {
+		t.Fatalf("Error: expected size 60x28, got %dx%d", list.Width(), list.Height())
+	}
+	if list.Help.Width != 60 || list.Paginator.PerPage <= perPage {
+		t.Fatalf("Error: expected help width and pagination to follow the new size, got %d and %d per page",
+			list.Help.Width, list.Paginator.PerPage)
+	}
+
+	list.DisableAutoSize()
+	list, _ = list.Update(tea.WindowSizeMsg{Width: 40, Height: 20})
+	if list.Width() != 60 {
+		t.Fatalf("Error: expected size to stay after disabling auto-size, got width %d", list.Width())
+	}
+}
+
+func TestItemKeyMap(t *testing.T) {
+	open := key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "open"))
+	del := key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "delete"))
+
+	d := NewDefaultDelegate()
+	d.ItemHelpFunc = func(_ int, i Item) []key.Binding {
+		if i.(item) == "locked" {
+			return []key.Binding{open}
+		}
+		return []key.Binding{open, del}
+	}
+	list := New([]Item{item("foo"), item("locked")}, d, 10, 10)
+
+	helpKeys := func() string {
+		var keys []string
+		for _, b := range list.ShortHelp() {
+			keys = append(keys, b.Help().Desc)
+		}
+		return strings.Join(keys, ",")
+	}
+
+	if got := helpKeys(); !strings.Contains(got, "open,delete") {
+		t.Fatalf("Error: expected item bindings in short help, got %s", got)
+	}
+	if got := list.FullHelp(); !reflect.DeepEqual(got[1], []key.Binding{open, del}) {
+		t.Fatalf("Error: expected item bindings as a full help column, got %v", got)
+	}
+
+	list.CursorDown()
+	if got := helpKeys(); !strings.Contains(got, "open") || strings.Contains(got, "delete") {
+		t.Fatalf("Error: expected bindings to follow the cursor, got %s", got)
+	}
+
+	list.SetFilterState(Filtering)
+	if got := helpKeys(); strings.Contains(got, "open") {
+		t.Fatalf("Error: expected no item bindings while filtering, got %s", got)
+	}
+}
+
+func TestState(t *testing.T) {
+	items := []Item{item("apple"), item("banana"), item("cherry"), item("apricot"), item("avocado")}
+	l := New(items, itemDelegate{}, 10, 10)
+	l.SetFilterText("a")
+	l.Select(2)
+	want := l.SelectedItem()
+	s := l.State()
+	if s.FilterText != "a" || s.FilterState != FilterApplied || s.Index != 2 {
+		t.Fatalf("unexpected state %#v", s)
+	}
+
+	restored := New(items, itemDelegate{}, 10, 10)
+	if cmd := restored.RestoreState(s); cmd != nil {
+		t.Fatal("expected no command when the filter is not being edited")
+	}
+	if restored.FilterState() != FilterApplied || restored.FilterValue() != "a" {
+		t.Fatalf("expected filter to be restored, got %v %q", restored.FilterState(), restored.FilterValue())
+	}
+	if got := restored.SelectedItem(); got != want {
+		t.Fatalf("expected %v to be selected, got %v", want, got)
+	}
+
+	// 设置了 ItemIdentity 时按标识恢复，即使项目的顺序变了。
+	l = New(items, itemDelegate{}, 10, 10)
+	l.ItemIdentity = func(i Item) string { return i.FilterValue() }
+	l.Select(1)
+	s = l.State()
+	if s.ItemID != "banana" {
+		t.Fatalf("expected item id banana, got %q", s.ItemID)
+	}
+	reordered := New([]Item{item("banana"), item("apple"), item("cherry")}, itemDelegate{}, 10, 10)
+	reordered.ItemIdentity = l.ItemIdentity
+	reordered.RestoreState(s)
+	if got := reordered.SelectedItem(); got != item("banana") {
+		t.Fatalf("expected banana to be selected, got %v", got)
+	}
+
+	// 恢复正在输入的过滤器。
+	restored = New(items, itemDelegate{}, 10, 10)
+	cmd := restored.RestoreState(State{FilterText: "ch", FilterState: Filtering})
+	if !restored.SettingFilter() || restored.FilterValue() != "ch" {
+		t.Fatalf("expected to be setting filter %q, got %v %q", "ch", restored.FilterState(), restored.FilterValue())
+	}
+	if cmd == nil {
+		t.Fatal("expected a command to blink the filter input's cursor")
+	}
+}
+
+func TestStatusFunc(t *testing.T) {
+	l := New([]Item{item("foo"), item("bar"), item("baz")}, itemDelegate{}, 20, 10)
+	l.SetFilterText("ba")
+
+	c := l.StatusCounts()
+	if c.Total != 3 || c.Visible != 2 || c.Filtered != 1 || c.Hidden != 0 {
+		t.Fatalf("unexpected counts %+v", c)
+	}
+
+	l.StatusFunc = func(m Model) string {
+		c := m.StatusCounts()
+		return fmt.Sprintf("共 %d 项，显示 %d 项", c.Total, c.Visible)
+	}
+	if got := l.statusView(); !strings.Contains(got, "共 3 项，显示 2 项") {
+		t.Fatalf("expected custom status, got %q", got)
+	}
+}
+
+func TestReordering(t *testing.T) {
+	items := []Item{item("a"), item("b"), item("c"), item("d"), item("e")}
+	l := New(items, itemDelegate{}, 10, 10, WithItemsPerPage(2))
+	l.SetReorderingEnabled(true)
+
+	keyMsg := func(s string) tea.KeyMsg {
+		switch s {
+		case "down":
+			return tea.KeyMsg{Type: tea.KeyDown}
+		case "up":
+			return tea.KeyMsg{Type: tea.KeyUp}
+		case "enter":
+			return tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			return tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	order := func() string {
+		var s string
+		for _, i := range l.Items() {
+			s += string(i.(item))
+		}
+		return s
+	}
+
+	l.Select(1)
+	l, _ = l.Update(keyMsg("m"))
+	if !l.Reordering() {
+		t.Fatal("expected to be reordering")
+	}
+
+	// 移动到下一页
+	var cmd tea.Cmd
+	l, cmd = l.Update(keyMsg("down"))
+	if msg, ok := cmd().(ItemMovedMsg); !ok || msg.From != 1 || msg.To != 2 {
+		t.Fatalf("expected item to move from 1 to 2, got %#v", cmd())
+	}
+	l, _ = l.Update(keyMsg("down"))
+	if order() != "acdbe" || l.Index() != 3 || l.Paginator.Page != 1 {
+		t.Fatalf("unexpected order %q at %d", order(), l.Index())
+	}
+
+	// 取消后放回原处
+	l, cmd = l.Update(keyMsg("esc"))
+	if msg, ok := cmd().(ItemMovedMsg); !ok || msg.From != 3 || msg.To != 1 || order() != "abcde" || l.Reordering() {
+		t.Fatalf("expected the item to move back, got %q %#v", order(), cmd())
+	}
+
+	l, _ = l.Update(keyMsg("m"))
+	l, _ = l.Update(keyMsg("up"))
+	l, _ = l.Update(keyMsg("enter"))
+	if order() != "bacde" || l.Reordering() || l.Index() != 0 {
+		t.Fatalf("expected b to be dropped first, got %q", order())
+	}
+
+	// 过滤时不能重新排序
+	l.SetFilterText("a")
+	if l.StartReordering() || l.KeyMap.Reorder.Enabled() {
+		t.Fatal("expected reordering to be disabled while filtering")
+	}
+}
+
+func TestFilterDebounce(t *testing.T) {
+	items := []Item{item("apple"), item("banana"), item("apricot"), item("grape")}
+	list := New(items, itemDelegate{}, 10, 20)
+	list.SetFilterDebounce(time.Millisecond)
+	list.SetFilterState(Filtering)
+
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if !list.FilterPending() || !list.showSpinner {
+		t.Fatal("Error: expected a pending filter with the spinner shown")
+	}
+
+	// 被取代的过滤请求不会开始过滤
+	id := list.spinner.ID()
+	if _, cmd := list.Update(filterDebounceMsg{id: id, gen: list.filterGen - 1}); cmd != nil {
+		t.Fatal("Error: expected a superseded filter request to be dropped")
+	}
+
+	_, cmd := list.Update(filterDebounceMsg{id: id, gen: list.filterGen})
+	if cmd == nil {
+		t.Fatal("Error: expected the latest filter request to start filtering")
+	}
+	result := cmd()
+
+	// 过期的结果被丢弃
+	stale := filterResultMsg{id: id, gen: list.filterGen - 1, matches: FilterMatchesMsg{{item: item("grape")}}}
+	list, _ = list.Update(stale)
+	if len(list.VisibleItems()) != 0 {
+		t.Fatalf("Error: expected a stale result to be dropped, got %v", list.VisibleItems())
+	}
+
+	list, _ = list.Update(result)
+	if got := len(list.VisibleItems()); got != 3 {
+		t.Fatalf("Error: expected 3 matches for \"ap\", got %d", got)
+	}
+	if list.FilterPending() || list.showSpinner {
+		t.Fatal("Error: expected the spinner to stop once the filter is done")
+	}
+}
+
+// disabledItem 是一个可以被禁用的项目
+type disabledItem struct {
+	name     string
+	disabled bool
+}
+
+func (i disabledItem) FilterValue() string { return i.name }
+func (i disabledItem) Title() string       { return i.name }
+func (i disabledItem) Description() string { return "" }
+func (i disabledItem) Disabled() bool      { return i.disabled }
+
+func TestDisabledItems(t *testing.T) {
+	items := []Item{
+		disabledItem{name: "a"},
+		disabledItem{name: "b", disabled: true},
+		disabledItem{name: "c", disabled: true},
+		disabledItem{name: "d"},
+		disabledItem{name: "e", disabled: true},
+	}
+	var updates int
+	delegate := NewDefaultDelegate()
+	delegate.ShowDescription = false
+	delegate.Styles.DisabledTitle = delegate.Styles.DisabledTitle.Transform(strings.ToUpper)
+	delegate.UpdateFunc = func(tea.Msg, *Model) tea.Cmd {
+		updates++
+		return nil
+	}
+	list := New(items, delegate, 10, 20)
+
+	list.CursorDown()
+	if list.Index() != 3 {
+		t.Fatalf("Error: expected the cursor to skip disabled items, got %d", list.Index())
+	}
+	list.CursorDown()
+	if list.Index() != 3 {
+		t.Fatalf("Error: expected the cursor to stay when only disabled items follow, got %d", list.Index())
+	}
+	list.CursorUp()
+	if list.Index() != 0 {
+		t.Fatalf("Error: expected the cursor to skip disabled items upwards, got %d", list.Index())
+	}
+
+	rendered := map[string]bool{}
+	view := list.View()
+	for _, l := range strings.Split(view, "\n") {
+		rendered[strings.TrimSpace(l)] = true
+	}
+	if !rendered["B"] || !rendered["C"] || !rendered["d"] {
+		t.Fatalf("Error: expected disabled items to render with the disabled style, got:\n%s", view)
+	}
+
+	// 光标位于被禁用的项目上时，按键不交给委托
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	list.Select(1)
+	list, _ = list.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if updates != 1 {
+		t.Fatalf("Error: expected 1 delegate update, got %d", updates)
+	}
+}
@@ -0,0 +1,80 @@
+package list
+
+import tea "github.com/purpose168/bubbletea-cn"
+
+// PredicateFunc 决定项目是否显示在列表中。
+type PredicateFunc func(Item) bool
+
+// PredicateChangedMsg 在谓词变化后发送，报告谓词显示和隐藏的项目数量。
+type PredicateChangedMsg struct {
+	Shown  int // 通过谓词的项目数量
+	Hidden int // 被谓词隐藏的项目数量
+}
+
+// WithPredicate 设置在文本过滤之前应用的谓词（参见 SetPredicate）。
+func WithPredicate(fn PredicateFunc) Option {
+	return func(m *Model) {
+		m.predicate = fn
+		m.applyPredicate()
+	}
+}
+
+// SetPredicate 设置在文本过滤之前应用的谓词，例如“只显示失败的任务”。
+// 未通过谓词的项目既不显示也不参与过滤；状态栏分别显示被谓词隐藏和被过滤器
+// 隐藏的项目数量。传入 nil 移除谓词。
+//
+// 光标会尽量停留在之前选中的项目上（参见 ItemIdentity）。返回的命令会发送
+// PredicateChangedMsg，如果正在过滤，还会重新过滤项目。
+func (m *Model) SetPredicate(fn PredicateFunc) tea.Cmd {
+	sel := m.rememberSelection()
+	if sel == nil {
+		sel = &selection{index: m.Index()}
+	}
+
+	m.predicate = fn
+	m.applyPredicate()
+
+	shown := len(m.candidates())
+	msg := PredicateChangedMsg{Shown: shown, Hidden: len(m.items) - shown}
+	cmds := []tea.Cmd{func() tea.Msg { return msg }}
+
+	if m.filterState != Unfiltered {
+		m.filteredItems = nil
+		m.pendingSelection = sel
+		cmds = append(cmds, filterItems(*m))
+	}
+
+	m.updatePagination()
+	if m.filterState == Unfiltered {
+		m.restoreSelection(sel)
+	}
+	m.updateKeybindings()
+	return tea.Batch(cmds...)
+}
+
+// Predicate 返回当前的谓词。
+func (m Model) Predicate() PredicateFunc {
+	return m.predicate
+}
+
+// applyPredicate 重新计算通过谓词的项目。项目变化后必须调用它。
+func (m *Model) applyPredicate() {
+	if m.predicate == nil {
+		m.predicateMatches = nil
+		return
+	}
+	m.predicateMatches = filteredItems{}
+	for i, item := range m.items {
+		if m.predicate(item) {
+			m.predicateMatches = append(m.predicateMatches, filteredItem{index: i, item: item})
+		}
+	}
+}
+
+// candidates 返回参与文本过滤的项目，即通过谓词的项目。
+func (m Model) candidates() filteredItems {
+	if m.predicate != nil {
+		return m.predicateMatches
+	}
+	return m.itemsAsFilterItems()
+}
@@ -0,0 +1,121 @@
+package list
+
+import (
+	"github.com/purpose168/bubbles-cn/key"
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// ItemMovedMsg 在重新排序时移动项目后发送。From 和 To 是项目移动前后在项目切片中的索引。
+type ItemMovedMsg struct {
+	From int
+	To   int
+}
+
+// SetReorderingEnabled 启用或禁用通过键盘重新排序项目：KeyMap.Reorder 拿起选中的项目，
+// CursorUp 和 CursorDown 在项目切片中移动它，KeyMap.DropItem 放下它，
+// KeyMap.CancelReorder 将它放回原处。过滤或设置了谓词时不能重新排序。
+// 禁用时，正在移动的项目留在当前位置。
+func (m *Model) SetReorderingEnabled(v bool) {
+	m.reorderingEnabled = v
+	if !v {
+		m.reordering = false
+	}
+	m.updateKeybindings()
+}
+
+// ReorderingEnabled 返回是否启用了重新排序。
+func (m Model) ReorderingEnabled() bool {
+	return m.reorderingEnabled
+}
+
+// Reordering 返回是否拿起了项目，正在移动它。
+func (m Model) Reordering() bool {
+	return m.reordering
+}
+
+// StartReordering 拿起选中的项目。如果不能重新排序，返回 false。
+func (m *Model) StartReordering() bool {
+	if !m.canReorder() {
+		return false
+	}
+	m.reordering = true
+	m.reorderOrigin = m.Index()
+	m.updateKeybindings()
+	return true
+}
+
+// DropItem 将正在移动的项目放在当前位置。
+func (m *Model) DropItem() {
+	m.reordering = false
+	m.updateKeybindings()
+}
+
+// CancelReordering 将正在移动的项目放回原处。如果项目被移动过，
+// 返回的命令会发送将它移回原处的 ItemMovedMsg。
+func (m *Model) CancelReordering() tea.Cmd {
+	if !m.reordering {
+		return nil
+	}
+	from := m.Index()
+	m.reordering = false
+	m.updateKeybindings()
+	return m.moveItem(from, m.reorderOrigin)
+}
+
+// canReorder 返回是否可以拿起选中的项目。
+func (m Model) canReorder() bool {
+	return m.reorderingEnabled && m.filterState == Unfiltered && m.predicate == nil && len(m.items) > 1
+}
+
+// moveItem 将项目从 from 移动到 to 并选中它，返回发送 ItemMovedMsg 的命令。
+// 如果位置没有变化或超出范围，返回 nil。
+func (m *Model) moveItem(from, to int) tea.Cmd {
+	if from == to || from < 0 || to < 0 || from >= len(m.items) || to >= len(m.items) {
+		return nil
+	}
+	item := m.items[from]
+	m.items = insertItemIntoSlice(removeItemFromSlice(m.items, from), item, to)
+	m.Select(to)
+
+	msg := ItemMovedMsg{From: from, To: to}
+	return func() tea.Msg { return msg }
+}
+
+// handleReordering 是拿起项目之后的更新：只处理移动、放下和取消的按键。
+func (m *Model) handleReordering(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.CursorUp):
+		return m.moveItem(m.Index(), m.Index()-1)
+	case key.Matches(keyMsg, m.KeyMap.CursorDown):
+		return m.moveItem(m.Index(), m.Index()+1)
+	case key.Matches(keyMsg, m.KeyMap.DropItem):
+		m.DropItem()
+	case key.Matches(keyMsg, m.KeyMap.CancelReorder):
+		return m.CancelReordering()
+	}
+	return nil
+}
+
+// updateReorderKeybindings 在拿起项目之后只启用移动、放下和取消的按键。
+func (m *Model) updateReorderKeybindings() {
+	m.KeyMap.CursorUp.SetEnabled(true)
+	m.KeyMap.CursorDown.SetEnabled(true)
+	m.KeyMap.NextPage.SetEnabled(false)
+	m.KeyMap.PrevPage.SetEnabled(false)
+	m.KeyMap.GoToStart.SetEnabled(false)
+	m.KeyMap.GoToEnd.SetEnabled(false)
+	m.KeyMap.Filter.SetEnabled(false)
+	m.KeyMap.ClearFilter.SetEnabled(false)
+	m.KeyMap.CancelWhileFiltering.SetEnabled(false)
+	m.KeyMap.AcceptWhileFiltering.SetEnabled(false)
+	m.KeyMap.Quit.SetEnabled(false)
+	m.KeyMap.ShowFullHelp.SetEnabled(false)
+	m.KeyMap.CloseFullHelp.SetEnabled(false)
+	m.KeyMap.Reorder.SetEnabled(false)
+	m.KeyMap.DropItem.SetEnabled(true)
+	m.KeyMap.CancelReorder.SetEnabled(true)
+}
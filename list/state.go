@@ -0,0 +1,65 @@
+package list
+
+import tea "github.com/purpose168/bubbletea-cn"
+
+// State 是列表中用户位置的快照：选中的项目、页面和过滤器。它只包含导出的基本类型字段，
+// 可以直接用 encoding/json 等序列化，以便在程序重启或切换标签页后恢复（参见 RestoreState）。
+type State struct {
+	// Index 是选中项目在可见项目（过滤后的项目）中的索引。
+	Index int
+
+	// Page 是选中项目所在的页面。恢复时根据 Index 和当前的每页项目数重新计算。
+	Page int
+
+	// ItemID 是选中项目的标识。只在设置了 ItemIdentity 时记录，
+	// 恢复时优先按标识查找项目，使项目变化后仍能选中同一个项目。
+	ItemID string
+
+	// FilterText 是过滤输入框中的文本。
+	FilterText string
+
+	// FilterState 是过滤状态。
+	FilterState FilterState
+}
+
+// State 返回列表当前的状态。
+func (m Model) State() State {
+	s := State{
+		Index:       m.Index(),
+		Page:        m.Paginator.Page,
+		FilterText:  m.FilterInput.Value(),
+		FilterState: m.filterState,
+	}
+	if item := m.SelectedItem(); item != nil && m.ItemIdentity != nil {
+		s.ItemID = m.ItemIdentity(item)
+	}
+	return s
+}
+
+// RestoreState 恢复 State 返回的状态：重新应用过滤器，并选中之前选中的项目。
+// 如果找不到该项目，则选择最接近之前索引的项目。应在设置项目之后调用。
+//
+// 恢复正在输入的过滤器时，返回使过滤输入框的光标闪烁的命令，否则返回 nil。
+func (m *Model) RestoreState(s State) tea.Cmd {
+	var cmd tea.Cmd
+	m.resetFiltering()
+	if m.filteringEnabled && s.FilterState != Unfiltered {
+		m.SetFilterText(s.FilterText)
+		if s.FilterState == Filtering {
+			m.filterState = Filtering
+			cmd = m.FilterInput.Focus()
+			m.updateKeybindings()
+		}
+	}
+
+	items := m.VisibleItems()
+	if len(items) == 0 {
+		return cmd
+	}
+	if s.ItemID != "" && m.ItemIdentity != nil {
+		m.restoreSelection(&selection{id: s.ItemID, index: s.Index})
+		return cmd
+	}
+	m.Select(clamp(s.Index, 0, len(items)-1))
+	return cmd
+}
@@ -0,0 +1,47 @@
+package list
+
+// StatusFunc 返回状态栏的内容，参见 Model.StatusFunc。
+type StatusFunc func(m Model) string
+
+// WithStatusFunc 设置状态栏内容函数（参见 Model.StatusFunc）。
+func WithStatusFunc(fn StatusFunc) Option {
+	return func(m *Model) {
+		m.StatusFunc = fn
+	}
+}
+
+// StatusCounts 是状态栏显示的项目计数。
+type StatusCounts struct {
+	// Total 是已加载的项目总数。
+	Total int
+
+	// Visible 是可见的项目数量，即过滤后的项目数量。
+	Visible int
+
+	// Hidden 是被谓词（参见 SetPredicate）隐藏的项目数量。
+	Hidden int
+
+	// Filtered 是被过滤器隐藏的项目数量，不包括被谓词隐藏的项目。
+	Filtered int
+
+	// Expected 是延迟加载（参见 SetLazyLoading）时预期的项目总数。如果未知，则为 0。
+	Expected int
+
+	// PerPage 是设置的每页项目数量。如果为 0，则每页项目数量由高度决定。
+	PerPage int
+}
+
+// StatusCounts 返回状态栏显示的项目计数，供 StatusFunc 使用。
+func (m Model) StatusCounts() StatusCounts {
+	c := StatusCounts{
+		Total:    len(m.items),
+		Visible:  len(m.VisibleItems()),
+		Expected: m.totalItems,
+		PerPage:  m.itemsPerPage,
+	}
+	if m.predicate != nil {
+		c.Hidden = c.Total - len(m.predicateMatches)
+	}
+	c.Filtered = max(0, c.Total-c.Hidden-c.Visible)
+	return c
+}
@@ -38,6 +38,8 @@ type Styles struct {
 
 	// NoItems 无项目时的样式
 	NoItems lipgloss.Style
+	// LoadingMore 延迟加载时加载指示器的样式和文本
+	LoadingMore lipgloss.Style
 
 	// PaginationStyle 分页样式
 	PaginationStyle lipgloss.Style
@@ -105,6 +107,11 @@ func DefaultStyles() (s Styles) {
 	s.NoItems = lipgloss.NewStyle().
 		Foreground(lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"})
 
+	// 设置加载指示器样式，与无项目时的样式一致
+	s.LoadingMore = lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"}).
+		SetString("Loading more…")
+
 	// 设置阿拉伯数字分页样式，使用柔和的灰色前景色
 	s.ArabicPagination = lipgloss.NewStyle().Foreground(subduedColor)
 
@@ -0,0 +1,365 @@
+// Package minibuffer 提供一个类似 Emacs/vim 命令行的单行交互组件，
+// 用于在屏幕底部提出问题（例如"保存更改？(y/n)"）或请求输入文本。
+package minibuffer
+
+import (
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/textinput"
+	tea "github.com/purpose168/bubbletea-cn"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// Kind 是提示的类型。
+type Kind int
+
+const (
+	// Input 请求输入一行自由文本。
+	Input Kind = iota
+
+	// Confirm 请求回答是或否。
+	Confirm
+)
+
+// Prompt 描述了一次提问。
+type Prompt struct {
+	// ID 用于在应答消息中识别提示。
+	ID string
+
+	// Question 是显示在输入之前的问题文本。
+	Question string
+
+	// Kind 是提示的类型。
+	Kind Kind
+
+	// Default 是 Input 提示的初始值。
+	Default string
+
+	// Suggestions 是 Input 提示的自动补全建议。
+	Suggestions []string
+}
+
+// NewInput 返回一个请求自由文本的提示。
+func NewInput(id, question string) Prompt {
+	return Prompt{ID: id, Question: question, Kind: Input}
+}
+
+// NewConfirm 返回一个请求回答是或否的提示。
+func NewConfirm(id, question string) Prompt {
+	return Prompt{ID: id, Question: question, Kind: Confirm}
+}
+
+// InputMsg 在 Input 提示被提交时发送。
+type InputMsg struct {
+	ID    string // 提示 ID
+	Value string // 输入的文本
+}
+
+// ConfirmMsg 在 Confirm 提示被回答时发送。
+type ConfirmMsg struct {
+	ID    string // 提示 ID
+	Value bool   // 是否确认
+}
+
+// CancelMsg 在提示被取消时发送。
+type CancelMsg struct {
+	ID string // 提示 ID
+}
+
+// KeyMap 是迷你缓冲区的按键绑定。
+type KeyMap struct {
+	Submit      key.Binding // 提交输入
+	Cancel      key.Binding // 取消提示
+	Yes         key.Binding // 回答是
+	No          key.Binding // 回答否
+	HistoryPrev key.Binding // 上一条历史记录
+	HistoryNext key.Binding // 下一条历史记录
+}
+
+// DefaultKeyMap 返回一组默认的按键绑定。
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Submit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "提交"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc", "ctrl+g"),
+			key.WithHelp("esc", "取消"),
+		),
+		Yes: key.NewBinding(
+			key.WithKeys("y", "Y"),
+			key.WithHelp("y", "是"),
+		),
+		No: key.NewBinding(
+			key.WithKeys("n", "N"),
+			key.WithHelp("n", "否"),
+		),
+		HistoryPrev: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "上一条历史"),
+		),
+		HistoryNext: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "下一条历史"),
+		),
+	}
+}
+
+// Styles 包含迷你缓冲区的样式。
+type Styles struct {
+	Question lipgloss.Style // 问题文本样式
+	Hint     lipgloss.Style // 确认提示中 "(y/n)" 的样式
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	return Styles{
+		Question: lipgloss.NewStyle().Bold(true),
+		Hint: lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
+			Light: "#909090",
+			Dark:  "#626262",
+		}),
+	}
+}
+
+// Model 是迷你缓冲区组件的 Bubble Tea 模型。
+type Model struct {
+	KeyMap KeyMap
+	Styles Styles
+
+	// ConfirmHint 渲染在确认问题之后。
+	ConfirmHint string
+
+	// MaxHistory 是保留的历史记录条数。如果为 0 或更小，则不限制。
+	MaxHistory int
+
+	// Width 是组件的宽度。如果为 0 或更小，则忽略。
+	Width int
+
+	input   textinput.Model
+	prompts []entry // 提示栈，最后一个为当前活动的提示
+
+	history    []string
+	historyPos int    // 当前浏览的历史位置；等于 len(history) 时表示当前输入
+	draft      string // 开始浏览历史前的输入
+}
+
+// entry 是提示栈中的一项。提示被压入栈中的新提示覆盖时，
+// 它的输入和光标位置保存在这里，以便重新成为活动提示时恢复。
+type entry struct {
+	prompt Prompt
+	value  string // 被覆盖时的输入
+	pos    int    // 被覆盖时的光标位置
+	saved  bool   // 是否保存了输入
+}
+
+// New 返回一个带有默认设置的迷你缓冲区。
+func New() Model {
+	input := textinput.New()
+	input.Prompt = ""
+	input.ShowSuggestions = true
+	// 上下键用于历史记录，因此建议只通过 ctrl+n/ctrl+p 切换。
+	input.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	input.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+
+	return Model{
+		KeyMap:      DefaultKeyMap(),
+		Styles:      DefaultStyles(),
+		ConfirmHint: " (y/n) ",
+		MaxHistory:  100,
+		input:       input,
+	}
+}
+
+// Ask 将一个提示压入栈中并使其成为当前活动的提示。当它被回答或取消后，
+// 之前的提示（如果有）会重新成为活动提示。
+func (m *Model) Ask(p Prompt) tea.Cmd {
+	if n := len(m.prompts); n > 0 {
+		top := &m.prompts[n-1]
+		top.value, top.pos, top.saved = m.input.Value(), m.input.Position(), true
+	}
+	m.prompts = append(m.prompts, entry{prompt: p})
+	return m.activate()
+}
+
+// Active 返回是否有正在等待回答的提示。
+func (m Model) Active() bool {
+	return len(m.prompts) > 0
+}
+
+// Current 返回当前活动的提示。如果没有活动的提示，ok 为 false。
+func (m Model) Current() (p Prompt, ok bool) {
+	if len(m.prompts) == 0 {
+		return Prompt{}, false
+	}
+	return m.prompts[len(m.prompts)-1].prompt, true
+}
+
+// Depth 返回栈中提示的数量。
+func (m Model) Depth() int {
+	return len(m.prompts)
+}
+
+// History 返回已提交的输入历史，最早的在前。
+func (m Model) History() []string {
+	return m.history
+}
+
+// SetHistory 设置输入历史，最早的在前。
+func (m *Model) SetHistory(h []string) {
+	m.history = h
+	m.trimHistory()
+	m.historyPos = len(m.history)
+}
+
+// Value 返回当前输入的文本。
+func (m Model) Value() string {
+	return m.input.Value()
+}
+
+// Init 存在以满足 tea.Model 接口。
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update 处理按键并在提示被回答时返回相应的应答消息。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	p, ok := m.Current()
+	if !ok {
+		return m, nil
+	}
+
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if isKey && key.Matches(keyMsg, m.KeyMap.Cancel) {
+		return m, m.finish(CancelMsg{ID: p.ID})
+	}
+
+	if p.Kind == Confirm {
+		if !isKey {
+			return m, nil
+		}
+		switch {
+		case key.Matches(keyMsg, m.KeyMap.Yes):
+			return m, m.finish(ConfirmMsg{ID: p.ID, Value: true})
+		case key.Matches(keyMsg, m.KeyMap.No):
+			return m, m.finish(ConfirmMsg{ID: p.ID, Value: false})
+		}
+		return m, nil
+	}
+
+	if isKey {
+		switch {
+		case key.Matches(keyMsg, m.KeyMap.Submit):
+			v := m.input.Value()
+			m.addHistory(v)
+			return m, m.finish(InputMsg{ID: p.ID, Value: v})
+		case key.Matches(keyMsg, m.KeyMap.HistoryPrev):
+			m.recall(-1)
+			return m, nil
+		case key.Matches(keyMsg, m.KeyMap.HistoryNext):
+			m.recall(1)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// View 渲染当前活动的提示。如果没有活动的提示，则返回空字符串。
+func (m Model) View() string {
+	p, ok := m.Current()
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(m.Styles.Question.Render(p.Question))
+	if p.Kind == Confirm {
+		b.WriteString(m.Styles.Hint.Render(m.ConfirmHint))
+	} else {
+		b.WriteString(m.input.View())
+	}
+
+	if m.Width > 0 {
+		return lipgloss.NewStyle().MaxWidth(m.Width).Render(b.String())
+	}
+	return b.String()
+}
+
+// activate 根据当前活动的提示重置输入。如果该提示曾被上层提示覆盖，
+// 则恢复被覆盖时的输入和光标位置。
+func (m *Model) activate() tea.Cmd {
+	if len(m.prompts) == 0 {
+		m.input.Blur()
+		m.input.Reset()
+		return nil
+	}
+	e := &m.prompts[len(m.prompts)-1]
+	p := e.prompt
+
+	m.input.Reset()
+	m.input.SetSuggestions(p.Suggestions)
+	if e.saved {
+		m.input.SetValue(e.value)
+		m.input.SetCursor(e.pos)
+		e.value, e.pos, e.saved = "", 0, false
+	} else {
+		m.input.SetValue(p.Default)
+		m.input.CursorEnd()
+	}
+	m.historyPos = len(m.history)
+	m.draft = ""
+
+	if p.Kind == Confirm {
+		m.input.Blur()
+		return nil
+	}
+	return m.input.Focus()
+}
+
+// finish 弹出当前提示并返回发送给定消息的命令。
+func (m *Model) finish(msg tea.Msg) tea.Cmd {
+	m.prompts = m.prompts[:len(m.prompts)-1]
+	return tea.Batch(
+		func() tea.Msg { return msg },
+		m.activate(),
+	)
+}
+
+// addHistory 将提交的值添加到历史记录中，忽略空值和连续的重复值。
+func (m *Model) addHistory(v string) {
+	if v == "" || (len(m.history) > 0 && m.history[len(m.history)-1] == v) {
+		return
+	}
+	m.history = append(m.history, v)
+	m.trimHistory()
+}
+
+// trimHistory 将历史记录限制在 MaxHistory 条以内。
+func (m *Model) trimHistory() {
+	if m.MaxHistory > 0 && len(m.history) > m.MaxHistory {
+		m.history = m.history[len(m.history)-m.MaxHistory:]
+	}
+}
+
+// recall 在历史记录中向前或向后移动。
+func (m *Model) recall(delta int) {
+	if len(m.history) == 0 {
+		return
+	}
+	if m.historyPos == len(m.history) {
+		m.draft = m.input.Value()
+	}
+
+	m.historyPos = max(0, min(len(m.history), m.historyPos+delta))
+	if m.historyPos == len(m.history) {
+		m.input.SetValue(m.draft)
+	} else {
+		m.input.SetValue(m.history[m.historyPos])
+	}
+	m.input.CursorEnd()
+}
@@ -0,0 +1,102 @@
+package minibuffer
+
+import (
+	"testing"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+func keyPress(k string) tea.Msg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// collect 执行命令并返回其中的应答消息
+func collect(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, collect(c)...)
+		}
+		return msgs
+	}
+	switch msg.(type) {
+	case InputMsg, ConfirmMsg, CancelMsg:
+		return []tea.Msg{msg}
+	}
+	return nil
+}
+
+func TestConfirm(t *testing.T) {
+	m := New()
+	m.Ask(NewConfirm("save", "保存更改？"))
+
+	m, cmd := m.Update(keyPress("y"))
+	msgs := collect(cmd)
+	if len(msgs) != 1 || msgs[0] != (ConfirmMsg{ID: "save", Value: true}) {
+		t.Fatalf("expected confirm message, got %v", msgs)
+	}
+	if m.Active() {
+		t.Fatal("expected no active prompt after answering")
+	}
+}
+
+func TestInputStackAndHistory(t *testing.T) {
+	m := New()
+	m.Ask(NewInput("outer", "名称: "))
+	for _, r := range "fo" {
+		m, _ = m.Update(keyPress(string(r)))
+	}
+	m, _ = m.Update(keyPress("left"))
+	m.Ask(NewConfirm("inner", "覆盖？"))
+
+	if p, _ := m.Current(); p.ID != "inner" {
+		t.Fatalf("expected inner prompt to be active, got %q", p.ID)
+	}
+
+	m, cmd := m.Update(keyPress("esc"))
+	if msgs := collect(cmd); len(msgs) != 1 || msgs[0] != (CancelMsg{ID: "inner"}) {
+		t.Fatalf("expected cancel message, got %v", msgs)
+	}
+	if p, _ := m.Current(); p.ID != "outer" {
+		t.Fatalf("expected outer prompt to be active again, got %q", p.ID)
+	}
+	if m.Value() != "fo" {
+		t.Fatalf("expected the outer input %q to be restored, got %q", "fo", m.Value())
+	}
+
+	// 光标位置也被恢复：在 "f" 之后插入
+	m, _ = m.Update(keyPress("x"))
+	if m.Value() != "fxo" {
+		t.Fatalf("expected the cursor position to be restored, got %q", m.Value())
+	}
+	m, cmd = m.Update(keyPress("enter"))
+	if msgs := collect(cmd); len(msgs) != 1 || msgs[0] != (InputMsg{ID: "outer", Value: "fxo"}) {
+		t.Fatalf("expected input message, got %v", msgs)
+	}
+
+	m.Ask(NewInput("again", "名称: "))
+	m, _ = m.Update(keyPress("up"))
+	if m.Value() != "fxo" {
+		t.Fatalf("expected history recall of %q, got %q", "fxo", m.Value())
+	}
+	m, _ = m.Update(keyPress("down"))
+	if m.Value() != "" {
+		t.Fatalf("expected draft to be restored, got %q", m.Value())
+	}
+}
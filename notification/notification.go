@@ -0,0 +1,451 @@
+// Package notification 提供一个通知中心组件。它保存有上限的通知历史记录，
+// 记录每条通知的已读/未读状态，提供一个可切换的面板（基于 list）来查看历史记录，
+// 支持按级别过滤，并提供用于状态栏的未读计数。
+package notification
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/list"
+	tea "github.com/purpose168/bubbletea-cn"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// defaultMaxHistory 是默认保留的通知数量。
+const defaultMaxHistory = 100
+
+var lastID int64
+
+// nextID 生成下一个唯一的通知 ID。
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
+}
+
+// Level 是通知的级别。
+type Level int
+
+// 可用的通知级别。
+const (
+	Info Level = iota
+	Success
+	Warning
+	Error
+)
+
+// allLevels 是所有级别，按过滤切换的顺序排列。
+var allLevels = []Level{Info, Success, Warning, Error}
+
+// String 返回级别的名称。
+func (l Level) String() string {
+	switch l {
+	case Success:
+		return "success"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Notification 是一条通知。它实现了 list.Item 接口。
+type Notification struct {
+	ID      int
+	Level   Level
+	Message string
+	Time    time.Time
+	Read    bool
+}
+
+// FilterValue 实现 list.Item 接口。
+func (n Notification) FilterValue() string {
+	return n.Message
+}
+
+// KeyMap 是通知面板的按键绑定。它满足 help.KeyMap 接口。
+type KeyMap struct {
+	MarkRead    key.Binding // 切换选中通知的已读状态
+	MarkAllRead key.Binding // 将所有通知标记为已读
+	CycleFilter key.Binding // 切换级别过滤
+	Clear       key.Binding // 清除所有通知
+	Close       key.Binding // 关闭面板
+}
+
+// ShortHelp 实现 help.KeyMap 接口。
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.MarkRead, k.CycleFilter, k.Close}
+}
+
+// FullHelp 实现 help.KeyMap 接口。
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.MarkRead, k.MarkAllRead},
+		{k.CycleFilter, k.Clear, k.Close},
+	}
+}
+
+// DefaultKeyMap 返回一组默认的按键绑定。
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		MarkRead: key.NewBinding(
+			key.WithKeys("enter", " "),
+			key.WithHelp("enter", "已读/未读"),
+		),
+		MarkAllRead: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "全部已读"),
+		),
+		CycleFilter: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "切换级别"),
+		),
+		Clear: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "清除"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc", "关闭"),
+		),
+	}
+}
+
+// Styles 包含通知中心的样式。
+type Styles struct {
+	Info    lipgloss.Style // Info 级别标签的样式
+	Success lipgloss.Style // Success 级别标签的样式
+	Warning lipgloss.Style // Warning 级别标签的样式
+	Error   lipgloss.Style // Error 级别标签的样式
+
+	Unread   lipgloss.Style // 未读通知消息的样式
+	Read     lipgloss.Style // 已读通知消息的样式
+	Selected lipgloss.Style // 选中通知的光标样式
+	Time     lipgloss.Style // 时间的样式
+	Badge    lipgloss.Style // 未读计数徽章的样式
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	return Styles{
+		Info:     lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+		Success:  lipgloss.NewStyle().Foreground(lipgloss.Color("42")),
+		Warning:  lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		Error:    lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Unread:   lipgloss.NewStyle().Bold(true),
+		Read:     lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"}),
+		Selected: lipgloss.NewStyle().Foreground(lipgloss.Color("212")),
+		Time:     lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		Badge:    lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("196")).Padding(0, 1),
+	}
+}
+
+// level 返回给定级别标签的样式。
+func (s Styles) level(l Level) lipgloss.Style {
+	switch l {
+	case Success:
+		return s.Success
+	case Warning:
+		return s.Warning
+	case Error:
+		return s.Error
+	default:
+		return s.Info
+	}
+}
+
+// Model 是通知中心的 Bubble Tea 模型。
+type Model struct {
+	KeyMap KeyMap
+	Styles Styles
+
+	// MaxHistory 是保留的通知数量上限。超出时丢弃最旧的通知。
+	// 如果为 0 或更小，则不限制。
+	MaxHistory int
+
+	// TimeFormat 是面板中通知时间的格式。
+	TimeFormat string
+
+	history []Notification // 通知历史记录，最新的在前
+	filter  []Level        // 显示的级别，为空时显示所有级别
+	list    list.Model     // 面板
+	visible bool           // 面板是否可见
+}
+
+// New 返回一个面板大小为给定宽度和高度的通知中心。
+func New(width, height int) Model {
+	// 新的通知插入到最前面，按 ID 识别项目使选中的通知保持不变。
+	l := list.New(nil, delegate{}, width, height, list.WithoutQuit(), list.WithItemIdentity(func(i list.Item) string {
+		return strconv.Itoa(i.(Notification).ID)
+	}))
+	l.Title = "Notifications"
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(false)
+	l.SetStatusBarItemName("notification", "notifications")
+
+	m := Model{
+		KeyMap:     DefaultKeyMap(),
+		Styles:     DefaultStyles(),
+		MaxHistory: defaultMaxHistory,
+		TimeFormat: "15:04:05",
+		list:       l,
+	}
+	m.refresh()
+	return m
+}
+
+// Push 添加一条通知并返回它的 ID。
+func (m *Model) Push(level Level, message string) int {
+	n := Notification{ID: nextID(), Level: level, Message: message, Time: time.Now()}
+	m.history = append([]Notification{n}, m.history...)
+	if m.MaxHistory > 0 && len(m.history) > m.MaxHistory {
+		m.history = m.history[:m.MaxHistory]
+	}
+	m.refresh()
+	return n.ID
+}
+
+// History 返回所有通知，最新的在前。
+func (m Model) History() []Notification {
+	return m.history
+}
+
+// Notifications 返回当前级别过滤下的通知，最新的在前。
+func (m Model) Notifications() []Notification {
+	var ns []Notification
+	for _, n := range m.history {
+		if m.shown(n.Level) {
+			ns = append(ns, n)
+		}
+	}
+	return ns
+}
+
+// Unread 返回未读通知的数量，不受级别过滤的影响。
+func (m Model) Unread() int {
+	var c int
+	for _, n := range m.history {
+		if !n.Read {
+			c++
+		}
+	}
+	return c
+}
+
+// UnreadByLevel 返回给定级别的未读通知的数量。
+func (m Model) UnreadByLevel(level Level) int {
+	var c int
+	for _, n := range m.history {
+		if !n.Read && n.Level == level {
+			c++
+		}
+	}
+	return c
+}
+
+// Badge 渲染未读计数徽章，用于状态栏。如果没有未读通知，则返回空字符串。
+func (m Model) Badge() string {
+	c := m.Unread()
+	if c == 0 {
+		return ""
+	}
+	return m.Styles.Badge.Render(fmt.Sprintf("%d", c))
+}
+
+// MarkRead 设置给定 ID 的通知的已读状态。
+func (m *Model) MarkRead(id int, read bool) {
+	m.history = append([]Notification(nil), m.history...)
+	for i := range m.history {
+		if m.history[i].ID == id {
+			m.history[i].Read = read
+			break
+		}
+	}
+	m.refresh()
+}
+
+// MarkAllRead 将当前级别过滤下的所有通知标记为已读。
+func (m *Model) MarkAllRead() {
+	m.history = append([]Notification(nil), m.history...)
+	for i := range m.history {
+		if m.shown(m.history[i].Level) {
+			m.history[i].Read = true
+		}
+	}
+	m.refresh()
+}
+
+// Clear 删除所有通知。
+func (m *Model) Clear() {
+	m.history = nil
+	m.refresh()
+}
+
+// LevelFilter 返回面板显示的级别。如果为空，则显示所有级别。
+func (m Model) LevelFilter() []Level {
+	return m.filter
+}
+
+// SetLevelFilter 设置面板显示的级别。不传参数以显示所有级别。
+func (m *Model) SetLevelFilter(levels ...Level) {
+	m.filter = append([]Level(nil), levels...)
+	m.refresh()
+}
+
+// cycleFilter 依次切换到只显示一个级别，最后恢复显示所有级别。
+func (m *Model) cycleFilter() {
+	if len(m.filter) != 1 {
+		m.SetLevelFilter(allLevels[0])
+		return
+	}
+	for i, l := range allLevels {
+		if l == m.filter[0] && i < len(allLevels)-1 {
+			m.SetLevelFilter(allLevels[i+1])
+			return
+		}
+	}
+	m.SetLevelFilter()
+}
+
+// Visible 返回面板是否可见。
+func (m Model) Visible() bool {
+	return m.visible
+}
+
+// Show 显示面板。
+func (m *Model) Show() {
+	m.visible = true
+}
+
+// Hide 隐藏面板。
+func (m *Model) Hide() {
+	m.visible = false
+}
+
+// Toggle 切换面板的可见性。
+func (m *Model) Toggle() {
+	m.visible = !m.visible
+}
+
+// SetSize 设置面板的宽度和高度。
+func (m *Model) SetSize(width, height int) {
+	m.list.SetSize(width, height)
+}
+
+// Update 在面板可见时处理按键。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.KeyMap.Close):
+			m.Hide()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.MarkRead):
+			if n, ok := m.list.SelectedItem().(Notification); ok {
+				m.MarkRead(n.ID, !n.Read)
+			}
+			return m, nil
+		case key.Matches(msg, m.KeyMap.MarkAllRead):
+			m.MarkAllRead()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.CycleFilter):
+			m.cycleFilter()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Clear):
+			m.Clear()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// View 渲染面板。如果面板不可见，则返回空字符串。
+func (m Model) View() string {
+	if !m.visible {
+		return ""
+	}
+	m.list.SetDelegate(delegate{styles: m.Styles, timeFormat: m.TimeFormat})
+	return m.list.View()
+}
+
+// shown 返回给定级别的通知是否在当前级别过滤下显示。
+func (m Model) shown(level Level) bool {
+	if len(m.filter) == 0 {
+		return true
+	}
+	for _, l := range m.filter {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// refresh 使面板与历史记录、级别过滤和样式保持同步。
+func (m *Model) refresh() {
+	ns := m.Notifications()
+	items := make([]list.Item, len(ns))
+	for i, n := range ns {
+		items[i] = n
+	}
+	m.list.SetItems(items)
+
+	m.list.Title = "Notifications"
+	if len(m.filter) > 0 {
+		names := make([]string, len(m.filter))
+		for i, l := range m.filter {
+			names[i] = l.String()
+		}
+		m.list.Title += " (" + strings.Join(names, ", ") + ")"
+	}
+}
+
+// delegate 将通知渲染为单行：未读标记、级别、消息和时间。
+type delegate struct {
+	styles     Styles
+	timeFormat string
+}
+
+// Height 实现 list.ItemDelegate 接口。
+func (d delegate) Height() int { return 1 }
+
+// Spacing 实现 list.ItemDelegate 接口。
+func (d delegate) Spacing() int { return 0 }
+
+// Update 实现 list.ItemDelegate 接口。
+func (d delegate) Update(tea.Msg, *list.Model) tea.Cmd { return nil }
+
+// Render 实现 list.ItemDelegate 接口。
+func (d delegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	n, ok := item.(Notification)
+	if !ok {
+		return
+	}
+
+	cursor := "  "
+	if index == m.Index() {
+		cursor = d.styles.Selected.Render("> ")
+	}
+	mark := " "
+	msgStyle := d.styles.Read
+	if !n.Read {
+		mark = "•"
+		msgStyle = d.styles.Unread
+	}
+	level := d.styles.level(n.Level).Render(fmt.Sprintf("%-7s", n.Level))
+	t := d.styles.Time.Render(n.Time.Format(d.timeFormat))
+
+	fmt.Fprintf(w, "%s%s %s %s %s", cursor, mark, level, t, msgStyle.Render(n.Message)) //nolint:errcheck
+}
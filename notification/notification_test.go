@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+func TestHistory(t *testing.T) {
+	m := New(40, 10)
+	m.MaxHistory = 2
+	m.Push(Info, "first")
+	m.Push(Warning, "second")
+	m.Push(Error, "third")
+
+	h := m.History()
+	if len(h) != 2 || h[0].Message != "third" || h[1].Message != "second" {
+		t.Fatalf("expected the two newest notifications, got %+v", h)
+	}
+	if m.Unread() != 2 || m.UnreadByLevel(Error) != 1 {
+		t.Fatalf("expected 2 unread notifications, got %d", m.Unread())
+	}
+	if !strings.Contains(m.Badge(), "2") {
+		t.Fatalf("expected badge to show 2, got %q", m.Badge())
+	}
+
+	m.MarkRead(h[0].ID, true)
+	if m.Unread() != 1 {
+		t.Fatalf("expected 1 unread notification, got %d", m.Unread())
+	}
+	m.MarkAllRead()
+	if m.Unread() != 0 || m.Badge() != "" {
+		t.Fatalf("expected no unread notifications, got %d", m.Unread())
+	}
+}
+
+func TestPanel(t *testing.T) {
+	m := New(60, 10)
+	m.Push(Info, "build started")
+	m.Push(Error, "build failed")
+
+	if m.View() != "" {
+		t.Fatal("expected hidden panel to render nothing")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Unread() != 2 {
+		t.Fatal("expected keys to be ignored while the panel is hidden")
+	}
+
+	m.Toggle()
+	view := m.View()
+	if !strings.Contains(view, "build started") || !strings.Contains(view, "build failed") {
+		t.Fatalf("expected panel to list notifications, got:\n%s", view)
+	}
+
+	// 过滤只显示 Info，然后切换到 Success
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.Notifications(); len(got) != 1 || got[0].Level != Info {
+		t.Fatalf("expected only info notifications, got %+v", got)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.Notifications(); len(got) != 0 {
+		t.Fatalf("expected no success notifications, got %+v", got)
+	}
+	m.SetLevelFilter()
+
+	// 切换选中（最新）通知的已读状态
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.History()[0].Read || m.History()[1].Read {
+		t.Fatalf("expected the newest notification to be read, got %+v", m.History())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Visible() {
+		t.Fatal("expected esc to close the panel")
+	}
+}
+
+func TestPanelKeepsSelection(t *testing.T) {
+	m := New(60, 10)
+	m.Push(Info, "first")
+	m.Push(Info, "second")
+	m.Toggle()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+	// 新的通知插入到最前面，选中的通知不变。
+	m.Push(Warning, "third")
+	if n, ok := m.list.SelectedItem().(Notification); !ok || n.Message != "first" {
+		t.Fatalf("expected the first notification to stay selected, got %+v", m.list.SelectedItem())
+	}
+}
@@ -10,6 +10,13 @@ import (
 	tea "github.com/purpose168/bubbletea-cn"
 )
 
+// PageChangedMsg 在 Update 中的按键使当前页发生变化时发送，
+// 父组件可以用它预取下一页的数据等。
+type PageChangedMsg struct {
+	Page     int // 新的当前页
+	PrevPage int // 变化之前的页
+}
+
 // Type 指定我们渲染分页的方式。
 type Type int
 
@@ -82,6 +89,13 @@ func (m *Model) SetTotalPages(items int) int {
 	return n
 }
 
+// SetPerPageFromHeight 是一个辅助函数，用于根据可用高度、每个项目的高度和项目之间的
+// 间距计算每页的项目数量，每页至少一个项目。它既返回每页的项目数量，又修改模型。
+func (m *Model) SetPerPageFromHeight(availableHeight, itemHeight, spacing int) int {
+	m.PerPage = max(1, availableHeight/max(1, itemHeight+spacing))
+	return m.PerPage
+}
+
 // ItemsOnPage 是一个辅助函数，用于返回当前页面上的项目数量，
 // 参数为传入的总项目数。
 func (m Model) ItemsOnPage(totalItems int) int {
@@ -173,8 +187,10 @@ func WithPerPage(perPage int) Option {
 	}
 }
 
-// Update 是 Tea 更新函数，将按键绑定到分页操作。
+// Update 是 Tea 更新函数，将按键绑定到分页操作。当前页变化时返回发送
+// PageChangedMsg 的命令。
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	prev := m.Page
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
@@ -185,7 +201,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 	}
 
-	return m, nil
+	if m.Page == prev {
+		return m, nil
+	}
+	changed := PageChangedMsg{Page: m.Page, PrevPage: prev}
+	return m, func() tea.Msg { return changed }
 }
 
 // View 将分页渲染为字符串。
@@ -185,3 +185,45 @@ func TestItemsOnPage(t *testing.T) {
 		}
 	}
 }
+
+// TestSetPerPageFromHeight 测试根据可用高度计算每页项目数量的功能
+func TestSetPerPageFromHeight(t *testing.T) {
+	tests := []struct {
+		name                   string // 测试用例名称
+		avail, height, spacing int    // 可用高度、项目高度和间距
+		expected               int    // 期望的每页项目数量
+	}{
+		{"Exact fit", 12, 2, 1, 4},  // 正好放下
+		{"Remainder", 13, 2, 1, 4},  // 有剩余高度
+		{"Too small", 1, 2, 1, 1},   // 至少一个项目
+		{"Zero height", 5, 0, 0, 5}, // 高度为 0 时按 1 计算
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := New()
+			if got := model.SetPerPageFromHeight(tt.avail, tt.height, tt.spacing); got != tt.expected || model.PerPage != tt.expected {
+				t.Errorf("SetPerPageFromHeight() = %d (PerPage %d), expected %d", got, model.PerPage, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPageChangedMsg 测试当前页变化时发送 PageChangedMsg 的功能
+func TestPageChangedMsg(t *testing.T) {
+	model := New(WithTotalPages(2))
+
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if cmd == nil {
+		t.Fatal("expected a command when the page changes")
+	}
+	want := PageChangedMsg{Page: 1, PrevPage: 0}
+	if msg := cmd(); msg != want {
+		t.Errorf("expected %+v, got %+v", want, msg)
+	}
+
+	// 停留在最后一页时不发送消息。
+	if _, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRight}); cmd != nil {
+		t.Error("expected no command when the page does not change")
+	}
+}
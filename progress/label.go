@@ -0,0 +1,84 @@
+package progress
+
+import (
+	"strings"
+
+	"github.com/muesli/termenv"
+	"github.com/purpose168/bubbles-cn/runeutil"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+// LabelPosition 是标签相对于进度条的位置。
+type LabelPosition int
+
+// 可用的标签位置。
+const (
+	LabelRight  LabelPosition = iota // 进度条右侧，百分比之前
+	LabelLeft                        // 进度条左侧
+	LabelInside                      // 居中覆盖在进度条上
+)
+
+// WithLabel 设置显示在进度条旁边或内部的文本标签（参见 Model.Label）。
+func WithLabel(label string) Option {
+	return func(m *Model) {
+		m.Label = label
+	}
+}
+
+// WithLabelPosition 设置标签的位置。
+func WithLabelPosition(p LabelPosition) Option {
+	return func(m *Model) {
+		m.LabelPosition = p
+	}
+}
+
+// besideLabel 渲染显示在进度条左侧或右侧的标签，标签和进度条之间有一个空格。
+// 标签占用进度条的宽度，超出可用宽度 w 时以省略号截断。
+func (m Model) besideLabel(w int) (left, right string) {
+	if m.Label == "" || m.LabelPosition == LabelInside || w <= 1 {
+		return "", ""
+	}
+	label := m.LabelStyle.Inline(true).Render(ansi.Truncate(m.Label, w-1, "…"))
+	if m.LabelPosition == LabelLeft {
+		return label + " ", ""
+	}
+	return "", " " + label
+}
+
+// insideLabel 返回覆盖在宽度为 tw 的进度条上的标签及其起始列。
+// 标签超出进度条宽度时以省略号截断。
+func (m Model) insideLabel(tw int) (string, int) {
+	if m.Label == "" || m.LabelPosition != LabelInside || tw <= 0 {
+		return "", 0
+	}
+	label := ansi.Truncate(m.Label, tw, "…")
+	return label, (tw - ansi.StringWidth(label)) / 2 //nolint:mnd
+}
+
+// writeLabel 从第 start 列开始写入覆盖在进度条上的标签，并返回它占用的列数。
+// 填充部分上的字符以填充颜色为背景、LabelFilledColor 为前景，
+// 空部分上的字符以 LabelColor 为前景，以保证在两部分上都清晰可见。
+func (m Model) writeLabel(b *strings.Builder, label string, start, fw, tw int) int {
+	col := start
+	for g, w := range runeutil.Graphemes(label) {
+		if col < fw {
+			b.WriteString(m.colored(g, m.LabelFilledColor, m.fillColor(col, fw, tw)))
+		} else {
+			b.WriteString(m.colored(g, m.LabelColor, ""))
+		}
+		col += w
+	}
+	return col - start
+}
+
+// colored 以给定的前景色和背景色渲染字符串。空颜色和颜色配置文件不支持的颜色被忽略。
+func (m Model) colored(s, fg, bg string) string {
+	style := termenv.String(s)
+	if c := m.color(fg); c != nil && c != (termenv.NoColor{}) {
+		style = style.Foreground(c)
+	}
+	if c := m.color(bg); c != nil && c != (termenv.NoColor{}) {
+		style = style.Background(c)
+	}
+	return style.String()
+}
@@ -25,10 +25,13 @@ func nextID() int {
 }
 
 const (
-	fps              = 60      // 帧率
-	defaultWidth     = 40      // 默认宽度
-	defaultFrequency = 18.0    // 默认频率
-	defaultDamping   = 1.0     // 默认阻尼
+	fps              = 60   // 帧率
+	defaultWidth     = 40   // 默认宽度
+	defaultFrequency = 18.0 // 默认频率
+	defaultDamping   = 1.0  // 默认阻尼
+
+	bounceSpeed        = 0.8 // 不确定模式下片段每秒移动的距离（占进度条的比例）
+	bounceSegmentRatio = 4   // 不确定模式下进度条宽度与片段宽度之比
 )
 
 // Option 用于在 New 中设置选项。例如：
@@ -112,6 +115,23 @@ func WithColorProfile(p termenv.Profile) Option {
 	}
 }
 
+// WithoutAnimation 将进度条设置为静态模式。在静态模式下，SetPercent 只会
+// 保存新的百分比而不会返回动画命令，View 会直接渲染该百分比，
+// 因此无需将 FrameMsg 路由到 Update。
+func WithoutAnimation() Option {
+	return func(m *Model) {
+		m.static = true
+	}
+}
+
+// WithIndeterminate 将进度条设置为不确定模式（参见 SetIndeterminate）。
+// 需要调用 Init 或 SetIndeterminate 返回的命令来启动动画。
+func WithIndeterminate() Option {
+	return func(m *Model) {
+		m.indeterminate = true
+	}
+}
+
 // FrameMsg 指示应该发生动画步骤。
 type FrameMsg struct {
 	id  int // 进度条 ID
@@ -138,21 +158,41 @@ type Model struct {
 	EmptyColor string // 空颜色
 
 	// 渲染数字百分比的设置。
-	ShowPercentage  bool            // 是否显示百分比
-	PercentFormat   string          // 浮点数的格式字符串
-	PercentageStyle lipgloss.Style  // 百分比样式
+	ShowPercentage  bool           // 是否显示百分比
+	PercentFormat   string         // 浮点数的格式字符串
+	PercentageStyle lipgloss.Style // 百分比样式
+
+	// 步骤模式下显示当前步骤的设置（参见 SetTotalSteps）。
+	ShowSteps     bool   // 是否在百分比之后显示当前步骤
+	StepFormat    string // 当前步骤的格式字符串，参数为已完成的步数和总步数
+	StepSeparator rune   // 进度条内部步骤之间的分隔符，为 0 时不绘制
+
+	// Label 是显示在进度条旁边或内部的文本，位置由 LabelPosition 决定。
+	// 标签超出可用宽度时以省略号截断。
+	Label         string
+	LabelPosition LabelPosition
+	LabelStyle    lipgloss.Style // 进度条旁边的标签的样式
+
+	// 覆盖在进度条内部（LabelInside）的标签在填充部分和空部分上的前景色。
+	// 填充部分上的标签以填充颜色为背景。
+	LabelFilledColor string
+	LabelColor       string
 
 	// 动画过渡的成员。
 	spring           harmonica.Spring // 弹簧对象
-	springCustomized bool            // 弹簧是否已自定义
-	percentShown     float64         // 当前显示的百分比
-	targetPercent    float64         // 我们正在动画化的目标百分比
-	velocity         float64         // 速度
+	springCustomized bool             // 弹簧是否已自定义
+	percentShown     float64          // 当前显示的百分比
+	targetPercent    float64          // 我们正在动画化的目标百分比
+	velocity         float64          // 速度
+
+	// 步骤模式的成员。
+	totalSteps int // 总步数，为 0 时不处于步骤模式
+	step       int // 已完成的步数
 
 	// 渐变设置
-	useRamp    bool            // 是否使用渐变
-	rampColorA colorful.Color  // 渐变起始颜色
-	rampColorB colorful.Color  // 渐变结束颜色
+	useRamp    bool           // 是否使用渐变
+	rampColorA colorful.Color // 渐变起始颜色
+	rampColorB colorful.Color // 渐变结束颜色
 
 	// 当为 true 时，我们缩放渐变以适应进度条填充部分的宽度。
 	// 当为 false 时，渐变的宽度将设置为进度条的全宽。
@@ -160,20 +200,31 @@ type Model struct {
 
 	// 进度条的颜色配置文件。
 	colorProfile termenv.Profile
+
+	// 当为 true 时，进度条不进行动画，百分比变化会立即显示。
+	static bool
+
+	// 不确定模式的成员。
+	indeterminate bool    // 是否处于不确定模式
+	bounce        float64 // 来回移动的片段的位置，范围为 0 到 1
+	bounceDir     float64 // 片段移动的方向，1 或 -1
 }
 
 // New 返回一个带有默认值的模型。
 func New(opts ...Option) Model {
 	m := Model{
-		id:             nextID(),
-		Width:          defaultWidth,
-		Full:           '█',
-		FullColor:      "#7571F9",
-		Empty:          '░',
-		EmptyColor:     "#606060",
-		ShowPercentage: true,
-		PercentFormat:  " %3.0f%%",
-		colorProfile:   termenv.ColorProfile(),
+		id:               nextID(),
+		Width:            defaultWidth,
+		Full:             '█',
+		FullColor:        "#7571F9",
+		Empty:            '░',
+		EmptyColor:       "#606060",
+		ShowPercentage:   true,
+		PercentFormat:    " %3.0f%%",
+		StepFormat:       " step %d/%d",
+		LabelFilledColor: "#FFFFFF",
+		colorProfile:     termenv.ColorProfile(),
+		bounceDir:        1,
 	}
 
 	for _, opt := range opts {
@@ -192,8 +243,11 @@ func New(opts ...Option) Model {
 // Deprecated: 请改用 [New]。
 var NewModel = New
 
-// Init 存在以满足 tea.Model 接口。
+// Init 存在以满足 tea.Model 接口。在不确定模式下，它返回启动动画的命令。
 func (m Model) Init() tea.Cmd {
+	if m.indeterminate {
+		return m.nextFrame()
+	}
 	return nil
 }
 
@@ -207,6 +261,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.indeterminate {
+			m.stepBounce()
+			return m, m.nextFrame()
+		}
+
 		// 如果我们已或多或少达到平衡，则停止更新。
 		if !m.IsAnimating() {
 			return m, nil
@@ -220,6 +279,53 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// Static 返回进度条是否处于静态（无动画）模式。
+func (m Model) Static() bool {
+	return m.static
+}
+
+// SetStatic 启用或禁用静态（无动画）模式。启用时，当前显示的百分比会立即
+// 跳转到目标百分比。
+func (m *Model) SetStatic(v bool) {
+	m.static = v
+	if v {
+		m.percentShown = m.targetPercent
+		m.velocity = 0
+	}
+}
+
+// Indeterminate 返回进度条是否处于不确定模式。
+func (m Model) Indeterminate() bool {
+	return m.indeterminate
+}
+
+// SetIndeterminate 启用或禁用不确定模式。在不确定模式下，一段彩色片段在进度条中
+// 来回移动，而不显示百分比，适用于总量未知的操作。动画使用与百分比过渡相同的
+// FrameMsg 循环，即使在静态模式下也是如此。启用时返回启动动画的命令。
+// 禁用后，进度条恢复显示当前百分比。
+func (m *Model) SetIndeterminate(v bool) tea.Cmd {
+	if m.indeterminate == v {
+		return nil
+	}
+	m.indeterminate = v
+	m.tag++
+	if !v {
+		m.bounce, m.bounceDir = 0, 1
+		return nil
+	}
+	return m.nextFrame()
+}
+
+// stepBounce 将片段移动一帧，并在到达两端时反转方向。
+func (m *Model) stepBounce() {
+	m.bounce += m.bounceDir * bounceSpeed / fps
+	if m.bounce >= 1 {
+		m.bounce, m.bounceDir = 1, -1
+	} else if m.bounce <= 0 {
+		m.bounce, m.bounceDir = 0, 1
+	}
+}
+
 // SetSpringOptions 设置当前弹簧的频率和阻尼。
 // 频率对应速度，阻尼对应弹性。详细信息请参阅：
 //
@@ -238,8 +344,15 @@ func (m Model) Percent() float64 {
 // SetPercent 设置模型的百分比状态以及将进度条动画化到此新百分比所需的命令。
 //
 // 如果您使用 ViewAs 渲染，则不需要此功能。
+//
+// 在静态模式下（参见 WithoutAnimation），百分比会立即生效并返回 nil。
 func (m *Model) SetPercent(p float64) tea.Cmd {
 	m.targetPercent = math.Max(0, math.Min(1, p))
+	if m.static {
+		m.percentShown = m.targetPercent
+		m.velocity = 0
+		return nil
+	}
 	m.tag++
 	return m.nextFrame()
 }
@@ -258,20 +371,38 @@ func (m *Model) DecrPercent(v float64) tea.Cmd {
 	return m.SetPercent(m.Percent() - v)
 }
 
-// View 在其当前状态下渲染动画进度条。要基于您自己的计算渲染静态进度条，请改用 ViewAs。
+// View 在其当前状态下渲染动画进度条。要基于您自己的计算渲染静态进度条，请改用 ViewAs
+// 或使用 WithoutAnimation 创建进度条。
 func (m Model) View() string {
+	if m.indeterminate {
+		b := strings.Builder{}
+		m.bounceView(&b)
+		return b.String()
+	}
 	return m.ViewAs(m.percentShown)
 }
 
 // ViewAs 使用给定的百分比渲染进度条。
 func (m Model) ViewAs(percent float64) string {
 	b := strings.Builder{}
-	percentView := m.percentageView(percent)
-	m.barView(&b, percent, ansi.StringWidth(percentView))
+	percentView := m.percentageView(percent) + m.stepsView()
+	textWidth := ansi.StringWidth(percentView)
+	left, right := m.besideLabel(m.Width - textWidth)
+	b.WriteString(left)
+	m.barView(&b, percent, textWidth+ansi.StringWidth(left)+ansi.StringWidth(right))
+	b.WriteString(right)
 	b.WriteString(percentView)
 	return b.String()
 }
 
+// ViewWithWidth 使用给定的百分比以给定的总宽度渲染进度条，与 Width 无关。
+// 这样同一个模型可以以不同的尺寸嵌入到不同的位置，例如列表行中的紧凑进度条
+// 和详情面板中的完整进度条，而无需复制和修改模型。
+func (m Model) ViewWithWidth(w int, percent float64) string {
+	m.Width = w
+	return m.ViewAs(percent)
+}
+
 // nextFrame 生成下一帧动画的命令
 func (m *Model) nextFrame() tea.Cmd {
 	return tea.Tick(time.Second/time.Duration(fps), func(time.Time) tea.Msg {
@@ -284,40 +415,77 @@ func (m Model) barView(b *strings.Builder, percent float64, textWidth int) {
 	var (
 		tw = max(0, m.Width-textWidth)                // 总宽度
 		fw = int(math.Round((float64(tw) * percent))) // 填充宽度
-		p  float64                                    // 渐变位置
 	)
 
 	fw = max(0, min(tw, fw))
 
-	if m.useRamp {
-		// 渐变填充
-		for i := 0; i < fw; i++ {
-			if fw == 1 {
-				// 这有待商榷：在宽度=1 的渐变中，单个渲染的字符应该是
-				// 第一种颜色、最后一种颜色还是正好在中间 50%？我选择了 50%
-				p = 0.5
-			} else if m.scaleRamp {
-				p = float64(i) / float64(fw-1)
-			} else {
-				p = float64(i) / float64(tw-1)
+	label, start := m.insideLabel(tw)
+	seps := m.stepSeparators(tw)
+	full := termenv.String(string(m.Full)).Foreground(m.color(m.FullColor)).String()
+	empty := termenv.String(string(m.Empty)).Foreground(m.color(m.EmptyColor)).String()
+	for i := 0; i < tw; i++ {
+		switch {
+		case label != "" && i == start:
+			i += m.writeLabel(b, label, i, fw, tw) - 1
+		case seps != nil && seps[i]:
+			// 步骤分隔符，颜色与所在的部分相同
+			c := m.EmptyColor
+			if i < fw {
+				c = m.fillColor(i, fw, tw)
 			}
-			c := m.rampColorA.BlendLuv(m.rampColorB, p).Hex()
-			b.WriteString(termenv.
-				String(string(m.Full)).
-				Foreground(m.color(c)).
-				String(),
-			)
+			b.WriteString(m.colored(string(m.StepSeparator), c, ""))
+		case i >= fw:
+			// 空填充
+			b.WriteString(empty)
+		case m.useRamp:
+			// 渐变填充
+			b.WriteString(termenv.String(string(m.Full)).Foreground(m.color(m.fillColor(i, fw, tw))).String())
+		default:
+			// 纯色填充
+			b.WriteString(full)
 		}
+	}
+}
+
+// fillColor 返回填充宽度为 fw、总宽度为 tw 的进度条中第 i 列的填充颜色。
+func (m Model) fillColor(i, fw, tw int) string {
+	if !m.useRamp {
+		return m.FullColor
+	}
+	var p float64 // 渐变位置
+	if fw == 1 {
+		// 这有待商榷：在宽度=1 的渐变中，单个渲染的字符应该是
+		// 第一种颜色、最后一种颜色还是正好在中间 50%？我选择了 50%
+		p = 0.5
+	} else if m.scaleRamp {
+		p = float64(i) / float64(fw-1)
 	} else {
-		// 纯色填充
-		s := termenv.String(string(m.Full)).Foreground(m.color(m.FullColor)).String()
-		b.WriteString(strings.Repeat(s, fw))
+		p = float64(i) / float64(tw-1)
 	}
+	return m.rampColorA.BlendLuv(m.rampColorB, p).Hex()
+}
+
+// bounceView 渲染不确定模式下的进度条：一段填充片段位于空填充之中。
+// 进度条占用全部宽度，因为没有百分比可以显示。
+func (m Model) bounceView(b *strings.Builder) {
+	tw := max(0, m.Width)
+	sw := min(tw, max(1, tw/bounceSegmentRatio)) // 片段宽度
+	start := int(math.Round(m.bounce * float64(tw-sw)))
 
-	// 空填充
 	e := termenv.String(string(m.Empty)).Foreground(m.color(m.EmptyColor)).String()
-	n := max(0, tw-fw)
-	b.WriteString(strings.Repeat(e, n))
+	b.WriteString(strings.Repeat(e, start))
+	for i := 0; i < sw; i++ {
+		c := m.FullColor
+		if m.useRamp {
+			p := 0.5
+			if sw > 1 {
+				p = float64(i) / float64(sw-1)
+			}
+			c = m.rampColorA.BlendLuv(m.rampColorB, p).Hex()
+		}
+		b.WriteString(termenv.String(string(m.Full)).Foreground(m.color(c)).String())
+	}
+	b.WriteString(strings.Repeat(e, max(0, tw-start-sw)))
 }
 
 // percentageView 渲染百分比视图
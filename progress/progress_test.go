@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/muesli/termenv"
+	tea "github.com/purpose168/bubbletea-cn"
 )
 
 const (
@@ -66,3 +67,134 @@ func TestGradient(t *testing.T) {
 	}
 
 }
+
+// TestStatic 测试静态模式下 SetPercent 立即生效且不返回命令
+func TestStatic(t *testing.T) {
+	p := New(WithoutAnimation(), WithoutPercentage(), WithWidth(10), WithFillCharacters('#', '-'), WithColorProfile(termenv.Ascii))
+
+	if cmd := p.SetPercent(0.5); cmd != nil {
+		t.Fatal("期望静态模式下 SetPercent 返回 nil 命令")
+	}
+	if p.IsAnimating() {
+		t.Fatal("期望静态模式下进度条不处于动画状态")
+	}
+	if got, want := p.View(), "#####-----"; got != want {
+		t.Errorf("期望视图为 %q，但得到了 %q", want, got)
+	}
+	if cmd := p.IncrPercent(0.2); cmd != nil {
+		t.Fatal("期望静态模式下 IncrPercent 返回 nil 命令")
+	}
+	if got, want := p.View(), "#######---"; got != want {
+		t.Errorf("期望视图为 %q，但得到了 %q", want, got)
+	}
+}
+
+// TestIndeterminate 测试不确定模式下片段在进度条中来回移动
+func TestIndeterminate(t *testing.T) {
+	p := New(WithWidth(8), WithFillCharacters('#', '-'), WithColorProfile(termenv.Ascii))
+
+	cmd := p.SetIndeterminate(true)
+	if cmd == nil {
+		t.Fatal("期望启用不确定模式时返回动画命令")
+	}
+	if got, want := p.View(), "##------"; got != want {
+		t.Errorf("期望视图为 %q，但得到了 %q", want, got)
+	}
+
+	// 片段移动到右端后应该反转方向
+	var m tea.Model = p
+	for i := 0; i < fps*2; i++ {
+		m, _ = m.Update(FrameMsg{id: p.id, tag: p.tag})
+		if got := m.View(); strings.Count(got, "#") != 2 || len(got) != 8 {
+			t.Fatalf("期望片段宽度为 2、总宽度为 8，但得到了 %q", got)
+		}
+	}
+	p = m.(Model)
+	if p.bounceDir != -1 {
+		t.Errorf("期望片段到达右端后向左移动")
+	}
+
+	if cmd := p.SetIndeterminate(false); cmd != nil {
+		t.Fatal("期望禁用不确定模式时不返回命令")
+	}
+	if got, want := p.View(), "---   0%"; got != want {
+		t.Errorf("期望视图为 %q，但得到了 %q", want, got)
+	}
+}
+
+// TestViewWithWidth 测试以显式宽度渲染进度条，而不修改模型的宽度
+func TestViewWithWidth(t *testing.T) {
+	p := New(WithWidth(10), WithFillCharacters('#', '-'), WithColorProfile(termenv.Ascii))
+
+	if got, want := p.ViewWithWidth(9, 0.5), "##--  50%"; got != want {
+		t.Errorf("期望视图为 %q，但得到了 %q", want, got)
+	}
+	if got, want := p.ViewAs(0.5), "###--  50%"; got != want {
+		t.Errorf("期望模型的宽度不受影响，视图为 %q，但得到了 %q", want, got)
+	}
+}
+
+// TestLabel 测试进度条旁边和内部的标签
+func TestLabel(t *testing.T) {
+	p := New(
+		WithWidth(16),
+		WithFillCharacters('#', '-'),
+		WithColorProfile(termenv.Ascii),
+		WithoutPercentage(),
+		WithLabel("copy"),
+	)
+
+	if got, want := p.ViewAs(0.5), "######----- copy"; got != want {
+		t.Errorf("期望标签在右侧，视图为 %q，但得到了 %q", want, got)
+	}
+	p.LabelPosition = LabelLeft
+	if got, want := p.ViewAs(0.5), "copy ######-----"; got != want {
+		t.Errorf("期望标签在左侧，视图为 %q，但得到了 %q", want, got)
+	}
+	p.LabelPosition = LabelInside
+	if got, want := p.ViewAs(0.5), "######copy------"; got != want {
+		t.Errorf("期望标签居中覆盖在进度条上，视图为 %q，但得到了 %q", want, got)
+	}
+
+	// 超出进度条宽度时以省略号截断
+	p.Label = "copying a very long file name"
+	if got, want := p.ViewAs(0.5), "copying a very …"; got != want {
+		t.Errorf("期望标签被截断，视图为 %q，但得到了 %q", want, got)
+	}
+}
+
+// TestSteps 测试步骤模式下进度条按步骤比例填充
+func TestSteps(t *testing.T) {
+	p := New(
+		WithoutAnimation(),
+		WithoutPercentage(),
+		WithWidth(12),
+		WithFillCharacters('#', '-'),
+		WithColorProfile(termenv.Ascii),
+		WithSteps(4),
+	)
+
+	p.IncrStep()
+	if got, want := p.View(), "###---------"; got != want {
+		t.Errorf("期望视图为 %q，但得到了 %q", want, got)
+	}
+	p.SetStep(10)
+	if p.Step() != 4 || p.Percent() != 1 {
+		t.Errorf("期望步数被限制为 4，但得到了 %d（%.2f）", p.Step(), p.Percent())
+	}
+
+	// 减少总步数时当前步骤不超过总步数
+	p.SetTotalSteps(3)
+	p.DecrStep()
+	if got, want := p.View(), "########----"; got != want {
+		t.Errorf("期望视图为 %q，但得到了 %q", want, got)
+	}
+
+	// 步骤后缀占用进度条的宽度
+	p.Width = 21
+	p.ShowSteps = true
+	p.StepSeparator = '|'
+	if got, want := p.View(), "####|###|--- step 2/3"; got != want {
+		t.Errorf("期望显示分隔符和步骤，视图为 %q，但得到了 %q", want, got)
+	}
+}
@@ -0,0 +1,93 @@
+package progress
+
+import (
+	"fmt"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// WithSteps 将进度条设置为步骤模式，共 n 步（参见 SetTotalSteps）。
+func WithSteps(n int) Option {
+	return func(m *Model) {
+		m.totalSteps = max(0, n)
+	}
+}
+
+// WithStepCount 在百分比之后显示当前步骤，例如 " step 3/7"（参见 Model.StepFormat）。
+func WithStepCount() Option {
+	return func(m *Model) {
+		m.ShowSteps = true
+	}
+}
+
+// WithStepSeparators 在进度条内部相邻步骤的分界处绘制分隔符 sep。
+func WithStepSeparators(sep rune) Option {
+	return func(m *Model) {
+		m.StepSeparator = sep
+	}
+}
+
+// SetTotalSteps 将进度条设置为步骤模式，共 n 步，并返回将进度条动画化到
+// 当前步骤对应的百分比所需的命令。当前步骤不会超过 n。如果 n 为 0 或更小，
+// 则退出步骤模式，百分比保持不变。
+func (m *Model) SetTotalSteps(n int) tea.Cmd {
+	m.totalSteps = max(0, n)
+	if m.totalSteps == 0 {
+		m.step = 0
+		return nil
+	}
+	return m.SetStep(m.step)
+}
+
+// TotalSteps 返回步骤模式下的总步数。不处于步骤模式时返回 0。
+func (m Model) TotalSteps() int {
+	return m.totalSteps
+}
+
+// Step 返回步骤模式下已完成的步数。
+func (m Model) Step() int {
+	return m.step
+}
+
+// SetStep 设置已完成的步数（0 到总步数之间），并返回将进度条动画化到
+// 相应百分比所需的命令。不处于步骤模式时无效。
+func (m *Model) SetStep(s int) tea.Cmd {
+	if m.totalSteps <= 0 {
+		return nil
+	}
+	m.step = max(0, min(m.totalSteps, s))
+	return m.SetPercent(float64(m.step) / float64(m.totalSteps))
+}
+
+// IncrStep 将已完成的步数加一，并返回将进度条动画化到新百分比所需的命令。
+func (m *Model) IncrStep() tea.Cmd {
+	return m.SetStep(m.step + 1)
+}
+
+// DecrStep 将已完成的步数减一，并返回将进度条动画化到新百分比所需的命令。
+func (m *Model) DecrStep() tea.Cmd {
+	return m.SetStep(m.step - 1)
+}
+
+// stepsView 渲染显示在百分比之后的当前步骤。
+func (m Model) stepsView() string {
+	if !m.ShowSteps || m.totalSteps <= 0 {
+		return ""
+	}
+	return m.PercentageStyle.Inline(true).Render(fmt.Sprintf(m.StepFormat, m.step, m.totalSteps))
+}
+
+// stepSeparators 返回宽度为 tw 的进度条中哪些列绘制步骤分隔符。
+// 第 k 个分隔符位于第 k 步结束的列；相邻的分隔符不会落在同一列。
+func (m Model) stepSeparators(tw int) []bool {
+	if m.StepSeparator == 0 || m.totalSteps <= 1 || tw <= 0 {
+		return nil
+	}
+	seps := make([]bool, tw)
+	for k := 1; k < m.totalSteps; k++ {
+		if i := k * tw / m.totalSteps; i > 0 && i < tw {
+			seps[i] = true
+		}
+	}
+	return seps
+}
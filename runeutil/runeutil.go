@@ -1,10 +1,13 @@
-// Package runeutil 为 Bubbles 提供一个实用函数，
-// 该函数可以处理包含符文的按键消息。
+// Package runeutil 为 Bubbles 提供处理符文的实用函数：清理按键消息中的符文，
+// 以及按字素簇计算显示宽度、截断和填充字符串，使各个组件对 CJK 字符和 emoji
+// 的处理保持一致。
 package runeutil
 
 import (
 	"unicode"
 	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // Sanitizer 是一个辅助工具，用于想要处理按键消息中符文的气泡小部件。
@@ -48,6 +51,50 @@ func ReplaceNewlines(nlRepl string) Option {
 	}
 }
 
+// StripControl 移除所有控制字符，包括换行符和制表符，而不是替换它们。
+func StripControl() Option {
+	return func(s sanitizer) sanitizer {
+		s.replaceNewLine = nil
+		s.replaceTab = nil
+		return s
+	}
+}
+
+// StripZeroWidth 移除零宽字符，例如零宽空格（U+200B）、零宽连接符（U+200D）
+// 和字节顺序标记（U+FEFF）。
+func StripZeroWidth() Option {
+	return func(s sanitizer) sanitizer {
+		s.stripZeroWidth = true
+		return s
+	}
+}
+
+// NormalizeNFC 将结果规范化为 Unicode 规范化形式 C（NFC），
+// 使组合字符序列（例如 "e" 加上组合重音符）合并为预组合字符。
+func NormalizeNFC() Option {
+	return func(s sanitizer) sanitizer {
+		s.normalizeNFC = true
+		return s
+	}
+}
+
+// Passthrough 是一个不做任何修改的清理器，用于完全禁用清理。
+var Passthrough Sanitizer = passthrough{}
+
+type passthrough struct{}
+
+// Sanitize 原样返回符文。
+func (passthrough) Sanitize(runes []rune) []rune { return runes }
+
+// isZeroWidth 返回符文是否为零宽字符。
+func isZeroWidth(r rune) bool {
+	switch r {
+	case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff':
+		return true
+	}
+	return false
+}
+
 func (s *sanitizer) Sanitize(runes []rune) []rune {
 	// dstrunes 是我们存储结果的地方。
 	dstrunes := runes[:0:len(runes)]
@@ -83,11 +130,17 @@ func (s *sanitizer) Sanitize(runes []rune) []rune {
 		case unicode.IsControl(r):
 			// 其他控制字符：跳过。
 
+		case s.stripZeroWidth && isZeroWidth(r):
+			// 零宽字符：跳过。
+
 		default:
 			// 保留字符。
 			dstrunes = append(dstrunes, runes[src])
 		}
 	}
+	if s.normalizeNFC && !norm.NFC.IsNormalString(string(dstrunes)) {
+		dstrunes = []rune(norm.NFC.String(string(dstrunes)))
+	}
 	return dstrunes
 }
 
@@ -95,4 +148,6 @@ func (s *sanitizer) Sanitize(runes []rune) []rune {
 type sanitizer struct {
 	replaceNewLine []rune // 替换换行符
 	replaceTab     []rune // 替换制表符
+	stripZeroWidth bool   // 移除零宽字符
+	normalizeNFC   bool   // 规范化为 NFC
 }
@@ -46,3 +46,65 @@ func TestSanitize(t *testing.T) {
 		}
 	}
 }
+
+// TestSanitizeOptions 测试可选的清理行为
+func TestSanitizeOptions(t *testing.T) {
+	td := []struct {
+		name          string
+		opts          []Option
+		input, output string
+	}{
+		{"strip control", []Option{StripControl()}, "a\tb\nc\x1bd", "abcd"},
+		{"zero width", []Option{StripZeroWidth()}, "a\u200bb\u200dc\ufeff", "abc"},
+		{"zero width kept", nil, "a\u200bb", "a\u200bb"},
+		{"nfc", []Option{NormalizeNFC()}, "cafe\u0301", "caf\u00e9"},
+		{"nfc unchanged", []Option{NormalizeNFC()}, "caf\u00e9", "caf\u00e9"},
+	}
+	for _, tc := range td {
+		result := string(NewSanitizer(tc.opts...).Sanitize([]rune(tc.input)))
+		if result != tc.output {
+			t.Errorf("%s: 期望 %q，但得到了 %q", tc.name, tc.output, result)
+		}
+	}
+
+	if result := string(Passthrough.Sanitize([]rune("a\tb\n"))); result != "a\tb\n" {
+		t.Errorf("passthrough: 期望原样返回，但得到了 %q", result)
+	}
+}
+
+// TestWidth 测试按字素簇计算宽度、截断和填充字符串
+func TestWidth(t *testing.T) {
+	// "é" 由 e 和组合重音符组成，"👍🏽" 由两个码点组成
+	const s = "é中文👍🏽ok"
+
+	var graphemes []string
+	width := 0
+	for g, w := range Graphemes(s) {
+		graphemes = append(graphemes, g)
+		width += w
+	}
+	if len(graphemes) != 6 || width != StringWidth(s) || width != 9 {
+		t.Fatalf("期望 6 个字素簇、宽度 9，实际为 %q、宽度 %d", graphemes, width)
+	}
+	if first, rest := FirstGrapheme(s); first != "é" || rest != "中文👍🏽ok" {
+		t.Fatalf("第一个字素簇应为 %q，实际为 %q、%q", "é", first, rest)
+	}
+
+	for _, tc := range []struct {
+		name, got, want string
+	}{
+		{"不需要截断", Truncate(s, 9, "…"), s},
+		{"截断末尾", Truncate(s, 6, "…"), "é中文…"},
+		{"不拆分宽字符", Truncate(s, 5, "…"), "é中…"},
+		{"放不下省略号", Truncate(s, 1, "…"), "é"},
+		{"截断开头", TruncateLeft(s, 5, "…"), "…👍🏽ok"},
+		{"开头不拆分宽字符", TruncateLeft(s, 6, "…"), "…👍🏽ok"},
+		{"向右填充", PadRight("中", 4), "中  "},
+		{"向左填充", PadLeft("中", 4), "  中"},
+		{"不需要填充", PadRight("中文", 3), "中文"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s：期望 %q，实际为 %q", tc.name, tc.want, tc.got)
+		}
+	}
+}
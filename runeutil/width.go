@@ -0,0 +1,111 @@
+package runeutil
+
+import (
+	"iter"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// 以下函数按字素簇（用户感知的字符，例如带有组合符号的字母、由多个码点组成的
+// emoji）处理字符串，并按终端中占用的单元格数计算宽度：CJK 字符和大多数 emoji
+// 占两个单元格。它们处理的是纯文本，不识别 ANSI 转义序列；对于已经带有样式的
+// 字符串，请使用 ansi 包。
+
+// Graphemes 返回按顺序遍历 s 中每个字素簇及其显示宽度的迭代器。
+func Graphemes(s string) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		state := -1
+		for len(s) > 0 {
+			var g string
+			var w int
+			g, s, w, state = uniseg.FirstGraphemeClusterInString(s, state)
+			if !yield(g, w) {
+				return
+			}
+		}
+	}
+}
+
+// FirstGrapheme 返回 s 的第一个字素簇和剩余的部分。
+func FirstGrapheme(s string) (first, rest string) {
+	first, rest, _, _ = uniseg.FirstGraphemeClusterInString(s, -1)
+	return first, rest
+}
+
+// StringWidth 返回 s 的显示宽度。
+func StringWidth(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// RuneWidth 返回单个符文的显示宽度。对于由多个符文组成的字素簇，
+// 请使用 StringWidth 或 Graphemes。
+func RuneWidth(r rune) int {
+	return uniseg.StringWidth(string(r))
+}
+
+// Truncate 将 s 截断到不超过 w 个单元格宽，保留开头。如果发生了截断，
+// 在末尾添加 tail（例如 "…"），tail 的宽度计入 w。
+// 如果 w 连 tail 都放不下，则不添加 tail。
+func Truncate(s string, w int, tail string) string {
+	if StringWidth(s) <= w {
+		return s
+	}
+	tw := StringWidth(tail)
+	if w <= tw {
+		tail, tw = "", 0
+	}
+
+	var b strings.Builder
+	width := 0
+	for g, gw := range Graphemes(s) {
+		if width+gw > w-tw {
+			break
+		}
+		b.WriteString(g)
+		width += gw
+	}
+	return b.String() + tail
+}
+
+// TruncateLeft 将 s 截断到不超过 w 个单元格宽，保留末尾。如果发生了截断，
+// 在开头添加 head（例如 "…"），head 的宽度计入 w。
+// 如果 w 连 head 都放不下，则不添加 head。
+func TruncateLeft(s string, w int, head string) string {
+	if StringWidth(s) <= w {
+		return s
+	}
+	hw := StringWidth(head)
+	if w <= hw {
+		head, hw = "", 0
+	}
+
+	var gs []string
+	var ws []int
+	for g, gw := range Graphemes(s) {
+		gs = append(gs, g)
+		ws = append(ws, gw)
+	}
+	i, width := len(gs), 0
+	for i > 0 && width+ws[i-1] <= w-hw {
+		width += ws[i-1]
+		i--
+	}
+	return head + strings.Join(gs[i:], "")
+}
+
+// PadRight 在 s 的末尾添加空格，使其至少为 w 个单元格宽。
+func PadRight(s string, w int) string {
+	if n := w - StringWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// PadLeft 在 s 的开头添加空格，使其至少为 w 个单元格宽。
+func PadLeft(s string, w int) string {
+	if n := w - StringWidth(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
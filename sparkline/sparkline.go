@@ -0,0 +1,263 @@
+// Package sparkline 提供一个迷你图组件，用块字符在一行中渲染紧凑的时间序列图表，
+// 适用于仪表盘中的小型图表。数据保存在固定容量的环形缓冲区中，新的数据从右侧进入，
+// 最旧的数据从左侧移出。
+package sparkline
+
+import (
+	"math"
+	"strings"
+
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// Mode 是迷你图的渲染模式。
+type Mode int
+
+const (
+	// Blocks 用高度不同的块字符渲染，每个数据占一列，有 8 级高度。
+	Blocks Mode = iota
+
+	// Braille 用盲文字符渲染，每列显示两个数据，有 4 级高度。宽度相同时
+	// 可以显示两倍的数据。
+	Braille
+)
+
+// blocks 是 Blocks 模式下从低到高的字符。
+var blocks = []rune("▁▂▃▄▅▆▇█")
+
+// brailleDots 是盲文字符中左右两列的点，从下往上。
+var brailleDots = [2][4]rune{
+	{0x40, 0x04, 0x02, 0x01},
+	{0x80, 0x20, 0x10, 0x08},
+}
+
+// Threshold 为不小于 Value 的数据指定样式。
+type Threshold struct {
+	Value float64
+	Style lipgloss.Style
+}
+
+// Model 是迷你图的 Bubble Tea 模型。
+type Model struct {
+	// Mode 是渲染模式，默认为 Blocks。
+	Mode Mode
+
+	// Style 是没有匹配的 Threshold 的数据的样式。
+	Style lipgloss.Style
+
+	// Thresholds 按 Value 从小到大排列。每个数据使用 Value 不大于它的最后一个
+	// Threshold 的样式。在 Braille 模式下，每列使用其中较大的数据的样式。
+	Thresholds []Threshold
+
+	values []float64 // 环形缓冲区
+	head   int       // 最旧的数据的索引
+	n      int       // 数据的数量
+
+	min, max float64
+	fixed    bool // 是否使用固定的上下限
+}
+
+// Option 用于在 New 中设置选项。
+type Option func(*Model)
+
+// New 返回一个最多保存 capacity 个数据的迷你图。capacity 至少为 1。
+func New(capacity int, opts ...Option) Model {
+	m := Model{values: make([]float64, max(capacity, 1))}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
+}
+
+// WithMode 设置渲染模式。
+func WithMode(mode Mode) Option {
+	return func(m *Model) {
+		m.Mode = mode
+	}
+}
+
+// WithStyle 设置默认样式。
+func WithStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.Style = s
+	}
+}
+
+// WithThresholds 设置按数据大小选择样式的阈值，参见 Model.Thresholds。
+func WithThresholds(t ...Threshold) Option {
+	return func(m *Model) {
+		m.Thresholds = t
+	}
+}
+
+// WithBounds 使用固定的上下限，参见 SetBounds。
+func WithBounds(lo, hi float64) Option {
+	return func(m *Model) {
+		m.SetBounds(lo, hi)
+	}
+}
+
+// SetBounds 使用固定的上下限缩放数据，超出范围的数据被限制在范围之内。
+// 默认根据缓冲区中的最小值和最大值自动缩放。
+func (m *Model) SetBounds(lo, hi float64) {
+	m.min, m.max = min(lo, hi), max(lo, hi)
+	m.fixed = true
+}
+
+// AutoScale 恢复根据缓冲区中的最小值和最大值自动缩放。
+func (m *Model) AutoScale() {
+	m.fixed = false
+}
+
+// Bounds 返回当前用于缩放的下限和上限。
+func (m Model) Bounds() (lo, hi float64) {
+	if m.fixed {
+		return m.min, m.max
+	}
+	first := true
+	for _, v := range m.Values() {
+		if math.IsNaN(v) {
+			continue
+		}
+		if first {
+			lo, hi, first = v, v, false
+			continue
+		}
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	return lo, hi
+}
+
+// Push 添加一个数据。缓冲区已满时移出最旧的数据。NaN 被渲染为空白。
+func (m *Model) Push(v float64) {
+	if len(m.values) == 0 {
+		m.values = make([]float64, 1)
+	}
+	if m.n < len(m.values) {
+		m.values[(m.head+m.n)%len(m.values)] = v
+		m.n++
+		return
+	}
+	m.values[m.head] = v
+	m.head = (m.head + 1) % len(m.values)
+}
+
+// Values 返回缓冲区中的数据，从旧到新。
+func (m Model) Values() []float64 {
+	out := make([]float64, m.n)
+	for i := range out {
+		out[i] = m.values[(m.head+i)%len(m.values)]
+	}
+	return out
+}
+
+// Len 返回缓冲区中数据的数量。
+func (m Model) Len() int {
+	return m.n
+}
+
+// Capacity 返回缓冲区的容量。
+func (m Model) Capacity() int {
+	return len(m.values)
+}
+
+// SetCapacity 修改缓冲区的容量，容量变小时移出最旧的数据。
+func (m *Model) SetCapacity(capacity int) {
+	values := m.Values()
+	if len(values) > capacity {
+		values = values[len(values)-max(capacity, 1):]
+	}
+	m.values = make([]float64, max(capacity, 1))
+	m.head, m.n = 0, copy(m.values, values)
+}
+
+// Clear 清空缓冲区。
+func (m *Model) Clear() {
+	m.head, m.n = 0, 0
+}
+
+// Width 返回渲染后的宽度：Blocks 模式下等于容量，Braille 模式下为容量的一半（向上取整）。
+func (m Model) Width() int {
+	if m.Mode == Braille {
+		return (m.Capacity() + 1) / 2 //nolint:mnd
+	}
+	return m.Capacity()
+}
+
+// View 渲染迷你图。数据靠右对齐，缓冲区未满时左侧以空格填充。
+func (m Model) View() string {
+	values := m.Values()
+	// 左侧的空白用 NaN 表示，使每列的数据对齐。
+	pad := make([]float64, m.Capacity()-len(values), m.Capacity())
+	for i := range pad {
+		pad[i] = math.NaN()
+	}
+	values = append(pad, values...)
+
+	lo, hi := m.Bounds()
+	var b strings.Builder
+	if m.Mode == Braille {
+		// 容量为奇数时，最左侧的一列只有右半边。
+		if len(values)%2 == 1 {
+			values = append([]float64{math.NaN()}, values...)
+		}
+		for i := 0; i < len(values); i += 2 {
+			b.WriteString(m.brailleCell(values[i], values[i+1], lo, hi))
+		}
+		return b.String()
+	}
+
+	for _, v := range values {
+		if math.IsNaN(v) {
+			b.WriteByte(' ')
+			continue
+		}
+		r := blocks[level(v, lo, hi, len(blocks)-1)]
+		b.WriteString(m.style(v).Render(string(r)))
+	}
+	return b.String()
+}
+
+// brailleCell 渲染显示 left 和 right 两个数据的盲文字符。
+func (m Model) brailleCell(left, right, lo, hi float64) string {
+	if math.IsNaN(left) && math.IsNaN(right) {
+		return " "
+	}
+	r := rune(0x2800) //nolint:mnd
+	for col, v := range []float64{left, right} {
+		if math.IsNaN(v) {
+			continue
+		}
+		// 最小的数据也显示一个点。
+		for _, dot := range brailleDots[col][:1+level(v, lo, hi, 3)] {
+			r |= dot
+		}
+	}
+
+	v := left
+	if math.IsNaN(v) || right > v {
+		v = right
+	}
+	return m.style(v).Render(string(r))
+}
+
+// level 将 v 按 [lo, hi] 缩放到 0 到 top 之间的整数。lo 等于 hi 时返回 0。
+func level(v, lo, hi float64, top int) int {
+	if hi <= lo {
+		return 0
+	}
+	f := (min(max(v, lo), hi) - lo) / (hi - lo)
+	return int(math.Round(f * float64(top)))
+}
+
+// style 返回数据 v 的样式。
+func (m Model) style(v float64) lipgloss.Style {
+	s := m.Style
+	for _, t := range m.Thresholds {
+		if v < t.Value {
+			break
+		}
+		s = t.Style
+	}
+	return s
+}
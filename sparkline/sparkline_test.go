@@ -0,0 +1,92 @@
+package sparkline
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+func TestPush(t *testing.T) {
+	m := New(3)
+	for _, v := range []float64{1, 2, 3, 4, 5} {
+		m.Push(v)
+	}
+	if got, want := m.Values(), []float64{3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	m.SetCapacity(2)
+	if got, want := m.Values(), []float64{4, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v after shrinking, got %v", want, got)
+	}
+	m.SetCapacity(4)
+	m.Push(6)
+	if got, want := m.Values(), []float64{4, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v after growing, got %v", want, got)
+	}
+
+	m.Clear()
+	if m.Len() != 0 || m.View() != "    " {
+		t.Fatalf("expected an empty chart, got %d values and %q", m.Len(), m.View())
+	}
+}
+
+func TestView(t *testing.T) {
+	m := New(10)
+	for _, v := range []float64{0, 1, 2, 3, 4, 5, 6, 7} {
+		m.Push(v)
+	}
+	if got, want := m.View(), "  ▁▂▃▄▅▆▇█"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	// 固定的上下限，超出范围的数据被限制在范围之内。
+	m = New(4, WithBounds(0, 14))
+	for _, v := range []float64{-5, 0, 7, 100} {
+		m.Push(v)
+	}
+	if got, want := m.View(), "▁▁▅█"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	m.AutoScale()
+	m.Push(math.NaN())
+	if got, want := m.View(), "▁▁█ "; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestBraille(t *testing.T) {
+	m := New(5, WithMode(Braille), WithBounds(0, 3))
+	for _, v := range []float64{0, 3, 1, 2, 3} {
+		m.Push(v)
+	}
+	if m.Width() != 3 {
+		t.Fatalf("expected width 3, got %d", m.Width())
+	}
+	// 最左侧一列只有右半边；其余每列两个数据。
+	if got, want := m.View(), "⢀⣧⣾"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestThresholds(t *testing.T) {
+	mark := func(c string) lipgloss.Style {
+		return lipgloss.NewStyle().Transform(func(s string) string { return c + s })
+	}
+	m := New(3,
+		WithBounds(0, 10),
+		WithStyle(mark("l")),
+		WithThresholds(Threshold{Value: 5, Style: mark("m")}, Threshold{Value: 8, Style: mark("h")}),
+	)
+	for _, v := range []float64{1, 5, 9} {
+		m.Push(v)
+	}
+	got := m.View()
+	if !strings.HasPrefix(got, "l") || !strings.Contains(got, "m") || !strings.Contains(got, "h") {
+		t.Errorf("expected low, medium and high styles, got %q", got)
+	}
+}
@@ -0,0 +1,90 @@
+package spinner
+
+import (
+	"os"
+	"strings"
+)
+
+// Charset 描述终端能够渲染的字符范围。
+type Charset int
+
+const (
+	// CharsetFull 可以渲染包括 emoji 在内的所有字符。
+	CharsetFull Charset = iota
+
+	// CharsetUnicode 可以渲染盲文、方块等 Unicode 符号，但不能渲染 emoji。
+	CharsetUnicode
+
+	// CharsetASCII 只能渲染 ASCII 字符。
+	CharsetASCII
+)
+
+// DefaultCharset 是 New 和 IsRenderable 默认假定的终端字符范围。
+// 默认值为 CharsetFull；应用程序可以在启动时将其设置为 DetectCharset()
+// 的结果，或根据自己掌握的终端信息进行设置。
+var DefaultCharset = CharsetFull
+
+// DetectCharset 根据环境变量推测终端能够渲染的字符范围：
+// Linux 控制台和 dumb 终端被视为只支持 ASCII，明确设置了非 UTF-8
+// 字符集的区域设置（例如 LANG=C）也被视为只支持 ASCII。
+func DetectCharset() Charset {
+	switch os.Getenv("TERM") {
+	case "linux", "dumb":
+		return CharsetASCII
+	}
+
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+		v = strings.ToLower(v)
+		if strings.Contains(v, "utf-8") || strings.Contains(v, "utf8") {
+			return CharsetFull
+		}
+		return CharsetASCII
+	}
+	return CharsetFull
+}
+
+// CanRender 返回该字符范围是否能够渲染加载动画的所有帧。
+func (c Charset) CanRender(s Spinner) bool {
+	for _, f := range s.Frames {
+		for _, r := range f {
+			switch {
+			case r < 0x80:
+			case c == CharsetASCII:
+				return false
+			case c == CharsetUnicode && isEmoji(r):
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// IsRenderable 返回在 DefaultCharset 下能否渲染加载动画的所有帧。
+func IsRenderable(s Spinner) bool {
+	return DefaultCharset.CanRender(s)
+}
+
+// isEmoji 粗略判断字符是否为 emoji：补充平面的符号和 emoji 变体选择符。
+func isEmoji(r rune) bool {
+	return r >= 0x1F000 || r == 0xFE0F
+}
+
+// WithCharset 是设置终端字符范围的选项。如果加载动画无法在该范围内渲染，
+// New 会改用后备加载动画（参见 WithFallback）。默认使用 DefaultCharset。
+func WithCharset(c Charset) Option {
+	return func(m *Model) {
+		m.charset = c
+	}
+}
+
+// WithFallback 是设置后备加载动画的选项。当终端无法渲染所选的加载动画时，
+// New 会改用它。默认为 Line。
+func WithFallback(s Spinner) Option {
+	return func(m *Model) {
+		m.fallback = s
+	}
+}
@@ -0,0 +1,60 @@
+package spinner
+
+import tea "github.com/purpose168/bubbletea-cn"
+
+// FinishedMsg 在有限次播放的加载动画（参见 Model.Repeat）播放完最后一帧后发送。
+type FinishedMsg struct {
+	ID int // 加载动画的 ID
+}
+
+// WithRepeat 是设置播放次数的选项，参见 Model.Repeat。
+func WithRepeat(n int) Option {
+	return func(m *Model) {
+		m.Repeat = n
+	}
+}
+
+// Finished 返回有限次播放的加载动画是否已经播放完毕。
+func (m Model) Finished() bool {
+	return m.finished
+}
+
+// Reset 从第一帧重新开始播放，并返回启动动画所需的命令。
+func (m *Model) Reset() tea.Cmd {
+	m.frame = 0
+	m.plays = 0
+	m.finished = false
+	m.tag++
+	return m.tick(m.id, m.tag)
+}
+
+// SetFrames 在播放过程中替换帧序列，而无需创建新的加载动画。
+// 如果当前帧超出了新的帧序列，则从第一帧继续播放；已经播放完毕的加载动画停留在最后一帧。
+func (m *Model) SetFrames(frames []string) {
+	m.Spinner.Frames = frames
+	switch {
+	case m.finished:
+		m.frame = max(0, len(frames)-1)
+	case m.frame >= len(frames):
+		m.frame = 0
+	}
+}
+
+// advance 前进一帧。有限次播放的加载动画播放完最后一帧时停留在最后一帧，
+// 并返回 true。
+func (m *Model) advance() bool {
+	m.frame++
+	if m.frame < len(m.Spinner.Frames) {
+		return false
+	}
+	if m.Repeat > 0 {
+		m.plays++
+		if m.plays >= m.Repeat {
+			m.frame = max(0, len(m.Spinner.Frames)-1)
+			m.finished = true
+			return true
+		}
+	}
+	m.frame = 0
+	return false
+}
@@ -2,6 +2,7 @@
 package spinner
 
 import (
+	"math"
 	"sync/atomic"
 	"time"
 
@@ -93,6 +94,16 @@ var (
 		Frames: []string{"", ".", "..", "..."},
 		FPS:    time.Second / 3, //nolint:mnd
 	}
+	// MeterFill 单调递增的仪表盘帧，适用于确定模式（参见 Model.SetPercent）
+	MeterFill = Spinner{
+		Frames: []string{"▱▱▱", "▰▱▱", "▰▰▱", "▰▰▰"},
+		FPS:    time.Second / 7, //nolint:mnd
+	}
+	// BlockFill 按八分之一逐渐填充的方块帧，适用于确定模式
+	BlockFill = Spinner{
+		Frames: []string{" ", "▏", "▎", "▍", "▌", "▋", "▊", "▉", "█"},
+		FPS:    time.Second / 9, //nolint:mnd
+	}
 )
 
 // Model 包含加载动画的状态。使用 New 来创建新模型，
@@ -108,9 +119,28 @@ type Model struct {
 	// https://github.com/charmbracelet/lipgloss
 	Style lipgloss.Style
 
+	// Label 是渲染在加载动画之后的可选后缀文本。
+	Label string
+
+	// Repeat 是帧序列的播放次数。播放完毕后加载动画停留在最后一帧，
+	// 并发送 FinishedMsg，适用于简短的“成功”对勾动画。如果为 0，则无限循环。
+	Repeat int
+
 	frame int // 当前帧索引
 	id    int // 唯一标识符
 	tag   int // 标签，用于防止消息过多
+
+	// 有限次播放（参见 Repeat）的进度。
+	plays    int  // 已播放完毕的次数
+	finished bool // 是否已经播放完毕
+
+	// 确定模式下，由百分比而不是计时器决定显示哪一帧。
+	determinate bool
+	percent     float64
+
+	// New 时用于选择后备加载动画的终端字符范围。
+	charset  Charset
+	fallback Spinner
 }
 
 // ID 返回加载动画的唯一 ID。
@@ -118,17 +148,25 @@ func (m Model) ID() int {
 	return m.id
 }
 
-// New 返回一个具有默认值的模型。
+// New 返回一个具有默认值的模型。如果终端无法渲染所选的加载动画
+// （参见 WithCharset），则改用后备加载动画。
 func New(opts ...Option) Model {
 	m := Model{
-		Spinner: Line,
-		id:      nextID(),
+		Spinner:  Line,
+		id:       nextID(),
+		charset:  DefaultCharset,
+		fallback: Line,
 	}
 
 	for _, opt := range opts {
 		opt(&m)
 	}
 
+	// 终端无法渲染所选的加载动画时，改用后备加载动画。
+	if !m.charset.CanRender(m.Spinner) && m.charset.CanRender(m.fallback) {
+		m.Spinner = m.fallback
+	}
+
 	return m
 }
 
@@ -159,9 +197,14 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 		}
 
-		m.frame++
-		if m.frame >= len(m.Spinner.Frames) {
-			m.frame = 0
+		// 确定模式下帧由百分比决定，播放完毕后停留在最后一帧，因此停止计时。
+		if m.determinate || m.finished {
+			return m, nil
+		}
+
+		if m.advance() {
+			id := m.id
+			return m, func() tea.Msg { return FinishedMsg{ID: id} }
 		}
 
 		m.tag++
@@ -171,13 +214,47 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	}
 }
 
+// SetPercent 将加载动画切换到确定模式，并设置当前进度（0 到 1 之间）。
+// 在确定模式下，显示的帧由百分比在帧序列中的位置决定，
+// 因此应使用单调递增的帧序列，例如 MeterFill 或 BlockFill。
+func (m *Model) SetPercent(p float64) {
+	m.determinate = true
+	m.percent = math.Max(0, math.Min(1, p))
+}
+
+// Percent 返回确定模式下的当前进度。
+func (m Model) Percent() float64 {
+	return m.percent
+}
+
+// Determinate 返回加载动画是否处于确定模式。
+func (m Model) Determinate() bool {
+	return m.determinate
+}
+
+// SetIndeterminate 将加载动画切换回不确定（动画）模式，
+// 并返回重新启动动画所需的命令。
+func (m *Model) SetIndeterminate() tea.Cmd {
+	if !m.determinate {
+		return nil
+	}
+	m.determinate = false
+	m.tag++
+	return m.tick(m.id, m.tag)
+}
+
 // View 渲染模型的视图。
 func (m Model) View() string {
-	if m.frame >= len(m.Spinner.Frames) {
+	frame := m.frame
+	if m.determinate && len(m.Spinner.Frames) > 0 {
+		frame = int(math.Round(m.percent * float64(len(m.Spinner.Frames)-1)))
+	}
+
+	if frame >= len(m.Spinner.Frames) {
 		return "(error)"
 	}
 
-	return m.Style.Render(m.Spinner.Frames[m.frame])
+	return m.Style.Render(m.Spinner.Frames[frame]) + m.Label
 }
 
 // Tick 是用于推进加载动画一帧的命令。使用此命令来有效地启动加载动画。
@@ -229,3 +306,10 @@ func WithStyle(style lipgloss.Style) Option {
 		m.Style = style
 	}
 }
+
+// WithLabel 是设置加载动画后缀文本的选项。
+func WithLabel(label string) Option {
+	return func(m *Model) {
+		m.Label = label
+	}
+}
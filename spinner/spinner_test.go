@@ -1,6 +1,7 @@
 package spinner_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/purpose168/bubbles-cn/spinner"
@@ -47,15 +48,17 @@ func TestSpinnerNew(t *testing.T) {
 
 	// 测试所有预定义的加载动画
 	tests := map[string]spinner.Spinner{
-		"Line":    spinner.Line,    // 线条加载动画
-		"Dot":     spinner.Dot,     // 点加载动画
-		"MiniDot": spinner.MiniDot, // 小点加载动画
-		"Jump":    spinner.Jump,    // 跳跃加载动画
-		"Pulse":   spinner.Pulse,   // 脉冲加载动画
-		"Points":  spinner.Points,  // 点加载动画
-		"Globe":   spinner.Globe,   // 地球加载动画
-		"Moon":    spinner.Moon,    // 月亮加载动画
-		"Monkey":  spinner.Monkey,  // 猴子加载动画
+		"Line":      spinner.Line,      // 线条加载动画
+		"Dot":       spinner.Dot,       // 点加载动画
+		"MiniDot":   spinner.MiniDot,   // 小点加载动画
+		"Jump":      spinner.Jump,      // 跳跃加载动画
+		"Pulse":     spinner.Pulse,     // 脉冲加载动画
+		"Points":    spinner.Points,    // 点加载动画
+		"Globe":     spinner.Globe,     // 地球加载动画
+		"Moon":      spinner.Moon,      // 月亮加载动画
+		"Monkey":    spinner.Monkey,    // 猴子加载动画
+		"MeterFill": spinner.MeterFill, // 仪表盘填充加载动画
+		"BlockFill": spinner.BlockFill, // 方块填充加载动画
 	}
 
 	for name, s := range tests {
@@ -64,3 +67,108 @@ func TestSpinnerNew(t *testing.T) {
 		})
 	}
 }
+
+// TestSpinnerDeterminate 测试由百分比驱动的确定模式
+func TestSpinnerDeterminate(t *testing.T) {
+	s := spinner.New(spinner.WithSpinner(spinner.MeterFill), spinner.WithLabel(" 下载中"))
+
+	tests := []struct {
+		percent float64
+		want    string
+	}{
+		{0, "▱▱▱ 下载中"},
+		{0.34, "▰▱▱ 下载中"},
+		{0.7, "▰▰▱ 下载中"},
+		{1.5, "▰▰▰ 下载中"},
+	}
+
+	for _, tc := range tests {
+		s.SetPercent(tc.percent)
+		if got := s.View(); got != tc.want {
+			t.Errorf("百分比 %v 时期望 %q，但得到了 %q", tc.percent, tc.want, got)
+		}
+	}
+
+	if !s.Determinate() {
+		t.Fatal("期望加载动画处于确定模式")
+	}
+	if _, cmd := s.Update(s.Tick()); cmd != nil {
+		t.Error("期望确定模式下不再继续计时")
+	}
+	if cmd := s.SetIndeterminate(); cmd == nil {
+		t.Error("期望切换回不确定模式时返回计时命令")
+	}
+}
+
+// TestSpinnerCharsetFallback 测试终端无法渲染时改用后备加载动画
+func TestSpinnerCharsetFallback(t *testing.T) {
+	assertEqualSpinner := func(t *testing.T, got, exp spinner.Spinner) {
+		t.Helper()
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf("期望加载动画 %v，但得到了 %v", exp.Frames, got.Frames)
+		}
+	}
+
+	s := spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithCharset(spinner.CharsetASCII))
+	assertEqualSpinner(t, s.Spinner, spinner.Line)
+
+	s = spinner.New(
+		spinner.WithSpinner(spinner.Globe),
+		spinner.WithCharset(spinner.CharsetUnicode),
+		spinner.WithFallback(spinner.MiniDot),
+	)
+	assertEqualSpinner(t, s.Spinner, spinner.MiniDot)
+
+	s = spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithCharset(spinner.CharsetUnicode))
+	assertEqualSpinner(t, s.Spinner, spinner.Dot)
+
+	if !spinner.CharsetASCII.CanRender(spinner.Ellipsis) {
+		t.Error("期望 ASCII 终端能够渲染 Ellipsis")
+	}
+	if spinner.CharsetUnicode.CanRender(spinner.Monkey) {
+		t.Error("期望不支持 emoji 的终端无法渲染 Monkey")
+	}
+	if !spinner.IsRenderable(spinner.Moon) {
+		t.Error("期望默认字符范围能够渲染所有加载动画")
+	}
+}
+
+// TestSpinnerRepeat 测试有限次播放的加载动画
+func TestSpinnerRepeat(t *testing.T) {
+	s := spinner.New(
+		spinner.WithSpinner(spinner.Spinner{Frames: []string{"a", "b", "c"}}),
+		spinner.WithRepeat(2),
+	)
+
+	for i := 0; i < 5; i++ {
+		s, _ = s.Update(s.Tick())
+		if s.Finished() {
+			t.Fatalf("播放完毕之前不应结束，第 %d 帧", i)
+		}
+	}
+
+	s, finish := s.Update(s.Tick())
+	if !s.Finished() || finish == nil {
+		t.Fatal("期望播放两次后结束")
+	}
+	if msg, ok := finish().(spinner.FinishedMsg); !ok || msg.ID != s.ID() {
+		t.Fatalf("期望 FinishedMsg，但得到了 %#v", msg)
+	}
+	if got := s.View(); got != "c" {
+		t.Fatalf("期望停留在最后一帧，但得到了 %q", got)
+	}
+
+	// 结束后不再前进。
+	if s2, c := s.Update(s.Tick()); c != nil || s2.View() != "c" {
+		t.Fatal("期望结束后忽略计时消息")
+	}
+
+	// SetFrames 替换帧序列，Reset 重新开始播放。
+	s.SetFrames([]string{"x", "✓"})
+	if got := s.View(); got != "✓" {
+		t.Fatalf("期望停留在新帧序列的最后一帧，但得到了 %q", got)
+	}
+	if s.Reset() == nil || s.Finished() || s.View() != "x" {
+		t.Fatal("期望 Reset 从第一帧重新开始")
+	}
+}
@@ -0,0 +1,72 @@
+package stopwatch
+
+import (
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// SharedTickMsg 是共享滴答源（参见 SharedTick）发送的消息，不属于任何一个秒表。
+// 将它传给所有使用共享滴答的秒表，它们根据消息中的时间计算已经过的时间。
+type SharedTickMsg struct {
+	Time time.Time // 滴答发生的时间
+}
+
+// SharedTick 返回在 interval 之后发送一个 SharedTickMsg 的命令。应用程序在收到
+// SharedTickMsg 时再次返回它，使多个秒表共用一个滴答源，而不是每个秒表
+// 各自调度滴答。例如显示每一行已用时间的任务列表：
+//
+//	case stopwatch.SharedTickMsg:
+//		for i := range m.stopwatches {
+//			m.stopwatches[i], _ = m.stopwatches[i].Update(msg)
+//		}
+//		return m, stopwatch.SharedTick(time.Second)
+func SharedTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return SharedTickMsg{Time: t}
+	})
+}
+
+// NewShared 创建一个使用共享滴答源的秒表。它不调度自己的 TickMsg，而是根据启动时
+// 和收到 SharedTickMsg 时的时间戳计算已经过的时间，因此 Elapsed 和 View 的精度取决于
+// 共享滴答的间隔，而记圈和停止时记录的时间是精确的。启动、停止、暂停和恢复的用法不变。
+func NewShared() Model {
+	m := New()
+	m.shared = true
+	return m
+}
+
+// Shared 返回秒表是否使用共享滴答源，参见 NewShared。
+func (m Model) Shared() bool {
+	return m.shared
+}
+
+// setRunning 在共享模式下启动或停止秒表。停止时把本次运行的时间计入 base，
+// 启动时以当前时间作为本次运行的起点。
+func (m *Model) setRunning(running bool) {
+	now := time.Now()
+	switch {
+	case running && !m.running:
+		m.since = now
+	case !running && m.running:
+		m.base = m.elapsedAt(now)
+		m.d = m.base
+	}
+	m.running = running
+}
+
+// elapsedAt 返回共享模式下到 t 为止已经过的时间。
+func (m Model) elapsedAt(t time.Time) time.Duration {
+	if !m.running {
+		return m.base
+	}
+	return m.base + max(0, t.Sub(m.since))
+}
+
+// updateShared 在收到 SharedTickMsg 时重新计算已经过的时间。
+func (m Model) updateShared(msg SharedTickMsg) (Model, tea.Cmd) {
+	if m.shared && m.running {
+		m.d = m.elapsedAt(msg.Time)
+	}
+	return m, nil
+}
@@ -37,12 +37,30 @@ type ResetMsg struct {
 	ID int // 秒表 ID
 }
 
+// PausedMsg 在秒表被暂停或恢复时发送。父模型可以监听它来更新界面。
+type PausedMsg struct {
+	ID     int  // 秒表 ID
+	Paused bool // 为 true 表示暂停，为 false 表示恢复
+}
+
+// LapMsg 在秒表记录一圈时发送。秒表处理该消息后，
+// 可以通过 Laps 和 Splits 读取记录的时间。
+type LapMsg struct {
+	ID int // 秒表 ID
+}
+
 // Model 秒表组件的模型。
 type Model struct {
-	d       time.Duration // 已经过的时间
-	id      int           // 唯一标识符
-	tag     int           // 标签，用于防止消息过多
-	running bool          // 是否正在运行
+	d       time.Duration   // 已经过的时间
+	id      int             // 唯一标识符
+	tag     int             // 标签，用于防止消息过多
+	running bool            // 是否正在运行
+	splits  []time.Duration // 每次记圈时的累计时间
+
+	// 共享模式（参见 NewShared）下，已经过的时间根据时间戳计算
+	shared bool          // 是否使用共享滴答源
+	base   time.Duration // 本次运行之前已经过的时间
+	since  time.Time     // 本次运行开始的时间
 
 	// 在每次触发之前等待多长时间。默认为 1 秒。
 	Interval time.Duration // 触发间隔
@@ -73,9 +91,13 @@ func (m Model) Init() tea.Cmd {
 
 // Start 启动秒表。
 func (m Model) Start() tea.Cmd {
-	return tea.Sequence(func() tea.Msg {
+	start := func() tea.Msg {
 		return StartStopMsg{ID: m.id, running: true}
-	}, tick(m.id, m.tag, m.Interval))
+	}
+	if m.shared {
+		return start
+	}
+	return tea.Sequence(start, tick(m.id, m.tag, m.Interval))
 }
 
 // Stop 停止秒表。
@@ -100,6 +122,44 @@ func (m Model) Reset() tea.Cmd {
 	}
 }
 
+// Pause 暂停秒表并保留已经过的时间，同时发送 PausedMsg。
+// 暂停期间发出的触发会被拒绝，恢复后不会重复计时。
+func (m Model) Pause() tea.Cmd {
+	return func() tea.Msg {
+		return PausedMsg{ID: m.id, Paused: true}
+	}
+}
+
+// Resume 从暂停处恢复秒表，同时发送 PausedMsg。
+func (m Model) Resume() tea.Cmd {
+	return func() tea.Msg {
+		return PausedMsg{ID: m.id, Paused: false}
+	}
+}
+
+// Lap 记录一圈，同时发送 LapMsg。
+func (m Model) Lap() tea.Cmd {
+	return func() tea.Msg {
+		return LapMsg{ID: m.id}
+	}
+}
+
+// Splits 返回每次记圈时的累计时间。
+func (m Model) Splits() []time.Duration {
+	return m.splits
+}
+
+// Laps 返回每一圈的用时，即相邻两次记圈之间的时间。
+func (m Model) Laps() []time.Duration {
+	laps := make([]time.Duration, len(m.splits))
+	var prev time.Duration
+	for i, s := range m.splits {
+		laps[i] = s - prev
+		prev = s
+	}
+	return laps
+}
+
 // Running 如果秒表正在运行则返回 true，如果已停止则返回 false。
 func (m Model) Running() bool {
 	return m.running
@@ -112,14 +172,45 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if msg.ID != m.id {
 			return m, nil
 		}
+		if m.shared {
+			m.setRunning(msg.running)
+			break
+		}
 		m.running = msg.running
 	case ResetMsg:
 		if msg.ID != m.id {
 			return m, nil
 		}
 		m.d = 0
+		m.splits = nil
+		m.base, m.since = 0, time.Now()
+	case PausedMsg:
+		if msg.ID != m.id {
+			return m, nil
+		}
+		if m.shared {
+			m.setRunning(!msg.Paused)
+			return m, nil
+		}
+		m.running = !msg.Paused
+		// 增加标签以拒绝暂停前发出的触发，这样恢复后不会重复计时。
+		m.tag++
+		if msg.Paused {
+			return m, nil
+		}
+		return m, tick(m.id, m.tag, m.Interval)
+	case LapMsg:
+		if msg.ID != m.id {
+			return m, nil
+		}
+		if m.shared {
+			m.d = m.elapsedAt(time.Now())
+		}
+		m.splits = append(m.splits[:len(m.splits):len(m.splits)], m.d)
+	case SharedTickMsg:
+		return m.updateShared(msg)
 	case TickMsg:
-		if !m.running || msg.ID != m.id {
+		if !m.running || m.shared || msg.ID != m.id {
 			break
 		}
 
@@ -0,0 +1,175 @@
+package stopwatch
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+const interval = 10 * time.Millisecond
+
+// tickMsg 返回秒表期望收到的下一次触发。
+func tickMsg(m Model) TickMsg {
+	return TickMsg{ID: m.id, tag: m.tag}
+}
+
+// approx 返回 got 与 want 的差是否不超过 tolerance。
+func approx(got, want, tolerance time.Duration) bool {
+	return got >= want-tolerance && got <= want+tolerance
+}
+
+// TestTick 测试秒表接受期望的触发并拒绝过期的或属于其他秒表的触发
+func TestTick(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     func(m Model, stale TickMsg) TickMsg
+		elapsed time.Duration
+		accept  bool
+	}{
+		{"expected tag", func(m Model, _ TickMsg) TickMsg { return tickMsg(m) }, 3 * interval, true},
+		{"stale tag", func(_ Model, stale TickMsg) TickMsg { return stale }, 2 * interval, false},
+		{"other stopwatch", func(m Model, _ TickMsg) TickMsg { return TickMsg{ID: m.id + 1, tag: m.tag} }, 2 * interval, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewWithInterval(interval)
+			m, _ = m.Update(StartStopMsg{ID: m.id, running: true})
+			m, _ = m.Update(tickMsg(m))
+			stale := tickMsg(m)
+			m, _ = m.Update(stale)
+
+			m, cmd := m.Update(tt.msg(m, stale))
+			if m.Elapsed() != tt.elapsed {
+				t.Errorf("elapsed = %v, expected %v", m.Elapsed(), tt.elapsed)
+			}
+			if (cmd != nil) != tt.accept {
+				t.Errorf("scheduled next tick = %v, expected %v", cmd != nil, tt.accept)
+			}
+		})
+	}
+}
+
+// TestPauseResume 测试暂停保留已经过的时间，暂停前发出的触发在恢复后被拒绝
+func TestPauseResume(t *testing.T) {
+	m := NewWithInterval(interval)
+	m, _ = m.Update(StartStopMsg{ID: m.id, running: true})
+	m, _ = m.Update(tickMsg(m))
+	stale := tickMsg(m)
+
+	m, cmd := m.Update(PausedMsg{ID: m.id, Paused: true})
+	if cmd != nil || m.Running() {
+		t.Fatal("expected the stopwatch to pause without scheduling a tick")
+	}
+	if m, _ = m.Update(stale); m.Elapsed() != interval {
+		t.Fatalf("expected ticks to be ignored while paused, got %v", m.Elapsed())
+	}
+
+	m, cmd = m.Update(PausedMsg{ID: m.id, Paused: false})
+	if cmd == nil || !m.Running() {
+		t.Fatal("expected the stopwatch to resume and schedule a tick")
+	}
+	if m, _ = m.Update(stale); m.Elapsed() != interval {
+		t.Fatalf("expected a tick from before the pause to be rejected, got %v", m.Elapsed())
+	}
+
+	m, _ = m.Update(tickMsg(m))
+	if m.Elapsed() != 2*interval {
+		t.Fatalf("elapsed = %v, expected %v", m.Elapsed(), 2*interval)
+	}
+}
+
+// TestLap 测试记圈记录累计时间和每一圈的用时，重置后清除
+func TestLap(t *testing.T) {
+	m := NewWithInterval(interval)
+	m, _ = m.Update(StartStopMsg{ID: m.id, running: true})
+
+	for _, ticks := range []int{2, 3} {
+		for range ticks {
+			m, _ = m.Update(tickMsg(m))
+		}
+		m, _ = m.Update(m.Lap()())
+	}
+	if splits := m.Splits(); len(splits) != 2 || splits[0] != 2*interval || splits[1] != 5*interval {
+		t.Fatalf("expected splits of %v and %v, got %v", 2*interval, 5*interval, splits)
+	}
+	if laps := m.Laps(); len(laps) != 2 || laps[0] != 2*interval || laps[1] != 3*interval {
+		t.Fatalf("expected laps of %v and %v, got %v", 2*interval, 3*interval, laps)
+	}
+
+	// 属于其他秒表的记圈被忽略。
+	if m, _ = m.Update(LapMsg{ID: m.id + 1}); len(m.Splits()) != 2 {
+		t.Fatalf("expected a lap for another stopwatch to be ignored, got %v", m.Splits())
+	}
+
+	m, _ = m.Update(m.Reset()())
+	if len(m.Splits()) != 0 || m.Elapsed() != 0 {
+		t.Fatalf("expected reset to clear the laps, got %v", m.Splits())
+	}
+}
+
+// TestShared 测试共享模式下根据时间戳计算已经过的时间
+func TestShared(t *testing.T) {
+	m := NewShared()
+	if !m.Shared() {
+		t.Fatal("expected a shared stopwatch")
+	}
+
+	// 启动时不调度自己的触发。
+	start := m.Start()
+	if _, ok := start().(StartStopMsg); !ok {
+		t.Fatal("expected Start to only send a StartStopMsg")
+	}
+	m, _ = m.Update(start())
+	since := m.since
+
+	tests := []struct {
+		name    string
+		msg     tea.Msg
+		elapsed time.Duration
+	}{
+		{"shared tick", SharedTickMsg{Time: since.Add(300 * time.Millisecond)}, 300 * time.Millisecond},
+		{"own tick ignored", TickMsg{ID: m.id, tag: m.tag}, 300 * time.Millisecond},
+		{"long suspension", SharedTickMsg{Time: since.Add(time.Hour)}, time.Hour},
+		{"clock before start", SharedTickMsg{Time: since.Add(-time.Second)}, 0},
+	}
+
+	for _, tt := range tests {
+		m, _ = m.Update(tt.msg)
+		if m.Elapsed() != tt.elapsed {
+			t.Fatalf("%s: elapsed = %v, expected %v", tt.name, m.Elapsed(), tt.elapsed)
+		}
+	}
+}
+
+// TestSharedPauseResume 测试共享模式下暂停期间的时间不计入
+func TestSharedPauseResume(t *testing.T) {
+	m := NewShared()
+	m, _ = m.Update(StartStopMsg{ID: m.id, running: true})
+	// 假设秒表已经运行了 2 秒。
+	m.since = m.since.Add(-2 * time.Second)
+
+	m, _ = m.Update(PausedMsg{ID: m.id, Paused: true})
+	paused := m.Elapsed()
+	if m.Running() || !approx(paused, 2*time.Second, 25*time.Millisecond) {
+		t.Fatalf("expected about 2s elapsed when paused, got %v", paused)
+	}
+
+	// 暂停期间的共享触发不改变已经过的时间。
+	if m, _ = m.Update(SharedTickMsg{Time: time.Now().Add(time.Hour)}); m.Elapsed() != paused {
+		t.Fatalf("expected shared ticks to be ignored while paused, got %v", m.Elapsed())
+	}
+
+	m, _ = m.Update(PausedMsg{ID: m.id, Paused: false})
+	m, _ = m.Update(SharedTickMsg{Time: m.since.Add(time.Second)})
+	if m.Elapsed() != paused+time.Second {
+		t.Fatalf("elapsed = %v, expected %v", m.Elapsed(), paused+time.Second)
+	}
+
+	// 记圈记录的时间包含暂停前的时间。
+	m, _ = m.Update(LapMsg{ID: m.id})
+	if splits := m.Splits(); len(splits) != 1 || splits[0] < paused {
+		t.Fatalf("expected a split including the time before the pause, got %v", splits)
+	}
+}
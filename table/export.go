@@ -0,0 +1,71 @@
+package table
+
+import "strings"
+
+// ToRows 返回表格当前显示的数据：按当前顺序排列的所有行，每行只包含未隐藏的列的值。
+// 滚动出视口的行和列也包括在内。单元格的值是原始值，不会被截断。
+func (m Model) ToRows() []Row {
+	cols := m.exportColumns()
+	rows := make([]Row, len(m.rows))
+	for i, row := range m.rows {
+		r := make(Row, len(cols))
+		for j, c := range cols {
+			if c < len(row) {
+				r[j] = row[c]
+			}
+		}
+		rows[i] = r
+	}
+	return rows
+}
+
+// ToCSV 将 ToRows 返回的数据连同列标题一起导出为以 separator 分隔字段的文本，
+// 例如 "," 导出 CSV，"\t" 导出 TSV。如果 separator 为空，使用 ","。
+// 包含分隔符、引号或换行符的字段按照 RFC 4180 加上引号。每行以 "\n" 结尾。
+func (m Model) ToCSV(separator string) string {
+	if separator == "" {
+		separator = ","
+	}
+
+	cols := m.exportColumns()
+	header := make(Row, len(cols))
+	for i, c := range cols {
+		header[i] = m.cols[c].Title
+	}
+
+	var b strings.Builder
+	for _, row := range append([]Row{header}, m.ToRows()...) {
+		for i, field := range row {
+			if i > 0 {
+				b.WriteString(separator)
+			}
+			b.WriteString(quoteField(field, separator))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// exportColumns 返回导出的列的索引：所有未隐藏的列。
+func (m Model) exportColumns() []int {
+	cols := make([]int, 0, len(m.cols))
+	for i := range m.cols {
+		if !m.cols[i].Hidden {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+// quoteField 在字段包含分隔符、引号、换行符或以空白开头时为它加上引号，
+// 并将其中的引号重复一次。
+func quoteField(field, separator string) string {
+	if field == "" {
+		return field
+	}
+	if !strings.Contains(field, separator) && !strings.ContainsAny(field, "\"\r\n") &&
+		field[0] != ' ' && field[0] != '\t' {
+		return field
+	}
+	return `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+}
@@ -0,0 +1,89 @@
+package table
+
+// hasFlexColumns 返回是否有列设置了 FlexFactor。
+func (m Model) hasFlexColumns() bool {
+	for _, col := range m.cols {
+		if col.FlexFactor > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// layoutColumns 按 FlexFactor 将表格宽度中固定宽度的列和单元格边距之外的剩余宽度
+// 分配给弹性列，并遵守各列的 MinWidth 和 MaxWidth。隐藏的列不参与分配。
+// 如果表格宽度为 0 或没有弹性列，则不做任何事。
+func (m *Model) layoutColumns() {
+	if m.viewport.Width <= 0 || !m.hasFlexColumns() {
+		return
+	}
+
+	frame := max(m.styles.Cell.GetHorizontalFrameSize(), m.styles.Header.GetHorizontalFrameSize())
+	avail := m.viewport.Width
+	var flex []int
+	for i, col := range m.cols {
+		if col.Hidden {
+			continue
+		}
+		avail -= frame
+		if col.FlexFactor > 0 {
+			flex = append(flex, i)
+		} else {
+			avail -= max(col.Width, 0)
+		}
+	}
+
+	widths := make(map[int]int, len(flex))
+	// 受 MinWidth 或 MaxWidth 限制的列固定下来后，重新分配其余的宽度，
+	// 直到所有弹性列都在限制之内。
+	for len(flex) > 0 {
+		total := 0
+		for _, i := range flex {
+			total += m.cols[i].FlexFactor
+		}
+
+		left := max(avail, 0)
+		var clamped, free []int
+		for _, i := range flex {
+			col := m.cols[i]
+			w := max(avail, 0) * col.FlexFactor / total
+			left -= w
+			switch {
+			case w < max(col.MinWidth, 1):
+				widths[i] = max(col.MinWidth, 1)
+				clamped = append(clamped, i)
+			case col.MaxWidth > 0 && w > col.MaxWidth:
+				widths[i] = col.MaxWidth
+				clamped = append(clamped, i)
+			default:
+				widths[i] = w
+				free = append(free, i)
+			}
+		}
+		if len(clamped) == 0 {
+			// 舍入剩下的宽度从前往后逐列分配。
+			for k := 0; left > 0; k = (k + 1) % len(free) {
+				widths[free[k]]++
+				left--
+			}
+			break
+		}
+		for _, i := range clamped {
+			avail -= widths[i]
+		}
+		flex = free
+	}
+
+	changed := false
+	for i, w := range widths {
+		changed = changed || m.cols[i].Width != w
+	}
+	if !changed {
+		return
+	}
+	// 列可能与调用者共享，因此先复制再修改。
+	m.cols = append([]Column(nil), m.cols...)
+	for i, w := range widths {
+		m.cols[i].Width = w
+	}
+}
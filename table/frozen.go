@@ -0,0 +1,126 @@
+package table
+
+// WithFrozenColumns 设置冻结的列数，参见 SetFrozenColumns。
+func WithFrozenColumns(n int) Option {
+	return func(m *Model) {
+		m.frozen = max(n, 0)
+	}
+}
+
+// SetFrozenColumns 冻结前 n 列。表格按列水平滚动（参见 ScrollLeft 和
+// ScrollRight）时，冻结的列始终显示在左侧，类似电子表格的行标题，
+// 其余的列在它们之后滚动。
+func (m *Model) SetFrozenColumns(n int) {
+	m.frozen = max(n, 0)
+	m.SetColumnOffset(m.colOffset)
+}
+
+// FrozenColumns 返回冻结的列数。
+func (m Model) FrozenColumns() int {
+	return m.frozen
+}
+
+// ColumnOffset 返回因水平滚动而隐藏的非冻结列的数量。
+func (m Model) ColumnOffset() int {
+	return m.colOffset
+}
+
+// SetColumnOffset 水平滚动表格，隐藏冻结列之后的 n 列。当剩余的列已经
+// 能完全显示在表格宽度内时，不再继续滚动。
+func (m *Model) SetColumnOffset(n int) {
+	m.colOffset = clamp(n, 0, m.maxColumnOffset())
+	m.UpdateViewport()
+}
+
+// ScrollLeft 向左滚动一列。
+func (m *Model) ScrollLeft() {
+	m.SetColumnOffset(m.colOffset - 1)
+}
+
+// ScrollRight 向右滚动一列。
+func (m *Model) ScrollRight() {
+	m.SetColumnOffset(m.colOffset + 1)
+}
+
+// maxColumnOffset 返回水平滚动的最大列数。如果没有设置宽度，
+// 可以一直滚动到只显示最后一列。
+func (m Model) maxColumnOffset() int {
+	frozen := min(m.frozen, len(m.cols))
+	last := max(0, len(m.cols)-frozen-1)
+	if m.viewport.Width <= 0 {
+		return last
+	}
+
+	avail := m.viewport.Width
+	for _, col := range m.cols[:frozen] {
+		avail -= m.columnWidth(col)
+	}
+	for off := 0; off < last; off++ {
+		w := 0
+		for _, col := range m.cols[frozen+off:] {
+			w += m.columnWidth(col)
+		}
+		if w <= avail {
+			return off
+		}
+	}
+	return last
+}
+
+// columnWidth 返回列渲染后的宽度，包括单元格样式的边距。隐藏的列宽度为 0。
+func (m Model) columnWidth(col Column) int {
+	if col.Width <= 0 || col.Hidden {
+		return 0
+	}
+	return col.Width + m.styles.Cell.GetHorizontalFrameSize()
+}
+
+// scrollToColumn 水平滚动，使第 i 列可见。
+func (m *Model) scrollToColumn(i int) {
+	switch {
+	case i < m.frozen:
+	case i-m.frozen < m.colOffset:
+		m.colOffset = i - m.frozen
+	default:
+		for m.colOffset < m.maxColumnOffset() && !m.columnVisible(i) {
+			m.colOffset++
+		}
+	}
+	m.UpdateViewport()
+}
+
+// columnVisible 返回第 i 列是否完全显示在表格宽度内。
+func (m Model) columnVisible(i int) bool {
+	if m.viewport.Width <= 0 {
+		return true
+	}
+	w := 0
+	for _, c := range m.visibleColumns() {
+		w += m.columnWidth(m.cols[c])
+		if c == i {
+			return w <= m.viewport.Width
+		}
+	}
+	return false
+}
+
+// visibleColumns 返回要渲染的列的索引：冻结的列，以及水平滚动后剩余的列，不包括隐藏的列。
+func (m Model) visibleColumns() []int {
+	frozen := min(m.frozen, len(m.cols))
+	cols := make([]int, 0, len(m.cols))
+	for i := range frozen {
+		cols = append(cols, i)
+	}
+	for i := frozen + m.colOffset; i < len(m.cols); i++ {
+		cols = append(cols, i)
+	}
+
+	// 跳过隐藏的列。
+	visible := cols[:0]
+	for _, i := range cols {
+		if !m.cols[i].Hidden {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
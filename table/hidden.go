@@ -0,0 +1,47 @@
+package table
+
+// WithHiddenColumns 隐藏给定索引的列（参见 SetColumnVisible）。它必须在 WithColumns 之后传入。
+func WithHiddenColumns(indices ...int) Option {
+	return func(m *Model) {
+		for _, i := range indices {
+			m.setColumnHidden(i, true)
+		}
+	}
+}
+
+// SetColumnVisible 显示或隐藏第 i 列。隐藏的列不渲染，但行中的值保持不变，
+// 因此行和列的索引不受影响。适用于在较窄的终端中隐藏次要的列。
+func (m *Model) SetColumnVisible(i int, visible bool) {
+	if m.setColumnHidden(i, !visible) {
+		m.SetColumnOffset(m.colOffset)
+	}
+}
+
+// ColumnVisible 返回第 i 列是否可见。
+func (m Model) ColumnVisible(i int) bool {
+	return i >= 0 && i < len(m.cols) && !m.cols[i].Hidden
+}
+
+// setColumnHidden 设置第 i 列的 Hidden 字段。返回该列是否发生了变化。
+func (m *Model) setColumnHidden(i int, hidden bool) bool {
+	if i < 0 || i >= len(m.cols) || m.cols[i].Hidden == hidden {
+		return false
+	}
+	// 列可能与调用者共享，因此先复制再修改。
+	m.cols = append([]Column(nil), m.cols...)
+	m.cols[i].Hidden = hidden
+	return true
+}
+
+// nextVisibleColumn 返回从第 i 列开始沿 step 方向（循环）的第一个可见的列。
+// 如果所有列都被隐藏，返回 i。
+func (m Model) nextVisibleColumn(i, step int) int {
+	n := len(m.cols)
+	for range n {
+		if m.ColumnVisible(i) {
+			return i
+		}
+		i = ((i+step)%n + n) % n
+	}
+	return i
+}
@@ -0,0 +1,162 @@
+package table
+
+import (
+	"strings"
+)
+
+// RowKeyFunc 返回标识一行的键。标记的行以键记录，因此行被重新排序或替换
+// （参见 SetRows）后，标记仍然跟随同一行。
+type RowKeyFunc func(Row) string
+
+// defaultRowKey 以行中所有单元格的值作为键。
+func defaultRowKey(r Row) string {
+	return strings.Join(r, "\x00")
+}
+
+// WithMultiSelect 启用标记多行，参见 SetMultiSelect。
+func WithMultiSelect(on bool) Option {
+	return func(m *Model) {
+		m.SetMultiSelect(on)
+	}
+}
+
+// WithRowKey 设置标识行的函数（参见 RowKeyFunc），例如返回 ID 列的值。
+// 默认以行中所有单元格的值作为键，因此内容相同的行被视为同一行。
+func WithRowKey(f RowKeyFunc) Option {
+	return func(m *Model) {
+		m.rowKey = f
+	}
+}
+
+// SetMultiSelect 启用或禁用标记多行。启用后，ToggleMark 键（默认为空格，
+// 优先于 PageDown）标记或取消标记光标所在的行，SelectUp 和 SelectDown 键
+// （默认为 shift+↑ 和 shift+↓）移动光标并标记从开始移动的行到光标之间的所有行。
+// 标记的行以 Styles.Marked 渲染，可以通过 SelectedRows 读取，用于批量操作。
+// 禁用时清除所有标记。
+func (m *Model) SetMultiSelect(on bool) {
+	m.multiSelect = on
+	m.KeyMap.ToggleMark.SetEnabled(on)
+	m.KeyMap.SelectUp.SetEnabled(on)
+	m.KeyMap.SelectDown.SetEnabled(on)
+	if !on {
+		m.ClearMarks()
+	}
+}
+
+// MultiSelect 返回是否启用了标记多行。
+func (m Model) MultiSelect() bool {
+	return m.multiSelect
+}
+
+// SelectedRows 返回标记的行的索引，从小到大排列。
+func (m Model) SelectedRows() []int {
+	var rows []int
+	for i := range m.rows {
+		if m.Marked(i) {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+// Marked 返回第 i 行是否被标记。
+func (m Model) Marked(i int) bool {
+	if len(m.marks) == 0 || i < 0 || i >= len(m.rows) {
+		return false
+	}
+	_, ok := m.marks[m.key(i)]
+	return ok
+}
+
+// SetMarked 标记或取消标记第 i 行。
+func (m *Model) SetMarked(i int, marked bool) {
+	m.setMarked(i, marked)
+	m.selecting = false
+	m.UpdateViewport()
+}
+
+// ToggleMark 切换第 i 行的标记。
+func (m *Model) ToggleMark(i int) {
+	m.SetMarked(i, !m.Marked(i))
+}
+
+// ClearMarks 取消标记所有的行。
+func (m *Model) ClearMarks() {
+	if len(m.marks) == 0 {
+		return
+	}
+	m.marks = nil
+	m.selecting = false
+	m.UpdateViewport()
+}
+
+// key 返回第 i 行的键。
+func (m Model) key(i int) string {
+	if m.rowKey != nil {
+		return m.rowKey(m.rows[i])
+	}
+	return defaultRowKey(m.rows[i])
+}
+
+// setMarked 标记或取消标记第 i 行，不重新渲染。
+func (m *Model) setMarked(i int, marked bool) {
+	if i < 0 || i >= len(m.rows) {
+		return
+	}
+	k := m.key(i)
+	if !marked {
+		delete(m.marks, k)
+		return
+	}
+	if m.marks == nil {
+		m.marks = make(map[string]struct{})
+	}
+	m.marks[k] = struct{}{}
+}
+
+// pruneMarks 移除不再对应任何行的标记。
+func (m *Model) pruneMarks() {
+	if len(m.marks) == 0 {
+		return
+	}
+	keys := make(map[string]struct{}, len(m.rows))
+	for i := range m.rows {
+		keys[m.key(i)] = struct{}{}
+	}
+	for k := range m.marks {
+		if _, ok := keys[k]; !ok {
+			delete(m.marks, k)
+		}
+	}
+}
+
+// extendSelection 将光标移动 n 行，并标记从开始移动的行到光标之间的所有行。
+// 连续移动时，范围随光标伸缩，开始移动之前的标记保持不变。
+func (m *Model) extendSelection(n int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	if !m.selecting {
+		m.selecting = true
+		m.anchor = m.cursor
+		m.baseMarks = make(map[string]struct{}, len(m.marks))
+		for k := range m.marks {
+			m.baseMarks[k] = struct{}{}
+		}
+	}
+
+	target := clamp(m.cursor+n, 0, len(m.rows)-1)
+	m.marks = make(map[string]struct{}, len(m.baseMarks))
+	for k := range m.baseMarks {
+		m.marks[k] = struct{}{}
+	}
+	for i := min(m.anchor, target); i <= max(m.anchor, target); i++ {
+		m.setMarked(i, true)
+	}
+
+	if n < 0 {
+		m.MoveUp(-n)
+	} else {
+		m.MoveDown(n)
+	}
+}
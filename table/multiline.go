@@ -0,0 +1,105 @@
+package table
+
+import (
+	"math"
+	"strings"
+
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// hasMultiline 返回是否有单元格的值包含换行符。
+func hasMultiline(rows []Row) bool {
+	for _, row := range rows {
+		for _, value := range row {
+			if strings.Contains(value, "\n") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cellLines 渲染单元格的每一行，每行都截断并填充到列宽。
+func (m Model) cellLines(r, c int, value string, styled bool, rowStyle lipgloss.Style) []string {
+	col := m.cols[c]
+	style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
+	var content lipgloss.Style
+	if styled {
+		content = m.contentStyle(r, c, value, rowStyle)
+	}
+
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		lines[i] = style.Render(truncate(line, col.Width, col.Truncate))
+		if styled {
+			lines[i] = content.Render(lines[i])
+		}
+	}
+	return lines
+}
+
+// alignCell 按照列的垂直对齐方式，用空行将单元格填充到 height 行。
+func (m Model) alignCell(lines []string, c, height int) string {
+	if gap := height - len(lines); gap > 0 {
+		blank := strings.Repeat(" ", m.cols[c].Width)
+		top := int(math.Round(float64(gap) * clampPosition(m.cols[c].VAlign)))
+		padded := make([]string, 0, height)
+		for range top {
+			padded = append(padded, blank)
+		}
+		padded = append(padded, lines...)
+		for len(padded) < height {
+			padded = append(padded, blank)
+		}
+		lines = padded
+	}
+	return strings.Join(lines, "\n")
+}
+
+// clampPosition 将位置限制在 lipgloss.Top 和 lipgloss.Bottom 之间。
+func clampPosition(p lipgloss.Position) float64 {
+	return math.Min(math.Max(float64(p), float64(lipgloss.Top)), float64(lipgloss.Bottom))
+}
+
+// updateMultilineViewport 是有多行单元格时 UpdateViewport 的实现。行的高度不同，
+// 因此按行而不是按行高滚动：start 是视口中显示的第一行，只在光标移出视口时改变，
+// 使光标所在的行总是完整显示（除非它比视口还高，此时显示它的开头）。
+func (m *Model) updateMultilineViewport() {
+	if len(m.rows) == 0 {
+		m.start, m.end = 0, 0
+		m.viewport.SetContent("")
+		return
+	}
+
+	cursor := clamp(m.cursor, 0, len(m.rows)-1)
+	rendered := make(map[int]string)
+	render := func(i int) string {
+		if s, ok := rendered[i]; ok {
+			return s
+		}
+		rendered[i] = m.renderRow(i)
+		return rendered[i]
+	}
+
+	m.start = clamp(m.start, 0, cursor)
+	lines := 0
+	for i := cursor; i >= m.start; i-- {
+		lines += lipgloss.Height(render(i))
+		if lines > m.viewport.Height && i < cursor {
+			m.start = i + 1
+			break
+		}
+	}
+
+	renderedRows := make([]string, 0, m.viewport.Height)
+	lines = 0
+	m.end = m.start
+	for ; m.end < len(m.rows) && (m.end <= cursor || lines < m.viewport.Height); m.end++ {
+		row := render(m.end)
+		renderedRows = append(renderedRows, row)
+		lines += lipgloss.Height(row)
+	}
+
+	m.viewport.SetContent(strings.Join(renderedRows, "\n"))
+	m.viewport.SetYOffset(0)
+}
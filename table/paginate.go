@@ -0,0 +1,58 @@
+package table
+
+import "strings"
+
+// WithPagination 启用分页模式（参见 SetPagination）。
+func WithPagination(perPage int) Option {
+	return func(m *Model) {
+		m.SetPagination(perPage)
+	}
+}
+
+// SetPagination 以每页 perPage 行的离散页面显示表格，而不是滚动的视口。
+// PageUp 和 PageDown 翻到上一页和下一页，表格下方显示分页器（参见 Paginator）。
+// 光标和 SelectedRow 始终对应所有行中的位置，与页面无关。
+// 如果 perPage 为 0 或更小，则恢复为滚动模式。
+func (m *Model) SetPagination(perPage int) {
+	m.perPage = max(0, perPage)
+	m.UpdateViewport()
+}
+
+// Paginated 返回表格是否处于分页模式。
+func (m Model) Paginated() bool {
+	return m.perPage > 0
+}
+
+// pageSize 返回 PageUp 和 PageDown 移动的行数：分页模式下是一页的行数，
+// 否则是视口的高度。
+func (m Model) pageSize() int {
+	if m.perPage > 0 {
+		return m.perPage
+	}
+	return m.viewport.Height
+}
+
+// updatePage 使分页器显示光标所在的页面。
+func (m *Model) updatePage() {
+	m.Paginator.PerPage = m.perPage
+	if len(m.rows) == 0 {
+		m.Paginator.TotalPages = 1
+	} else {
+		m.Paginator.SetTotalPages(len(m.rows))
+	}
+	m.Paginator.Page = clamp(m.cursor/m.perPage, 0, m.Paginator.TotalPages-1)
+}
+
+// pageView 渲染当前页的行和分页器。不足一页时用空行填充，使分页器的位置保持不变。
+func (m Model) pageView() string {
+	start, end := m.Paginator.GetSliceBounds(len(m.rows))
+	rows := make([]string, 0, m.perPage+1)
+	for i := start; i < end; i++ {
+		rows = append(rows, m.renderRow(i))
+	}
+	for len(rows) < m.perPage {
+		rows = append(rows, "")
+	}
+	rows = append(rows, m.Paginator.View())
+	return strings.Join(rows, "\n")
+}
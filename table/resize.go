@@ -0,0 +1,138 @@
+package table
+
+import (
+	tea "github.com/purpose168/bubbletea-cn"
+
+	"github.com/purpose168/bubbles-cn/key"
+)
+
+// ResizedMsg 在用户交互式地调整列宽后发送，应用程序可以用它保存列宽。
+type ResizedMsg struct {
+	Column int   // 被调整的列的索引
+	Width  int   // 该列的新宽度
+	Widths []int // 调整后所有列的宽度
+}
+
+// WithResizable 启用交互式调整列宽，参见 SetResizable。
+func WithResizable(on bool) Option {
+	return func(m *Model) {
+		m.SetResizable(on)
+	}
+}
+
+// SetResizable 启用或禁用交互式调整列宽。启用后，Resize 键（默认为 w）进入
+// 调整列宽模式，参见 StartResize。禁用时退出调整列宽模式。
+func (m *Model) SetResizable(on bool) {
+	m.resizable = on
+	m.KeyMap.Resize.SetEnabled(on)
+	m.KeyMap.ResizeShrink.SetEnabled(on)
+	m.KeyMap.ResizeGrow.SetEnabled(on)
+	m.KeyMap.ResizeNext.SetEnabled(on)
+	m.KeyMap.ResizePrev.SetEnabled(on)
+	if !on && m.resizing {
+		m.StopResize()
+	}
+}
+
+// Resizable 返回是否启用了交互式调整列宽。
+func (m Model) Resizable() bool {
+	return m.resizable
+}
+
+// StartResize 进入调整列宽模式。在该模式下，ResizeShrink 和 ResizeGrow
+// 调整聚焦列的宽度，ResizeNext 和 ResizePrev 切换聚焦的列，
+// 再次按下 Resize 或按下 esc 退出；其他按键（例如上下移动）照常处理。
+// 聚焦列的表头以选中样式渲染。如果没有启用 SetResizable，则不做任何事。
+func (m *Model) StartResize() {
+	if !m.resizable || len(m.cols) == 0 {
+		return
+	}
+	m.resizing = true
+	m.resizeCol = m.nextVisibleColumn(clamp(m.resizeCol, 0, len(m.cols)-1), 1)
+	m.UpdateViewport()
+}
+
+// StopResize 退出调整列宽模式。
+func (m *Model) StopResize() {
+	m.resizing = false
+	m.UpdateViewport()
+}
+
+// Resizing 返回表格是否处于调整列宽模式。
+func (m Model) Resizing() bool {
+	return m.resizing
+}
+
+// ResizeColumn 返回调整列宽模式下聚焦的列的索引。
+func (m Model) ResizeColumn() int {
+	return m.resizeCol
+}
+
+// SetColumnWidth 将第 i 列的宽度设置为 w，并限制在该列的 MinWidth 和 MaxWidth
+// 之间。弹性列（参见 Column.FlexFactor）会变为固定宽度的列，否则下一次布局会覆盖
+// 设置的宽度，其余的弹性列重新分配剩余的宽度。返回该列是否发生了变化。
+func (m *Model) SetColumnWidth(i, w int) bool {
+	if i < 0 || i >= len(m.cols) {
+		return false
+	}
+	col := m.cols[i]
+	w = max(w, max(col.MinWidth, 1))
+	if col.MaxWidth > 0 {
+		w = min(w, col.MaxWidth)
+	}
+	if w == col.Width && col.FlexFactor == 0 {
+		return false
+	}
+
+	// 列可能与调用者共享，因此先复制再修改。
+	m.cols = append([]Column(nil), m.cols...)
+	m.cols[i].Width = w
+	m.cols[i].FlexFactor = 0
+	m.UpdateViewport()
+	return true
+}
+
+// updateResize 处理调整列宽模式下的按键，并返回按键是否被处理。
+// 未被处理的按键交给常规的按键处理。
+func (m *Model) updateResize(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// 列可能在调整期间被替换。
+	if len(m.cols) == 0 {
+		m.StopResize()
+		return nil, false
+	}
+	m.resizeCol = clamp(m.resizeCol, 0, len(m.cols)-1)
+
+	switch {
+	case key.Matches(msg, m.KeyMap.Resize), msg.Type == tea.KeyEsc:
+		m.StopResize()
+	case key.Matches(msg, m.KeyMap.ResizeNext):
+		m.resizeCol = m.nextVisibleColumn((m.resizeCol+1)%len(m.cols), 1)
+		m.scrollToColumn(m.resizeCol)
+	case key.Matches(msg, m.KeyMap.ResizePrev):
+		m.resizeCol = m.nextVisibleColumn((m.resizeCol+len(m.cols)-1)%len(m.cols), -1)
+		m.scrollToColumn(m.resizeCol)
+	case key.Matches(msg, m.KeyMap.ResizeShrink):
+		return m.resize(-1), true
+	case key.Matches(msg, m.KeyMap.ResizeGrow):
+		return m.resize(1), true
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// resize 将聚焦列的宽度改变 delta，如果宽度发生了变化则返回发送 ResizedMsg 的命令。
+func (m *Model) resize(delta int) tea.Cmd {
+	i := m.resizeCol
+	if !m.SetColumnWidth(i, m.cols[i].Width+delta) {
+		return nil
+	}
+	widths := make([]int, len(m.cols))
+	for j, col := range m.cols {
+		widths[j] = col.Width
+	}
+	msg := ResizedMsg{Column: i, Width: m.cols[i].Width, Widths: widths}
+	return func() tea.Msg {
+		return msg
+	}
+}
@@ -0,0 +1,192 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/runeutil"
+)
+
+// ErrNotStruct 表示传给 FromStructs 的元素类型不是结构体或结构体指针。
+var ErrNotStruct = errors.New("table: element type is not a struct")
+
+// structField 描述从结构体字段生成的一列。
+type structField struct {
+	index  []int
+	column Column
+	format string
+}
+
+// FromStructs 根据结构体切片生成表格的列和行，省去手动将领域类型映射为 []Row 的代码。
+//
+// 每个导出字段（包括嵌入结构体中提升的字段）生成一列，按字段声明的顺序排列。
+// 如果给出了 fields，则只生成这些字段（按 Go 字段名）的列，并按给出的顺序排列。
+// 列可以通过 table 结构体标签配置，逗号后的选项为格式化提示：
+//
+//	type Pod struct {
+//		Name     string  `table:"名称,width=20,truncate=middle"`
+//		CPU      float64 `table:"CPU,format=%.1f%%"`
+//		Internal string  `table:"-"` // 不生成列
+//	}
+//
+// 可用的选项有 width（列宽，默认为标题和内容中最宽者）、min 和 max（参见
+// Column.MinWidth 和 Column.MaxWidth）、format（fmt 格式，默认使用 fmt.Sprint）
+// 和 truncate（end、start 或 middle）。值为 nil 指针的行和字段生成空单元格。
+func FromStructs[T any](rows []T, fields ...string) ([]Column, []Row, error) {
+	typ := reflect.TypeFor[T]()
+	if !isStruct(typ) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNotStruct, typ)
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	sfs, err := structFields(typ, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cols := make([]Column, len(sfs))
+	widths := make([]int, len(sfs))
+	for i, sf := range sfs {
+		cols[i] = sf.column
+		widths[i] = runeutil.StringWidth(sf.column.Title)
+	}
+
+	out := make([]Row, len(rows))
+	for r, row := range rows {
+		v := reflect.Indirect(reflect.ValueOf(&row).Elem())
+		out[r] = make(Row, len(sfs))
+		for i, sf := range sfs {
+			cell := ""
+			if v.IsValid() {
+				cell = sf.cell(v)
+			}
+			out[r][i] = cell
+			widths[i] = max(widths[i], runeutil.StringWidth(cell))
+		}
+	}
+
+	for i := range cols {
+		if cols[i].Width == 0 {
+			cols[i].Width = widths[i]
+		}
+	}
+	return cols, out, nil
+}
+
+// structFields 返回结构体类型中生成列的字段。
+func structFields(typ reflect.Type, names []string) ([]structField, error) {
+	var all []structField
+	byName := make(map[string]structField)
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous && isStruct(f.Type) {
+			continue
+		}
+		tag := f.Tag.Get("table")
+		if tag == "-" {
+			continue
+		}
+		sf, err := parseStructTag(f, tag)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, sf)
+		byName[f.Name] = sf
+	}
+	if len(names) == 0 {
+		return all, nil
+	}
+
+	sfs := make([]structField, len(names))
+	for i, name := range names {
+		sf, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("table: unknown field %q in %s", name, typ)
+		}
+		sfs[i] = sf
+	}
+	return sfs, nil
+}
+
+// isStruct 返回 t 是否为结构体或结构体指针。
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// parseStructTag 解析字段的 table 结构体标签。
+func parseStructTag(f reflect.StructField, tag string) (structField, error) {
+	sf := structField{index: f.Index, column: Column{Title: f.Name}}
+	title, opts, _ := strings.Cut(tag, ",")
+	if title != "" {
+		sf.column.Title = title
+	}
+	if opts == "" {
+		return sf, nil
+	}
+
+	// format 可能包含逗号，因此它之后的所有内容都属于它。
+	for opts != "" {
+		var opt string
+		if strings.HasPrefix(opts, "format=") {
+			opt, opts = opts, ""
+		} else {
+			opt, opts, _ = strings.Cut(opts, ",")
+		}
+
+		k, v, _ := strings.Cut(opt, "=")
+		var err error
+		switch k {
+		case "width":
+			sf.column.Width, err = strconv.Atoi(v)
+		case "min":
+			sf.column.MinWidth, err = strconv.Atoi(v)
+		case "max":
+			sf.column.MaxWidth, err = strconv.Atoi(v)
+		case "format":
+			sf.format = v
+		case "truncate":
+			switch v {
+			case "end":
+				sf.column.Truncate = TruncateEnd
+			case "start":
+				sf.column.Truncate = TruncateStart
+			case "middle":
+				sf.column.Truncate = TruncateMiddle
+			default:
+				err = errors.New("unknown truncation")
+			}
+		default:
+			err = errors.New("unknown option")
+		}
+		if err != nil {
+			return sf, fmt.Errorf("table: field %s: option %q: %w", f.Name, opt, err)
+		}
+	}
+	return sf, nil
+}
+
+// cell 格式化结构体值 v 中该字段的值。
+func (sf structField) cell(v reflect.Value) string {
+	fv, err := v.FieldByIndexErr(sf.index)
+	if err != nil {
+		// 字段位于值为 nil 的嵌入结构体指针中。
+		return ""
+	}
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return ""
+		}
+		fv = fv.Elem()
+	}
+	if sf.format != "" {
+		return fmt.Sprintf(sf.format, fv.Interface())
+	}
+	return fmt.Sprint(fv.Interface())
+}
@@ -4,12 +4,12 @@ package table
 import (
 	"strings"
 
-	"github.com/mattn/go-runewidth"
 	tea "github.com/purpose168/bubbletea-cn"
 	lipgloss "github.com/purpose168/lipgloss-cn"
 
 	"github.com/purpose168/bubbles-cn/help"
 	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/paginator"
 	"github.com/purpose168/bubbles-cn/viewport"
 )
 
@@ -18,6 +18,10 @@ type Model struct {
 	KeyMap KeyMap     // 键位映射
 	Help   help.Model // 帮助模型
 
+	// Paginator 是分页模式（参见 SetPagination）下显示在表格下方的分页器。
+	// 可以通过它配置分页器的显示方式。
+	Paginator paginator.Model
+
 	cols   []Column // 列定义
 	rows   []Row    // 行数据
 	cursor int      // 光标位置
@@ -27,6 +31,39 @@ type Model struct {
 	viewport viewport.Model // 视口
 	start    int            // 起始行
 	end      int            // 结束行
+	perPage  int            // 分页模式下每页的行数，0 表示滚动模式
+
+	multiline bool // 是否有包含换行符的单元格，此时行的高度可能不同
+
+	frozen    int // 冻结的列数
+	colOffset int // 水平滚动隐藏的非冻结列数
+
+	resizable bool // 是否启用交互式调整列宽
+	resizing  bool // 是否处于调整列宽模式
+	resizeCol int  // 调整列宽模式下聚焦的列
+
+	cellStyleFunc CellStyleFunc // 单元格样式回调
+	rowStyleFunc  RowStyleFunc  // 行样式回调
+
+	multiSelect bool                // 是否启用标记多行
+	marks       map[string]struct{} // 标记的行的键
+	rowKey      RowKeyFunc          // 标识行的函数，为 nil 时使用 defaultRowKey
+	selecting   bool                // 是否正在用 SelectUp 和 SelectDown 选择范围
+	anchor      int                 // 开始选择范围时光标所在的行
+	baseMarks   map[string]struct{} // 开始选择范围之前的标记
+}
+
+// CellStyleFunc 根据单元格的位置和值返回其样式。返回的样式作用于单元格内容，
+// 优先于行样式，选中行的样式仍会叠加在其上。
+type CellStyleFunc func(rowIdx, colIdx int, value string) lipgloss.Style
+
+// RowStyleFunc 根据行的位置和数据返回其样式。选中行的样式仍会叠加在其上。
+type RowStyleFunc func(rowIdx int, row Row) lipgloss.Style
+
+// RowActivatedMsg 在用户激活（默认按下 enter）选中的行时发送。
+type RowActivatedMsg struct {
+	Index int // 行索引
+	Row   Row // 行数据
 }
 
 // Row 表示表格中的一行。
@@ -34,8 +71,27 @@ type Row []string
 
 // Column 定义表格结构。
 type Column struct {
-	Title string // 列标题
-	Width int    // 列宽度
+	Title    string     // 列标题
+	Width    int        // 列宽度
+	Truncate Truncation // 内容超出列宽时的截断策略
+
+	// MinWidth 和 MaxWidth 限制交互式调整宽度（参见 StartResize）时的列宽。
+	// MinWidth 为 0 时最小宽度为 1，MaxWidth 为 0 时不限制最大宽度。
+	MinWidth int
+	MaxWidth int
+
+	// FlexFactor 大于 0 时，该列的宽度由表格自动计算：固定宽度的列和单元格边距之外的
+	// 剩余宽度按 FlexFactor 的比例分配给这些列，并限制在 MinWidth 和 MaxWidth 之间。
+	// 表格宽度变化时（SetWidth 或 tea.WindowSizeMsg）重新分配，Width 字段被忽略。
+	// 用 SetColumnWidth 设置宽度（包括交互式调整列宽）会将该列变为固定宽度的列。
+	FlexFactor int
+
+	// Hidden 隐藏该列，参见 SetColumnVisible。
+	Hidden bool
+
+	// VAlign 是单元格在比它高的行中的垂直对齐方式，从 lipgloss.Top（默认）
+	// 到 lipgloss.Bottom。只有值包含换行符的多行单元格才会使行变高。
+	VAlign lipgloss.Position
 }
 
 // KeyMap 定义键绑定。它满足 help.KeyMap 接口，
@@ -49,6 +105,21 @@ type KeyMap struct {
 	HalfPageDown key.Binding // 向下翻半页
 	GotoTop      key.Binding // 跳转到顶部
 	GotoBottom   key.Binding // 跳转到底部
+	Activate     key.Binding // 激活选中的行
+	ScrollLeft   key.Binding // 向左滚动一列
+	ScrollRight  key.Binding // 向右滚动一列
+
+	// 调整列宽模式的键绑定，默认禁用，参见 SetResizable。
+	Resize       key.Binding // 进入或退出调整列宽模式
+	ResizeShrink key.Binding // 减小聚焦列的宽度
+	ResizeGrow   key.Binding // 增大聚焦列的宽度
+	ResizeNext   key.Binding // 聚焦下一列
+	ResizePrev   key.Binding // 聚焦上一列
+
+	// 标记多行的键绑定，参见 SetMultiSelect。
+	ToggleMark key.Binding // 标记或取消标记光标所在的行
+	SelectUp   key.Binding // 向上移动并扩展选择范围
+	SelectDown key.Binding // 向下移动并扩展选择范围
 }
 
 // ShortHelp 实现 KeyMap 接口。
@@ -61,6 +132,10 @@ func (km KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{km.LineUp, km.LineDown, km.GotoTop, km.GotoBottom},
 		{km.PageUp, km.PageDown, km.HalfPageUp, km.HalfPageDown},
+		{km.ScrollLeft, km.ScrollRight},
+		{km.Activate},
+		{km.Resize, km.ResizeShrink, km.ResizeGrow, km.ResizeNext, km.ResizePrev},
+		{km.ToggleMark, km.SelectUp, km.SelectDown},
 	}
 }
 
@@ -100,6 +175,58 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("end", "G"),
 			key.WithHelp("G/end", "go to end"),
 		),
+		Activate: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "activate"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "scroll right"),
+		),
+		Resize: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "resize columns"),
+			key.WithDisabled(),
+		),
+		ResizeShrink: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "narrower"),
+			key.WithDisabled(),
+		),
+		ResizeGrow: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "wider"),
+			key.WithDisabled(),
+		),
+		ResizeNext: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next column"),
+			key.WithDisabled(),
+		),
+		ResizePrev: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "prev column"),
+			key.WithDisabled(),
+		),
+		ToggleMark: key.NewBinding(
+			key.WithKeys(spacebar),
+			key.WithHelp("space", "mark row"),
+			key.WithDisabled(),
+		),
+		SelectUp: key.NewBinding(
+			key.WithKeys("shift+up", "K"),
+			key.WithHelp("shift+↑/K", "select up"),
+			key.WithDisabled(),
+		),
+		SelectDown: key.NewBinding(
+			key.WithKeys("shift+down", "J"),
+			key.WithHelp("shift+↓/J", "select down"),
+			key.WithDisabled(),
+		),
 	}
 }
 
@@ -109,6 +236,7 @@ type Styles struct {
 	Header   lipgloss.Style // 表头样式
 	Cell     lipgloss.Style // 单元格样式
 	Selected lipgloss.Style // 选中样式
+	Marked   lipgloss.Style // 标记的行的样式，参见 SetMultiSelect
 }
 
 // DefaultStyles 返回此表格的默认样式定义集合。
@@ -117,6 +245,7 @@ func DefaultStyles() Styles {
 		Selected: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")),
 		Header:   lipgloss.NewStyle().Bold(true).Padding(0, 1),
 		Cell:     lipgloss.NewStyle().Padding(0, 1),
+		Marked:   lipgloss.NewStyle().Foreground(lipgloss.Color("141")),
 	}
 }
 
@@ -126,6 +255,18 @@ func (m *Model) SetStyles(s Styles) {
 	m.UpdateViewport()
 }
 
+// SetCellStyleFunc 设置单元格样式回调。传入 nil 以移除回调。
+func (m *Model) SetCellStyleFunc(f CellStyleFunc) {
+	m.cellStyleFunc = f
+	m.UpdateViewport()
+}
+
+// SetRowStyleFunc 设置行样式回调。传入 nil 以移除回调。
+func (m *Model) SetRowStyleFunc(f RowStyleFunc) {
+	m.rowStyleFunc = f
+	m.UpdateViewport()
+}
+
 // Option 用于在 New 中设置选项。例如：
 //
 //	table := New(WithColumns([]Column{{Title: "ID", Width: 10}}))
@@ -137,9 +278,10 @@ func New(opts ...Option) Model {
 		cursor:   0,
 		viewport: viewport.New(0, 20), //nolint:mnd
 
-		KeyMap: DefaultKeyMap(),
-		Help:   help.New(),
-		styles: DefaultStyles(),
+		KeyMap:    DefaultKeyMap(),
+		Help:      help.New(),
+		Paginator: paginator.New(),
+		styles:    DefaultStyles(),
 	}
 
 	for _, opt := range opts {
@@ -162,6 +304,7 @@ func WithColumns(cols []Column) Option {
 func WithRows(rows []Row) Option {
 	return func(m *Model) {
 		m.rows = rows
+		m.multiline = hasMultiline(rows)
 	}
 }
 
@@ -193,6 +336,20 @@ func WithStyles(s Styles) Option {
 	}
 }
 
+// WithCellStyleFunc 设置单元格样式回调，可用于按值为单元格着色。
+func WithCellStyleFunc(f CellStyleFunc) Option {
+	return func(m *Model) {
+		m.cellStyleFunc = f
+	}
+}
+
+// WithRowStyleFunc 设置行样式回调，可用于按条件为整行着色。
+func WithRowStyleFunc(f RowStyleFunc) Option {
+	return func(m *Model) {
+		m.rowStyleFunc = f
+	}
+}
+
 // WithKeyMap 设置键映射。
 func WithKeyMap(km KeyMap) Option {
 	return func(m *Model) {
@@ -202,35 +359,73 @@ func WithKeyMap(km KeyMap) Option {
 
 // Update 是 Bubble Tea 更新循环。
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	// 有弹性列时，表格宽度跟随终端宽度。
+	if msg, ok := msg.(tea.WindowSizeMsg); ok && m.hasFlexColumns() {
+		m.SetWidth(msg.Width)
+		return m, nil
+	}
 	if !m.focus {
 		return m, nil
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.resizing {
+			if cmd, ok := m.updateResize(msg); ok {
+				return m, cmd
+			}
+		}
+		if m.multiSelect && !key.Check(msg, m.KeyMap.SelectUp, m.KeyMap.SelectDown) {
+			m.selecting = false
+		}
 		switch {
+		case m.multiSelect && key.Matches(msg, m.KeyMap.ToggleMark):
+			m.ToggleMark(m.cursor)
+		case m.multiSelect && key.Matches(msg, m.KeyMap.SelectUp):
+			m.extendSelection(-1)
+		case m.multiSelect && key.Matches(msg, m.KeyMap.SelectDown):
+			m.extendSelection(1)
+		case m.resizable && key.Matches(msg, m.KeyMap.Resize):
+			m.StartResize()
 		case key.Matches(msg, m.KeyMap.LineUp):
 			m.MoveUp(1)
 		case key.Matches(msg, m.KeyMap.LineDown):
 			m.MoveDown(1)
 		case key.Matches(msg, m.KeyMap.PageUp):
-			m.MoveUp(m.viewport.Height)
+			m.MoveUp(m.pageSize())
 		case key.Matches(msg, m.KeyMap.PageDown):
-			m.MoveDown(m.viewport.Height)
+			m.MoveDown(m.pageSize())
 		case key.Matches(msg, m.KeyMap.HalfPageUp):
-			m.MoveUp(m.viewport.Height / 2) //nolint:mnd
+			m.MoveUp(m.pageSize() / 2) //nolint:mnd
 		case key.Matches(msg, m.KeyMap.HalfPageDown):
-			m.MoveDown(m.viewport.Height / 2) //nolint:mnd
+			m.MoveDown(m.pageSize() / 2) //nolint:mnd
 		case key.Matches(msg, m.KeyMap.GotoTop):
 			m.GotoTop()
 		case key.Matches(msg, m.KeyMap.GotoBottom):
 			m.GotoBottom()
+		case key.Matches(msg, m.KeyMap.ScrollLeft):
+			m.ScrollLeft()
+		case key.Matches(msg, m.KeyMap.ScrollRight):
+			m.ScrollRight()
+		case key.Matches(msg, m.KeyMap.Activate):
+			return m, m.activate()
 		}
 	}
 
 	return m, nil
 }
 
+// activate 返回发送选中行 RowActivatedMsg 的命令。如果没有选中的行，则返回 nil。
+func (m Model) activate() tea.Cmd {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	msg := RowActivatedMsg{Index: m.cursor, Row: m.rows[m.cursor]}
+	return func() tea.Msg {
+		return msg
+	}
+}
+
 // Focused 返回表格的聚焦状态。
 func (m Model) Focused() bool {
 	return m.focus
@@ -251,6 +446,9 @@ func (m *Model) Blur() {
 
 // View 渲染组件。
 func (m Model) View() string {
+	if m.perPage > 0 {
+		return m.headersView() + "\n" + m.pageView()
+	}
 	return m.headersView() + "\n" + m.viewport.View()
 }
 
@@ -263,6 +461,16 @@ func (m Model) HelpView() string {
 
 // UpdateViewport 根据先前定义的列和行更新列表内容。
 func (m *Model) UpdateViewport() {
+	m.layoutColumns()
+	if m.perPage > 0 {
+		m.updatePage()
+		return
+	}
+	if m.multiline {
+		m.updateMultilineViewport()
+		return
+	}
+
 	renderedRows := make([]string, 0, len(m.rows))
 
 	// 仅渲染从 m.cursor-m.viewport.Height 到 m.cursor+m.viewport.Height 的行
@@ -306,6 +514,9 @@ func (m Model) Columns() []Column {
 // SetRows 设置新的行状态。
 func (m *Model) SetRows(r []Row) {
 	m.rows = r
+	m.multiline = hasMultiline(r)
+	m.selecting = false
+	m.pruneMarks()
 
 	if m.cursor > len(m.rows)-1 {
 		m.cursor = len(m.rows) - 1
@@ -357,6 +568,10 @@ func (m *Model) SetCursor(n int) {
 // 它不能超过第一行。
 func (m *Model) MoveUp(n int) {
 	m.cursor = clamp(m.cursor-n, 0, len(m.rows)-1)
+	if m.multiline {
+		m.UpdateViewport()
+		return
+	}
 	switch {
 	case m.start == 0:
 		m.viewport.SetYOffset(clamp(m.viewport.YOffset, 0, m.cursor))
@@ -373,6 +588,9 @@ func (m *Model) MoveUp(n int) {
 func (m *Model) MoveDown(n int) {
 	m.cursor = clamp(m.cursor+n, 0, len(m.rows)-1)
 	m.UpdateViewport()
+	if m.multiline {
+		return
+	}
 
 	switch {
 	case m.end == len(m.rows) && m.viewport.YOffset > 0:
@@ -412,30 +630,52 @@ func (m *Model) FromValues(value, separator string) {
 
 func (m Model) headersView() string {
 	s := make([]string, 0, len(m.cols))
-	for _, col := range m.cols {
+	for _, i := range m.visibleColumns() {
+		col := m.cols[i]
 		if col.Width <= 0 {
 			continue
 		}
 		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
-		renderedCell := style.Render(runewidth.Truncate(col.Title, col.Width, "…"))
+		renderedCell := style.Render(truncate(col.Title, col.Width, col.Truncate))
+		if m.resizing && i == m.resizeCol {
+			renderedCell = m.styles.Selected.Inline(true).Render(renderedCell)
+		}
 		s = append(s, m.styles.Header.Render(renderedCell))
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, s...)
 }
 
 func (m *Model) renderRow(r int) string {
-	s := make([]string, 0, len(m.cols))
-	for i, value := range m.rows[r] {
-		if m.cols[i].Width <= 0 {
+	styled := m.cellStyleFunc != nil || m.rowStyleFunc != nil
+	var rowStyle lipgloss.Style
+	if m.rowStyleFunc != nil {
+		rowStyle = m.rowStyleFunc(r, m.rows[r])
+	}
+
+	// 行的高度是其中最高的单元格的行数。
+	cols := make([]int, 0, len(m.cols))
+	cells := make([][]string, 0, len(m.cols))
+	height := 1
+	for _, i := range m.visibleColumns() {
+		if i >= len(m.rows[r]) || m.cols[i].Width <= 0 {
 			continue
 		}
-		style := lipgloss.NewStyle().Width(m.cols[i].Width).MaxWidth(m.cols[i].Width).Inline(true)
-		renderedCell := m.styles.Cell.Render(style.Render(runewidth.Truncate(value, m.cols[i].Width, "…")))
-		s = append(s, renderedCell)
+		lines := m.cellLines(r, i, m.rows[r][i], styled, rowStyle)
+		height = max(height, len(lines))
+		cols = append(cols, i)
+		cells = append(cells, lines)
+	}
+
+	s := make([]string, 0, len(cells))
+	for k, lines := range cells {
+		s = append(s, m.styles.Cell.Render(m.alignCell(lines, cols[k], height)))
 	}
 
 	row := lipgloss.JoinHorizontal(lipgloss.Top, s...)
 
+	if m.Marked(r) {
+		row = m.styles.Marked.Render(row)
+	}
 	if r == m.cursor {
 		return m.styles.Selected.Render(row)
 	}
@@ -443,6 +683,19 @@ func (m *Model) renderRow(r int) string {
 	return row
 }
 
+// contentStyle 组合单元格内容的样式：选中样式优先，其次是单元格样式，最后是行样式。
+// 由于内部样式的重置序列会清除外层样式，选中样式必须在每个单元格上重新应用。
+func (m Model) contentStyle(r, c int, value string, rowStyle lipgloss.Style) lipgloss.Style {
+	style := rowStyle
+	if m.cellStyleFunc != nil {
+		style = m.cellStyleFunc(r, c, value).Inherit(rowStyle)
+	}
+	if r == m.cursor {
+		style = m.styles.Selected.Inherit(style)
+	}
+	return style.Inline(true)
+}
+
 func clamp(v, low, high int) int {
 	return min(max(v, low), high)
 }
@@ -1,11 +1,16 @@
 package table
 
 import (
+	"errors"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/purpose168/bubbles-cn/help"
+	"github.com/purpose168/bubbles-cn/paginator"
 	"github.com/purpose168/bubbles-cn/viewport"
+	tea "github.com/purpose168/bubbletea-cn"
 	"github.com/purpose168/charm-experimental-packages-cn/ansi"
 	"github.com/purpose168/charm-experimental-packages-cn/exp/golden"
 	lipgloss "github.com/purpose168/lipgloss-cn"
@@ -27,11 +32,12 @@ func TestNew(t *testing.T) {
 		"Default": { // 默认情况
 			want: Model{
 				// Default fields 默认字段
-				cursor:   0,
-				viewport: viewport.New(0, 20),
-				KeyMap:   DefaultKeyMap(),
-				Help:     help.New(),
-				styles:   DefaultStyles(),
+				cursor:    0,
+				viewport:  viewport.New(0, 20),
+				KeyMap:    DefaultKeyMap(),
+				Help:      help.New(),
+				Paginator: paginator.New(),
+				styles:    DefaultStyles(),
 			},
 		},
 		"WithColumns": { // 设置列
@@ -43,11 +49,12 @@ func TestNew(t *testing.T) {
 			},
 			want: Model{
 				// Default fields 默认字段
-				cursor:   0,
-				viewport: viewport.New(0, 20),
-				KeyMap:   DefaultKeyMap(),
-				Help:     help.New(),
-				styles:   DefaultStyles(),
+				cursor:    0,
+				viewport:  viewport.New(0, 20),
+				KeyMap:    DefaultKeyMap(),
+				Help:      help.New(),
+				Paginator: paginator.New(),
+				styles:    DefaultStyles(),
 
 				// Modified fields 修改的字段
 				cols: []Column{
@@ -69,11 +76,12 @@ func TestNew(t *testing.T) {
 			},
 			want: Model{
 				// Default fields 默认字段
-				cursor:   0,
-				viewport: viewport.New(0, 20),
-				KeyMap:   DefaultKeyMap(),
-				Help:     help.New(),
-				styles:   DefaultStyles(),
+				cursor:    0,
+				viewport:  viewport.New(0, 20),
+				KeyMap:    DefaultKeyMap(),
+				Help:      help.New(),
+				Paginator: paginator.New(),
+				styles:    DefaultStyles(),
 
 				// Modified fields 修改的字段
 				cols: []Column{
@@ -92,10 +100,11 @@ func TestNew(t *testing.T) {
 			},
 			want: Model{
 				// Default fields 默认字段
-				cursor: 0,
-				KeyMap: DefaultKeyMap(),
-				Help:   help.New(),
-				styles: DefaultStyles(),
+				cursor:    0,
+				KeyMap:    DefaultKeyMap(),
+				Help:      help.New(),
+				Paginator: paginator.New(),
+				styles:    DefaultStyles(),
 
 				// Modified fields 修改的字段
 				// Viewport height is 1 less than the provided height when no header is present since lipgloss.Height adds 1
@@ -109,10 +118,11 @@ func TestNew(t *testing.T) {
 			},
 			want: Model{
 				// Default fields 默认字段
-				cursor: 0,
-				KeyMap: DefaultKeyMap(),
-				Help:   help.New(),
-				styles: DefaultStyles(),
+				cursor:    0,
+				KeyMap:    DefaultKeyMap(),
+				Help:      help.New(),
+				Paginator: paginator.New(),
+				styles:    DefaultStyles(),
 
 				// Modified fields 修改的字段
 				// Viewport height is 1 less than the provided height when no header is present since lipgloss.Height adds 1
@@ -126,11 +136,12 @@ func TestNew(t *testing.T) {
 			},
 			want: Model{
 				// Default fields 默认字段
-				cursor:   0,
-				viewport: viewport.New(0, 20),
-				KeyMap:   DefaultKeyMap(),
-				Help:     help.New(),
-				styles:   DefaultStyles(),
+				cursor:    0,
+				viewport:  viewport.New(0, 20),
+				KeyMap:    DefaultKeyMap(),
+				Help:      help.New(),
+				Paginator: paginator.New(),
+				styles:    DefaultStyles(),
 
 				// Modified fields 修改的字段
 				focus: true,
@@ -142,11 +153,12 @@ func TestNew(t *testing.T) {
 			},
 			want: Model{
 				// Default fields 默认字段
-				cursor:   0,
-				viewport: viewport.New(0, 20),
-				KeyMap:   DefaultKeyMap(),
-				Help:     help.New(),
-				styles:   DefaultStyles(),
+				cursor:    0,
+				viewport:  viewport.New(0, 20),
+				KeyMap:    DefaultKeyMap(),
+				Help:      help.New(),
+				Paginator: paginator.New(),
+				styles:    DefaultStyles(),
 
 				// Modified fields 修改的字段
 				// 已移除重复的 styles 字段赋值，因在上一层已赋值
@@ -158,10 +170,11 @@ func TestNew(t *testing.T) {
 			},
 			want: Model{
 				// Default fields 默认字段
-				cursor:   0,
-				viewport: viewport.New(0, 20),
-				Help:     help.New(),
-				styles:   DefaultStyles(),
+				cursor:    0,
+				viewport:  viewport.New(0, 20),
+				Help:      help.New(),
+				Paginator: paginator.New(),
+				styles:    DefaultStyles(),
 
 				// Modified fields 修改的字段
 				KeyMap: KeyMap{},
@@ -255,6 +268,19 @@ func TestModel_RenderRow(t *testing.T) {
 			},
 			expected: "FoooooooooBaaaaaaaarQuuuuuuuux",
 		},
+		{
+			name: "row with truncation strategies", // 带截断策略的行
+			table: &Model{
+				rows: []Row{{"pkg/table/table.go", "id-0123456789", "hello, wide world"}},
+				cols: []Column{
+					{Title: "path", Width: 10, Truncate: TruncateStart},
+					{Title: "id", Width: 10, Truncate: TruncateMiddle},
+					{Title: "text", Width: 10, Truncate: TruncateEnd},
+				},
+				styles: Styles{Cell: lipgloss.NewStyle()},
+			},
+			expected: "…/table.goid-01…6789hello, wi…",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -756,3 +782,550 @@ func TestModel_View_CenteredInABox(t *testing.T) {
 
 	golden.RequireEqual(t, []byte(got))
 }
+
+// TestStyleFuncs 测试单元格和行样式回调
+func TestStyleFuncs(t *testing.T) {
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	blue := lipgloss.NewStyle().Foreground(lipgloss.Color("4"))
+
+	var cells, rows []string
+	m := New(
+		WithColumns(testCols),
+		WithRows([]Row{{"ok", "fail", "x"}, {"ok", "ok", "y"}}),
+		WithCellStyleFunc(func(_, _ int, value string) lipgloss.Style {
+			cells = append(cells, value)
+			if value == "fail" {
+				return red
+			}
+			return lipgloss.NewStyle()
+		}),
+		WithRowStyleFunc(func(_ int, row Row) lipgloss.Style {
+			rows = append(rows, row[2])
+			return blue
+		}),
+	)
+	if len(rows) != 2 || len(cells) != 6 {
+		t.Fatalf("expected callbacks for 2 rows and 6 cells, got %v and %v", rows, cells)
+	}
+
+	if got := m.contentStyle(1, 1, "fail", blue).GetForeground(); got != lipgloss.Color("1") {
+		t.Errorf("expected cell style to override row style, got %v", got)
+	}
+	if got := m.contentStyle(1, 0, "ok", blue).GetForeground(); got != lipgloss.Color("4") {
+		t.Errorf("expected row style to apply, got %v", got)
+	}
+	sel := m.contentStyle(0, 1, "fail", blue)
+	if got := sel.GetForeground(); got != m.styles.Selected.GetForeground() {
+		t.Errorf("expected selected style on top, got %v", got)
+	}
+
+	plain := New(WithColumns(testCols), WithRows([]Row{{"ok", "fail", "x"}}))
+	if got, want := ansi.Strip(m.renderRow(0)), ansi.Strip(plain.renderRow(0)); got != want {
+		t.Errorf("styling should not change the row layout, want %q got %q", want, got)
+	}
+}
+
+// TestActivate 测试激活选中的行
+func TestActivate(t *testing.T) {
+	m := New(
+		WithColumns(testCols),
+		WithRows([]Row{{"r1"}, {"r2"}, {"r3"}}),
+		WithFocused(true),
+	)
+	m.MoveDown(1)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command when activating a row")
+	}
+	msg, ok := cmd().(RowActivatedMsg)
+	if !ok {
+		t.Fatalf("expected RowActivatedMsg, got %T", cmd())
+	}
+	if msg.Index != 1 || !reflect.DeepEqual(msg.Row, Row{"r2"}) {
+		t.Errorf("expected row 1 {r2} to be activated, got %d %v", msg.Index, msg.Row)
+	}
+
+	m.SetRows(nil)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected no command when there are no rows")
+	}
+}
+
+func TestPagination(t *testing.T) {
+	rows := make([]Row, 7)
+	for i := range rows {
+		rows[i] = Row{"r" + strconv.Itoa(i)}
+	}
+	m := New(
+		WithColumns([]Column{{Title: "Name", Width: 4}}),
+		WithRows(rows),
+		WithFocused(true),
+		WithPagination(3),
+	)
+	m.SetStyles(Styles{})
+
+	if got, want := m.View(), "Name\nr0  \nr1  \nr2  \n1/3"; got != want {
+		t.Fatalf("expected first page\n%q, got\n%q", want, got)
+	}
+
+	// PageDown 翻到下一页，保持光标在页内的位置
+	m.MoveDown(1)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.Cursor() != 4 || m.Paginator.Page != 1 || !reflect.DeepEqual(m.SelectedRow(), Row{"r4"}) {
+		t.Fatalf("expected row 4 on page 1, got row %d on page %d", m.Cursor(), m.Paginator.Page)
+	}
+
+	// 最后一页不足一页时用空行填充
+	m.GotoBottom()
+	if got, want := m.View(), "Name\nr6  \n\n\n3/3"; got != want {
+		t.Fatalf("expected last page\n%q, got\n%q", want, got)
+	}
+
+	m.SetPagination(0)
+	if m.Paginated() || strings.Contains(m.View(), "3/3") {
+		t.Fatal("expected pagination to be disabled")
+	}
+}
+
+func TestResize(t *testing.T) {
+	cols := []Column{
+		{Title: "Name", Width: 4, MinWidth: 3},
+		{Title: "Size", Width: 4, MaxWidth: 5},
+	}
+	m := New(
+		WithColumns(cols),
+		WithRows([]Row{{"a", "1"}}),
+		WithHeight(2),
+		WithFocused(true),
+	)
+	m.SetStyles(Styles{})
+
+	// 默认不启用调整列宽
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if m.Resizing() {
+		t.Fatal("expected w to be ignored unless resizing is enabled")
+	}
+
+	m.SetResizable(true)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if !m.Resizing() || m.ResizeColumn() != 0 {
+		t.Fatal("expected w to enter resize mode on the first column")
+	}
+
+	// 缩小到最小宽度为止
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if cmd == nil {
+		t.Fatal("expected a ResizedMsg")
+	}
+	if msg := cmd().(ResizedMsg); msg.Column != 0 || msg.Width != 3 || !reflect.DeepEqual(msg.Widths, []int{3, 4}) {
+		t.Fatalf("unexpected ResizedMsg %+v", msg)
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyLeft}); cmd != nil {
+		t.Fatal("expected no ResizedMsg below the minimum width")
+	}
+	if cols[0].Width != 4 {
+		t.Fatal("expected the caller's columns not to be modified")
+	}
+
+	// 增大下一列直到最大宽度为止
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	for range 3 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	}
+	if got := m.Columns()[1].Width; got != 5 {
+		t.Fatalf("expected the second column to stop at 5, got %d", got)
+	}
+	if got, want := m.View(), "Na…Size \na  1    "; got != want {
+		t.Fatalf("expected resized view\n%q, got\n%q", want, got)
+	}
+
+	// 调整列宽时仍然可以上下移动
+	m.SetRows([]Row{{"a", "1"}, {"b", "2"}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if !m.Resizing() || m.Cursor() != 1 {
+		t.Fatalf("expected down to move the cursor in resize mode, got %d", m.Cursor())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Resizing() {
+		t.Fatal("expected esc to leave resize mode")
+	}
+
+	m.StartResize()
+	m.SetResizable(false)
+	if m.Resizing() {
+		t.Fatal("expected disabling resizing to leave resize mode")
+	}
+}
+
+func TestFromStructs(t *testing.T) {
+	type Meta struct {
+		Owner string
+	}
+	type Pod struct {
+		Name     string  `table:"名称,truncate=middle"`
+		CPU      float64 `table:"CPU,width=6,format=%.1f%%"`
+		Restarts *int
+		internal string
+		Hidden   string `table:"-"`
+		*Meta
+	}
+
+	three := 3
+	pods := []*Pod{
+		{Name: "api-7f9c", CPU: 12.34, Restarts: &three, Meta: &Meta{Owner: "ops"}},
+		{Name: "worker", CPU: 5, internal: "x", Hidden: "y"},
+		nil,
+	}
+
+	cols, rows, err := FromStructs(pods)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCols := []Column{
+		{Title: "名称", Width: 8, Truncate: TruncateMiddle},
+		{Title: "CPU", Width: 6},
+		{Title: "Restarts", Width: 8},
+		{Title: "Owner", Width: 5},
+	}
+	if !reflect.DeepEqual(cols, wantCols) {
+		t.Fatalf("expected columns %+v, got %+v", wantCols, cols)
+	}
+	wantRows := []Row{
+		{"api-7f9c", "12.3%", "3", "ops"},
+		{"worker", "5.0%", "", ""},
+		{"", "", "", ""},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Fatalf("expected rows %q, got %q", wantRows, rows)
+	}
+
+	cols, rows, err = FromStructs(pods, "Owner", "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 2 || cols[0].Title != "Owner" || rows[0][1] != "api-7f9c" {
+		t.Fatalf("expected selected fields in order, got %+v %q", cols, rows)
+	}
+
+	if _, _, err := FromStructs(pods, "Hidden"); err == nil {
+		t.Fatal("expected an error for an excluded field")
+	}
+	if _, _, err := FromStructs([]int{1}); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("expected ErrNotStruct, got %v", err)
+	}
+}
+
+func TestFrozenColumns(t *testing.T) {
+	tbl := New(
+		WithColumns([]Column{
+			{Title: "ID", Width: 5},
+			{Title: "A", Width: 5},
+			{Title: "B", Width: 5},
+			{Title: "C", Width: 5},
+		}),
+		WithRows([]Row{{"1", "a", "b", "c"}}),
+		WithStyles(Styles{Cell: lipgloss.NewStyle().Padding(0, 1), Header: lipgloss.NewStyle().Padding(0, 1)}),
+		WithWidth(21),
+		WithHeight(2),
+		WithFrozenColumns(1),
+		WithFocused(true),
+	)
+
+	want := " ID     A      B      C     "
+	if got := tbl.headersView(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if got := tbl.ColumnOffset(); got != 1 {
+		t.Fatalf("expected column offset 1, got %d", got)
+	}
+	want = " ID     B      C     "
+	if got := tbl.headersView(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got := tbl.renderRow(0); got != " 1      b      c     " {
+		t.Fatalf("expected the frozen cell to stay, got %q", got)
+	}
+
+	// 剩余的列已经能完全显示，不再继续滚动。
+	tbl.ScrollRight()
+	if got := tbl.ColumnOffset(); got != 1 {
+		t.Fatalf("expected column offset to stop at 1, got %d", got)
+	}
+
+	tbl.ScrollLeft()
+	if got := tbl.ColumnOffset(); got != 0 {
+		t.Fatalf("expected column offset 0, got %d", got)
+	}
+
+	// 调整列宽时聚焦的列会滚动到可见的位置。
+	tbl.SetResizable(true)
+	tbl.StartResize()
+	tbl.resizeCol = 2
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := tbl.ColumnOffset(); got != 1 {
+		t.Fatalf("expected the resized column to scroll into view, got offset %d", got)
+	}
+}
+
+func TestMultilineCells(t *testing.T) {
+	tbl := New(
+		WithColumns([]Column{
+			{Title: "A", Width: 3},
+			{Title: "B", Width: 3, VAlign: lipgloss.Bottom},
+		}),
+		WithRows([]Row{
+			{"a1\na2\na3", "b"},
+			{"c", "d"},
+			{"e1\ne2", "f"},
+		}),
+		WithStyles(Styles{}),
+		WithHeight(4),
+		WithFocused(true),
+	)
+
+	want := "a1    \na2    \na3 b  "
+	if got := tbl.renderRow(0); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	// 视口有 3 行：第一行正好占满。
+	if got := tbl.viewport.View(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	tbl.MoveDown(1)
+	if got := tbl.viewport.View(); !strings.Contains(got, "c  d  ") {
+		t.Fatalf("expected the cursor row to be visible, got %q", got)
+	}
+
+	tbl.MoveDown(1)
+	want = "c  d  \ne1    \ne2 f  "
+	if got := tbl.viewport.View(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	tbl.GotoTop()
+	if got := tbl.viewport.View(); !strings.HasPrefix(got, "a1") {
+		t.Fatalf("expected the first row at the top, got %q", got)
+	}
+}
+
+func TestHiddenColumns(t *testing.T) {
+	tbl := New(
+		WithColumns([]Column{
+			{Title: "A", Width: 3},
+			{Title: "B", Width: 3},
+			{Title: "C", Width: 3},
+		}),
+		WithRows([]Row{{"a", "b", "c"}}),
+		WithStyles(Styles{}),
+		WithHiddenColumns(1),
+		WithHeight(2),
+		WithFocused(true),
+	)
+
+	if tbl.ColumnVisible(1) || !tbl.ColumnVisible(0) {
+		t.Fatal("expected only the second column to be hidden")
+	}
+	if got, want := tbl.headersView(), "A  C  "; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got, want := tbl.renderRow(0), "a  c  "; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	// 调整列宽时跳过隐藏的列。
+	tbl.SetResizable(true)
+	tbl.StartResize()
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if got := tbl.ResizeColumn(); got != 2 {
+		t.Fatalf("expected resize to skip the hidden column, got %d", got)
+	}
+	tbl.StopResize()
+
+	tbl.SetColumnVisible(1, true)
+	tbl.SetColumnVisible(0, false)
+	if got, want := tbl.renderRow(0), "b  c  "; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestToCSV(t *testing.T) {
+	tbl := New(
+		WithColumns([]Column{
+			{Title: "Name", Width: 4},
+			{Title: "Secret", Width: 4},
+			{Title: "Note", Width: 4},
+		}),
+		WithRows([]Row{
+			{"plain", "x", "no quotes needed"},
+			{"comma", "x", "a, b"},
+			{"quote", "x", `say "hi"`},
+			{"multi", "x", "line 1\nline 2"},
+			{"tab", "x", "a\tb"},
+		}),
+		WithHiddenColumns(1),
+	)
+
+	want := []Row{{"plain", "no quotes needed"}, {"comma", "a, b"}}
+	if got := tbl.ToRows(); !reflect.DeepEqual(got[:2], want) {
+		t.Fatalf("expected %q, got %q", want, got[:2])
+	}
+
+	for name, sep := range map[string]string{"csv": ",", "tsv": "\t"} {
+		t.Run(name, func(t *testing.T) {
+			golden.RequireEqual(t, []byte(tbl.ToCSV(sep)))
+		})
+	}
+}
+
+func TestFlexColumns(t *testing.T) {
+	tbl := New(
+		WithColumns([]Column{
+			{Title: "ID", Width: 4},
+			{Title: "Name", FlexFactor: 2},
+			{Title: "Note", FlexFactor: 1, MaxWidth: 10},
+			{Title: "Tag", FlexFactor: 1, MinWidth: 3},
+		}),
+		WithWidth(40),
+	)
+
+	widths := func() []int {
+		var w []int
+		for _, col := range tbl.Columns() {
+			w = append(w, col.Width)
+		}
+		return w
+	}
+
+	// 40 - 4*2 的边距 - 4 = 28，按 2:1:1 分配。
+	if got, want := widths(), []int{4, 14, 7, 7}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected widths %v, got %v", want, got)
+	}
+
+	// Note 受 MaxWidth 限制，剩余宽度在 Name 和 Tag 之间按 2:1 分配。
+	tbl, _ = tbl.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	if got, want := widths(), []int{4, 39, 10, 19}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected widths %v, got %v", want, got)
+	}
+	if tbl.Width() != 80 {
+		t.Fatalf("expected width 80, got %d", tbl.Width())
+	}
+
+	// 宽度不足时弹性列不小于 MinWidth（默认为 1）。
+	tbl.SetWidth(10)
+	if got, want := widths(), []int{4, 1, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected widths %v, got %v", want, got)
+	}
+
+	// 隐藏的列不参与分配。
+	tbl.SetWidth(40)
+	tbl.SetColumnVisible(3, false)
+	if got := widths(); got[1]+got[2] != 40-3*2-4 {
+		t.Fatalf("expected flex widths to fill the table, got %v", got)
+	}
+}
+
+func TestResizeFlexColumn(t *testing.T) {
+	tbl := New(
+		WithColumns([]Column{
+			{Title: "ID", Width: 4},
+			{Title: "Name", FlexFactor: 1},
+			{Title: "Note", FlexFactor: 1},
+		}),
+		WithWidth(30),
+		WithFocused(true),
+		WithResizable(true),
+	)
+
+	widths := func() []int {
+		var w []int
+		for _, col := range tbl.Columns() {
+			w = append(w, col.Width)
+		}
+		return w
+	}
+
+	// 30 - 3*2 的边距 - 4 = 20，按 1:1 分配。
+	if got, want := widths(), []int{4, 10, 10}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected widths %v, got %v", want, got)
+	}
+
+	// 调整弹性列的宽度使它变为固定宽度，其余的弹性列填满剩余的宽度。
+	tbl.StartResize()
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeyTab})
+	tbl, cmd := tbl.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if cmd == nil {
+		t.Fatal("expected a ResizedMsg")
+	}
+	if got, want := widths(), []int{4, 11, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected widths %v, got %v", want, got)
+	}
+	if tbl.Columns()[1].FlexFactor != 0 {
+		t.Fatal("expected the resized column to become fixed")
+	}
+
+	// 表格宽度变化时保持设置的宽度。
+	tbl.SetWidth(40)
+	if got, want := widths(), []int{4, 11, 19}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected widths %v, got %v", want, got)
+	}
+
+	// 设置为弹性列当前的宽度也会使它变为固定宽度。
+	if !tbl.SetColumnWidth(2, 19) || tbl.Columns()[2].FlexFactor != 0 {
+		t.Fatal("expected setting the current width to fix the flex column")
+	}
+}
+
+func TestMultiSelect(t *testing.T) {
+	rows := []Row{{"1", "a"}, {"2", "b"}, {"3", "c"}, {"4", "d"}, {"5", "e"}}
+	tbl := New(
+		WithColumns([]Column{{Title: "ID", Width: 2}, {Title: "Name", Width: 4}}),
+		WithRows(rows),
+		WithFocused(true),
+		WithRowKey(func(r Row) string { return r[0] }),
+	)
+
+	// 未启用时空格仍然向下翻页。
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if len(tbl.SelectedRows()) != 0 {
+		t.Fatalf("expected no marks without multi-select, got %v", tbl.SelectedRows())
+	}
+
+	tbl.SetMultiSelect(true)
+	tbl.GotoTop()
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if got, want := tbl.SelectedRows(), []int{0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v after toggling, got %v", want, got)
+	}
+
+	// 范围选择：从第 2 行向下扩展两行，再收回一行。
+	tbl.SetCursor(2)
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	if got, want := tbl.SelectedRows(), []int{0, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v after extending, got %v", want, got)
+	}
+	tbl, _ = tbl.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	if got, want := tbl.SelectedRows(), []int{0, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v after shrinking, got %v", want, got)
+	}
+	if tbl.Cursor() != 3 {
+		t.Fatalf("expected cursor 3, got %d", tbl.Cursor())
+	}
+
+	// 标记跟随重新排序后的行，消失的行的标记被移除。
+	tbl.SetRows([]Row{rows[4], rows[3], rows[2], rows[1]})
+	if got, want := tbl.SelectedRows(), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v after sorting, got %v", want, got)
+	}
+
+	tbl.SetMultiSelect(false)
+	if len(tbl.SelectedRows()) != 0 {
+		t.Fatalf("expected marks to be cleared, got %v", tbl.SelectedRows())
+	}
+}
@@ -0,0 +1,43 @@
+package table
+
+import "github.com/purpose168/bubbles-cn/runeutil"
+
+// Truncation 决定单元格内容超出列宽时保留哪一部分。
+type Truncation int
+
+// 可用的截断策略。
+const (
+	// TruncateEnd 保留开头，在末尾截断，例如 "pkg/table/ta…"。这是默认策略。
+	TruncateEnd Truncation = iota
+
+	// TruncateStart 保留末尾，在开头截断，例如 "…/table/table.go"，
+	// 适用于需要保留文件名的路径和需要保留后缀的 ID。
+	TruncateStart
+
+	// TruncateMiddle 保留开头和末尾，在中间截断，例如 "pkg/ta…table.go"。
+	TruncateMiddle
+)
+
+// ellipsis 是截断内容时使用的省略号。
+const ellipsis = "…"
+
+// truncate 按照给定的策略将 s 截断到 w 个单元格宽。
+func truncate(s string, w int, t Truncation) string {
+	if runeutil.StringWidth(s) <= w {
+		return s
+	}
+	tw := runeutil.StringWidth(ellipsis)
+	if w <= tw {
+		return runeutil.Truncate(s, w, "")
+	}
+
+	switch t {
+	case TruncateStart:
+		return runeutil.TruncateLeft(s, w, ellipsis)
+	case TruncateMiddle:
+		headWidth := (w - tw + 1) / 2 //nolint:mnd
+		return runeutil.Truncate(s, headWidth, "") + ellipsis + runeutil.TruncateLeft(s, w-tw-headWidth, "")
+	default:
+		return runeutil.Truncate(s, w, ellipsis)
+	}
+}
@@ -0,0 +1,656 @@
+// Package tasks 提供一个任务监视器组件。它跟踪一组命名的长时间运行的任务
+// （等待中、运行中、成功、失败），每个任务可以带有进度和日志尾部。
+// 它将任务渲染为一个紧凑的面板，运行中的任务显示加载动画或进度条，
+// 并支持用键盘选中任务进入详情视图查看其日志。
+package tasks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/purpose168/bubbles-cn/key"
+	"github.com/purpose168/bubbles-cn/progress"
+	"github.com/purpose168/bubbles-cn/spinner"
+	"github.com/purpose168/bubbles-cn/viewport"
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// defaultMaxLog 是每个任务默认保留的日志行数。
+const defaultMaxLog = 100
+
+// Status 是任务的状态。
+type Status int
+
+// 可用的任务状态。
+const (
+	Pending Status = iota
+	Running
+	Succeeded
+	Failed
+)
+
+// String 返回状态的名称。
+func (s Status) String() string {
+	switch s {
+	case Running:
+		return "running"
+	case Succeeded:
+		return "succeeded"
+	case Failed:
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
+// Finished 返回状态是否表示任务已结束（成功或失败）。
+func (s Status) Finished() bool {
+	return s == Succeeded || s == Failed
+}
+
+// Task 是一个任务的快照。
+type Task struct {
+	Name   string
+	Status Status
+
+	// Progress 是任务的进度（0 到 1 之间）。仅当 HasProgress 为 true 时有效，
+	// 否则运行中的任务显示加载动画。
+	Progress    float64
+	HasProgress bool
+
+	// Err 是任务失败的原因。
+	Err error
+
+	// Log 是任务最近的日志行，最旧的在前。
+	Log []string
+
+	Started  time.Time // 任务开始运行的时间
+	Finished time.Time // 任务结束的时间
+}
+
+// Elapsed 返回任务已运行的时长。如果任务尚未开始，则返回 0。
+func (t Task) Elapsed() time.Duration {
+	switch {
+	case t.Started.IsZero():
+		return 0
+	case t.Finished.IsZero():
+		return time.Since(t.Started)
+	default:
+		return t.Finished.Sub(t.Started)
+	}
+}
+
+// StatusMsg 设置任务的状态。Err 仅在 Status 为 Failed 时使用。
+type StatusMsg struct {
+	Name   string
+	Status Status
+	Err    error
+}
+
+// ProgressMsg 设置任务的进度（0 到 1 之间）。
+type ProgressMsg struct {
+	Name    string
+	Percent float64
+}
+
+// LogMsg 向任务的日志追加一行。
+type LogMsg struct {
+	Name string
+	Line string
+}
+
+// DoneMsg 在所有任务都结束时发送。
+type DoneMsg struct {
+	Succeeded int // 成功的任务数量
+	Failed    int // 失败的任务数量
+}
+
+// KeyMap 是任务监视器的按键绑定。它满足 help.KeyMap 接口。
+type KeyMap struct {
+	Up   key.Binding // 选中上一个任务
+	Down key.Binding // 选中下一个任务
+	Open key.Binding // 打开选中任务的详情视图
+	Back key.Binding // 从详情视图返回面板
+}
+
+// ShortHelp 实现 help.KeyMap 接口。
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Open, k.Back}
+}
+
+// FullHelp 实现 help.KeyMap 接口。
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down}, {k.Open, k.Back}}
+}
+
+// DefaultKeyMap 返回一组默认的按键绑定。
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "上移"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "下移"),
+		),
+		Open: key.NewBinding(
+			key.WithKeys("enter", "l"),
+			key.WithHelp("enter", "详情"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "h"),
+			key.WithHelp("esc", "返回"),
+			key.WithDisabled(),
+		),
+	}
+}
+
+// Styles 包含任务监视器的样式。
+type Styles struct {
+	Pending   lipgloss.Style // 等待中任务图标的样式
+	Running   lipgloss.Style // 运行中任务加载动画的样式
+	Succeeded lipgloss.Style // 成功任务图标的样式
+	Failed    lipgloss.Style // 失败任务图标的样式
+
+	Name     lipgloss.Style // 任务名称的样式
+	Selected lipgloss.Style // 选中任务的光标和名称的样式
+	Info     lipgloss.Style // 状态文本和耗时的样式
+	Log      lipgloss.Style // 日志行的样式
+	Error    lipgloss.Style // 错误信息的样式
+	Title    lipgloss.Style // 详情视图标题的样式
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	subdued := lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"}
+	return Styles{
+		Pending:   lipgloss.NewStyle().Foreground(subdued),
+		Running:   lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+		Succeeded: lipgloss.NewStyle().Foreground(lipgloss.Color("42")),
+		Failed:    lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Name:      lipgloss.NewStyle(),
+		Selected:  lipgloss.NewStyle().Foreground(lipgloss.Color("212")),
+		Info:      lipgloss.NewStyle().Foreground(subdued),
+		Log:       lipgloss.NewStyle().Foreground(subdued),
+		Error:     lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Title:     lipgloss.NewStyle().Bold(true),
+	}
+}
+
+// Model 是任务监视器的 Bubble Tea 模型。
+type Model struct {
+	KeyMap KeyMap
+	Styles Styles
+
+	// MaxLog 是每个任务保留的日志行数上限。超出时丢弃最旧的行。
+	// 如果为 0 或更小，则不限制。
+	MaxLog int
+
+	// Spinner 是运行中且没有进度的任务显示的加载动画。
+	Spinner spinner.Model
+
+	// Progress 用于渲染有进度的任务的进度条，它的宽度由面板决定。
+	Progress progress.Model
+
+	tasks   []Task
+	cursor  int  // 选中任务的索引
+	offset  int  // 面板中第一个可见任务的索引
+	detail  bool // 是否显示详情视图
+	ticking bool // 加载动画是否正在计时
+	width   int
+	height  int
+
+	log viewport.Model // 详情视图中的日志
+}
+
+// New 返回一个给定宽度和高度的任务监视器。
+func New(width, height int) Model {
+	log := viewport.New(width, height)
+	log.Follow = true
+
+	m := Model{
+		KeyMap:   DefaultKeyMap(),
+		Styles:   DefaultStyles(),
+		MaxLog:   defaultMaxLog,
+		Spinner:  spinner.New(spinner.WithSpinner(spinner.Dot)),
+		Progress: progress.New(progress.WithSolidFill("#7571F9")),
+		log:      log,
+	}
+	m.SetSize(width, height)
+	return m
+}
+
+// Init 实现 tea.Model 接口。
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// SetSize 设置任务监视器的宽度和高度。
+func (m *Model) SetSize(width, height int) {
+	m.width, m.height = width, height
+	m.log.Width = width
+	m.log.Height = max(0, height-m.detailHeaderHeight())
+	m.clampOffset()
+}
+
+// Width 返回任务监视器的宽度。
+func (m Model) Width() int {
+	return m.width
+}
+
+// Height 返回任务监视器的高度。
+func (m Model) Height() int {
+	return m.height
+}
+
+// Add 添加给定名称的等待中任务。已存在的名称会被忽略。
+func (m *Model) Add(names ...string) {
+	for _, name := range names {
+		if m.index(name) < 0 {
+			m.tasks = append(m.tasks, Task{Name: name})
+		}
+	}
+}
+
+// Remove 删除给定名称的任务。
+func (m *Model) Remove(name string) {
+	i := m.index(name)
+	if i < 0 {
+		return
+	}
+	// 详情视图显示的任务被移除时，返回面板。
+	if m.detail && i == m.cursor {
+		m.closeDetail()
+	}
+	m.tasks = append(m.tasks[:i:i], m.tasks[i+1:]...)
+	// 移除之前的任务时，光标仍指向原来选中的任务。
+	if i < m.cursor {
+		m.cursor--
+	}
+	if m.cursor >= len(m.tasks) {
+		m.cursor = max(0, len(m.tasks)-1)
+	}
+	if len(m.tasks) == 0 {
+		m.closeDetail()
+	}
+	m.clampOffset()
+}
+
+// Tasks 返回所有任务，按添加的顺序排列。
+func (m Model) Tasks() []Task {
+	return m.tasks
+}
+
+// Task 返回给定名称的任务，以及它是否存在。
+func (m Model) Task(name string) (Task, bool) {
+	i := m.index(name)
+	if i < 0 {
+		return Task{}, false
+	}
+	return m.tasks[i], true
+}
+
+// Selected 返回选中的任务，以及是否有任务。
+func (m Model) Selected() (Task, bool) {
+	if len(m.tasks) == 0 {
+		return Task{}, false
+	}
+	return m.tasks[m.cursor], true
+}
+
+// Start 将任务标记为运行中并返回启动加载动画所需的命令。
+// 如果任务不存在，则先添加它。
+func (m *Model) Start(name string) tea.Cmd {
+	return m.setStatus(name, Running, nil)
+}
+
+// Succeed 将任务标记为成功。如果所有任务都已结束，返回的命令发送 DoneMsg。
+func (m *Model) Succeed(name string) tea.Cmd {
+	return m.setStatus(name, Succeeded, nil)
+}
+
+// Fail 将任务标记为失败。如果所有任务都已结束，返回的命令发送 DoneMsg。
+func (m *Model) Fail(name string, err error) tea.Cmd {
+	return m.setStatus(name, Failed, err)
+}
+
+// SetProgress 设置任务的进度（0 到 1 之间）。设置进度后，
+// 运行中的任务显示进度条而不是加载动画。
+func (m *Model) SetProgress(name string, percent float64) {
+	t := m.task(name)
+	t.Progress = max(0, min(1, percent))
+	t.HasProgress = true
+}
+
+// AppendLog 向任务的日志追加行。
+func (m *Model) AppendLog(name string, lines ...string) {
+	i := m.ensure(name)
+	t := &m.tasks[i]
+	t.Log = append(t.Log, lines...)
+	if m.MaxLog > 0 && len(t.Log) > m.MaxLog {
+		t.Log = append([]string(nil), t.Log[len(t.Log)-m.MaxLog:]...)
+	}
+	if m.detail && i == m.cursor {
+		m.log.AppendLines(lines)
+	}
+}
+
+// Done 返回是否有任务并且所有任务都已结束。
+func (m Model) Done() bool {
+	if len(m.tasks) == 0 {
+		return false
+	}
+	for _, t := range m.tasks {
+		if !t.Status.Finished() {
+			return false
+		}
+	}
+	return true
+}
+
+// Count 返回处于给定状态的任务数量。
+func (m Model) Count(status Status) int {
+	var c int
+	for _, t := range m.tasks {
+		if t.Status == status {
+			c++
+		}
+	}
+	return c
+}
+
+// Summary 返回任务的概要，例如 "3/5 done, 1 failed"，用于状态栏。
+func (m Model) Summary() string {
+	done := m.Count(Succeeded) + m.Count(Failed)
+	s := fmt.Sprintf("%d/%d done", done, len(m.tasks))
+	if f := m.Count(Failed); f > 0 {
+		s += fmt.Sprintf(", %d failed", f)
+	}
+	return s
+}
+
+// Detail 返回是否正在显示详情视图。
+func (m Model) Detail() bool {
+	return m.detail
+}
+
+// OpenDetail 显示选中任务的详情视图。
+func (m *Model) OpenDetail() {
+	if len(m.tasks) == 0 {
+		return
+	}
+	m.detail = true
+	m.KeyMap.Up.SetEnabled(false)
+	m.KeyMap.Down.SetEnabled(false)
+	m.KeyMap.Open.SetEnabled(false)
+	m.KeyMap.Back.SetEnabled(true)
+	m.log.Height = max(0, m.height-m.detailHeaderHeight())
+	m.log.SetContent(strings.Join(m.tasks[m.cursor].Log, "\n"))
+	m.log.GotoBottom()
+}
+
+// CloseDetail 从详情视图返回面板。
+func (m *Model) CloseDetail() {
+	m.closeDetail()
+}
+
+func (m *Model) closeDetail() {
+	m.detail = false
+	m.log.SetContent("")
+	m.KeyMap.Up.SetEnabled(true)
+	m.KeyMap.Down.SetEnabled(true)
+	m.KeyMap.Open.SetEnabled(true)
+	m.KeyMap.Back.SetEnabled(false)
+}
+
+// Update 实现 tea.Model 接口。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case StatusMsg:
+		return m, m.setStatus(msg.Name, msg.Status, msg.Err)
+
+	case ProgressMsg:
+		m.SetProgress(msg.Name, msg.Percent)
+		return m, nil
+
+	case LogMsg:
+		m.AppendLog(msg.Name, msg.Line)
+		return m, nil
+
+	case spinner.TickMsg:
+		if msg.ID != m.Spinner.ID() {
+			return m, nil
+		}
+		// 没有运行中的任务时停止计时，在下一个任务开始时重新启动。
+		if m.Count(Running) == 0 {
+			m.ticking = false
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.Spinner, cmd = m.Spinner.Update(msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			m.clampOffset()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Down):
+			if m.cursor < len(m.tasks)-1 {
+				m.cursor++
+			}
+			m.clampOffset()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Open):
+			m.OpenDetail()
+			return m, nil
+		case key.Matches(msg, m.KeyMap.Back):
+			m.closeDetail()
+			return m, nil
+		}
+	}
+
+	if m.detail {
+		var cmd tea.Cmd
+		m.log, cmd = m.log.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View 实现 tea.Model 接口。
+func (m Model) View() string {
+	if m.detail && len(m.tasks) > 0 {
+		return m.detailView()
+	}
+	return m.boardView()
+}
+
+// boardView 渲染面板，每个任务一行。
+func (m Model) boardView() string {
+	nameWidth := 0
+	for _, t := range m.tasks {
+		nameWidth = max(nameWidth, ansi.StringWidth(t.Name))
+	}
+
+	end := len(m.tasks)
+	if m.height > 0 {
+		end = min(end, m.offset+m.height)
+	}
+
+	rows := make([]string, 0, end-m.offset)
+	for i := m.offset; i < end; i++ {
+		rows = append(rows, m.row(i, nameWidth))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// row 渲染面板中的一行：光标、状态图标、名称、进度或状态，以及最后一行日志。
+func (m Model) row(i, nameWidth int) string {
+	t := m.tasks[i]
+
+	cursor, name := "  ", m.Styles.Name
+	if i == m.cursor {
+		cursor, name = m.Styles.Selected.Render("> "), m.Styles.Selected
+	}
+	pad := strings.Repeat(" ", nameWidth-ansi.StringWidth(t.Name))
+	s := cursor + m.icon(t) + " " + name.Render(t.Name) + pad + "  "
+
+	if t.Status == Running && t.HasProgress {
+		barWidth := min(m.Progress.Width, max(0, m.width-ansi.StringWidth(s)))
+		s += m.Progress.ViewWithWidth(barWidth, t.Progress)
+	} else {
+		s += m.status(t)
+	}
+
+	if n := len(t.Log); n > 0 && t.Status != Failed {
+		if avail := m.width - ansi.StringWidth(s) - 2; avail > 0 {
+			s += "  " + m.Styles.Log.Render(ansi.Truncate(t.Log[n-1], avail, "…"))
+		}
+	}
+	if m.width > 0 {
+		s = ansi.Truncate(s, m.width, "…")
+	}
+	return s
+}
+
+// detailView 渲染选中任务的详情：标题、进度或错误，以及日志。
+func (m Model) detailView() string {
+	t := m.tasks[m.cursor]
+
+	header := []string{m.icon(t) + " " + m.Styles.Title.Render(t.Name) + "  " + m.status(t)}
+	if t.Status == Running && t.HasProgress {
+		header = append(header, m.Progress.ViewWithWidth(min(m.Progress.Width, m.width), t.Progress))
+	}
+	if t.Status == Failed && t.Err != nil {
+		header = append(header, m.Styles.Error.Render(t.Err.Error()))
+	}
+	header = append(header, "")
+
+	return strings.Join(header, "\n") + "\n" + m.log.View()
+}
+
+// detailHeaderHeight 返回详情视图中日志上方的行数。
+func (m Model) detailHeaderHeight() int {
+	if len(m.tasks) == 0 {
+		return 2
+	}
+	t := m.tasks[m.cursor]
+	h := 2
+	if t.Status == Running && t.HasProgress || t.Status == Failed && t.Err != nil {
+		h++
+	}
+	return h
+}
+
+// icon 返回任务的状态图标。运行中的任务显示加载动画。
+func (m Model) icon(t Task) string {
+	switch t.Status {
+	case Running:
+		sp := m.Spinner
+		sp.Style = m.Styles.Running
+		return sp.View()
+	case Succeeded:
+		return m.Styles.Succeeded.Render("✓")
+	case Failed:
+		return m.Styles.Failed.Render("✗")
+	default:
+		return m.Styles.Pending.Render("·")
+	}
+}
+
+// status 返回任务的状态文本，已开始的任务附带耗时。
+func (m Model) status(t Task) string {
+	s := t.Status.String()
+	if !t.Started.IsZero() {
+		s += " " + t.Elapsed().Round(100*time.Millisecond).String()
+	}
+	if t.Status == Failed && t.Err != nil && !m.detail {
+		return m.Styles.Info.Render(s+": ") + m.Styles.Error.Render(t.Err.Error())
+	}
+	return m.Styles.Info.Render(s)
+}
+
+// setStatus 设置任务的状态并返回所需的命令：启动加载动画，
+// 或在所有任务都结束时发送 DoneMsg。
+func (m *Model) setStatus(name string, status Status, err error) tea.Cmd {
+	t := m.task(name)
+	if t.Status == status {
+		return nil
+	}
+	t.Status = status
+	t.Err = nil
+	switch status {
+	case Pending:
+		t.Started, t.Finished = time.Time{}, time.Time{}
+	case Running:
+		t.Started, t.Finished = time.Now(), time.Time{}
+	default:
+		if t.Started.IsZero() {
+			t.Started = time.Now()
+		}
+		t.Finished = time.Now()
+		if status == Failed {
+			t.Err = err
+		}
+	}
+	if m.detail {
+		m.log.Height = max(0, m.height-m.detailHeaderHeight())
+	}
+
+	if status == Running && !m.ticking {
+		m.ticking = true
+		return m.Spinner.Tick
+	}
+	if status.Finished() && m.Done() {
+		done := DoneMsg{Succeeded: m.Count(Succeeded), Failed: m.Count(Failed)}
+		return func() tea.Msg { return done }
+	}
+	return nil
+}
+
+// index 返回给定名称的任务的索引。如果任务不存在，则返回 -1。
+func (m Model) index(name string) int {
+	for i, t := range m.tasks {
+		if t.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ensure 返回给定名称的任务的索引，如果任务不存在则先添加它。
+func (m *Model) ensure(name string) int {
+	if i := m.index(name); i >= 0 {
+		return i
+	}
+	m.tasks = append(m.tasks, Task{Name: name})
+	return len(m.tasks) - 1
+}
+
+// task 返回指向给定名称的任务的指针，如果任务不存在则先添加它。
+func (m *Model) task(name string) *Task {
+	return &m.tasks[m.ensure(name)]
+}
+
+// clampOffset 调整面板的滚动位置，使选中的任务可见。
+func (m *Model) clampOffset() {
+	if m.height <= 0 {
+		m.offset = 0
+		return
+	}
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.tasks)-m.height))
+}
@@ -0,0 +1,118 @@
+package tasks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/purpose168/bubbles-cn/spinner"
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+func TestLifecycle(t *testing.T) {
+	m := New(60, 5)
+	m.MaxLog = 2
+	m.Add("build", "test", "deploy")
+
+	if cmd := m.Start("build"); cmd == nil {
+		t.Fatal("expected starting a task to start the spinner")
+	}
+	if cmd := m.Start("test"); cmd != nil {
+		t.Fatal("expected the spinner to be started only once")
+	}
+
+	m, _ = m.Update(ProgressMsg{Name: "build", Percent: 0.5})
+	m, _ = m.Update(LogMsg{Name: "build", Line: "one"})
+	m, _ = m.Update(LogMsg{Name: "build", Line: "two"})
+	m, _ = m.Update(LogMsg{Name: "build", Line: "three"})
+	if task, _ := m.Task("build"); !task.HasProgress || task.Progress != 0.5 || strings.Join(task.Log, ",") != "two,three" {
+		t.Fatalf("expected progress and a two line log tail, got %+v", task)
+	}
+
+	m.Succeed("build")
+	m.Fail("test", errors.New("boom"))
+	if m.Done() || m.Summary() != "2/3 done, 1 failed" {
+		t.Fatalf("expected 2 of 3 tasks done, got %q", m.Summary())
+	}
+
+	// 没有运行中的任务时加载动画停止计时
+	if _, cmd := m.Update(spinner.TickMsg{ID: m.Spinner.ID()}); cmd != nil {
+		t.Fatal("expected the spinner to stop without running tasks")
+	}
+
+	var cmd tea.Cmd
+	m, cmd = m.Update(StatusMsg{Name: "deploy", Status: Succeeded})
+	if cmd == nil {
+		t.Fatal("expected a DoneMsg once all tasks finished")
+	}
+	if msg, ok := cmd().(DoneMsg); !ok || msg.Succeeded != 2 || msg.Failed != 1 {
+		t.Fatalf("expected DoneMsg{2, 1}, got %#v", cmd())
+	}
+}
+
+func TestBoardAndDetail(t *testing.T) {
+	m := New(50, 2)
+	m.Add("a", "b", "c")
+	m.Start("a")
+	m.AppendLog("a", "compiling")
+	m.Fail("b", errors.New("exit 1"))
+
+	view := ansi.Strip(m.View())
+	if lines := strings.Split(view, "\n"); len(lines) != 2 || !strings.Contains(lines[0], "compiling") || !strings.Contains(lines[1], "exit 1") {
+		t.Fatalf("unexpected board:\n%s", view)
+	}
+
+	// 移动到第三个任务时面板滚动
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if task, _ := m.Selected(); task.Name != "c" || !strings.Contains(ansi.Strip(m.View()), "c") || strings.Contains(ansi.Strip(m.View()), "a ") {
+		t.Fatalf("expected the board to scroll to c, got:\n%s", ansi.Strip(m.View()))
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.SetSize(50, 10)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.Detail() {
+		t.Fatal("expected enter to open the detail view")
+	}
+	m.AppendLog("a", "linking")
+	view = ansi.Strip(m.View())
+	if !strings.Contains(view, "compiling") || !strings.Contains(view, "linking") {
+		t.Fatalf("expected the detail view to show the log, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Detail() {
+		t.Fatal("expected esc to return to the board")
+	}
+}
+
+func TestRemoveDetail(t *testing.T) {
+	m := New(50, 10)
+	m.Add("a", "b", "c")
+	m.AppendLog("b", "b output")
+	m.AppendLog("c", "c output")
+
+	// 移除之前的任务时，详情视图仍显示原来的任务。
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.OpenDetail()
+	m.Remove("a")
+	if task, _ := m.Selected(); !m.Detail() || task.Name != "b" {
+		t.Fatalf("expected the detail view of b to stay open, got %q", task.Name)
+	}
+
+	// 移除详情视图显示的任务时，返回面板，不再显示它的日志。
+	m.Remove("b")
+	if m.Detail() {
+		t.Fatal("expected removing the shown task to close the detail view")
+	}
+	if view := ansi.Strip(m.View()); strings.Contains(view, "b output") {
+		t.Fatalf("expected the removed task's log to be gone, got:\n%s", view)
+	}
+	m.OpenDetail()
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "c output") || strings.Contains(view, "b output") {
+		t.Fatalf("expected the detail view of c, got:\n%s", view)
+	}
+}
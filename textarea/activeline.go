@@ -0,0 +1,81 @@
+package textarea
+
+import lipgloss "github.com/purpose168/lipgloss-cn"
+
+// CursorLineSpan 决定 CursorLine 样式覆盖光标所在行的哪些部分。
+type CursorLineSpan int
+
+const (
+	// SpanFull 使光标行样式覆盖提示符、行号和文本，整个活动行保持一致。这是默认值。
+	SpanFull CursorLineSpan = iota
+
+	// SpanLineNumber 使光标行样式覆盖行号和文本，提示符使用普通样式。
+	SpanLineNumber
+
+	// SpanText 使光标行样式只覆盖文本，提示符和行号使用普通样式，
+	// 行号仍然使用 CursorLineNumber 样式的颜色。
+	SpanText
+)
+
+// promptStyle 返回某一行提示符的样式。active 表示该行是光标所在的行。
+func (m Model) promptStyle(active bool) lipgloss.Style {
+	if active && m.CursorLineSpan == SpanFull {
+		return m.style.Prompt.Inherit(m.style.CursorLine).Inherit(m.style.Base).Inline(true)
+	}
+	return m.style.computedPrompt()
+}
+
+// lineNumberStyle 返回某一行行号的样式。active 表示该行是光标所在的行。
+func (m Model) lineNumberStyle(active bool) lipgloss.Style {
+	switch {
+	case !active:
+		return m.style.computedLineNumber()
+	case m.CursorLineSpan == SpanText:
+		return m.style.CursorLineNumber.Inherit(m.style.Base).Inline(true)
+	default:
+		return m.style.computedCursorLineNumber()
+	}
+}
+
+// baseStyle 返回当前状态的 Base 样式。聚焦时，FocusRing 中设置的边框样式和
+// 边框颜色覆盖 Base 的边框。
+func (m Model) baseStyle() lipgloss.Style {
+	base := m.style.Base
+	if !m.focus {
+		return base
+	}
+
+	r := m.FocusRing
+	if b := r.GetBorderStyle(); b != (lipgloss.Border{}) {
+		base = base.BorderStyle(b)
+	}
+	set := func(c lipgloss.TerminalColor) bool {
+		_, unset := c.(lipgloss.NoColor)
+		return !unset
+	}
+	if c := r.GetBorderTopForeground(); set(c) {
+		base = base.BorderTopForeground(c)
+	}
+	if c := r.GetBorderRightForeground(); set(c) {
+		base = base.BorderRightForeground(c)
+	}
+	if c := r.GetBorderBottomForeground(); set(c) {
+		base = base.BorderBottomForeground(c)
+	}
+	if c := r.GetBorderLeftForeground(); set(c) {
+		base = base.BorderLeftForeground(c)
+	}
+	if c := r.GetBorderTopBackground(); set(c) {
+		base = base.BorderTopBackground(c)
+	}
+	if c := r.GetBorderRightBackground(); set(c) {
+		base = base.BorderRightBackground(c)
+	}
+	if c := r.GetBorderBottomBackground(); set(c) {
+		base = base.BorderBottomBackground(c)
+	}
+	if c := r.GetBorderLeftBackground(); set(c) {
+		base = base.BorderLeftBackground(c)
+	}
+	return base
+}
@@ -0,0 +1,142 @@
+package textarea
+
+import "unicode"
+
+// autoPairs 是 AutoPair 自动补全的开闭字符对。引号的开闭字符相同。
+var autoPairs = map[rune]rune{
+	'(': ')', '[': ']', '{': '}',
+	'"': '"', '\'': '\'', '`': '`',
+	'（': '）', '「': '」', '『': '』', '《': '》', '“': '”', '‘': '’',
+}
+
+// closers 是 autoPairs 中所有的闭合字符。
+var closers = func() map[rune]bool {
+	c := make(map[rune]bool, len(autoPairs))
+	for _, r := range autoPairs {
+		c[r] = true
+	}
+	return c
+}()
+
+// openers 将括号的闭合字符映射到开启字符，用于匹配括号。引号不参与匹配，
+// 因为无法区分它们是开启还是闭合。
+var openers = func() map[rune]rune {
+	o := make(map[rune]rune, len(autoPairs))
+	for open, r := range autoPairs {
+		if open != r {
+			o[r] = open
+		}
+	}
+	return o
+}()
+
+// insertTyped 插入用户键入的字符。启用 AutoPair 时，键入开启字符会同时插入对应的
+// 闭合字符并把光标留在两者之间；光标后正好是键入的闭合字符时，光标跳过它而不再插入。
+func (m *Model) insertTyped(runes []rune) {
+	if !m.AutoPair || len(runes) != 1 {
+		m.insertRunesFromUserInput(runes)
+		return
+	}
+
+	r, line := runes[0], m.value[m.row]
+	m.col = clamp(m.col, 0, len(line))
+	if closers[r] && m.col < len(line) && line[m.col] == r {
+		m.SetCursor(m.col + 1)
+		return
+	}
+	if closer, ok := autoPairs[r]; ok && m.shouldPair(r) {
+		before := m.Length()
+		m.insertRunesFromUserInput([]rune{r, closer})
+		if m.Length()-before == 2 { //nolint:mnd
+			m.SetCursor(m.col - 1)
+		}
+		return
+	}
+	m.insertRunesFromUserInput(runes)
+}
+
+// shouldPair 返回在光标处键入开启字符 r 时是否应该自动插入闭合字符：
+// 光标后必须是行尾、空白或闭合字符；对于引号，光标前也不能是字母或数字，
+// 以免在 "don't" 这样的单词中插入多余的引号。
+func (m Model) shouldPair(r rune) bool {
+	line := m.value[m.row]
+	if m.col < len(line) && !unicode.IsSpace(line[m.col]) && !closers[line[m.col]] {
+		return false
+	}
+	if autoPairs[r] == r && m.col > 0 {
+		prev := line[m.col-1]
+		return !unicode.IsLetter(prev) && !unicode.IsDigit(prev) && prev != r
+	}
+	return true
+}
+
+// deletePair 在启用 AutoPair 并且光标位于一对空的开闭字符之间时，同时删除两者，
+// 并返回 true。
+func (m *Model) deletePair() bool {
+	line := m.value[m.row]
+	if !m.AutoPair || m.col <= 0 || m.col >= len(line) {
+		return false
+	}
+	if closer, ok := autoPairs[line[m.col-1]]; !ok || line[m.col] != closer {
+		return false
+	}
+	m.value[m.row] = append(line[:m.col-1], line[m.col+1:]...)
+	m.SetCursor(m.col - 1)
+	return true
+}
+
+// matchingBrackets 返回光标处的括号及与之匹配的括号的位置。如果光标处不是括号，
+// 则检查光标之前的字符。没有匹配的括号时返回 nil。
+func (m Model) matchingBrackets() []Position {
+	line := m.value[m.row]
+	for _, col := range []int{m.col, m.col - 1} {
+		if col < 0 || col >= len(line) {
+			continue
+		}
+		at := Position{Row: m.row, Col: col}
+		if match, ok := m.matchBracket(at); ok {
+			return []Position{at, match}
+		}
+	}
+	return nil
+}
+
+// matchBracket 返回与位置 p 处的括号匹配的括号的位置，跨行查找并考虑嵌套。
+func (m Model) matchBracket(p Position) (Position, bool) {
+	r := m.value[p.Row][p.Col]
+	var (
+		opener, closer rune
+		dir            int
+	)
+	if c, ok := autoPairs[r]; ok && c != r {
+		opener, closer, dir = r, c, 1
+	} else if o, ok := openers[r]; ok {
+		opener, closer, dir = o, r, -1
+	} else {
+		return Position{}, false
+	}
+
+	depth := 0
+	for row, col := p.Row, p.Col; row >= 0 && row < len(m.value); {
+		line := m.value[row]
+		for ; col >= 0 && col < len(line); col += dir {
+			switch line[col] {
+			case opener:
+				depth += dir
+			case closer:
+				depth -= dir
+			}
+			if depth == 0 {
+				return Position{Row: row, Col: col}, true
+			}
+		}
+		row += dir
+		if row >= 0 && row < len(m.value) {
+			col = 0
+			if dir < 0 {
+				col = len(m.value[row]) - 1
+			}
+		}
+	}
+	return Position{}, false
+}
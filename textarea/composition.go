@@ -0,0 +1,89 @@
+package textarea
+
+import (
+	"github.com/purpose168/bubbles-cn/runeutil"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// SetComposition 设置输入法正在编辑、尚未提交的预编辑文本（例如拼音或假名输入中
+// 的候选文本）。预编辑文本会以 Composition 样式渲染在光标处，并参与软换行的计算，
+// 但不会成为值的一部分。传入空字符串以清除预编辑文本。
+func (m *Model) SetComposition(s string) {
+	m.composition = []rune(s)
+	c := m.composed()
+	c.repositionView()
+}
+
+// Composition 返回当前的预编辑文本。
+func (m Model) Composition() string {
+	return string(m.composition)
+}
+
+// Composing 返回是否有正在编辑的预编辑文本。
+func (m Model) Composing() bool {
+	return len(m.composition) > 0
+}
+
+// CommitComposition 将预编辑文本插入到光标处并清除它。
+func (m *Model) CommitComposition() {
+	runes := m.composition
+	m.composition = nil
+	if len(runes) > 0 {
+		m.insertRunesFromUserInput(runes)
+	}
+}
+
+// CursorPosition 返回光标相对于视图左上角的屏幕位置（以单元格为单位），
+// 考虑了提示符、行号、软换行、滚动和预编辑文本。光标位于预编辑文本的末尾。
+// 应用程序可以用它来定位终端光标，使输入法的候选窗口出现在正确的位置。
+func (m Model) CursorPosition() (x, y int) {
+	m = m.composed()
+
+	x = m.style.Base.GetBorderLeftSize() + m.style.Base.GetPaddingLeft()
+	x += runeutil.StringWidth(m.getPromptString(m.cursorLineNumber()))
+	if m.ShowLineNumbers {
+		x += runeutil.StringWidth(m.formatLineNumber(m.row + 1))
+	}
+	x += m.LineInfo().CharOffset
+
+	y = m.style.Base.GetBorderTopSize() + m.style.Base.GetPaddingTop()
+	y += m.cursorLineNumber() - m.viewport.YOffset
+	return x, y
+}
+
+// composed 返回一个将预编辑文本插入到光标处的副本，光标位于预编辑文本之后。
+// 副本仅用于渲染和计算位置，不会修改原始值。
+func (m Model) composed() Model {
+	if len(m.composition) == 0 {
+		m.compStart, m.compEnd = 0, 0
+		return m
+	}
+
+	row := make([]rune, 0, len(m.value[m.row])+len(m.composition))
+	row = append(row, m.value[m.row][:m.col]...)
+	row = append(row, m.composition...)
+	row = append(row, m.value[m.row][m.col:]...)
+
+	value := make([][]rune, len(m.value))
+	copy(value, m.value)
+	value[m.row] = row
+	m.value = value
+
+	m.compStart = m.col
+	m.compEnd = m.col + len(m.composition)
+	m.col = m.compEnd
+	return m
+}
+
+// renderRunes 渲染光标行中从 offset 开始的字符，并以 Composition 样式渲染
+// 其中的预编辑文本，以 SecondaryCursor 样式渲染其中的次光标。
+func (m Model) renderRunes(style lipgloss.Style, runes []rune, offset int) string {
+	start := clamp(m.compStart-offset, 0, len(runes))
+	end := clamp(m.compEnd-offset, 0, len(runes))
+	if start >= end {
+		return m.renderSecondary(style, m.row, runes, offset)
+	}
+	return m.renderSecondary(style, m.row, runes[:start], offset) +
+		m.style.computedComposition().Render(m.expandTabs(runes[start:end])) +
+		m.renderSecondary(style, m.row, runes[end:], offset+end)
+}
@@ -0,0 +1,114 @@
+package textarea
+
+import (
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/runeutil"
+)
+
+// defaultTabWidth 是默认的制表符宽度。
+const defaultTabWidth = 4
+
+// TabMode 决定 InsertTab 键以及粘贴的文本中的制表符如何处理。
+type TabMode int
+
+// 可用的制表符处理方式。
+const (
+	// TabIgnore 忽略 InsertTab 键，粘贴的制表符替换为 4 个空格（默认）。
+	// 适用于 tab 用于在表单中切换焦点的应用程序。
+	TabIgnore TabMode = iota
+
+	// TabSpaces 将制表符替换为 TabWidth 个空格。
+	TabSpaces
+
+	// TabHard 插入真正的制表符，按 TabWidth 个单元格的固定宽度渲染。
+	TabHard
+)
+
+// tabWidth 返回制表符的宽度。
+func (m Model) tabWidth() int {
+	if m.TabWidth <= 0 {
+		return defaultTabWidth
+	}
+	return m.TabWidth
+}
+
+// tabReplacement 返回清理器替换制表符所用的字符串。
+func (m Model) tabReplacement() string {
+	switch m.TabMode {
+	case TabSpaces:
+		return strings.Repeat(" ", m.tabWidth())
+	case TabHard:
+		return "\t"
+	default:
+		return strings.Repeat(" ", defaultTabWidth)
+	}
+}
+
+// insertTab 在光标位置插入一个制表符或 TabWidth 个空格。
+func (m *Model) insertTab() {
+	m.insertRunesFromUserInput([]rune(m.tabReplacement()))
+}
+
+// indentation 返回第 row 行在第 col 列之前的前导空白（空格和制表符）。
+func (m Model) indentation(row, col int) []rune {
+	line := m.value[row][:col]
+	n := 0
+	for n < len(line) && (line[n] == ' ' || line[n] == '\t') {
+		n++
+	}
+	return append([]rune(nil), line[:n]...)
+}
+
+// insertNewline 在光标位置分割行。如果启用了 AutoIndent，新行沿用当前行的缩进。
+func (m *Model) insertNewline() {
+	m.col = clamp(m.col, 0, len(m.value[m.row]))
+	var indent []rune
+	if m.AutoIndent {
+		indent = m.indentation(m.row, m.col)
+	}
+
+	rows := len(m.value)
+	m.splitLine(m.row, m.col)
+	if len(m.value) == rows || len(indent) == 0 {
+		return
+	}
+	if m.CharLimit > 0 {
+		indent = indent[:clamp(m.CharLimit-m.Length(), 0, len(indent))]
+	}
+	m.value[m.row] = append(indent, m.value[m.row]...)
+	m.col = len(indent)
+}
+
+// widthWithTabs 返回字符的显示宽度，每个制表符占 tab 个单元格。
+func widthWithTabs(runes []rune, tab int) int {
+	w := runeutil.StringWidth(string(runes))
+	for _, r := range runes {
+		if r == '\t' {
+			w += tab
+		}
+	}
+	return w
+}
+
+// runesWidth 返回字符的显示宽度，每个制表符占 TabWidth 个单元格。
+func (m Model) runesWidth(runes []rune) int {
+	return widthWithTabs(runes, m.tabWidth())
+}
+
+// runeWidth 返回单个字符的显示宽度，制表符占 TabWidth 个单元格。
+func (m Model) runeWidth(r rune) int {
+	if r == '\t' {
+		return m.tabWidth()
+	}
+	return runeutil.RuneWidth(r)
+}
+
+// expandTabs 将字符中的制表符展开为 TabWidth 个空格以供渲染。
+func (m Model) expandTabs(runes []rune) string {
+	s := string(runes)
+	if !strings.ContainsRune(s, '\t') {
+		return s
+	}
+	return strings.ReplaceAll(s, "\t", strings.Repeat(" ", m.tabWidth()))
+}
@@ -0,0 +1,70 @@
+package textarea
+
+import (
+	"strings"
+
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// SetLabel 设置文本区域的标签，例如表单字段的名称。如果 Base 样式有上边框，
+// 标签嵌入在上边框中；否则渲染在文本区域上方单独的一行，这一行不计入
+// SetHeight 设置的高度。标签使用当前状态（聚焦或模糊）的 Label 样式。
+// 传入空字符串移除标签。
+func (m *Model) SetLabel(label string) {
+	m.label = label
+}
+
+// Label 返回文本区域的标签。
+func (m Model) Label() string {
+	return m.label
+}
+
+// renderBase 使用 Base 样式渲染文本区域的内容，并在有标签时添加标签。
+func (m Model) renderBase(content string) string {
+	base := m.baseStyle()
+	if m.label == "" {
+		return base.Render(content)
+	}
+
+	label := m.style.Label.Inline(true).Render(m.label)
+	if !base.GetBorderTop() || base.GetBorderStyle().Top == "" {
+		return label + "\n" + base.Render(content)
+	}
+
+	// 先渲染不带外边距的边框，将标签嵌入上边框后再添加外边距。
+	top, right, bottom, left := base.GetMargin()
+	lines := strings.Split(base.UnsetMargins().Render(content), "\n")
+	lines[0] = labeledBorder(base, lines[0], label)
+	return lipgloss.NewStyle().
+		Margin(top, right, bottom, left).
+		Render(strings.Join(lines, "\n"))
+}
+
+// labeledBorder 返回嵌入了标签的上边框。如果标签放不下，则返回原来的上边框。
+func labeledBorder(base lipgloss.Style, line, label string) string {
+	b := base.GetBorderStyle()
+	style := lipgloss.NewStyle().
+		Foreground(base.GetBorderTopForeground()).
+		Background(base.GetBorderTopBackground())
+
+	var left, right string
+	if base.GetBorderLeft() {
+		left = b.TopLeft
+	}
+	if base.GetBorderRight() {
+		right = b.TopRight
+	}
+
+	width := ansi.StringWidth(line)
+	lead := left + b.Top + " "
+	avail := width - ansi.StringWidth(lead) - ansi.StringWidth(right) - 1
+	if avail < 1 {
+		return line
+	}
+	label = ansi.Truncate(label, avail, "…")
+
+	fill := avail - ansi.StringWidth(label)
+	return style.Render(lead) + label +
+		style.Render(" "+strings.Repeat(b.Top, fill/max(1, ansi.StringWidth(b.Top)))+right)
+}
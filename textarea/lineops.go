@@ -0,0 +1,51 @@
+package textarea
+
+// DeleteLine 删除光标所在的行。光标移到下一行（如果删除的是最后一行，则移到上一行），
+// 并尽量保持所在的列。只有一行时清空该行。
+func (m *Model) DeleteLine() {
+	if len(m.value) <= 1 {
+		m.value = [][]rune{{}}
+		m.row, m.col = 0, 0
+		return
+	}
+	m.value = append(m.value[:m.row:m.row], m.value[m.row+1:]...)
+	m.row = min(m.row, len(m.value)-1)
+	m.SetCursor(m.col)
+}
+
+// DuplicateLine 在光标所在的行下方插入该行的副本，并将光标移到副本上的同一列。
+// 如果超出 MaxLines、MaxHeight 或 CharLimit，则不做任何事情。
+func (m *Model) DuplicateLine() {
+	if m.MaxLines > 0 && len(m.value) >= m.MaxLines ||
+		m.MaxHeight > 0 && len(m.value) >= m.MaxHeight ||
+		m.CharLimit > 0 && m.Length()+len(m.value[m.row])+1 > m.CharLimit {
+		return
+	}
+
+	line := make([]rune, len(m.value[m.row]))
+	copy(line, m.value[m.row])
+	value := make([][]rune, 0, len(m.value)+1)
+	value = append(value, m.value[:m.row+1]...)
+	value = append(value, line)
+	m.value = append(value, m.value[m.row+1:]...)
+	m.row++
+	m.growCache()
+}
+
+// MoveLineUp 将光标所在的行与上一行交换，光标随行移动。
+func (m *Model) MoveLineUp() {
+	if m.row <= 0 {
+		return
+	}
+	m.value[m.row-1], m.value[m.row] = m.value[m.row], m.value[m.row-1]
+	m.row--
+}
+
+// MoveLineDown 将光标所在的行与下一行交换，光标随行移动。
+func (m *Model) MoveLineDown() {
+	if m.row >= len(m.value)-1 {
+		return
+	}
+	m.value[m.row+1], m.value[m.row] = m.value[m.row], m.value[m.row+1]
+	m.row++
+}
@@ -0,0 +1,75 @@
+package textarea
+
+import (
+	"strings"
+
+	"github.com/purpose168/bubbles-cn/runeutil"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// StyledSegment 是占位符行中使用同一样式的一段文本。
+type StyledSegment struct {
+	Text  string
+	Style lipgloss.Style
+}
+
+// StyledLine 是由若干段组成的一行占位符，参见 Model.PlaceholderFunc。
+type StyledLine []StyledSegment
+
+// hasPlaceholder 返回是否设置了占位符。
+func (m Model) hasPlaceholder() bool {
+	return m.Placeholder != "" || m.PlaceholderFunc != nil
+}
+
+// placeholderLines 返回占位符的各行。Placeholder 按宽度换行，返回的是纯文本；
+// PlaceholderFunc 返回的行已经渲染了各段的样式，并截断到宽度。
+func (m Model) placeholderLines() []string {
+	if m.PlaceholderFunc == nil {
+		// 自动换行
+		pwordwrap := ansi.Wordwrap(m.Placeholder, m.width, "")
+		// 换行（处理无法自动换行的行）
+		pwrap := ansi.Hardwrap(pwordwrap, m.width, true)
+		// 按换行符分割字符串
+		return strings.Split(strings.TrimSpace(pwrap), "\n")
+	}
+
+	base := m.style.computedPlaceholder()
+	styled := m.PlaceholderFunc()
+	lines := make([]string, 0, len(styled))
+	for _, line := range styled {
+		var b strings.Builder
+		for _, seg := range line {
+			b.WriteString(seg.Style.Inherit(base).Inline(true).Render(seg.Text))
+		}
+		lines = append(lines, ansi.Truncate(b.String(), m.width, ""))
+	}
+	return lines
+}
+
+// placeholderTop 返回占位符第一行所在的行。只有设置了 CenterPlaceholder
+// 并且占位符的行数少于高度时才不为 0。
+func (m Model) placeholderTop(n int) int {
+	if !m.CenterPlaceholder || n >= m.height {
+		return 0
+	}
+	return (m.height - n) / 2 //nolint:mnd
+}
+
+// splitPlaceholderLine 将占位符的第一行分为显示在光标下的第一个字符和其余部分。
+func (m Model) splitPlaceholderLine(line string) (string, string) {
+	if m.PlaceholderFunc == nil {
+		return runeutil.FirstGrapheme(line)
+	}
+	ch, _ := runeutil.FirstGrapheme(ansi.Strip(line))
+	w := ansi.StringWidth(line)
+	return ch, ansi.Cut(line, runeutil.StringWidth(ch), w)
+}
+
+// renderPlaceholderText 渲染占位符的文本。PlaceholderFunc 返回的行已经带有样式。
+func (m Model) renderPlaceholderText(style lipgloss.Style, text string) string {
+	if m.PlaceholderFunc != nil {
+		return text
+	}
+	return style.Render(text)
+}
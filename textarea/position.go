@@ -0,0 +1,67 @@
+package textarea
+
+import "unicode/utf8"
+
+// PositionInfo 描述光标在整个值中的位置以及值的总大小，
+// 供状态栏显示诸如 "Ln 12, Col 4 (1.2k chars)" 的信息。
+// 所有偏移量都从 0 开始，换行符计为一个字节和一个字符。
+type PositionInfo struct {
+	// Byte 是光标在值中的字节偏移量（UTF-8）。
+	Byte int
+	// Rune 是光标在值中的字符（rune）偏移量。
+	Rune int
+	// Line 是光标所在的行，与 Line() 相同。
+	Line int
+	// Column 是光标在行中的字符偏移量。
+	Column int
+	// VisualColumn 是光标在行中的显示列，考虑了双宽度字符，不考虑软换行。
+	VisualColumn int
+
+	// TotalBytes 是值的字节数。
+	TotalBytes int
+	// TotalRunes 是值的字符数。
+	TotalRunes int
+	// TotalLines 是值的行数。
+	TotalLines int
+}
+
+// PositionInfo 返回光标的位置信息。它直接遍历内部缓冲区，
+// 不会像 Value() 那样构建整个值的字符串。
+func (m Model) PositionInfo() PositionInfo {
+	info := PositionInfo{
+		Line:       m.row,
+		Column:     m.col,
+		TotalLines: len(m.value),
+	}
+
+	for i, row := range m.value {
+		n := runesLen(row)
+		if i > 0 {
+			// 上一行末尾的换行符
+			info.TotalBytes++
+			info.TotalRunes++
+		}
+		if i < m.row {
+			info.Byte = info.TotalBytes + n
+			info.Rune = info.TotalRunes + len(row)
+		}
+		if i == m.row {
+			col := clamp(m.col, 0, len(row))
+			info.Byte = info.TotalBytes + runesLen(row[:col])
+			info.Rune = info.TotalRunes + col
+			info.VisualColumn = m.runesWidth(row[:col])
+		}
+		info.TotalBytes += n
+		info.TotalRunes += len(row)
+	}
+	return info
+}
+
+// runesLen 返回字符以 UTF-8 编码后的字节数。
+func runesLen(runes []rune) int {
+	var n int
+	for _, r := range runes {
+		n += utf8.RuneLen(r)
+	}
+	return n
+}
@@ -0,0 +1,84 @@
+package textarea
+
+import (
+	"sort"
+	"strings"
+
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// Position 是值中的一个位置：Row 是行，Col 是行中的字符（rune）偏移量，都从 0 开始。
+type Position struct {
+	Row int
+	Col int
+}
+
+// SetSecondaryPositions 设置次光标的位置。次光标以 Style.SecondaryCursor 样式渲染，
+// 不会闪烁，也不影响编辑；只有主光标闪烁并接收输入。这为多光标编辑显示所有光标。
+// 超出值范围的位置以及与主光标重合的位置不会渲染。传入 nil 清除次光标。
+func (m *Model) SetSecondaryPositions(positions []Position) {
+	m.secondary = append(m.secondary[:0:0], positions...)
+	sort.Slice(m.secondary, func(i, j int) bool {
+		if m.secondary[i].Row == m.secondary[j].Row {
+			return m.secondary[i].Col < m.secondary[j].Col
+		}
+		return m.secondary[i].Row < m.secondary[j].Row
+	})
+}
+
+// SecondaryPositions 返回次光标的位置，按行和列排序。
+func (m Model) SecondaryPositions() []Position {
+	return append([]Position(nil), m.secondary...)
+}
+
+// mark 是以特殊样式渲染的一个字符。
+type mark struct {
+	col   int
+	style lipgloss.Style
+}
+
+// renderSecondary 渲染第 row 行中从 offset 开始的字符，并以 SecondaryCursor 样式
+// 渲染其中的次光标，以 MatchingBracket 样式渲染匹配的括号。聚焦时才渲染它们。
+func (m Model) renderSecondary(style lipgloss.Style, row int, runes []rune, offset int) string {
+	marks := m.marks(style, row)
+	if len(marks) == 0 {
+		return style.Render(m.expandTabs(runes))
+	}
+
+	var (
+		b    strings.Builder
+		last int
+	)
+	for _, mk := range marks {
+		i := mk.col - offset
+		if i < last || i >= len(runes) {
+			continue
+		}
+		b.WriteString(style.Render(m.expandTabs(runes[last:i])))
+		b.WriteString(mk.style.Render(m.expandTabs(runes[i : i+1])))
+		last = i + 1
+	}
+	b.WriteString(style.Render(m.expandTabs(runes[last:])))
+	return b.String()
+}
+
+// marks 返回第 row 行中以特殊样式渲染的字符，按列排序。主光标所在的字符除外。
+func (m Model) marks(style lipgloss.Style, row int) []mark {
+	if !m.focus || (len(m.secondary) == 0 && len(m.brackets) == 0) {
+		return nil
+	}
+
+	var marks []mark
+	add := func(positions []Position, s lipgloss.Style) {
+		s = s.Inherit(style).Inline(true)
+		for _, p := range positions {
+			if p.Row == row && (p.Row != m.row || p.Col != m.col) {
+				marks = append(marks, mark{col: p.Col, style: s})
+			}
+		}
+	}
+	add(m.secondary, m.style.SecondaryCursor)
+	add(m.brackets, m.style.MatchingBracket)
+	sort.SliceStable(marks, func(i, j int) bool { return marks[i].col < marks[j].col })
+	return marks
+}
@@ -9,7 +9,6 @@ import (
 	"unicode"
 
 	"github.com/atotto/clipboard"
-	rw "github.com/mattn/go-runewidth"
 	"github.com/purpose168/bubbles-cn/cursor"
 	"github.com/purpose168/bubbles-cn/key"
 	"github.com/purpose168/bubbles-cn/runeutil"
@@ -18,7 +17,6 @@ import (
 	tea "github.com/purpose168/bubbletea-cn"
 	"github.com/purpose168/charm-experimental-packages-cn/ansi"
 	lipgloss "github.com/purpose168/lipgloss-cn"
-	"github.com/rivo/uniseg"
 )
 
 const (
@@ -29,8 +27,8 @@ const (
 	defaultMaxHeight = 99  // 默认最大高度
 	defaultMaxWidth  = 500 // 默认最大宽度
 
-	// XXX: 在 v2 版本中，使最大行数动态化，并使默认最大行数可配置。
-	maxLines = 10000 // 最大行数
+	defaultMaxLines = 10000 // 默认最大行数
+	cacheSize       = 10000 // 软换行缓存的默认容量
 )
 
 // 剪贴板操作的内部消息。
@@ -50,6 +48,7 @@ type KeyMap struct {
 	DeleteWordBackward      key.Binding // 向后删除单词
 	DeleteWordForward       key.Binding // 向前删除单词
 	InsertNewline           key.Binding // 插入换行
+	InsertTab               key.Binding // 插入制表符，参见 Model.TabMode
 	LineEnd                 key.Binding // 行尾
 	LineNext                key.Binding // 下一行
 	LinePrevious            key.Binding // 上一行
@@ -65,6 +64,11 @@ type KeyMap struct {
 	CapitalizeWordForward key.Binding // 向前首字母大写单词
 
 	TransposeCharacterBackward key.Binding // 向前交换字符
+
+	DeleteLine    key.Binding // 删除当前行
+	DuplicateLine key.Binding // 复制当前行
+	MoveLineUp    key.Binding // 将当前行上移
+	MoveLineDown  key.Binding // 将当前行下移
 }
 
 // DefaultKeyMap 是用于在 textarea 中导航和操作的默认键绑定集合。
@@ -80,6 +84,7 @@ var DefaultKeyMap = KeyMap{
 	DeleteAfterCursor:       key.NewBinding(key.WithKeys("ctrl+k"), key.WithHelp("ctrl+k", "delete after cursor")),
 	DeleteBeforeCursor:      key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "delete before cursor")),
 	InsertNewline:           key.NewBinding(key.WithKeys("enter", "ctrl+m"), key.WithHelp("enter", "insert newline")),
+	InsertTab:               key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "insert tab")),
 	DeleteCharacterBackward: key.NewBinding(key.WithKeys("backspace", "ctrl+h"), key.WithHelp("backspace", "delete character backward")),
 	DeleteCharacterForward:  key.NewBinding(key.WithKeys("delete", "ctrl+d"), key.WithHelp("delete", "delete character forward")),
 	LineStart:               key.NewBinding(key.WithKeys("home", "ctrl+a"), key.WithHelp("home", "line start")),
@@ -93,6 +98,11 @@ var DefaultKeyMap = KeyMap{
 	UppercaseWordForward:  key.NewBinding(key.WithKeys("alt+u"), key.WithHelp("alt+u", "uppercase word forward")),
 
 	TransposeCharacterBackward: key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", "transpose character backward")),
+
+	DeleteLine:    key.NewBinding(key.WithKeys("alt+k"), key.WithHelp("alt+k", "delete line")),
+	DuplicateLine: key.NewBinding(key.WithKeys("alt+y"), key.WithHelp("alt+y", "duplicate line")),
+	MoveLineUp:    key.NewBinding(key.WithKeys("alt+up"), key.WithHelp("alt+up", "move line up")),
+	MoveLineDown:  key.NewBinding(key.WithKeys("alt+down"), key.WithHelp("alt+down", "move line down")),
 }
 
 // LineInfo 是一个辅助结构，用于跟踪软换行相关的行信息。
@@ -129,6 +139,10 @@ type Style struct {
 	Placeholder      lipgloss.Style // 占位符样式
 	Prompt           lipgloss.Style // 提示符样式
 	Text             lipgloss.Style // 文本样式
+	Composition      lipgloss.Style // 输入法预编辑文本样式
+	Label            lipgloss.Style // 标签样式（参见 SetLabel）
+	SecondaryCursor  lipgloss.Style // 次光标样式（参见 SetSecondaryPositions）
+	MatchingBracket  lipgloss.Style // 光标处的括号及与之匹配的括号的样式（参见 AutoPair）
 }
 
 func (s Style) computedCursorLine() lipgloss.Style {
@@ -142,6 +156,13 @@ func (s Style) computedCursorLineNumber() lipgloss.Style {
 		Inline(true)
 }
 
+func (s Style) computedComposition() lipgloss.Style {
+	return s.Composition.
+		Inherit(s.CursorLine).
+		Inherit(s.Base).
+		Inline(true)
+}
+
 func (s Style) computedEndOfBuffer() lipgloss.Style {
 	return s.EndOfBuffer.Inherit(s.Base).Inline(true)
 }
@@ -164,13 +185,15 @@ func (s Style) computedText() lipgloss.Style {
 
 // line 是文本换行函数的输入。这存储在一个结构体中，以便进行哈希和记忆化。
 type line struct {
-	runes []rune // 字符数组
-	width int    // 宽度
+	runes []rune   // 字符数组
+	width int      // 宽度
+	tab   int      // 制表符宽度
+	mode  WrapMode // 换行模式
 }
 
 // Hash 返回行的哈希值。
 func (w line) Hash() string {
-	v := fmt.Sprintf("%s:%d", string(w.runes), w.width)
+	v := fmt.Sprintf("%s:%d:%d:%d", string(w.runes), w.width, w.tab, w.mode)
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(v)))
 }
 
@@ -191,12 +214,39 @@ type Model struct {
 	// Placeholder 是当用户尚未输入任何内容时显示的文本。
 	Placeholder string
 
+	// PlaceholderFunc 如果设置，返回代替 Placeholder 显示的带样式的占位符行，
+	// 例如暗淡的提示加上高亮的按键提示。每行由若干段组成，每段的样式继承
+	// Placeholder 样式。超出宽度的行被截断而不是换行。
+	PlaceholderFunc func() []StyledLine
+
+	// CenterPlaceholder 使行数少于文本区域高度的占位符垂直居中显示。
+	// 光标仍然位于第一行。
+	CenterPlaceholder bool
+
 	// ShowLineNumbers 如果启用，会导致在提示符后打印行号。
 	ShowLineNumbers bool
 
 	// EndOfBufferCharacter 在输入的末尾显示。
 	EndOfBufferCharacter rune
 
+	// CursorLineSpan 决定 CursorLine 样式覆盖光标所在行的哪些部分：
+	// 提示符、行号和文本（默认），或者只覆盖其中的一部分。
+	CursorLineSpan CursorLineSpan
+
+	// FocusRing 是聚焦时叠加在 Base 边框上的样式。只使用它的边框样式和边框颜色，
+	// 因此它不会改变文本区域的大小：边框的各边仍由 Base 决定。
+	FocusRing lipgloss.Style
+
+	// AutoIndent 在插入换行时使新行沿用当前行的缩进（光标之前的前导空格和制表符）。
+	AutoIndent bool
+
+	// TabMode 决定 InsertTab 键和粘贴的文本中的制表符如何处理，默认忽略 InsertTab 键。
+	TabMode TabMode
+
+	// TabWidth 是 TabSpaces 模式下插入的空格数，以及 TabHard 模式下制表符渲染的宽度。
+	// 默认为 4。
+	TabWidth int
+
 	// KeyMap 编码了小部件识别的键绑定。
 	KeyMap KeyMap
 
@@ -220,6 +270,19 @@ type Model struct {
 	// MaxWidth 是文本区域的最大宽度（以列为单位）。如果为 0 或更小，则没有限制。
 	MaxWidth int
 
+	// MaxLines 是文本区域接受的最大（硬换行）行数。如果为 0 或更小，则没有限制。
+	// 默认为 10000。
+	MaxLines int
+
+	// WrapMode 决定软换行时可以在哪里断行。默认为 WordBoundary，只在空白处断行；
+	// 没有空格的中文文本应使用 CJKFriendly。
+	WrapMode WrapMode
+
+	// AutoPair 启用括号和引号的自动配对：键入开启字符时自动插入闭合字符，
+	// 光标后正好是键入的闭合字符时跳过它，在一对空的括号之间退格时同时删除两者。
+	// 启用后，光标处的括号及与之匹配的括号以 Style.MatchingBracket 样式高亮。
+	AutoPair bool
+
 	// 如果设置了 promptFunc，它将替换 Prompt 作为每行开头提示符字符串的生成器。
 	promptFunc func(line int) string
 
@@ -251,8 +314,24 @@ type Model struct {
 	// viewport 是多行文本输入的垂直滚动视口。
 	viewport *viewport.Model
 
-	// 输入的字符清理器。
-	rsan runeutil.Sanitizer
+	// 输入的字符清理器，以及它替换制表符所用的字符串。
+	rsan    runeutil.Sanitizer
+	rsanTab string
+
+	// composition 是输入法正在编辑、尚未提交的文本。
+	composition []rune
+
+	// compStart 和 compEnd 是预编辑文本在光标行中的范围，仅在渲染时设置。
+	compStart, compEnd int
+
+	// label 是文本区域的标签（参见 SetLabel）。
+	label string
+
+	// secondary 是次光标的位置，按行和列排序（参见 SetSecondaryPositions）。
+	secondary []Position
+
+	// brackets 是渲染时光标处的括号及与之匹配的括号的位置（参见 AutoPair）。
+	brackets []Position
 }
 
 // New 创建一个具有默认设置的新模型。
@@ -267,17 +346,19 @@ func New() Model {
 		CharLimit:            defaultCharLimit,
 		MaxHeight:            defaultMaxHeight,
 		MaxWidth:             defaultMaxWidth,
+		MaxLines:             defaultMaxLines,
 		Prompt:               lipgloss.ThickBorder().Left + " ",
 		style:                &blurredStyle,
 		FocusedStyle:         focusedStyle,
 		BlurredStyle:         blurredStyle,
-		cache:                memoization.NewMemoCache[line, [][]rune](maxLines),
+		cache:                memoization.NewMemoCache[line, [][]rune](cacheSize),
 		EndOfBufferCharacter: ' ',
 		ShowLineNumbers:      true,
+		TabWidth:             defaultTabWidth,
 		Cursor:               cur,
 		KeyMap:               DefaultKeyMap,
 
-		value: make([][]rune, minHeight, maxLines),
+		value: make([][]rune, minHeight),
 		focus: false,
 		col:   0,
 		row:   0,
@@ -302,6 +383,10 @@ func DefaultStyles() (Style, Style) {
 		Placeholder:      lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 		Prompt:           lipgloss.NewStyle().Foreground(lipgloss.Color("7")),
 		Text:             lipgloss.NewStyle(),
+		Composition:      lipgloss.NewStyle().Underline(true),
+		Label:            lipgloss.NewStyle().Bold(true),
+		SecondaryCursor:  lipgloss.NewStyle().Background(lipgloss.AdaptiveColor{Light: "250", Dark: "240"}),
+		MatchingBracket:  lipgloss.NewStyle().Bold(true).Underline(true),
 	}
 	blurred := Style{
 		Base:             lipgloss.NewStyle(),
@@ -312,6 +397,10 @@ func DefaultStyles() (Style, Style) {
 		Placeholder:      lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
 		Prompt:           lipgloss.NewStyle().Foreground(lipgloss.Color("7")),
 		Text:             lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "7"}),
+		Composition:      lipgloss.NewStyle().Underline(true),
+		Label:            lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "7"}),
+		SecondaryCursor:  lipgloss.NewStyle().Background(lipgloss.AdaptiveColor{Light: "250", Dark: "240"}),
+		MatchingBracket:  lipgloss.NewStyle().Bold(true).Underline(true),
 	}
 
 	return focused, blurred
@@ -368,8 +457,8 @@ func (m *Model) insertRunesFromUserInput(runes []rune) {
 	}
 
 	// 遵守最大行数限制。
-	if maxLines > 0 && len(m.value)+len(lines)-1 > maxLines {
-		allowedHeight := max(0, maxLines-len(m.value)+1)
+	if m.MaxLines > 0 && len(m.value)+len(lines)-1 > m.MaxLines {
+		allowedHeight := max(0, m.MaxLines-len(m.value)+1)
 		lines = lines[:allowedHeight]
 	}
 
@@ -412,6 +501,7 @@ func (m *Model) insertRunesFromUserInput(runes []rune) {
 	m.value[m.row] = append(m.value[m.row], tail...)
 
 	m.SetCursor(m.col)
+	m.growCache()
 }
 
 // Value 返回文本输入的值。
@@ -433,7 +523,7 @@ func (m Model) Value() string {
 func (m *Model) Length() int {
 	var l int
 	for _, row := range m.value {
-		l += uniseg.StringWidth(string(row))
+		l += runeutil.StringWidth(string(row))
 	}
 	// 我们添加 len(m.value) 以包含换行符。
 	return l + len(m.value) - 1
@@ -478,7 +568,7 @@ func (m *Model) CursorDown() {
 		if m.row >= len(m.value) || m.col >= len(m.value[m.row]) || offset >= nli.CharWidth-1 {
 			break
 		}
-		offset += rw.RuneWidth(m.value[m.row][m.col])
+		offset += m.runeWidth(m.value[m.row][m.col])
 		m.col++
 	}
 }
@@ -512,7 +602,7 @@ func (m *Model) CursorUp() {
 		if m.col >= len(m.value[m.row]) || offset >= nli.CharWidth-1 {
 			break
 		}
-		offset += rw.RuneWidth(m.value[m.row][m.col])
+		offset += m.runeWidth(m.value[m.row][m.col])
 		m.col++
 	}
 }
@@ -559,7 +649,8 @@ func (m *Model) Blur() {
 
 // Reset 将输入设置为其默认状态，没有输入。
 func (m *Model) Reset() {
-	m.value = make([][]rune, minHeight, maxLines)
+	m.composition = nil
+	m.value = make([][]rune, minHeight)
 	m.col = 0
 	m.row = 0
 	m.viewport.GotoTop()
@@ -568,9 +659,10 @@ func (m *Model) Reset() {
 
 // san 初始化或检索字符清理器。
 func (m *Model) san() runeutil.Sanitizer {
-	if m.rsan == nil {
-		// Textinput 将其所有输入都放在单行上，因此将换行符/制表符折叠为单个空格。
-		m.rsan = runeutil.NewSanitizer()
+	if tab := m.tabReplacement(); m.rsan == nil || m.rsanTab != tab {
+		// 按照 TabMode 替换或保留制表符。
+		m.rsan = runeutil.NewSanitizer(runeutil.ReplaceTabs(tab))
+		m.rsanTab = tab
 	}
 	return m.rsan
 }
@@ -783,19 +875,19 @@ func (m Model) LineInfo() LineInfo {
 				RowOffset:    i + 1,
 				StartColumn:  m.col,
 				Width:        len(grid[i+1]),
-				CharWidth:    uniseg.StringWidth(string(line)),
+				CharWidth:    m.runesWidth(line),
 			}
 		}
 
 		if counter+len(line) >= m.col {
 			return LineInfo{
-				CharOffset:   uniseg.StringWidth(string(line[:max(0, m.col-counter)])),
+				CharOffset:   m.runesWidth(line[:max(0, m.col-counter)]),
 				ColumnOffset: m.col - counter,
 				Height:       len(grid),
 				RowOffset:    i,
 				StartColumn:  counter,
 				Width:        len(line),
-				CharWidth:    uniseg.StringWidth(string(line)),
+				CharWidth:    m.runesWidth(line),
 			}
 		}
 
@@ -842,7 +934,7 @@ func (m *Model) SetWidth(w int) {
 	// 仅当没有提示符函数时才更新提示符宽度，因为 SetPromptFunc
 	// 在调用时会更新提示符宽度。
 	if m.promptFunc == nil {
-		m.promptWidth = uniseg.StringWidth(m.Prompt)
+		m.promptWidth = runeutil.StringWidth(m.Prompt)
 	}
 
 	// 将基础样式边框和填充添加到保留的外部宽度。
@@ -914,13 +1006,15 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.value[m.row] = make([]rune, 0)
 	}
 
-	if m.MaxHeight > 0 && m.MaxHeight != m.cache.Capacity() {
-		m.cache = memoization.NewMemoCache[line, [][]rune](m.MaxHeight)
-	}
+	m.growCache()
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
+		case msg.Paste:
+			// 括号粘贴的内容作为一个整体插入，而不是逐个字符处理，
+			// 并且不会被当作按键绑定。
+			m.insertRunesFromUserInput(msg.Runes)
 		case key.Matches(msg, m.KeyMap.DeleteAfterCursor):
 			m.col = clamp(m.col, 0, len(m.value[m.row]))
 			if m.col >= len(m.value[m.row]) {
@@ -941,6 +1035,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.mergeLineAbove(m.row)
 				break
 			}
+			if m.deletePair() {
+				break
+			}
 			if len(m.value[m.row]) > 0 {
 				m.value[m.row] = append(m.value[m.row][:max(0, m.col-1)], m.value[m.row][m.col:]...)
 				if m.col > 0 {
@@ -972,8 +1069,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			if m.MaxHeight > 0 && len(m.value) >= m.MaxHeight {
 				return m, nil
 			}
-			m.col = clamp(m.col, 0, len(m.value[m.row]))
-			m.splitLine(m.row, m.col)
+			m.insertNewline()
+		case m.TabMode != TabIgnore && key.Matches(msg, m.KeyMap.InsertTab):
+			m.insertTab()
 		case key.Matches(msg, m.KeyMap.LineEnd):
 			m.CursorEnd()
 		case key.Matches(msg, m.KeyMap.LineStart):
@@ -1004,9 +1102,17 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.capitalizeRight()
 		case key.Matches(msg, m.KeyMap.TransposeCharacterBackward):
 			m.transposeLeft()
+		case key.Matches(msg, m.KeyMap.DeleteLine):
+			m.DeleteLine()
+		case key.Matches(msg, m.KeyMap.DuplicateLine):
+			m.DuplicateLine()
+		case key.Matches(msg, m.KeyMap.MoveLineUp):
+			m.MoveLineUp()
+		case key.Matches(msg, m.KeyMap.MoveLineDown):
+			m.MoveLineDown()
 
 		default:
-			m.insertRunesFromUserInput(msg.Runes)
+			m.insertTyped(msg.Runes)
 		}
 
 	case pasteMsg:
@@ -1035,9 +1141,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 // View 渲染文本区域的当前状态。
 func (m Model) View() string {
-	if m.Value() == "" && m.row == 0 && m.col == 0 && m.Placeholder != "" {
+	m = m.composed()
+	if m.Value() == "" && m.row == 0 && m.col == 0 && m.hasPlaceholder() {
 		return m.placeholderView()
 	}
+	if m.AutoPair {
+		m.brackets = m.matchingBrackets()
+	}
 	m.Cursor.TextStyle = m.style.computedCursorLine()
 
 	var (
@@ -1051,6 +1161,7 @@ func (m Model) View() string {
 	displayLine := 0
 	for l, line := range m.value {
 		wrappedLines := m.memoizedWrap(line, m.width)
+		offset := 0 // 当前软换行行在逻辑行中的起始位置
 
 		if m.row == l {
 			style = m.style.computedCursorLine()
@@ -1059,30 +1170,22 @@ func (m Model) View() string {
 		}
 
 		for wl, wrappedLine := range wrappedLines {
-			prompt := m.getPromptString(displayLine)
-			prompt = m.style.computedPrompt().Render(prompt)
-			s.WriteString(style.Render(prompt))
+			start := offset
+			offset += len(wrappedLine)
+
+			// 提示符和行号各自使用一个组合好的样式渲染，而不是嵌套在光标行样式中，
+			// 否则内层样式的重置序列会截断光标行的背景。
+			s.WriteString(m.promptStyle(m.row == l).Render(m.getPromptString(displayLine)))
 			displayLine++
 
 			var ln string
-			if m.ShowLineNumbers { //nolint:nestif
+			if m.ShowLineNumbers {
 				if wl == 0 {
-					if m.row == l {
-						ln = style.Render(m.style.computedCursorLineNumber().Render(m.formatLineNumber(l + 1)))
-						s.WriteString(ln)
-					} else {
-						ln = style.Render(m.style.computedLineNumber().Render(m.formatLineNumber(l + 1)))
-						s.WriteString(ln)
-					}
+					ln = m.lineNumberStyle(m.row == l).Render(m.formatLineNumber(l + 1))
 				} else {
-					if m.row == l {
-						ln = style.Render(m.style.computedCursorLineNumber().Render(m.formatLineNumber(" ")))
-						s.WriteString(ln)
-					} else {
-						ln = style.Render(m.style.computedLineNumber().Render(m.formatLineNumber(" ")))
-						s.WriteString(ln)
-					}
+					ln = m.lineNumberStyle(m.row == l).Render(m.formatLineNumber(" "))
 				}
+				s.WriteString(ln)
 			}
 
 			// 记录最宽的行号以便稍后填充。
@@ -1091,7 +1194,7 @@ func (m Model) View() string {
 				widestLineNumber = lnw
 			}
 
-			strwidth := uniseg.StringWidth(string(wrappedLine))
+			strwidth := m.runesWidth(wrappedLine)
 			padding := m.width - strwidth
 			// 如果尾随空格导致行比宽度更宽，我们不应该将其绘制到屏幕上，
 			// 因为这会导致行末尾有一个额外的空格，这在显示光标行时看起来不正常。
@@ -1102,18 +1205,29 @@ func (m Model) View() string {
 				padding -= m.width - strwidth
 			}
 			if m.row == l && lineInfo.RowOffset == wl {
-				s.WriteString(style.Render(string(wrappedLine[:lineInfo.ColumnOffset])))
+				s.WriteString(m.renderRunes(style, wrappedLine[:lineInfo.ColumnOffset], start))
 				if m.col >= len(line) && lineInfo.CharOffset >= m.width {
 					m.Cursor.SetChar(" ")
 					s.WriteString(m.Cursor.View())
 				} else {
-					m.Cursor.SetChar(string(wrappedLine[lineInfo.ColumnOffset]))
-					s.WriteString(style.Render(m.Cursor.View()))
-					s.WriteString(style.Render(string(wrappedLine[lineInfo.ColumnOffset+1:])))
+					// 光标下的制表符以空格显示，光标占据它的第一个单元格。
+					char := wrappedLine[lineInfo.ColumnOffset]
+					if char == '\t' {
+						m.Cursor.SetChar(" ")
+						s.WriteString(style.Render(m.Cursor.View()))
+						s.WriteString(style.Render(strings.Repeat(" ", m.tabWidth()-1)))
+					} else {
+						m.Cursor.SetChar(string(char))
+						s.WriteString(style.Render(m.Cursor.View()))
+					}
+					s.WriteString(m.renderRunes(style, wrappedLine[lineInfo.ColumnOffset+1:], start+lineInfo.ColumnOffset+1))
 				}
+			} else if m.row == l {
+				s.WriteString(m.renderRunes(style, wrappedLine, start))
 			} else {
-				s.WriteString(style.Render(string(wrappedLine)))
+				s.WriteString(m.renderSecondary(style, l, wrappedLine, start))
 			}
+
 			s.WriteString(style.Render(strings.Repeat(" ", max(0, padding))))
 			s.WriteRune('\n')
 			newLines++
@@ -1136,7 +1250,7 @@ func (m Model) View() string {
 	}
 
 	m.viewport.SetContent(s.String())
-	return m.style.Base.Render(m.viewport.View())
+	return m.renderBase(m.viewport.View())
 }
 
 // formatLineNumber 根据最大行数动态格式化行号以供显示。
@@ -1152,7 +1266,7 @@ func (m Model) getPromptString(displayLine int) (prompt string) {
 		return prompt
 	}
 	prompt = m.promptFunc(displayLine)
-	pl := uniseg.StringWidth(prompt)
+	pl := runeutil.StringWidth(prompt)
 	if pl < m.promptWidth {
 		prompt = fmt.Sprintf("%*s%s", m.promptWidth-pl, "", prompt)
 	}
@@ -1162,30 +1276,23 @@ func (m Model) getPromptString(displayLine int) (prompt string) {
 // placeholderView 返回提示符和占位符视图（如果有）。
 func (m Model) placeholderView() string {
 	var (
-		s     strings.Builder
-		p     = m.Placeholder
-		style = m.style.computedPlaceholder()
+		s      strings.Builder
+		style  = m.style.computedPlaceholder()
+		plines = m.placeholderLines()
+		top    = m.placeholderTop(len(plines))
 	)
 
-	// 自动换行
-	pwordwrap := ansi.Wordwrap(p, m.width, "")
-	// 换行（处理无法自动换行的行）
-	pwrap := ansi.Hardwrap(pwordwrap, m.width, true)
-	// 按换行符分割字符串
-	plines := strings.Split(strings.TrimSpace(pwrap), "\n")
-
 	for i := 0; i < m.height; i++ {
+		j := i - top // 占位符的行
+		hasLine := j >= 0 && j < len(plines)
+		active := hasLine || i == 0
 		lineStyle := m.style.computedPlaceholder()
-		lineNumberStyle := m.style.computedLineNumber()
-		if len(plines) > i {
+		if active {
 			lineStyle = m.style.computedCursorLine()
-			lineNumberStyle = m.style.computedCursorLineNumber()
 		}
 
 		// 渲染提示符
-		prompt := m.getPromptString(i)
-		prompt = m.style.computedPrompt().Render(prompt)
-		s.WriteString(lineStyle.Render(prompt))
+		s.WriteString(m.promptStyle(active).Render(m.getPromptString(i)))
 
 		// 当启用显示行号时：
 		// - 仅渲染光标行的行号
@@ -1198,29 +1305,37 @@ func (m Model) placeholderView() string {
 			case i == 0:
 				ln = strconv.Itoa(i + 1)
 				fallthrough
-			case len(plines) > i:
-				s.WriteString(lineStyle.Render(lineNumberStyle.Render(m.formatLineNumber(ln))))
+			case active:
+				s.WriteString(m.lineNumberStyle(active).Render(m.formatLineNumber(ln)))
 			default:
 			}
 		}
 
 		switch {
 		// 第一行
-		case i == 0:
+		case i == 0 && hasLine:
 			// 第一行的第一个字符作为带有字符的光标
 			m.Cursor.TextStyle = m.style.computedPlaceholder()
 
-			ch, rest, _, _ := uniseg.FirstGraphemeClusterInString(plines[0], 0)
+			ch, rest := m.splitPlaceholderLine(plines[0])
 			m.Cursor.SetChar(ch)
 			s.WriteString(lineStyle.Render(m.Cursor.View()))
 
 			// 第一行的其余部分
-			s.WriteString(lineStyle.Render(style.Render(rest)))
+			s.WriteString(lineStyle.Render(m.renderPlaceholderText(style, rest)))
+		// 占位符垂直居中时，第一行只有光标
+		case i == 0:
+			m.Cursor.TextStyle = m.style.computedPlaceholder()
+			m.Cursor.SetChar(" ")
+			s.WriteString(lineStyle.Render(m.Cursor.View()))
 		// 剩余行
-		case len(plines) > i:
+		case hasLine:
 			// 当前行占位符文本
-			if len(plines) > i {
-				s.WriteString(lineStyle.Render(style.Render(plines[i] + strings.Repeat(" ", max(0, m.width-uniseg.StringWidth(plines[i]))))))
+			if m.PlaceholderFunc != nil {
+				pad := strings.Repeat(" ", max(0, m.width-ansi.StringWidth(plines[j])))
+				s.WriteString(lineStyle.Render(plines[j] + style.Render(pad)))
+			} else {
+				s.WriteString(lineStyle.Render(style.Render(plines[j] + strings.Repeat(" ", max(0, m.width-runeutil.StringWidth(plines[j]))))))
 			}
 		default:
 			// 行缓冲区结束字符
@@ -1233,7 +1348,7 @@ func (m Model) placeholderView() string {
 	}
 
 	m.viewport.SetContent(s.String())
-	return m.style.Base.Render(m.viewport.View())
+	return m.renderBase(m.viewport.View())
 }
 
 // Blink 返回光标的闪烁命令。
@@ -1241,12 +1356,21 @@ func Blink() tea.Msg {
 	return cursor.Blink()
 }
 
+// growCache 在行数接近软换行缓存的容量时扩大缓存。渲染时会查询每一行的换行结果，
+// 如果缓存容量小于行数，LRU 淘汰会使每次渲染都重新换行所有行；这在粘贴
+// 大量文本后尤其明显。扩大时保留额外的空间，以容纳编辑产生的过期条目。
+func (m *Model) growCache() {
+	if need := 2 * len(m.value); need > m.cache.Capacity() { //nolint:mnd
+		m.cache = memoization.NewMemoCache[line, [][]rune](need)
+	}
+}
+
 func (m Model) memoizedWrap(runes []rune, width int) [][]rune {
-	input := line{runes: runes, width: width}
+	input := line{runes: runes, width: width, tab: m.tabWidth(), mode: m.WrapMode}
 	if v, ok := m.cache.Get(input); ok {
 		return v
 	}
-	v := wrap(runes, width)
+	v := wrapMode(runes, width, m.tabWidth(), m.WrapMode)
 	m.cache.Set(input, v)
 	return v
 }
@@ -1306,6 +1430,11 @@ func (m *Model) mergeLineAbove(row int) {
 }
 
 func (m *Model) splitLine(row, col int) {
+	// 遵守最大行数限制。
+	if m.MaxLines > 0 && len(m.value) >= m.MaxLines {
+		return
+	}
+
 	// 要执行分割，取当前行并保留光标之前的内容，取光标之后的内容
 	// 并使其成为下方行的内容，然后将剩余行向下移动一行
 	head, tailSrc := m.value[row][:col], m.value[row][col:]
@@ -1330,41 +1459,44 @@ func Paste() tea.Msg {
 	return pasteMsg(str)
 }
 
-func wrap(runes []rune, width int) [][]rune {
+func wrap(runes []rune, width, tab int) [][]rune {
 	var (
 		lines  = [][]rune{{}}
 		word   = []rune{}
 		row    int
-		spaces int
+		spaces []rune
 	)
 
-	// 对字符进行自动换行
+	// 对字符进行自动换行。空白字符被替换为空格，但制表符被保留，按 tab 个单元格计算宽度。
 	for _, r := range runes {
 		if unicode.IsSpace(r) {
-			spaces++
+			if r != '\t' {
+				r = ' '
+			}
+			spaces = append(spaces, r)
 		} else {
 			word = append(word, r)
 		}
 
-		if spaces > 0 { //nolint:nestif
-			if uniseg.StringWidth(string(lines[row]))+uniseg.StringWidth(string(word))+spaces > width {
+		if len(spaces) > 0 { //nolint:nestif
+			if widthWithTabs(lines[row], tab)+runeutil.StringWidth(string(word))+widthWithTabs(spaces, tab) > width {
 				row++
 				lines = append(lines, []rune{})
 				lines[row] = append(lines[row], word...)
-				lines[row] = append(lines[row], repeatSpaces(spaces)...)
-				spaces = 0
+				lines[row] = append(lines[row], spaces...)
+				spaces = nil
 				word = nil
 			} else {
 				lines[row] = append(lines[row], word...)
-				lines[row] = append(lines[row], repeatSpaces(spaces)...)
-				spaces = 0
+				lines[row] = append(lines[row], spaces...)
+				spaces = nil
 				word = nil
 			}
 		} else {
 			// 如果最后一个字符是双宽度字符，那么我们可能无法将其添加到此行，
 			// 因为它可能会导致我们超过宽度。
-			lastCharLen := rw.RuneWidth(word[len(word)-1])
-			if uniseg.StringWidth(string(word))+lastCharLen > width {
+			lastCharLen := runeutil.RuneWidth(word[len(word)-1])
+			if runeutil.StringWidth(string(word))+lastCharLen > width {
 				// 如果当前行有任何内容，让我们移动到下一行，
 				// 因为当前单词填满了整行。
 				if len(lines[row]) > 0 {
@@ -1377,26 +1509,22 @@ func wrap(runes []rune, width int) [][]rune {
 		}
 	}
 
-	if uniseg.StringWidth(string(lines[row]))+uniseg.StringWidth(string(word))+spaces >= width {
+	if widthWithTabs(lines[row], tab)+runeutil.StringWidth(string(word))+widthWithTabs(spaces, tab) >= width {
 		lines = append(lines, []rune{})
 		lines[row+1] = append(lines[row+1], word...)
 		// 我们在行末尾添加一个额外的空格，以考虑前一个软换行行末尾的尾随空格，
 		// 这样导航时的行为是一致的，并且我们不需要不断添加边缘来处理换行输入的最后一行。
-		spaces++
-		lines[row+1] = append(lines[row+1], repeatSpaces(spaces)...)
+		spaces = append(spaces, ' ')
+		lines[row+1] = append(lines[row+1], spaces...)
 	} else {
 		lines[row] = append(lines[row], word...)
-		spaces++
-		lines[row] = append(lines[row], repeatSpaces(spaces)...)
+		spaces = append(spaces, ' ')
+		lines[row] = append(lines[row], spaces...)
 	}
 
 	return lines
 }
 
-func repeatSpaces(n int) []rune {
-	return []rune(strings.Repeat(string(' '), n))
-}
-
 func clamp(v, low, high int) int {
 	if high < low {
 		low, high = high, low
@@ -1,6 +1,7 @@
 package textarea
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 	"unicode"
@@ -1776,6 +1777,71 @@ func TestView(t *testing.T) {
 	}
 }
 
+// TestComposition 测试输入法预编辑文本的渲染和光标定位
+func TestComposition(t *testing.T) {
+	textarea := newTextArea()
+	textarea.ShowLineNumbers = false
+	textarea.SetWidth(10)
+	textarea = sendString(textarea, "ab")
+	textarea.SetCursor(1)
+
+	textarea.SetComposition("中文")
+	if !textarea.Composing() || textarea.Composition() != "中文" {
+		t.Fatalf("expected composition %q, got %q", "中文", textarea.Composition())
+	}
+	if textarea.Value() != "ab" {
+		t.Fatalf("composition must not change the value, got %q", textarea.Value())
+	}
+
+	view := stripString(textarea.View())
+	if !strings.HasPrefix(view, "> a中文b") {
+		t.Fatalf("expected composition to be rendered at the cursor, got:\n%s", view)
+	}
+
+	// 提示符宽度 2 + "a" 宽度 1 + "中文" 宽度 4
+	if x, y := textarea.CursorPosition(); x != 7 || y != 0 {
+		t.Fatalf("expected cursor at (7, 0), got (%d, %d)", x, y)
+	}
+
+	// 预编辑文本参与软换行：第二行为 "文中文b"，光标位于 "文中文" 之后
+	textarea.SetComposition("中文中文中文")
+	if x, y := textarea.CursorPosition(); x != 8 || y != 1 {
+		t.Fatalf("expected cursor on the soft-wrapped line at (8, 1), got (%d, %d)", x, y)
+	}
+
+	textarea.CommitComposition()
+	if textarea.Composing() {
+		t.Fatal("expected composition to be cleared after commit")
+	}
+	if textarea.Value() != "a中文中文中文b" {
+		t.Fatalf("expected committed value, got %q", textarea.Value())
+	}
+}
+
+// TestMaxLines 测试可配置的最大行数
+func TestMaxLines(t *testing.T) {
+	textarea := newTextArea()
+	textarea.MaxLines = 3
+
+	textarea.InsertString("a\nb\nc\nd")
+	if got := textarea.Value(); got != "a\nb\nc" {
+		t.Fatalf("expected insertion to stop at 3 lines, got %q", got)
+	}
+
+	textarea, _ = textarea.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if textarea.LineCount() != 3 {
+		t.Fatalf("expected newline to respect MaxLines, got %d lines", textarea.LineCount())
+	}
+
+	// 0 表示不限制
+	textarea.MaxLines = 0
+	textarea.MaxHeight = 0
+	textarea.SetValue(strings.Repeat("x\n", defaultMaxLines+1))
+	if got := textarea.LineCount(); got != defaultMaxLines+2 {
+		t.Fatalf("expected %d lines without a limit, got %d", defaultMaxLines+2, got)
+	}
+}
+
 func newTextArea() Model {
 	textarea := New()
 
@@ -1815,3 +1881,373 @@ func stripString(str string) string {
 
 	return strings.Join(lines, "\n")
 }
+
+func TestPositionInfo(t *testing.T) {
+	textarea := newTextArea()
+	textarea.SetValue("héllo\n世界 wide\nend")
+	textarea.row = 1
+	textarea.SetCursor(3)
+
+	got := textarea.PositionInfo()
+	want := PositionInfo{
+		Byte:         len("héllo\n世界 "),
+		Rune:         len([]rune("héllo\n世界 ")),
+		Line:         1,
+		Column:       3,
+		VisualColumn: 5,
+		TotalBytes:   len(textarea.Value()),
+		TotalRunes:   len([]rune(textarea.Value())),
+		TotalLines:   3,
+	}
+	if got != want {
+		t.Fatalf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestBracketedPaste(t *testing.T) {
+	textarea := newTextArea()
+	textarea.CharLimit = 0
+	textarea.SetWidth(20)
+	textarea.SetHeight(5)
+
+	// 粘贴的内容作为一个整体插入，其中的字符不会被当作按键绑定
+	lines := make([]string, 3000)
+	for i := range lines {
+		lines[i] = "line " + strconv.Itoa(i) + "\twith a tab"
+	}
+	paste := strings.Join(lines, "\n")
+	textarea, _ = textarea.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(paste), Paste: true})
+
+	if got := textarea.LineCount(); got != len(lines) {
+		t.Fatalf("expected %d lines after pasting, got %d", len(lines), got)
+	}
+	if got, want := textarea.Line(), len(lines)-1; got != want {
+		t.Fatalf("expected cursor on line %d, got %d", want, got)
+	}
+
+	// 软换行缓存必须能容纳所有行，否则每次渲染都会重新换行所有行
+	if textarea.cache.Capacity() < len(lines) {
+		t.Fatalf("expected wrap cache to hold %d lines, capacity is %d", len(lines), textarea.cache.Capacity())
+	}
+	textarea.View()
+	if size := textarea.cache.Size(); size < len(lines) {
+		t.Fatalf("expected all lines to stay cached after rendering, got %d", size)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	textarea := newTextArea()
+	textarea.Prompt = ""
+	textarea.ShowLineNumbers = false
+	textarea.FocusedStyle = Style{}
+	textarea.BlurredStyle = Style{}
+	textarea.Focus()
+	textarea.SetWidth(16)
+	textarea.SetHeight(1)
+	textarea.SetLabel("Description")
+
+	// 没有边框时，标签渲染在单独的一行
+	if got := strings.Split(ansi.Strip(textarea.View()), "\n")[0]; got != "Description" {
+		t.Fatalf("expected label row, got %q", got)
+	}
+
+	// 有上边框时，标签嵌入在上边框中
+	textarea.FocusedStyle.Base = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+	textarea.Focus()
+	textarea.SetWidth(16)
+	lines := strings.Split(ansi.Strip(textarea.View()), "\n")
+	if got, want := lines[0], "╭─ Description ╮"; got != want {
+		t.Fatalf("expected label in the top border %q, got %q", want, got)
+	}
+	if len(lines) != 3 || ansi.StringWidth(lines[1]) != ansi.StringWidth(lines[0]) {
+		t.Fatalf("expected a 3-line box of even width, got %q", lines)
+	}
+
+	// 标签放不下时被截断
+	textarea.SetLabel("A very long description")
+	if got, want := strings.Split(ansi.Strip(textarea.View()), "\n")[0], "╭─ A very lon… ╮"; got != want {
+		t.Fatalf("expected truncated label %q, got %q", want, got)
+	}
+}
+
+func TestActiveLine(t *testing.T) {
+	textarea := newTextArea()
+	bg := lipgloss.Color("4")
+	textarea.FocusedStyle = Style{CursorLine: lipgloss.NewStyle().Background(bg)}
+	textarea.Focus()
+
+	for _, tc := range []struct {
+		span               CursorLineSpan
+		prompt, lineNumber bool
+	}{
+		{SpanFull, true, true},
+		{SpanLineNumber, false, true},
+		{SpanText, false, false},
+	} {
+		textarea.CursorLineSpan = tc.span
+		if got := textarea.promptStyle(true).GetBackground() == bg; got != tc.prompt {
+			t.Errorf("span %d: expected cursor line background on prompt %t, got %t", tc.span, tc.prompt, got)
+		}
+		if got := textarea.lineNumberStyle(true).GetBackground() == bg; got != tc.lineNumber {
+			t.Errorf("span %d: expected cursor line background on line number %t, got %t", tc.span, tc.lineNumber, got)
+		}
+		if textarea.promptStyle(false).GetBackground() == bg || textarea.lineNumberStyle(false).GetBackground() == bg {
+			t.Errorf("span %d: expected no cursor line background on other lines", tc.span)
+		}
+	}
+
+	// 聚焦时，FocusRing 的边框样式覆盖 Base 的边框，大小不变
+	textarea.FocusedStyle.Base = lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+	textarea.BlurredStyle.Base = textarea.FocusedStyle.Base
+	textarea.FocusRing = lipgloss.NewStyle().BorderStyle(lipgloss.ThickBorder())
+	textarea.SetWidth(10)
+	textarea.SetHeight(1)
+	focused := strings.Split(ansi.Strip(textarea.View()), "\n")
+	textarea.Blur()
+	blurred := strings.Split(ansi.Strip(textarea.View()), "\n")
+	if !strings.HasPrefix(focused[0], "┏") || !strings.HasPrefix(blurred[0], "┌") {
+		t.Fatalf("expected a thick border only when focused, got %q and %q", focused[0], blurred[0])
+	}
+	if len(focused) != len(blurred) || ansi.StringWidth(focused[0]) != ansi.StringWidth(blurred[0]) {
+		t.Fatalf("expected the focus ring not to change the size, got %q and %q", focused, blurred)
+	}
+}
+
+func TestAutoIndentAndTabs(t *testing.T) {
+	textarea := newTextArea()
+	textarea.ShowLineNumbers = false
+	textarea.SetWidth(20)
+	textarea.AutoIndent = true
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+
+	textarea.SetValue("if x {")
+	textarea, _ = textarea.Update(enter)
+	textarea = sendString(textarea, "  y")
+	textarea, _ = textarea.Update(enter)
+	textarea = sendString(textarea, "z")
+	if got := textarea.Value(); got != "if x {\n  y\n  z" {
+		t.Fatalf("expected the indentation to carry over, got %q", got)
+	}
+
+	// 默认忽略 tab 键。
+	textarea.Reset()
+	textarea, _ = textarea.Update(tab)
+	if got := textarea.Value(); got != "" {
+		t.Fatalf("expected tab to be ignored by default, got %q", got)
+	}
+
+	textarea.TabMode = TabSpaces
+	textarea.TabWidth = 2
+	textarea, _ = textarea.Update(tab)
+	textarea.InsertString("a\tb")
+	if got := textarea.Value(); got != "  a  b" {
+		t.Fatalf("expected tabs to become spaces, got %q", got)
+	}
+
+	textarea.Reset()
+	textarea.TabMode = TabHard
+	textarea.TabWidth = 4
+	textarea, _ = textarea.Update(tab)
+	textarea = sendString(textarea, "a")
+	textarea, _ = textarea.Update(enter)
+	textarea = sendString(textarea, "b")
+	if got := textarea.Value(); got != "\ta\n\tb" {
+		t.Fatalf("expected hard tabs to be kept and carried over, got %q", got)
+	}
+	if got := textarea.LineInfo().CharOffset; got != 5 {
+		t.Fatalf("expected the tab to count as 4 cells, got offset %d", got)
+	}
+	if got := textarea.PositionInfo().VisualColumn; got != 5 {
+		t.Fatalf("expected visual column 5, got %d", got)
+	}
+	lines := strings.Split(stripString(textarea.View()), "\n")
+	if lines[0] != ">     a" || lines[1] != ">     b" {
+		t.Fatalf("expected tabs to render 4 cells wide, got %q", lines[:2])
+	}
+}
+
+func TestPlaceholderFunc(t *testing.T) {
+	textarea := newTextArea()
+	textarea.Prompt = ""
+	textarea.ShowLineNumbers = false
+	textarea.EndOfBufferCharacter = '~'
+	textarea.FocusedStyle = Style{}
+	textarea.BlurredStyle = Style{}
+	textarea.Focus()
+	textarea.SetWidth(12)
+	textarea.SetHeight(5)
+	textarea.PlaceholderFunc = func() []StyledLine {
+		return []StyledLine{
+			{{Text: "Write here"}},
+			{{Text: "press "}, {Text: "ctrl+s", Style: lipgloss.NewStyle().Transform(strings.ToUpper)}, {Text: " to save"}},
+		}
+	}
+
+	lines := strings.Split(ansi.Strip(textarea.View()), "\n")
+	if got, want := strings.TrimRight(lines[0], " "), "Write here"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	// 每段使用自己的样式，超出宽度的行被截断。
+	if got, want := lines[1], "press CTRL+S"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got := strings.TrimRight(lines[2], " "); got != "~" {
+		t.Fatalf("expected end of buffer, got %q", got)
+	}
+
+	// 垂直居中：光标仍在第一行，占位符从第 2 行开始。
+	textarea.CenterPlaceholder = true
+	lines = strings.Split(ansi.Strip(textarea.View()), "\n")
+	if got := strings.TrimRight(lines[0], " "); got != "" {
+		t.Fatalf("expected only the cursor on the first row, got %q", got)
+	}
+	if got := strings.TrimRight(lines[1], " "); got != "Write here" {
+		t.Fatalf("expected centered placeholder, got %q", got)
+	}
+	if got := lines[2]; got != "press CTRL+S" {
+		t.Fatalf("expected centered placeholder, got %q", got)
+	}
+}
+
+func TestLineOperations(t *testing.T) {
+	textarea := newTextArea()
+	textarea.SetValue("one\ntwo\nthree")
+	textarea.row, textarea.col = 1, 2
+
+	textarea, _ = textarea.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y"), Alt: true})
+	if got, want := textarea.Value(), "one\ntwo\ntwo\nthree"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if textarea.Line() != 2 || textarea.col != 2 {
+		t.Fatalf("expected cursor on the duplicate, got %d:%d", textarea.Line(), textarea.col)
+	}
+
+	textarea, _ = textarea.Update(tea.KeyMsg{Type: tea.KeyDown, Alt: true})
+	if got, want := textarea.Value(), "one\ntwo\nthree\ntwo"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	textarea.MoveLineDown()
+	if textarea.Line() != 3 {
+		t.Fatalf("expected the last line not to move, got %d", textarea.Line())
+	}
+
+	textarea, _ = textarea.Update(tea.KeyMsg{Type: tea.KeyUp, Alt: true})
+	textarea.MoveLineUp()
+	if got, want := textarea.Value(), "one\ntwo\ntwo\nthree"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	textarea, _ = textarea.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k"), Alt: true})
+	if got, want := textarea.Value(), "one\ntwo\nthree"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if textarea.Line() != 1 || textarea.col != 2 {
+		t.Fatalf("expected cursor to stay on the next line, got %d:%d", textarea.Line(), textarea.col)
+	}
+
+	textarea.row = 2
+	textarea.DeleteLine()
+	if textarea.Value() != "one\ntwo" || textarea.Line() != 1 {
+		t.Fatalf("expected the previous line after deleting the last one, got %q at %d", textarea.Value(), textarea.Line())
+	}
+	textarea.DeleteLine()
+	textarea.DeleteLine()
+	if textarea.Value() != "" || textarea.Line() != 0 {
+		t.Fatalf("expected an empty value, got %q", textarea.Value())
+	}
+}
+
+func TestSecondaryCursors(t *testing.T) {
+	textarea := newTextArea()
+	textarea.ShowLineNumbers = false
+	textarea.Prompt = ""
+	textarea.SetWidth(10)
+	textarea.FocusedStyle.SecondaryCursor = lipgloss.NewStyle().Transform(strings.ToUpper)
+	textarea.Focus()
+	textarea.SetValue("abc\ndef")
+	textarea.row, textarea.col = 1, 0
+
+	// 与主光标重合和超出范围的位置不渲染。
+	textarea.SetSecondaryPositions([]Position{{Row: 1, Col: 2}, {Row: 0, Col: 1}, {Row: 1, Col: 0}, {Row: 5, Col: 0}})
+	if got := textarea.SecondaryPositions(); got[0] != (Position{Row: 0, Col: 1}) {
+		t.Fatalf("expected positions to be sorted, got %v", got)
+	}
+
+	lines := strings.Split(ansi.Strip(textarea.View()), "\n")
+	if !strings.HasPrefix(lines[0], "aBc") || !strings.HasPrefix(lines[1], "deF") {
+		t.Fatalf("expected secondary cursors to be rendered, got %q", lines[:2])
+	}
+
+	textarea.Blur()
+	if lines := strings.Split(ansi.Strip(textarea.View()), "\n"); !strings.HasPrefix(lines[0], "abc") {
+		t.Fatalf("expected secondary cursors to be hidden when blurred, got %q", lines[0])
+	}
+}
+
+func TestWrapMode(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  WrapMode
+		input string
+		width int
+		want  []string
+	}{
+		{"word boundary", WordBoundary, "你好世界，欢迎使用", 8, []string{"你好世界", "，欢迎使", "用 "}},
+		{"break anywhere", BreakAnywhere, "hello world", 4, []string{"hell", "o wo", "rld "}},
+		{"cjk", CJKFriendly, "你好世界，欢迎使用", 8, []string{"你好世", "界，欢迎", "使用 "}},
+		{"cjk keeps words", CJKFriendly, "使用bubbles组件", 10, []string{"使用", "bubbles组", "件 "}},
+		{"cjk opening bracket", CJKFriendly, "一二三（四）", 8, []string{"一二三", "（四） "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, l := range wrapMode([]rune(tt.input), tt.width, defaultTabWidth, tt.mode) {
+				got = append(got, string(l))
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAutoPair(t *testing.T) {
+	textarea := newTextArea()
+	textarea.ShowLineNumbers = false
+	textarea.Prompt = ""
+	textarea.SetWidth(20)
+	textarea.AutoPair = true
+	textarea.FocusedStyle.MatchingBracket = lipgloss.NewStyle().Transform(func(s string) string { return "<" + s + ">" })
+	textarea.Focus()
+
+	typeText := func(s string) {
+		for _, r := range s {
+			textarea, _ = textarea.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	// 自动插入闭合字符，键入闭合字符时跳过已有的
+	typeText(`f(x) "a" don't [`)
+	if got, want := textarea.Value(), `f(x) "a" don't []`; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if textarea.col != 16 {
+		t.Fatalf("expected the cursor between the brackets, got %d", textarea.col)
+	}
+
+	// 在一对空的括号之间退格时同时删除两者
+	textarea, _ = textarea.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if got, want := textarea.Value(), `f(x) "a" don't `; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	// 高亮与光标处的括号匹配的括号
+	textarea.SetValue("a(b[c]\nd)")
+	textarea.row, textarea.col = 1, 2
+	lines := strings.Split(ansi.Strip(textarea.View()), "\n")
+	if !strings.HasPrefix(lines[0], "a<(>b[c]") || !strings.HasPrefix(lines[1], "d<)>") {
+		t.Fatalf("expected the matching brackets to be highlighted, got %q", lines[:2])
+	}
+}
@@ -0,0 +1,119 @@
+package textarea
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WrapMode 决定软换行时可以在哪里断行，参见 Model.WrapMode。
+type WrapMode int
+
+const (
+	// WordBoundary 只在空白处断行，过长的单词才被截断。这是默认值。
+	WordBoundary WrapMode = iota
+
+	// BreakAnywhere 可以在任意两个字符之间断行。
+	BreakAnywhere
+
+	// CJKFriendly 除空白处外，还可以在中日韩字符之间以及它们与其他字符之间断行，
+	// 并遵守避头尾规则：行首不出现 "、。，）" 等标点，行尾不出现 "（「" 等标点。
+	CJKFriendly
+)
+
+const (
+	// noLineStart 是不能出现在行首的字符。
+	noLineStart = "、。，．：；？！）」』】〕〉》〗〙〛’”…‥ー々〻・" +
+		"ぁぃぅぇぉっゃゅょゎァィゥェォッャュョヮヵヶ,.:;?!)]}%"
+
+	// noLineEnd 是不能出现在行尾的字符。
+	noLineEnd = "（「『【〔〈《〖〘〚‘“([{"
+)
+
+// wrapMode 按照 mode 对字符进行自动换行。WordBoundary 使用 wrap，其他模式先将字符
+// 分成不可分割的片段，再逐个放入行中。与 wrap 相同，空白字符被替换为空格（制表符
+// 被保留），并且最后一行末尾添加一个额外的空格。
+func wrapMode(runes []rune, width, tab int, mode WrapMode) [][]rune {
+	if mode == WordBoundary {
+		return wrap(runes, width, tab)
+	}
+
+	segments := segment(runes, mode)
+	if len(segments) == 0 {
+		segments = [][]rune{{}}
+	}
+	segments[len(segments)-1] = append(segments[len(segments)-1], ' ')
+
+	lines := [][]rune{{}}
+	row := 0
+	for _, seg := range segments {
+		w := widthWithTabs(seg, tab)
+		if len(lines[row]) > 0 && widthWithTabs(lines[row], tab)+w > width {
+			row++
+			lines = append(lines, []rune{})
+		}
+		if w <= width {
+			lines[row] = append(lines[row], seg...)
+			continue
+		}
+
+		// 片段比整行更宽，只能逐个字符截断。
+		for _, r := range seg {
+			if len(lines[row]) > 0 && widthWithTabs(lines[row], tab)+widthWithTabs([]rune{r}, tab) > width {
+				row++
+				lines = append(lines, []rune{})
+			}
+			lines[row] = append(lines[row], r)
+		}
+	}
+	return lines
+}
+
+// segment 将字符分成片段，只能在片段之间断行。每个片段末尾的空白属于该片段。
+func segment(runes []rune, mode WrapMode) [][]rune {
+	var (
+		segments [][]rune
+		prev     rune // 上一个非空白字符
+		space    bool // 当前片段是否以空白结尾
+	)
+	for _, r := range runes {
+		if unicode.IsSpace(r) {
+			if r != '\t' {
+				r = ' '
+			}
+			if len(segments) == 0 {
+				segments = append(segments, nil)
+			}
+			segments[len(segments)-1] = append(segments[len(segments)-1], r)
+			space = true
+			continue
+		}
+
+		if len(segments) == 0 || (prev != 0 && canBreak(prev, r, space, mode)) {
+			segments = append(segments, nil)
+		}
+		segments[len(segments)-1] = append(segments[len(segments)-1], r)
+		prev, space = r, false
+	}
+	return segments
+}
+
+// canBreak 返回是否可以在非空白字符 prev 和 r 之间断行。space 表示它们之间是否有空白。
+func canBreak(prev, r rune, space bool, mode WrapMode) bool {
+	switch {
+	case space || mode == BreakAnywhere:
+		return true
+	case mode != CJKFriendly:
+		return false
+	case strings.ContainsRune(noLineStart, r), strings.ContainsRune(noLineEnd, prev):
+		return false
+	default:
+		return isCJK(prev) || isCJK(r)
+	}
+}
+
+// isCJK 返回 r 是否是中日韩文字或全角标点。
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303f) || // 中日韩符号和标点
+		(r >= 0xff00 && r <= 0xffef) // 全角和半角形式
+}
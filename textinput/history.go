@@ -0,0 +1,75 @@
+package textinput
+
+// defaultHistorySize 是默认保留的历史记录条数。
+const defaultHistorySize = 100
+
+// SetHistory 设置历史记录（最旧的在前）并启用历史记录功能。启用后，
+// 按下 Accept 键会将当前值追加到历史记录中；在未显示自动补全建议时，
+// HistoryPrev 和 HistoryNext 键会像 shell 提示符一样在历史记录中切换，
+// 回到最新位置时恢复正在编辑的值。
+func (m *Model) SetHistory(history []string) {
+	m.history = append([]string(nil), history...)
+	if m.HistorySize > 0 && len(m.history) > m.HistorySize {
+		m.history = m.history[len(m.history)-m.HistorySize:]
+	}
+	m.HistoryEnabled = true
+	m.resetHistoryNavigation()
+}
+
+// History 返回历史记录，最旧的在前。
+func (m Model) History() []string {
+	return m.history
+}
+
+// AddHistory 将给定的值追加到历史记录中。空值和与最后一条相同的值会被忽略。
+// 如果历史记录超过 HistorySize 条，则丢弃最旧的记录。
+func (m *Model) AddHistory(s string) {
+	defer m.resetHistoryNavigation()
+	if s == "" || (len(m.history) > 0 && m.history[len(m.history)-1] == s) {
+		return
+	}
+	m.history = append(m.history[:len(m.history):len(m.history)], s)
+	if m.HistorySize > 0 && len(m.history) > m.HistorySize {
+		m.history = m.history[len(m.history)-m.HistorySize:]
+	}
+}
+
+// historyPrev 切换到上一条（更旧的）历史记录。
+func (m *Model) historyPrev() {
+	if m.historyIndex == 0 || len(m.history) == 0 {
+		return
+	}
+	if m.historyIndex >= len(m.history) {
+		// 离开最新位置时保存正在编辑的值。
+		m.historyIndex = len(m.history)
+		m.historyDraft = string(m.value)
+	}
+	m.historyIndex--
+	m.recall(m.history[m.historyIndex])
+}
+
+// historyNext 切换到下一条（更新的）历史记录，或恢复正在编辑的值。
+func (m *Model) historyNext() {
+	if m.historyIndex >= len(m.history) {
+		return
+	}
+	m.historyIndex++
+	if m.historyIndex == len(m.history) {
+		m.recall(m.historyDraft)
+		m.historyDraft = ""
+		return
+	}
+	m.recall(m.history[m.historyIndex])
+}
+
+// recall 将值设置为给定的历史记录，并将光标移动到末尾。
+func (m *Model) recall(s string) {
+	m.SetValue(s)
+	m.CursorEnd()
+}
+
+// resetHistoryNavigation 回到历史记录的最新位置。
+func (m *Model) resetHistoryNavigation() {
+	m.historyIndex = len(m.history)
+	m.historyDraft = ""
+}
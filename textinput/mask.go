@@ -0,0 +1,173 @@
+package textinput
+
+import "unicode"
+
+// 掩码中的占位符。掩码中的其他字符都被视为字面量，
+// 在输入时自动插入。可以使用反斜杠将占位符转义为字面量。
+const (
+	MaskDigit        = '#' // 匹配一个数字
+	MaskLetter       = '@' // 匹配一个字母
+	MaskAlphanumeric = '*' // 匹配一个字母或数字
+	maskEscape       = '\\'
+)
+
+// maskSlot 是解析后的掩码中的一个位置。
+type maskSlot struct {
+	placeholder rune // 占位符；为 0 时表示字面量
+	literal     rune // 字面量字符
+}
+
+// parseMask 将掩码字符串解析为位置列表。
+func parseMask(mask string) []maskSlot {
+	var (
+		slots   []maskSlot
+		escaped bool
+	)
+	for _, r := range mask {
+		switch {
+		case escaped:
+			slots = append(slots, maskSlot{literal: r})
+			escaped = false
+		case r == maskEscape:
+			escaped = true
+		case r == MaskDigit, r == MaskLetter, r == MaskAlphanumeric:
+			slots = append(slots, maskSlot{placeholder: r})
+		default:
+			slots = append(slots, maskSlot{literal: r})
+		}
+	}
+	return slots
+}
+
+// accepts 返回占位符是否接受给定的字符。
+func (s maskSlot) accepts(r rune) bool {
+	switch s.placeholder {
+	case MaskDigit:
+		return unicode.IsDigit(r)
+	case MaskLetter:
+		return unicode.IsLetter(r)
+	case MaskAlphanumeric:
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	default:
+		return false
+	}
+}
+
+// literals 将值与掩码对齐，并返回每个字符是否为字面量（或无法放入掩码的字符）。
+// 对齐是必要的，因为字面量本身也可能被占位符接受，例如 "+1 (###)" 中的 "1"。
+func literals(slots []maskSlot, v []rune) []bool {
+	lit := make([]bool, len(v))
+	j := 0
+	for i, r := range v {
+		if j < len(slots) && slots[j].placeholder == 0 && slots[j].literal == r {
+			lit[i] = true
+			j++
+			continue
+		}
+
+		// 跳到下一个占位符
+		for j < len(slots) && slots[j].placeholder == 0 {
+			j++
+		}
+		if j < len(slots) && slots[j].accepts(r) {
+			j++
+			continue
+		}
+		lit[i] = true
+	}
+	return lit
+}
+
+// rawRunes 返回去除字面量后的原始输入。
+func rawRunes(slots []maskSlot, v []rune) []rune {
+	raw := make([]rune, 0, len(v))
+	for i, l := range literals(slots, v) {
+		if !l {
+			raw = append(raw, v[i])
+		}
+	}
+	return raw
+}
+
+// formatMask 按照掩码格式化原始输入。字面量只在其后还有输入时插入，
+// 不被当前占位符接受的字符会被丢弃。返回格式化后的值，以及每个被接受的
+// 原始字符在格式化值中的结束位置。
+func formatMask(slots []maskSlot, raw []rune) (formatted []rune, ends []int) {
+	i := 0
+	for _, s := range slots {
+		if i >= len(raw) {
+			break
+		}
+		if s.placeholder == 0 {
+			formatted = append(formatted, s.literal)
+			continue
+		}
+		for i < len(raw) && !s.accepts(raw[i]) {
+			i++
+		}
+		if i >= len(raw) {
+			break
+		}
+		formatted = append(formatted, raw[i])
+		ends = append(ends, len(formatted))
+		i++
+	}
+
+	// 去除末尾未被占位符跟随的字面量
+	end := 0
+	if len(ends) > 0 {
+		end = ends[len(ends)-1]
+	}
+	return formatted[:end], ends
+}
+
+// applyMask 按照 Mask 重新格式化当前值，并保持光标在相同的原始字符之后。
+func (m *Model) applyMask() {
+	if m.Mask == "" {
+		return
+	}
+	slots := parseMask(m.Mask)
+	before := 0
+	for i, l := range literals(slots, m.value) {
+		if i < m.pos && !l {
+			before++
+		}
+	}
+	formatted, ends := formatMask(slots, rawRunes(slots, m.value))
+
+	m.value = formatted
+	switch {
+	case before == 0:
+		m.pos = 0
+	case before > len(ends):
+		m.pos = len(formatted)
+	default:
+		m.pos = ends[before-1]
+	}
+}
+
+// skipMaskLiterals 将光标移过字面量，使删除操作作用于最近的原始字符。
+// dir 为 -1 时向后移动，为 1 时向前移动。
+func (m *Model) skipMaskLiterals(dir int) {
+	if m.Mask == "" {
+		return
+	}
+	lit := literals(parseMask(m.Mask), m.value)
+	if dir < 0 {
+		for m.pos > 0 && lit[m.pos-1] {
+			m.pos--
+		}
+		return
+	}
+	for m.pos < len(m.value) && lit[m.pos] {
+		m.pos++
+	}
+}
+
+// RawValue 返回去除掩码字面量后的原始输入。如果没有设置 Mask，则与 Value 相同。
+func (m Model) RawValue() string {
+	if m.Mask == "" {
+		return string(m.value)
+	}
+	return string(rawRunes(parseMask(m.Mask), m.value))
+}
@@ -0,0 +1,155 @@
+package textinput
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 数字模式下的错误。
+var (
+	// ErrNotANumber 表示值为空或不是有效的数字。
+	ErrNotANumber = errors.New("textinput: not a number")
+
+	// ErrOutOfRange 表示值超出了 Min 和 Max 的范围。
+	ErrOutOfRange = errors.New("textinput: number out of range")
+)
+
+// NumberMode 限制输入框只接受数字。
+type NumberMode int
+
+// 可用的数字模式。
+const (
+	// NumberNone 不限制输入（默认）。
+	NumberNone NumberMode = iota
+
+	// NumberInt 只接受整数：数字和开头的负号。
+	NumberInt
+
+	// NumberFloat 只接受小数：数字、开头的负号和一个小数点。
+	NumberFloat
+)
+
+// ValueInt 将值解析为整数。值为空或不是整数时返回 ErrNotANumber，
+// 超出 Min 和 Max 的范围时返回该值和 ErrOutOfRange。
+func (m Model) ValueInt() (int, error) {
+	n, err := strconv.Atoi(string(m.value))
+	if err != nil {
+		return 0, ErrNotANumber
+	}
+	if !m.inRange(float64(n)) {
+		return n, ErrOutOfRange
+	}
+	return n, nil
+}
+
+// ValueFloat 将值解析为小数。值为空或不是数字时返回 ErrNotANumber，
+// 超出 Min 和 Max 的范围时返回该值和 ErrOutOfRange。
+func (m Model) ValueFloat() (float64, error) {
+	f, err := strconv.ParseFloat(string(m.value), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, ErrNotANumber
+	}
+	if !m.inRange(f) {
+		return f, ErrOutOfRange
+	}
+	return f, nil
+}
+
+// bounded 返回是否设置了 Min 和 Max。
+func (m Model) bounded() bool {
+	return m.Max > m.Min
+}
+
+// inRange 返回 f 是否在 Min 和 Max 之间。
+func (m Model) inRange(f float64) bool {
+	return !m.bounded() || (f >= m.Min && f <= m.Max)
+}
+
+// validateNumber 在数字模式下检查 v 是否是有效且在范围内的数字。空值是有效的。
+func (m Model) validateNumber(v []rune) error {
+	if m.NumberMode == NumberNone || len(v) == 0 {
+		return nil
+	}
+	n := m
+	n.value = v
+	var err error
+	if m.NumberMode == NumberInt {
+		_, err = n.ValueInt()
+	} else {
+		_, err = n.ValueFloat()
+	}
+	return err
+}
+
+// numberRunes 过滤要在光标处插入的字符，只保留在数字模式下有效的字符：
+// 数字、值开头的负号，以及 NumberFloat 模式下的一个小数点。
+func (m Model) numberRunes(runes []rune) []rune {
+	if m.NumberMode == NumberNone {
+		return runes
+	}
+	head := append([]rune(nil), m.value[:m.pos]...)
+	tail := m.value[m.pos:]
+	for _, r := range runes {
+		switch {
+		case unicode.IsDigit(r) && r < unicode.MaxASCII:
+		case r == '-' && len(head) == 0 && !strings.HasPrefix(string(tail), "-"):
+		case r == '.' && m.NumberMode == NumberFloat &&
+			!strings.ContainsRune(string(head), '.') && !strings.ContainsRune(string(tail), '.'):
+		default:
+			continue
+		}
+		head = append(head, r)
+	}
+	return head[m.pos:]
+}
+
+// step 返回按下 Increment 或 Decrement 时的步长，默认为 1。
+func (m Model) step() float64 {
+	if m.Step > 0 {
+		return m.Step
+	}
+	return 1
+}
+
+// largeStep 返回按下 IncrementLarge 或 DecrementLarge 时的步长，默认为步长的 10 倍。
+func (m Model) largeStep() float64 {
+	if m.LargeStep > 0 {
+		return m.LargeStep
+	}
+	return m.step() * 10 //nolint:mnd
+}
+
+// stepNumber 将值加上 delta 并限制在 Min 和 Max 之间。值为空或无效时视为 0。
+func (m *Model) stepNumber(delta float64) {
+	f, err := strconv.ParseFloat(string(m.value), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		f = 0
+	}
+	f += delta
+	if m.bounded() {
+		f = min(max(f, m.Min), m.Max)
+	}
+
+	var s string
+	if m.NumberMode == NumberInt {
+		s = strconv.FormatInt(int64(math.Round(f)), 10)
+	} else {
+		// 按步长和当前值中较多的小数位数格式化，避免浮点误差。
+		prec := max(decimals(strconv.FormatFloat(m.step(), 'f', -1, 64)),
+			decimals(string(m.value)))
+		s = strconv.FormatFloat(f, 'f', prec, 64)
+	}
+	m.SetValue(s)
+	m.CursorEnd()
+}
+
+// decimals 返回数字字符串中小数点后的位数。
+func decimals(s string) int {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
@@ -0,0 +1,59 @@
+package textinput
+
+import "github.com/purpose168/bubbles-cn/runeutil"
+
+// SetPreedit 设置输入法正在编辑、尚未提交的预编辑文本（例如拼音输入中的候选文本）。
+// 预编辑文本以 PreeditStyle 渲染在光标之前，并参与水平滚动的计算，
+// 但不会成为值的一部分。传入空字符串以清除预编辑文本。
+func (m *Model) SetPreedit(s string) {
+	m.preedit = []rune(s)
+}
+
+// Preedit 返回当前的预编辑文本。
+func (m Model) Preedit() string {
+	return string(m.preedit)
+}
+
+// Composing 返回是否有正在编辑的预编辑文本。
+func (m Model) Composing() bool {
+	return len(m.preedit) > 0
+}
+
+// CommitPreedit 将预编辑文本插入到光标处并清除它。
+func (m *Model) CommitPreedit() {
+	runes := m.preedit
+	m.preedit = nil
+	if len(runes) > 0 {
+		m.insertRunesFromUserInput(runes)
+		m.handleOverflow()
+	}
+}
+
+// preeditWidth 返回预编辑文本的显示宽度。
+func (m Model) preeditWidth() int {
+	return runeutil.StringWidth(m.echoTransform(string(m.preedit)))
+}
+
+// preeditView 渲染预编辑文本。
+func (m Model) preeditView() string {
+	if len(m.preedit) == 0 {
+		return ""
+	}
+	return m.PreeditStyle.Inherit(m.TextStyle).Inline(true).Render(m.echoTransform(string(m.preedit)))
+}
+
+// withPreedit 返回一个为预编辑文本腾出空间的副本：设置了 Width 时，
+// 缩小可见的范围，使光标之前的文本、预编辑文本和光标能够显示在宽度内。
+func (m Model) withPreedit() Model {
+	if len(m.preedit) == 0 || m.Width <= 0 {
+		return m
+	}
+	w := m.preeditWidth()
+	for m.offset < m.pos && runeutil.StringWidth(string(m.value[m.offset:m.pos]))+w > m.Width {
+		m.offset++
+	}
+	for m.offsetRight > m.pos && runeutil.StringWidth(string(m.value[m.offset:m.offsetRight]))+w > m.Width {
+		m.offsetRight--
+	}
+	return m
+}
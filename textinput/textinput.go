@@ -8,14 +8,12 @@ import (
 	"unicode"
 
 	"github.com/atotto/clipboard"
-	rw "github.com/mattn/go-runewidth"
 	"github.com/purpose168/bubbles-cn/cursor"
 	"github.com/purpose168/bubbles-cn/key"
 	"github.com/purpose168/bubbles-cn/runeutil"
 	tea "github.com/purpose168/bubbletea-cn"
 	"github.com/purpose168/charm-experimental-packages-cn/ansi"
 	lipgloss "github.com/purpose168/lipgloss-cn"
-	"github.com/rivo/uniseg"
 )
 
 // 剪贴板操作的内部消息类型
@@ -61,6 +59,13 @@ type KeyMap struct {
 	AcceptSuggestion        key.Binding // 接受建议
 	NextSuggestion          key.Binding // 下一个建议
 	PrevSuggestion          key.Binding // 上一个建议
+	Accept                  key.Binding // 接受输入，启用历史记录时追加到历史记录中
+	HistoryPrev             key.Binding // 上一条历史记录
+	HistoryNext             key.Binding // 下一条历史记录
+	Increment               key.Binding // 数字模式下将值加上 Step
+	Decrement               key.Binding // 数字模式下将值减去 Step
+	IncrementLarge          key.Binding // 数字模式下将值加上 LargeStep
+	DecrementLarge          key.Binding // 数字模式下将值减去 LargeStep
 }
 
 // DefaultKeyMap 是默认的键绑定集合，用于导航和操作文本输入框
@@ -81,6 +86,13 @@ var DefaultKeyMap = KeyMap{
 	AcceptSuggestion:        key.NewBinding(key.WithKeys("tab")),                              // Tab键
 	NextSuggestion:          key.NewBinding(key.WithKeys("down", "ctrl+n")),                   // 下箭头或Ctrl+N
 	PrevSuggestion:          key.NewBinding(key.WithKeys("up", "ctrl+p")),                     // 上箭头或Ctrl+P
+	Accept:                  key.NewBinding(key.WithKeys("enter")),                            // 回车键
+	HistoryPrev:             key.NewBinding(key.WithKeys("up", "ctrl+p")),                     // 上箭头或Ctrl+P
+	HistoryNext:             key.NewBinding(key.WithKeys("down", "ctrl+n")),                   // 下箭头或Ctrl+N
+	Increment:               key.NewBinding(key.WithKeys("up")),                               // 上箭头
+	Decrement:               key.NewBinding(key.WithKeys("down")),                             // 下箭头
+	IncrementLarge:          key.NewBinding(key.WithKeys("shift+up", "pgup")),                 // Shift+上箭头或PgUp
+	DecrementLarge:          key.NewBinding(key.WithKeys("shift+down", "pgdown")),             // Shift+下箭头或PgDown
 }
 
 // Model 是文本输入元素的Bubble Tea模型
@@ -105,6 +117,7 @@ type Model struct {
 	TextStyle        lipgloss.Style // 文本样式
 	PlaceholderStyle lipgloss.Style // 占位符样式
 	CompletionStyle  lipgloss.Style // 自动补全样式
+	PreeditStyle     lipgloss.Style // 输入法预编辑文本样式，参见 SetPreedit
 
 	// 已弃用：请使用Cursor.Style代替
 	CursorStyle lipgloss.Style
@@ -113,11 +126,37 @@ type Model struct {
 	// 如果为0或更小，则没有限制
 	CharLimit int
 
+	// Mask 是输入的格式模板，例如 "(###) ###-####" 或 "####-##-##"。
+	// # 匹配数字，@ 匹配字母，* 匹配字母或数字，其他字符是在输入时自动插入的字面量
+	// （可以用反斜杠转义占位符）。设置后，输入被限制为模板的格式，
+	// Value 返回格式化后的值，RawValue 返回去除字面量后的原始输入。
+	Mask string
+
+	// NumberMode 限制输入为整数或小数（参见 NumberMode）。在数字模式下，
+	// Increment 和 Decrement 键按 Step 增减值，IncrementLarge 和 DecrementLarge
+	// 键按 LargeStep 增减值，结果限制在 Min 和 Max 之间；输入的值无效或超出范围时
+	// 设置 Err。使用 ValueInt 或 ValueFloat 读取值。
+	NumberMode NumberMode
+
+	// Step 是数字模式下的步长。如果为 0 或更小，则为 1
+	Step float64
+
+	// LargeStep 是数字模式下的大步长。如果为 0 或更小，则为 Step 的 10 倍
+	LargeStep float64
+
+	// Min 和 Max 是数字模式下值的范围。如果 Max 不大于 Min，则不限制
+	Min, Max float64
+
 	// Width 是一次可以显示的最大字符数
 	// 它本质上将文本字段视为水平滚动的视口
 	// 如果为0或更小，则忽略此设置
 	Width int
 
+	// TextAlign 是设置了 Width 时内容在字段中的对齐方式，
+	// 可以是 lipgloss.Left（默认）、lipgloss.Center 或 lipgloss.Right。
+	// 例如右对齐的数字字段或居中的搜索框。如果未设置 Width，则忽略此设置。
+	TextAlign lipgloss.Position
+
 	// KeyMap 是小部件识别的键绑定
 	KeyMap KeyMap
 
@@ -135,11 +174,30 @@ type Model struct {
 	offset      int // 左偏移量
 	offsetRight int // 右偏移量
 
+	// preedit 是输入法正在编辑、尚未提交的文本。
+	preedit []rune
+
 	// Validate 是一个函数，用于检查输入中的文本是否有效
 	// 如果无效，`Err`字段将设置为函数返回的错误
 	// 如果未定义该函数，则所有输入都被视为有效
 	Validate ValidateFunc
 
+	// ValidateOn 决定何时运行 Validate，默认为每次编辑时（参见 ValidateOn）
+	ValidateOn ValidateOn
+
+	// ErrorStyle 在 Err 不为 nil 时叠加到 PromptStyle 和 TextStyle 上
+	ErrorStyle lipgloss.Style
+
+	// ShowErrorMessage 在 Err 不为 nil 时在输入框下方显示错误消息
+	ShowErrorMessage bool
+
+	// ErrorMessageStyle 是错误消息的样式
+	ErrorMessageStyle lipgloss.Style
+
+	// DisplayTransform 在渲染时转换值（参见 DisplayTransformFunc），
+	// 只影响显示，不影响 Value。仅在 EchoNormal 回显模式下使用。
+	DisplayTransform DisplayTransformFunc
+
 	// 输入的符文清理器
 	rsan runeutil.Sanitizer
 
@@ -150,6 +208,16 @@ type Model struct {
 	suggestions            [][]rune // 所有建议
 	matchedSuggestions     [][]rune // 匹配的建议
 	currentSuggestionIndex int      // 当前选中的建议索引
+
+	// HistoryEnabled 启用历史记录功能（参见 SetHistory）
+	HistoryEnabled bool
+
+	// HistorySize 是保留的历史记录条数上限。如果为 0 或更小，则不限制
+	HistorySize int
+
+	history      []string // 历史记录，最旧的在前
+	historyIndex int      // 当前浏览的历史记录索引，等于 len(history) 时表示正在编辑的值
+	historyDraft string   // 开始浏览历史记录前正在编辑的值
 }
 
 // New 创建一个具有默认设置的新模型
@@ -161,8 +229,13 @@ func New() Model {
 		PlaceholderStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("240")), // 占位符样式
 		ShowSuggestions:  false,                                                 // 默认不显示自动补全建议
 		CompletionStyle:  lipgloss.NewStyle().Foreground(lipgloss.Color("240")), // 自动补全样式
+		PreeditStyle:     lipgloss.NewStyle().Underline(true),                   // 预编辑文本样式
+		ErrorStyle:       lipgloss.NewStyle().Foreground(lipgloss.Color("9")),   // 错误样式
 		Cursor:           cursor.New(),                                          // 新的光标模型
 		KeyMap:           DefaultKeyMap,                                         // 默认键绑定
+		HistorySize:      defaultHistorySize,                                    // 默认历史记录条数上限
+
+		ErrorMessageStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("9")), // 错误消息样式
 
 		suggestions: [][]rune{}, // 空的建议列表
 		value:       nil,        // 空的文本值
@@ -198,6 +271,10 @@ func (m *Model) setValueInternal(runes []rune, err error) {
 	if (m.pos == 0 && empty) || m.pos > len(m.value) {
 		m.SetCursor(len(m.value))
 	}
+	if m.Mask != "" {
+		m.applyMask()
+		m.Err = m.validate(m.value)
+	}
 	m.handleOverflow()
 }
 
@@ -211,6 +288,19 @@ func (m Model) Position() int {
 	return m.pos
 }
 
+// CursorScreenPosition 返回光标相对于组件左边缘的屏幕列（从 0 开始）。
+// 计算时会考虑提示符宽度、水平滚动偏移、回显模式以及宽字符，
+// 父组件可以据此将弹出层（例如建议下拉框）直接定位在光标下方。
+func (m Model) CursorScreenPosition() (col int) {
+	col = lipgloss.Width(m.PromptStyle.Render(m.Prompt)) + m.alignOffset()
+	if len(m.value) == 0 {
+		return col
+	}
+	start := clamp(m.offset, 0, len(m.value))
+	end := clamp(m.pos, start, len(m.value))
+	return col + runeutil.StringWidth(m.echoTransform(string(m.value[start:end])))
+}
+
 // SetCursor moves the cursor to the given position. If the position is
 // out of bounds the cursor will be moved to the start or end accordingly.
 func (m *Model) SetCursor(pos int) {
@@ -245,11 +335,15 @@ func (m *Model) Focus() tea.Cmd {
 func (m *Model) Blur() {
 	m.focus = false
 	m.Cursor.Blur()
+	if m.ValidateOn == ValidateOnBlur {
+		m.Check()
+	}
 }
 
 // Reset sets the input to its default state with no input.
 func (m *Model) Reset() {
 	m.value = nil
+	m.preedit = nil
 	m.SetCursor(0)
 }
 
@@ -263,13 +357,32 @@ func (m *Model) SetSuggestions(suggestions []string) {
 	m.updateSuggestions()
 }
 
+// DefaultSanitizer returns the sanitizer used by default: since textinput has
+// all its input on a single line, it collapses newlines/tabs to single spaces
+// and strips other control characters. Additional options are applied after
+// the defaults, e.g. DefaultSanitizer(runeutil.NormalizeNFC()).
+func DefaultSanitizer(opts ...runeutil.Option) runeutil.Sanitizer {
+	return runeutil.NewSanitizer(append([]runeutil.Option{
+		runeutil.ReplaceTabs(" "), runeutil.ReplaceNewlines(" "),
+	}, opts...)...)
+}
+
+// SetSanitizer sets the rune sanitizer applied to typed input, pastes and
+// SetValue. Use runeutil.Passthrough to disable sanitization entirely, or nil
+// to restore the default.
+func (m *Model) SetSanitizer(s runeutil.Sanitizer) {
+	m.rsan = s
+}
+
+// Sanitizer returns the rune sanitizer in use.
+func (m *Model) Sanitizer() runeutil.Sanitizer {
+	return m.san()
+}
+
 // rsan initializes or retrieves the rune sanitizer.
 func (m *Model) san() runeutil.Sanitizer {
 	if m.rsan == nil {
-		// Textinput has all its input on a single line so collapse
-		// newlines/tabs to single spaces.
-		m.rsan = runeutil.NewSanitizer(
-			runeutil.ReplaceTabs(" "), runeutil.ReplaceNewlines(" "))
+		m.rsan = DefaultSanitizer()
 	}
 	return m.rsan
 }
@@ -278,7 +391,7 @@ func (m *Model) insertRunesFromUserInput(v []rune) {
 	// Clean up any special characters in the input provided by the
 	// clipboard. This avoids bugs due to e.g. tab characters and
 	// whatnot.
-	paste := m.san().Sanitize(v)
+	paste := m.numberRunes(m.san().Sanitize(v))
 
 	var availSpace int
 	if m.CharLimit > 0 {
@@ -323,7 +436,7 @@ func (m *Model) insertRunesFromUserInput(v []rune) {
 // If a max width is defined, perform some logic to treat the visible area
 // as a horizontally scrolling viewport.
 func (m *Model) handleOverflow() {
-	if m.Width <= 0 || uniseg.StringWidth(string(m.value)) <= m.Width {
+	if m.Width <= 0 || runeutil.StringWidth(string(m.value)) <= m.Width {
 		m.offset = 0
 		m.offsetRight = len(m.value)
 		return
@@ -340,7 +453,7 @@ func (m *Model) handleOverflow() {
 		runes := m.value[m.offset:]
 
 		for i < len(runes) && w <= m.Width {
-			w += rw.RuneWidth(runes[i])
+			w += runeutil.RuneWidth(runes[i])
 			if w <= m.Width+1 {
 				i++
 			}
@@ -355,7 +468,7 @@ func (m *Model) handleOverflow() {
 		i := len(runes) - 1
 
 		for i > 0 && w < m.Width {
-			w += rw.RuneWidth(runes[i])
+			w += runeutil.RuneWidth(runes[i])
 			if w <= m.Width {
 				i--
 			}
@@ -536,7 +649,7 @@ func (m *Model) wordForward() {
 func (m Model) echoTransform(v string) string {
 	switch m.EchoMode {
 	case EchoPassword:
-		return strings.Repeat(string(m.EchoCharacter), uniseg.StringWidth(v))
+		return strings.Repeat(string(m.EchoCharacter), runeutil.StringWidth(v))
 	case EchoNone:
 		return ""
 	case EchoNormal:
@@ -567,11 +680,23 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		numeric := m.NumberMode != NumberNone
 		switch {
+		case numeric && key.Matches(msg, m.KeyMap.Increment):
+			m.stepNumber(m.step())
+		case numeric && key.Matches(msg, m.KeyMap.Decrement):
+			m.stepNumber(-m.step())
+		case numeric && key.Matches(msg, m.KeyMap.IncrementLarge):
+			m.stepNumber(m.largeStep())
+		case numeric && key.Matches(msg, m.KeyMap.DecrementLarge):
+			m.stepNumber(-m.largeStep())
 		case key.Matches(msg, m.KeyMap.DeleteWordBackward):
 			m.deleteWordBackward()
 		case key.Matches(msg, m.KeyMap.DeleteCharacterBackward):
-			m.Err = nil
+			if m.ValidateOn == ValidateOnKeyPress {
+				m.Err = nil
+			}
+			m.skipMaskLiterals(-1)
 			if len(m.value) > 0 {
 				m.value = append(m.value[:max(0, m.pos-1)], m.value[m.pos:]...)
 				m.Err = m.validate(m.value)
@@ -594,6 +719,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case key.Matches(msg, m.KeyMap.LineStart):
 			m.CursorStart()
 		case key.Matches(msg, m.KeyMap.DeleteCharacterForward):
+			m.skipMaskLiterals(1)
 			if len(m.value) > 0 && m.pos < len(m.value) {
 				m.value = append(m.value[:m.pos], m.value[m.pos+1:]...)
 				m.Err = m.validate(m.value)
@@ -608,6 +734,17 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, Paste
 		case key.Matches(msg, m.KeyMap.DeleteWordForward):
 			m.deleteWordForward()
+		case (m.HistoryEnabled || m.ValidateOn == ValidateOnSubmit) && key.Matches(msg, m.KeyMap.Accept):
+			if m.ValidateOn == ValidateOnSubmit {
+				m.Check()
+			}
+			if m.HistoryEnabled {
+				m.AddHistory(string(m.value))
+			}
+		case m.HistoryEnabled && !m.ShowSuggestions && key.Matches(msg, m.KeyMap.HistoryPrev):
+			m.historyPrev()
+		case m.HistoryEnabled && !m.ShowSuggestions && key.Matches(msg, m.KeyMap.HistoryNext):
+			m.historyNext()
 		case key.Matches(msg, m.KeyMap.NextSuggestion):
 			m.nextSuggestion()
 		case key.Matches(msg, m.KeyMap.PrevSuggestion):
@@ -617,6 +754,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.insertRunesFromUserInput(msg.Runes)
 		}
 
+		// 编辑操作可能破坏了掩码格式，因此重新格式化
+		if m.Mask != "" {
+			m.applyMask()
+			m.Err = m.validate(m.value)
+		}
+
 		// Check again if can be completed
 		// because value might be something that does not match the completion prefix
 		m.updateSuggestions()
@@ -645,25 +788,32 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 // View renders the textinput in its current state.
 func (m Model) View() string {
+	return m.errorView(m.errorStyled().inputView())
+}
+
+// inputView 渲染输入框本身。
+func (m Model) inputView() string {
 	// Placeholder text
-	if len(m.value) == 0 && m.Placeholder != "" {
+	if len(m.value) == 0 && len(m.preedit) == 0 && m.Placeholder != "" {
 		return m.placeholderView()
 	}
 
+	m = m.withPreedit()
 	styleText := m.TextStyle.Inline(true).Render
 
 	value := m.value[m.offset:m.offsetRight]
 	pos := max(0, m.pos-m.offset)
-	v := styleText(m.echoTransform(string(value[:pos])))
+	v, after := m.displayText(pos)
+	v += m.preeditView() // IME composition before the cursor
 
 	if pos < len(value) { //nolint:nestif
 		char := m.echoTransform(string(value[pos]))
 		m.Cursor.SetChar(char)
-		v += m.Cursor.View()                                   // cursor and text under it
-		v += styleText(m.echoTransform(string(value[pos+1:]))) // text after cursor
-		v += m.completionView(0)                               // suggested completion
+		v += m.Cursor.View()     // cursor and text under it
+		v += after               // text after cursor
+		v += m.completionView(0) // suggested completion
 	} else {
-		if m.focus && m.canAcceptSuggestion() {
+		if m.focus && m.canAcceptSuggestion() && len(m.preedit) == 0 {
 			suggestion := m.matchedSuggestions[m.currentSuggestionIndex]
 			if len(value) < len(suggestion) {
 				m.Cursor.TextStyle = m.CompletionStyle
@@ -682,18 +832,58 @@ func (m Model) View() string {
 
 	// If a max width and background color were set fill the empty spaces with
 	// the background color.
-	valWidth := uniseg.StringWidth(string(value))
-	if m.Width > 0 && valWidth <= m.Width {
-		padding := max(0, m.Width-valWidth)
-		if valWidth+padding <= m.Width && pos < len(value) {
-			padding++
+	if padding, ok := m.padding(); ok {
+		left, right := m.alignPadding(padding)
+		if left > 0 {
+			v = styleText(strings.Repeat(" ", left)) + v
 		}
-		v += styleText(strings.Repeat(" ", padding))
+		v += styleText(strings.Repeat(" ", right))
 	}
 
 	return m.PromptStyle.Render(m.Prompt) + v
 }
 
+// padding 返回设置了 Width 时用于填充字段的空格数。如果没有设置 Width
+// 或内容超出了 Width，ok 为 false。
+func (m Model) padding() (padding int, ok bool) {
+	value := m.value[m.offset:m.offsetRight]
+	valWidth := runeutil.StringWidth(string(value)) + m.preeditWidth()
+	if m.Width <= 0 || valWidth > m.Width {
+		return 0, false
+	}
+	padding = max(0, m.Width-valWidth)
+	if m.pos-m.offset < len(value) {
+		padding++
+	}
+	return padding, true
+}
+
+// alignPadding 根据 TextAlign 将填充拆分为内容左侧和右侧的空格数。
+func (m Model) alignPadding(padding int) (left, right int) {
+	align := min(max(float64(m.TextAlign), 0), 1)
+	left = int(float64(padding) * align)
+	return left, padding - left
+}
+
+// alignOffset 返回对齐方式在内容左侧插入的空格数。
+func (m Model) alignOffset() int {
+	if len(m.value) == 0 && len(m.preedit) == 0 && m.Placeholder != "" {
+		if m.Width <= 0 {
+			return 0
+		}
+		first, rest := runeutil.FirstGrapheme(m.Placeholder)
+		_, padding := m.placeholderLayout(first, rest)
+		left, _ := m.alignPadding(padding)
+		return left
+	}
+	padding, ok := m.padding()
+	if !ok {
+		return 0
+	}
+	left, _ := m.alignPadding(padding)
+	return left
+}
+
 // placeholderView returns the prompt and placeholder view, if any.
 func (m Model) placeholderView() string {
 	var (
@@ -703,21 +893,20 @@ func (m Model) placeholderView() string {
 	)
 
 	m.Cursor.TextStyle = m.PlaceholderStyle
-	first, rest, _, _ := uniseg.FirstGraphemeClusterInString(m.Placeholder, 0)
+	first, rest := runeutil.FirstGrapheme(m.Placeholder)
 	m.Cursor.SetChar(first)
 	v += m.Cursor.View()
 
 	// If the entire placeholder is already set and no padding is needed, finish
-	if m.Width < 1 && uniseg.StringWidth(rest) <= 1 {
+	if m.Width < 1 && runeutil.StringWidth(rest) <= 1 {
 		return m.PromptStyle.Render(m.Prompt) + v
 	}
 
 	// If Width is set then size placeholder accordingly
 	if m.Width > 0 {
-		width := m.Width - lipgloss.Width(p) - lipgloss.Width(v)
-		placeholderRest := ansi.Truncate(rest, width, "…")
-		availWidth := max(0, width-lipgloss.Width(placeholderRest))
-		v += style(placeholderRest) + strings.Repeat(" ", availWidth)
+		placeholderRest, availWidth := m.placeholderLayout(first, rest)
+		left, right := m.alignPadding(availWidth)
+		v = strings.Repeat(" ", left) + v + style(placeholderRest) + strings.Repeat(" ", right)
 	} else {
 		// if there is no width, the placeholder can be any length
 		v += style(rest)
@@ -726,6 +915,13 @@ func (m Model) placeholderView() string {
 	return p + v
 }
 
+// placeholderLayout 返回设置了 Width 时截断后的占位符剩余部分，以及需要填充的空格数。
+func (m Model) placeholderLayout(first, rest string) (placeholderRest string, availWidth int) {
+	width := m.Width - lipgloss.Width(m.PromptStyle.Render(m.Prompt)) - runeutil.StringWidth(first)
+	placeholderRest = ansi.Truncate(rest, width, "…")
+	return placeholderRest, max(0, width-lipgloss.Width(placeholderRest))
+}
+
 // Blink is a command used to initialize cursor blinking.
 func Blink() tea.Msg {
 	return cursor.Blink()
@@ -876,10 +1072,3 @@ func (m *Model) previousSuggestion() {
 		m.currentSuggestionIndex = len(m.matchedSuggestions) - 1
 	}
 }
-
-func (m Model) validate(v []rune) error {
-	if m.Validate != nil {
-		return m.Validate(string(v))
-	}
-	return nil
-}
@@ -1,12 +1,17 @@
 package textinput
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/purpose168/bubbles-cn/runeutil"
 	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
 )
 
 func Test_CurrentSuggestion(t *testing.T) {
@@ -72,6 +77,63 @@ func TestPlaceholderTruncate(t *testing.T) {
 	}
 }
 
+func TestCursorScreenPosition(t *testing.T) {
+	textinput := New()
+	if got := textinput.CursorScreenPosition(); got != 2 {
+		t.Fatalf("expected cursor column 2 on empty input but got %d", got)
+	}
+
+	textinput.SetValue("你好ab")
+	if got := textinput.CursorScreenPosition(); got != 8 {
+		t.Fatalf("expected cursor column 8 after wide runes but got %d", got)
+	}
+
+	textinput.SetCursor(1)
+	if got := textinput.CursorScreenPosition(); got != 4 {
+		t.Fatalf("expected cursor column 4 but got %d", got)
+	}
+
+	textinput.EchoMode = EchoNone
+	if got := textinput.CursorScreenPosition(); got != 2 {
+		t.Fatalf("expected cursor column 2 with EchoNone but got %d", got)
+	}
+
+	textinput.EchoMode = EchoNormal
+	textinput.Width = 4
+	textinput.SetValue("abcdefghij")
+	textinput.CursorEnd()
+	if got := textinput.CursorScreenPosition(); got > 2+textinput.Width {
+		t.Fatalf("expected cursor column to stay within width but got %d", got)
+	}
+}
+
+func TestTextAlign(t *testing.T) {
+	textinput := New()
+	textinput.Width = 10
+	textinput.SetValue("42")
+
+	textinput.TextAlign = lipgloss.Right
+	if got, want := ansi.Strip(textinput.View()), ">         42 "; got != want {
+		t.Fatalf("expected %q but got %q", want, got)
+	}
+	if got := textinput.CursorScreenPosition(); got != 12 {
+		t.Fatalf("expected cursor column 12 but got %d", got)
+	}
+
+	textinput.TextAlign = lipgloss.Center
+	if got, want := ansi.Strip(textinput.View()), ">     42     "; got != want {
+		t.Fatalf("expected %q but got %q", want, got)
+	}
+	if got := textinput.CursorScreenPosition(); got != 8 {
+		t.Fatalf("expected cursor column 8 but got %d", got)
+	}
+
+	textinput.TextAlign = lipgloss.Left
+	if got, want := ansi.Strip(textinput.View()), "> 42         "; got != want {
+		t.Fatalf("expected %q but got %q", want, got)
+	}
+}
+
 func ExampleValidateFunc() {
 	creditCardNumber := New()
 	creditCardNumber.Placeholder = "4505 **** **** 1234"
@@ -116,3 +178,313 @@ func sendString(m Model, str string) Model {
 
 	return m
 }
+
+func TestMask(t *testing.T) {
+	textinput := New()
+	textinput.Mask = "(###) ###-####"
+	textinput.Focus()
+
+	textinput = sendString(textinput, "555a1234567")
+	if got, want := textinput.Value(), "(555) 123-4567"; got != want {
+		t.Fatalf("expected formatted value %q but got %q", want, got)
+	}
+	if got, want := textinput.RawValue(), "5551234567"; got != want {
+		t.Fatalf("expected raw value %q but got %q", want, got)
+	}
+
+	// 掩码已满，多余的输入被忽略
+	textinput = sendString(textinput, "8")
+	if got, want := textinput.Value(), "(555) 123-4567"; got != want {
+		t.Fatalf("expected input beyond the mask to be ignored but got %q", got)
+	}
+
+	// 退格跳过字面量，删除最近的数字
+	textinput.SetCursor(9)
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if got, want := textinput.Value(), "(555) 124-567"; got != want {
+		t.Fatalf("expected %q after backspace but got %q", want, got)
+	}
+	if got, want := textinput.Position(), 8; got != want {
+		t.Fatalf("expected cursor at %d but got %d", want, got)
+	}
+
+	textinput.Mask = "+1 (###)"
+	textinput.SetValue("+1 (12")
+	if got, want := textinput.RawValue(), "12"; got != want {
+		t.Fatalf("expected literal digits to be excluded from raw value but got %q", got)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	textinput := New()
+	textinput.Focus()
+	textinput.SetHistory([]string{"ls", "cd /tmp"})
+
+	up := tea.KeyMsg{Type: tea.KeyUp}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	textinput.SetValue("git st")
+	for _, want := range []string{"cd /tmp", "ls", "ls"} {
+		textinput, _ = textinput.Update(up)
+		if got := textinput.Value(); got != want {
+			t.Fatalf("expected %q after up, got %q", want, got)
+		}
+	}
+	for _, want := range []string{"cd /tmp", "git st", "git st"} {
+		textinput, _ = textinput.Update(down)
+		if got := textinput.Value(); got != want {
+			t.Fatalf("expected %q after down, got %q", want, got)
+		}
+	}
+
+	textinput.SetValue("git status")
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got, want := textinput.History(), []string{"ls", "cd /tmp", "git status"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected history %q, got %q", want, got)
+	}
+
+	textinput.Reset()
+	textinput, _ = textinput.Update(up)
+	if got := textinput.Value(); got != "git status" {
+		t.Fatalf("expected the newest entry after up, got %q", got)
+	}
+
+	// 显示自动补全建议时，上下键用于切换建议
+	textinput.ShowSuggestions = true
+	textinput, _ = textinput.Update(up)
+	if got := textinput.Value(); got != "git status" {
+		t.Fatalf("expected up to be left to suggestions, got %q", got)
+	}
+}
+
+func TestSanitizer(t *testing.T) {
+	textinput := New()
+	textinput.SetValue("a\tb")
+	if v := textinput.Value(); v != "a b" {
+		t.Fatalf("expected tabs to be replaced by default, got %q", v)
+	}
+
+	textinput.SetSanitizer(DefaultSanitizer(runeutil.StripZeroWidth(), runeutil.NormalizeNFC()))
+	textinput.SetValue("cafe\u0301\u200b")
+	textinput.Focus()
+	textinput.CursorEnd()
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("\u200b!"), Paste: true})
+	if v := textinput.Value(); v != "caf\u00e9!" {
+		t.Fatalf("expected sanitized value, got %q", v)
+	}
+
+	textinput.SetSanitizer(runeutil.Passthrough)
+	textinput.SetValue("a\tb")
+	if v := textinput.Value(); v != "a\tb" {
+		t.Fatalf("expected sanitization to be disabled, got %q", v)
+	}
+
+	textinput.SetSanitizer(nil)
+	textinput.SetValue("a\tb")
+	if v := textinput.Value(); v != "a b" {
+		t.Fatalf("expected default sanitizer to be restored, got %q", v)
+	}
+}
+
+func TestDisplayTransform(t *testing.T) {
+	textinput := New()
+	textinput.Prompt = ""
+	textinput.DisplayTransform = func(value string, _ int) string {
+		words := strings.Split(value, " ")
+		for i, w := range words {
+			if strings.HasPrefix(w, "-") {
+				words[i] = "\x1b[1m" + w + "\x1b[m"
+			}
+		}
+		return strings.Join(words, " ")
+	}
+	textinput.SetValue("ls -la dir")
+	textinput.Focus()
+	textinput.SetCursor(4)
+
+	view := textinput.View()
+	if !strings.Contains(view, "\x1b[1m-") || !strings.Contains(view, "a\x1b[m") {
+		t.Fatalf("expected the flag to be styled around the cursor, got %q", view)
+	}
+	if got := ansi.Strip(view); got != "ls -la dir" {
+		t.Fatalf("expected the transformed view to keep the text, got %q", got)
+	}
+	if textinput.Value() != "ls -la dir" {
+		t.Fatalf("expected the value to be untouched, got %q", textinput.Value())
+	}
+
+	// 水平滚动时只渲染可见部分
+	textinput.Width = 5
+	textinput.CursorEnd()
+	textinput, _ = textinput.Update(nil)
+	if got := ansi.Strip(textinput.View()); got != "a dir " {
+		t.Fatalf("expected the visible window, got %q", got)
+	}
+
+	textinput.EchoMode = EchoPassword
+	if strings.Contains(textinput.View(), "\x1b[1m") {
+		t.Fatal("expected the transform to be skipped in password mode")
+	}
+}
+
+func TestValidateOn(t *testing.T) {
+	errShort := errors.New("too short")
+	newInput := func(on ValidateOn) Model {
+		textinput := New()
+		textinput.Prompt = ""
+		textinput.ValidateOn = on
+		textinput.Validate = func(s string) error {
+			if len(s) < 3 {
+				return errShort
+			}
+			return nil
+		}
+		textinput.Focus()
+		return textinput
+	}
+	typ := func(m Model, s string) Model {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+		return m
+	}
+
+	textinput := typ(newInput(ValidateOnKeyPress), "a")
+	if textinput.Err != errShort {
+		t.Fatalf("expected validation on key press, got %v", textinput.Err)
+	}
+
+	textinput = typ(newInput(ValidateOnBlur), "a")
+	if textinput.Err != nil {
+		t.Fatalf("expected no validation before blur, got %v", textinput.Err)
+	}
+	textinput.Blur()
+	if textinput.Err != errShort {
+		t.Fatalf("expected validation on blur, got %v", textinput.Err)
+	}
+
+	textinput = typ(newInput(ValidateOnSubmit), "a")
+	textinput.Blur()
+	textinput.Focus()
+	if textinput.Err != nil {
+		t.Fatalf("expected no validation before submit, got %v", textinput.Err)
+	}
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if textinput.Err != errShort {
+		t.Fatalf("expected validation on submit, got %v", textinput.Err)
+	}
+
+	// 显示错误后，编辑会重新验证，使错误在更正后消失。
+	textinput = typ(textinput, "b")
+	if textinput.Err != errShort {
+		t.Fatalf("expected the error to stay, got %v", textinput.Err)
+	}
+	textinput = typ(textinput, "c")
+	if textinput.Err != nil {
+		t.Fatalf("expected the error to clear once fixed, got %v", textinput.Err)
+	}
+}
+
+func TestErrorView(t *testing.T) {
+	textinput := New()
+	textinput.Prompt = "> "
+	textinput.ErrorStyle = lipgloss.NewStyle().Bold(true)
+	textinput.SetValue("abc")
+	textinput.ShowErrorMessage = true
+
+	if got := textinput.View(); strings.Contains(got, "\n") || textinput.errorStyled().PromptStyle.GetBold() {
+		t.Fatalf("expected no error state, got %q", got)
+	}
+
+	textinput.Err = errors.New("invalid")
+	if styled := textinput.errorStyled(); !styled.PromptStyle.GetBold() || !styled.TextStyle.GetBold() {
+		t.Fatal("expected the prompt and text to use ErrorStyle")
+	}
+	if got := ansi.Strip(textinput.View()); got != "> abc \ninvalid" {
+		t.Fatalf("expected the error message below the input, got %q", got)
+	}
+}
+
+func TestPreedit(t *testing.T) {
+	textinput := New()
+	textinput.Prompt = ""
+	textinput.Focus()
+	textinput.SetValue("ab")
+	textinput.SetPreedit("ni")
+
+	if got := ansi.Strip(textinput.View()); !strings.HasPrefix(got, "abni") {
+		t.Fatalf("expected preedit before the cursor, got %q", got)
+	}
+	if textinput.Value() != "ab" || !textinput.Composing() {
+		t.Fatalf("expected preedit not to be part of the value, got %q", textinput.Value())
+	}
+
+	textinput.SetPreedit("你")
+	textinput.CommitPreedit()
+	if textinput.Value() != "ab你" || textinput.Composing() || textinput.Position() != 3 {
+		t.Fatalf("expected committed preedit, got %q at %d", textinput.Value(), textinput.Position())
+	}
+
+	// 设置了宽度时，为预编辑文本腾出空间。
+	textinput.Width = 4
+	textinput.SetValue("abcdef")
+	textinput.CursorEnd()
+	textinput.SetPreedit("xy")
+	if got := ansi.Strip(textinput.View()); !strings.HasPrefix(got, "efxy") {
+		t.Fatalf("expected the view to scroll for the preedit, got %q", got)
+	}
+}
+
+func TestNumberMode(t *testing.T) {
+	textinput := New()
+	textinput.NumberMode = NumberInt
+	textinput.Min, textinput.Max = -5, 20
+	textinput.Focus()
+
+	// 非数字的字符、不在开头的负号和小数点被忽略
+	textinput = sendString(textinput, "1a-2.")
+	if got, want := textinput.Value(), "12"; got != want {
+		t.Fatalf("expected %q but got %q", want, got)
+	}
+
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if n, err := textinput.ValueInt(); n != 13 || err != nil {
+		t.Fatalf("expected 13 after increment but got %d, %v", n, err)
+	}
+	// 大步长的结果被限制在 Max 之内
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	if got, want := textinput.Value(), "20"; got != want {
+		t.Fatalf("expected %q after large increment but got %q", want, got)
+	}
+
+	// 输入超出范围的值时设置 Err
+	textinput = sendString(textinput, "0")
+	if _, err := textinput.ValueInt(); !errors.Is(err, ErrOutOfRange) || !errors.Is(textinput.Err, ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange but got %v (Err %v)", err, textinput.Err)
+	}
+
+	textinput.Reset()
+	textinput = sendString(textinput, "-")
+	if _, err := textinput.ValueInt(); !errors.Is(err, ErrNotANumber) {
+		t.Fatalf("expected ErrNotANumber but got %v", err)
+	}
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got, want := textinput.Value(), "-1"; got != want {
+		t.Fatalf("expected %q after decrementing an invalid value but got %q", want, got)
+	}
+
+	textinput = New()
+	textinput.NumberMode = NumberFloat
+	textinput.Step = 0.1
+	textinput.Focus()
+	textinput = sendString(textinput, "0.2.5")
+	if got, want := textinput.Value(), "0.25"; got != want {
+		t.Fatalf("expected %q but got %q", want, got)
+	}
+	textinput, _ = textinput.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if f, err := textinput.ValueFloat(); f != 0.35 || err != nil {
+		t.Fatalf("expected 0.35 after increment but got %v, %v", f, err)
+	}
+	if got, want := textinput.Value(), "0.35"; got != want {
+		t.Fatalf("expected %q but got %q", want, got)
+	}
+}
@@ -0,0 +1,37 @@
+package textinput
+
+import (
+	"github.com/purpose168/bubbles-cn/runeutil"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+// DisplayTransformFunc 在渲染时转换输入的值，例如为命令行中的参数和选项着色。
+// 它接收完整的值和光标位置（以字符计），返回带样式的值。
+// 返回值只能添加 ANSI 样式，其可见文本必须与 value 相同，
+// 因为光标和水平滚动仍然基于原始值计算。
+type DisplayTransformFunc func(value string, pos int) string
+
+// displayText 返回可见部分中光标之前和光标之后（不含光标下的字符）的渲染文本。
+// 设置了 DisplayTransform 且回显模式为 EchoNormal 时使用转换后的值，
+// 此时由转换负责样式，不再应用 TextStyle。
+func (m Model) displayText(pos int) (before, after string) {
+	value := m.value[m.offset:m.offsetRight]
+	if m.DisplayTransform == nil || m.EchoMode != EchoNormal {
+		styleText := m.TextStyle.Inline(true).Render
+		before = styleText(m.echoTransform(string(value[:pos])))
+		if pos < len(value) {
+			after = styleText(m.echoTransform(string(value[pos+1:])))
+		}
+		return before, after
+	}
+
+	styled := m.DisplayTransform(string(m.value), m.pos)
+	start := runeutil.StringWidth(string(m.value[:m.offset]))
+	cursor := start + runeutil.StringWidth(string(value[:pos]))
+	before = ansi.Cut(styled, start, cursor)
+	if pos < len(value) {
+		end := start + runeutil.StringWidth(string(value))
+		after = ansi.Cut(styled, cursor+runeutil.StringWidth(string(value[pos])), end)
+	}
+	return before, after
+}
@@ -0,0 +1,61 @@
+package textinput
+
+// ValidateOn 决定何时运行 Validate。
+type ValidateOn int
+
+// 可用的验证时机。
+const (
+	// ValidateOnKeyPress 在每次编辑时验证（默认）。
+	ValidateOnKeyPress ValidateOn = iota
+
+	// ValidateOnBlur 在输入框失去焦点（调用 Blur）时验证。
+	ValidateOnBlur
+
+	// ValidateOnSubmit 在按下 Accept 键时验证。
+	ValidateOnSubmit
+)
+
+// Check 立即运行 Validate 并设置 Err，与 ValidateOn 无关。
+// 表单可以在提交时对所有输入框调用它。
+func (m *Model) Check() error {
+	m.Err = m.runValidate(m.value)
+	return m.Err
+}
+
+// validate 返回编辑后的值 v 的验证结果。ValidateOnBlur 和 ValidateOnSubmit
+// 模式下编辑不触发验证；但如果已经显示了错误，则每次编辑都重新验证，
+// 使错误在输入被更正后立即消失。
+func (m Model) validate(v []rune) error {
+	if m.ValidateOn != ValidateOnKeyPress && m.Err == nil {
+		return nil
+	}
+	return m.runValidate(v)
+}
+
+// runValidate 在数字模式下检查 v 是否是有效的数字，然后使用 Validate 验证 v。
+func (m Model) runValidate(v []rune) error {
+	if err := m.validateNumber(v); err != nil {
+		return err
+	}
+	if m.Validate != nil {
+		return m.Validate(string(v))
+	}
+	return nil
+}
+
+// errorStyled 在 Err 不为 nil 时将 ErrorStyle 叠加到提示符和文本的样式上。
+func (m Model) errorStyled() Model {
+	if m.Err != nil {
+		m.PromptStyle = m.ErrorStyle.Inherit(m.PromptStyle)
+		m.TextStyle = m.ErrorStyle.Inherit(m.TextStyle)
+	}
+	return m
+}
+
+// errorView 在设置了 ShowErrorMessage 且 Err 不为 nil 时，在输入框下方渲染错误消息。
+func (m Model) errorView(input string) string {
+	if !m.ShowErrorMessage || m.Err == nil {
+		return input
+	}
+	return input + "\n" + m.ErrorMessageStyle.Inline(true).Render(m.Err.Error())
+}
@@ -0,0 +1,58 @@
+package timer
+
+import "time"
+
+// advance 根据滴答发生的时间 at 重新计算剩余时间，并返回此前错过的滴答数量。
+//
+// 设置了 Deadline 时直接根据它计算。否则剩余时间等于起点时的剩余时间减去
+// 自起点以来在单调时钟上经过的时间，而不是每次滴答扣减一个 Interval，
+// 因此程序被挂起或事件循环繁忙时计时器不会变慢，误差也不会累积。
+// 启动、恢复或 Timeout 被直接修改后，以上一次滴答的时间重新设置起点。
+func (m *Model) advance(at time.Time) int {
+	if at.IsZero() {
+		// 没有时间的滴答，例如手动构造的 TickMsg。
+		m.Timeout -= m.Interval
+		m.started = time.Time{}
+		return 0
+	}
+
+	skipped := 0
+	if !m.last.IsZero() && m.Interval > 0 {
+		skipped = max(0, int(at.Sub(m.last)/m.Interval)-1)
+	}
+
+	if !m.Deadline.IsZero() {
+		m.Timeout = max(0, m.Deadline.Sub(at))
+	} else {
+		if m.started.IsZero() || m.Timeout != m.computed {
+			from := m.last
+			if from.IsZero() {
+				// 启动后的第一次滴答，距离启动经过了一个间隔。
+				from = at.Add(-m.Interval)
+			}
+			m.started, m.budget = from, m.Timeout
+		}
+		m.Timeout = max(0, m.budget-at.Sub(m.started))
+	}
+	m.computed, m.last = m.Timeout, at
+	return skipped
+}
+
+// setRunning 启动或停止计时器。停止时把自上一次滴答以来经过的时间计入剩余时间，
+// 启动时以当前时间作为新的起点，这样停止期间的时间不会被计入。
+func (m *Model) setRunning(running bool) {
+	if running == m.running && !m.started.IsZero() {
+		return
+	}
+	now := time.Now()
+	if m.running && !running && !m.started.IsZero() && m.Timeout == m.computed && m.Deadline.IsZero() {
+		m.Timeout = max(0, m.budget-max(0, now.Sub(m.started)))
+	}
+	m.running = running
+	m.started = time.Time{}
+	m.last = time.Time{}
+	if running {
+		m.started, m.budget, m.last = now, m.Timeout, now
+	}
+	m.computed = m.Timeout
+}
@@ -0,0 +1,37 @@
+package timer
+
+import (
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// WarningMsg 在剩余时间首次不超过 Model.Warning 时发送一次。
+type WarningMsg struct {
+	ID        int
+	Remaining time.Duration // 发送时的剩余时间
+}
+
+// NewWithDeadline 创建一个在给定时刻到期、默认 1 秒间隔的新计时器。
+func NewWithDeadline(deadline time.Time) Model {
+	m := New(max(0, time.Until(deadline)))
+	m.Deadline = deadline
+	return m
+}
+
+// Warned 返回是否已发送 WarningMsg。
+func (m Model) Warned() bool {
+	return m.warned
+}
+
+// warning 在剩余时间首次不超过 Warning 时返回发送 WarningMsg 的命令。
+func (m *Model) warning() tea.Cmd {
+	if m.Warning <= 0 || m.warned || m.Timedout() || m.Timeout > m.Warning {
+		return nil
+	}
+	m.warned = true
+	msg := WarningMsg{ID: m.id, Remaining: m.Timeout}
+	return func() tea.Msg {
+		return msg
+	}
+}
@@ -0,0 +1,61 @@
+package timer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Format 是剩余时间的内置显示格式，参见 Model.Format。
+type Format int
+
+const (
+	// FormatDefault 使用 time.Duration 的 String 方法，例如 "3m20s"。
+	FormatDefault Format = iota
+
+	// FormatMinutes 显示为 "mm:ss"，例如 "03:20"。超过一小时时，分钟数可以超过 59。
+	FormatMinutes
+
+	// FormatHours 显示为 "hh:mm:ss"，例如 "00:03:20"。
+	FormatHours
+
+	// FormatChinese 显示为中文，省略为 0 的部分，例如 "3分钟20秒"、"1小时5秒"。
+	FormatChinese
+)
+
+// FormatFunc 将剩余时间格式化为显示的文本，参见 Model.FormatFunc。
+type FormatFunc func(remaining time.Duration) string
+
+// format 按照格式显示剩余时间 d。除 FormatDefault 外，不足一秒的部分向上取整，
+// 因此倒计时只在超时时显示 0。
+func (f Format) format(d time.Duration) string {
+	if f == FormatDefault {
+		return d.String()
+	}
+
+	secs := int64(max(0, (d+time.Second-1)/time.Second))
+	h, m, s := secs/3600, secs/60%60, secs%60 //nolint:mnd
+	switch f {
+	case FormatMinutes:
+		return fmt.Sprintf("%02d:%02d", secs/60, s) //nolint:mnd
+	case FormatHours:
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	case FormatChinese:
+		if secs == 0 {
+			return "0秒"
+		}
+		var b strings.Builder
+		if h > 0 {
+			fmt.Fprintf(&b, "%d小时", h)
+		}
+		if m > 0 {
+			fmt.Fprintf(&b, "%d分钟", m)
+		}
+		if s > 0 {
+			fmt.Fprintf(&b, "%d秒", s)
+		}
+		return b.String()
+	default:
+		return d.String()
+	}
+}
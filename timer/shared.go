@@ -0,0 +1,63 @@
+package timer
+
+import (
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// SharedTickMsg 是共享滴答源（参见 SharedTick）发送的消息，不属于任何一个计时器。
+// 将它传给所有使用共享滴答的计时器，它们根据消息中的时间计算剩余时间。
+type SharedTickMsg struct {
+	Time time.Time // 滴答发生的时间
+}
+
+// SharedTick 返回在 interval 之后发送一个 SharedTickMsg 的命令。应用程序在收到
+// SharedTickMsg 时再次返回它，使多个计时器共用一个滴答源，而不是每个计时器
+// 各自调度滴答。例如显示每个任务剩余时间的任务列表：
+//
+//	case timer.SharedTickMsg:
+//		for i := range m.timers {
+//			m.timers[i], cmd = m.timers[i].Update(msg)
+//			cmds = append(cmds, cmd)
+//		}
+//		cmds = append(cmds, timer.SharedTick(time.Second))
+func SharedTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return SharedTickMsg{Time: t}
+	})
+}
+
+// NewShared 创建一个使用共享滴答源的计时器，从创建时开始计时。它不调度自己的
+// TickMsg，而是在收到 SharedTickMsg 时根据时间戳重新计算剩余时间，因此显示的精度
+// 取决于共享滴答的间隔。启动、停止、暂停和恢复的用法不变，超时时仍然发送 TimeoutMsg，
+// 剩余时间不超过 Warning 时仍然发送 WarningMsg。
+func NewShared(timeout time.Duration) Model {
+	m := New(timeout)
+	m.shared = true
+	m.running = false
+	m.setRunning(true)
+	return m
+}
+
+// Shared 返回计时器是否使用共享滴答源，参见 NewShared。
+func (m Model) Shared() bool {
+	return m.shared
+}
+
+// updateShared 在收到 SharedTickMsg 时重新计算剩余时间。
+func (m Model) updateShared(msg SharedTickMsg) (Model, tea.Cmd) {
+	if !m.shared || !m.Running() {
+		return m, nil
+	}
+	m.advance(msg.Time)
+	return m, tea.Batch(m.warning(), m.timedout())
+}
+
+// schedule 返回调度下一次滴答的命令。使用共享滴答源时返回 nil。
+func (m Model) schedule() tea.Cmd {
+	if m.shared {
+		return nil
+	}
+	return m.tick()
+}
@@ -44,6 +44,13 @@ type StartStopMsg struct {
 	running bool
 }
 
+// PausedMsg 在计时器被暂停或恢复时发送。父模型可以监听它来更新界面。
+// 与 StartStopMsg 一样，ID 为 0 的消息作用于所有计时器。
+type PausedMsg struct {
+	ID     int
+	Paused bool // 为 true 表示暂停，为 false 表示恢复
+}
+
 // TickMsg 是每次计时器滴答时发送的消息。
 type TickMsg struct {
 	// ID 是发送消息的计时器的标识符。这使得在多个计时器运行时，
@@ -57,7 +64,12 @@ type TickMsg struct {
 	// 你也可以选择监听 TimeoutMsg。
 	Timeout bool
 
+	// Skipped 是本次滴答之前错过的滴答数量，例如程序被挂起或事件循环繁忙时。
+	// 计时器不会补发错过的滴答，而是发送一个合并的滴答，剩余时间已包含错过的时间。
+	Skipped int
+
 	tag int
+	at  time.Time // 滴答发生的时间
 }
 
 // TimeoutMsg 是计时器超时时发送一次的消息。
@@ -75,9 +87,33 @@ type Model struct {
 	// Interval 每次滴答前的等待时间。默认为 1 秒。
 	Interval time.Duration
 
+	// Deadline 是计时器到期的时刻。如果设置了 Deadline，每次滴答时
+	// 都根据它和当前时间重新计算 Timeout，暂停不会推迟到期时刻。
+	// 参见 NewWithDeadline。
+	Deadline time.Time
+
+	// Warning 是发送 WarningMsg 的剩余时间阈值，例如 10 秒。
+	// 剩余时间首次不超过该阈值时发送一次。如果为 0，则不发送。
+	Warning time.Duration
+
+	// Format 是 View 显示剩余时间的格式。默认为 FormatDefault。
+	Format Format
+
+	// FormatFunc 如果设置，View 使用它而不是 Format 显示剩余时间，
+	// 例如用于本地化或自定义样式。
+	FormatFunc FormatFunc
+
 	id      int
 	tag     int
 	running bool
+	warned  bool // 是否已发送 WarningMsg
+	shared  bool // 是否使用共享滴答源，参见 NewShared
+
+	// 剩余时间根据单调时钟上的起点计算，参见 advance。
+	started  time.Time     // 起点
+	budget   time.Duration // 起点时的剩余时间
+	computed time.Duration // 上一次计算出的剩余时间，用于发现对 Timeout 的修改
+	last     time.Time     // 上一次滴答的时间
 }
 
 // NewWithInterval 创建一个具有指定超时和滴答间隔的新计时器。
@@ -115,7 +151,7 @@ func (m Model) Timedout() bool {
 
 // Init 启动计时器。
 func (m Model) Init() tea.Cmd {
-	return m.tick()
+	return m.schedule()
 }
 
 // Update 处理计时器滴答。
@@ -125,10 +161,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if msg.ID != 0 && msg.ID != m.id {
 			return m, nil
 		}
-		m.running = msg.running
-		return m, m.tick()
+		m.setRunning(msg.running)
+		return m, m.schedule()
 	case TickMsg:
-		if !m.Running() || (msg.ID != 0 && msg.ID != m.id) {
+		if !m.Running() || m.shared || (msg.ID != 0 && msg.ID != m.id) {
 			break
 		}
 
@@ -138,16 +174,33 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 		}
 
-		m.Timeout -= m.Interval
-		return m, tea.Batch(m.tick(), m.timedout())
+		skipped := m.advance(msg.at)
+		m.tag++
+		return m, tea.Batch(m.tickSkipped(skipped), m.warning(), m.timedout())
+	case PausedMsg:
+		if msg.ID != 0 && msg.ID != m.id {
+			return m, nil
+		}
+		m.setRunning(!msg.Paused)
+		// 增加标签以拒绝暂停前发出的滴答，这样恢复后不会重复计时。
+		m.tag++
+		if msg.Paused {
+			return m, nil
+		}
+		return m, m.schedule()
+	case SharedTickMsg:
+		return m.updateShared(msg)
 	}
 
 	return m, nil
 }
 
-// View 计时器组件的视图。
+// View 计时器组件的视图。它按照 FormatFunc 或 Format 显示剩余时间。
 func (m Model) View() string {
-	return m.Timeout.String()
+	if m.FormatFunc != nil {
+		return m.FormatFunc(m.Timeout)
+	}
+	return m.Format.format(m.Timeout)
 }
 
 // Start 恢复计时器。如果计时器已超时，则无效。
@@ -160,6 +213,17 @@ func (m *Model) Stop() tea.Cmd {
 	return m.startStop(false)
 }
 
+// Pause 暂停计时器并保留剩余时间，同时发送 PausedMsg。
+// 与 Stop 不同，暂停期间发出的滴答会被拒绝，恢复后不会重复计时。
+func (m Model) Pause() tea.Cmd {
+	return m.paused(true)
+}
+
+// Resume 恢复已暂停的计时器，同时发送 PausedMsg。如果计时器已超时，则无效。
+func (m Model) Resume() tea.Cmd {
+	return m.paused(false)
+}
+
 // Toggle 如果计时器正在运行则停止，如果已停止则启动。
 func (m *Model) Toggle() tea.Cmd {
 	return m.startStop(!m.Running())
@@ -167,8 +231,13 @@ func (m *Model) Toggle() tea.Cmd {
 
 // tick 生成滴答消息的命令
 func (m Model) tick() tea.Cmd {
-	return tea.Tick(m.Interval, func(_ time.Time) tea.Msg {
-		return TickMsg{ID: m.id, tag: m.tag, Timeout: m.Timedout()}
+	return m.tickSkipped(0)
+}
+
+// tickSkipped 生成滴答消息的命令，消息中记录之前错过的滴答数量
+func (m Model) tickSkipped(skipped int) tea.Cmd {
+	return tea.Tick(m.Interval, func(t time.Time) tea.Msg {
+		return TickMsg{ID: m.id, tag: m.tag, Timeout: m.Timedout(), Skipped: skipped, at: t}
 	})
 }
 
@@ -182,6 +251,13 @@ func (m Model) timedout() tea.Cmd {
 	}
 }
 
+// paused 生成暂停/恢复消息的命令
+func (m Model) paused(v bool) tea.Cmd {
+	return func() tea.Msg {
+		return PausedMsg{ID: m.id, Paused: v}
+	}
+}
+
 // startStop 生成启动/停止消息的命令
 func (m Model) startStop(v bool) tea.Cmd {
 	return func() tea.Msg {
@@ -0,0 +1,308 @@
+package timer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+const interval = 10 * time.Millisecond
+
+// collect 执行命令并返回计时器发送的消息，展开批量命令。
+func collect(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, collect(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+// tickAt 返回计时器在 at 时刻期望收到的滴答。
+func tickAt(m Model, at time.Time) TickMsg {
+	return TickMsg{ID: m.id, tag: m.tag, at: at}
+}
+
+// approx 返回 got 与 want 的差是否不超过 tolerance。
+func approx(got, want, tolerance time.Duration) bool {
+	return got >= want-tolerance && got <= want+tolerance
+}
+
+// TestTick 测试剩余时间根据滴答的时间戳计算
+func TestTick(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		ticks       []time.Duration // 滴答相对于第一次滴答的时间
+		remaining   time.Duration
+		skipped     int  // 下一次滴答的 Skipped
+		wantTimeout bool // 是否发送 TimeoutMsg
+	}{
+		{"steady", time.Second, []time.Duration{0, 10 * time.Millisecond, 20 * time.Millisecond}, 970 * time.Millisecond, 0, false},
+		{"late tick", time.Second, []time.Duration{0, 35 * time.Millisecond}, 955 * time.Millisecond, 2, false},
+		{"long suspension", time.Second, []time.Duration{0, 10 * time.Millisecond, 610 * time.Millisecond}, 380 * time.Millisecond, 59, false},
+		{"timeout", 30 * time.Millisecond, []time.Duration{0, 10 * time.Millisecond, 20 * time.Millisecond}, 0, 0, true},
+		{"suspended past timeout", 30 * time.Millisecond, []time.Duration{0, time.Second}, 0, 99, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewWithInterval(tt.timeout, interval)
+			t0 := time.Now()
+
+			var msgs []tea.Msg
+			for _, d := range tt.ticks {
+				var cmd tea.Cmd
+				m, cmd = m.Update(tickAt(m, t0.Add(d)))
+				msgs = collect(cmd)
+			}
+
+			if m.Timeout != tt.remaining {
+				t.Errorf("remaining = %v, expected %v", m.Timeout, tt.remaining)
+			}
+
+			// 错过的滴答合并为一次滴答，而不是逐个补发。
+			var ticks []TickMsg
+			timedOut := false
+			for _, msg := range msgs {
+				switch msg := msg.(type) {
+				case TickMsg:
+					ticks = append(ticks, msg)
+				case TimeoutMsg:
+					timedOut = true
+				}
+			}
+			if len(ticks) != 1 {
+				t.Fatalf("expected a single next tick, got %d", len(ticks))
+			}
+			if ticks[0].Skipped != tt.skipped {
+				t.Errorf("skipped = %d, expected %d", ticks[0].Skipped, tt.skipped)
+			}
+			if timedOut != tt.wantTimeout || ticks[0].Timeout != tt.wantTimeout {
+				t.Errorf("timed out = %v, expected %v", timedOut, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+// TestRejectTick 测试计时器拒绝过期的或属于其他计时器的滴答
+func TestRejectTick(t *testing.T) {
+	m := NewWithInterval(time.Second, interval)
+	t0 := time.Now()
+	m, _ = m.Update(tickAt(m, t0))
+	// 标签为 0 的滴答（例如 Init 发出的第一个滴答）总是被接受，因此用之后的滴答作为过期的滴答。
+	stale := tickAt(m, t0.Add(interval))
+	m, _ = m.Update(stale)
+
+	tests := []struct {
+		name string
+		msg  TickMsg
+	}{
+		{"stale tag", TickMsg{ID: m.id, tag: stale.tag, at: t0.Add(2 * interval)}},
+		{"other timer", TickMsg{ID: m.id + 1, tag: m.tag, at: t0.Add(2 * interval)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cmd := m.Update(tt.msg)
+			if cmd != nil {
+				t.Error("expected no command")
+			}
+			if got.Timeout != m.Timeout {
+				t.Errorf("remaining = %v, expected %v", got.Timeout, m.Timeout)
+			}
+		})
+	}
+}
+
+// TestPauseResume 测试暂停保留剩余时间，暂停期间的时间不计入，暂停前的滴答被拒绝
+func TestPauseResume(t *testing.T) {
+	m := NewWithInterval(time.Second, interval)
+	m, _ = m.Update(tickAt(m, time.Now()))
+	stale := tickAt(m, time.Now())
+
+	m, cmd := m.Update(PausedMsg{ID: m.id, Paused: true})
+	if cmd != nil || m.Running() {
+		t.Fatal("expected the timer to pause without scheduling a tick")
+	}
+	remaining := m.Timeout
+	if !approx(remaining, 990*time.Millisecond, 25*time.Millisecond) {
+		t.Fatalf("expected about 990ms remaining when paused, got %v", remaining)
+	}
+
+	// 暂停期间的滴答被拒绝。
+	if m, _ = m.Update(stale); m.Timeout != remaining {
+		t.Fatalf("expected ticks to be ignored while paused, got %v", m.Timeout)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	m, cmd = m.Update(PausedMsg{ID: m.id, Paused: false})
+	if cmd == nil || !m.Running() {
+		t.Fatal("expected the timer to resume and schedule a tick")
+	}
+
+	// 暂停前发出的滴答在恢复后仍然被拒绝。
+	if _, cmd := m.Update(stale); cmd != nil {
+		t.Fatal("expected a tick from before the pause to be rejected")
+	}
+
+	// 恢复后从暂停时的剩余时间继续计时。
+	m, _ = m.Update(tickAt(m, m.started.Add(interval)))
+	if m.Timeout != remaining-interval {
+		t.Fatalf("remaining = %v, expected %v", m.Timeout, remaining-interval)
+	}
+
+	// 属于其他计时器的消息被忽略，ID 为 0 的消息作用于所有计时器。
+	if m, _ = m.Update(PausedMsg{ID: m.id + 1, Paused: true}); !m.Running() {
+		t.Fatal("expected a PausedMsg for another timer to be ignored")
+	}
+	if m, _ = m.Update(PausedMsg{Paused: true}); m.Running() {
+		t.Fatal("expected a PausedMsg with ID 0 to pause every timer")
+	}
+}
+
+// TestDeadlineWarning 测试设置 Deadline 时 WarningMsg 只发送一次
+func TestDeadlineWarning(t *testing.T) {
+	t0 := time.Now()
+	m := NewWithInterval(time.Second, interval)
+	m.Deadline = t0.Add(time.Second)
+	m.Warning = 500 * time.Millisecond
+
+	tests := []struct {
+		at        time.Duration
+		remaining time.Duration
+		warn      bool
+	}{
+		{400 * time.Millisecond, 600 * time.Millisecond, false},
+		{600 * time.Millisecond, 400 * time.Millisecond, true},
+		{700 * time.Millisecond, 300 * time.Millisecond, false},
+		{1200 * time.Millisecond, 0, false},
+	}
+
+	for _, tt := range tests {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tickAt(m, t0.Add(tt.at)))
+		if m.Timeout != tt.remaining {
+			t.Fatalf("at %v: remaining = %v, expected %v", tt.at, m.Timeout, tt.remaining)
+		}
+
+		warned := 0
+		for _, msg := range collect(cmd) {
+			if msg, ok := msg.(WarningMsg); ok {
+				warned++
+				if msg.ID != m.id || msg.Remaining != tt.remaining {
+					t.Fatalf("at %v: unexpected %+v", tt.at, msg)
+				}
+			}
+		}
+		if (warned == 1) != tt.warn || warned > 1 {
+			t.Fatalf("at %v: got %d warnings, expected warning %v", tt.at, warned, tt.warn)
+		}
+	}
+	if !m.Warned() || !m.Timedout() {
+		t.Fatal("expected the timer to have warned and timed out")
+	}
+}
+
+// TestDeadline 测试剩余时间根据 Deadline 和滴答的时间计算，暂停不会推迟到期时刻
+func TestDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Second)
+	m := NewWithDeadline(deadline)
+	if !approx(m.Timeout, time.Second, 25*time.Millisecond) {
+		t.Fatalf("expected about 1s remaining, got %v", m.Timeout)
+	}
+
+	// 滴答晚到时，剩余时间仍然与到期时刻一致，不会因为滴答的次数而偏移。
+	m, _ = m.Update(tickAt(m, deadline.Add(-700*time.Millisecond)))
+	if m.Timeout != 700*time.Millisecond {
+		t.Fatalf("remaining = %v, expected %v", m.Timeout, 700*time.Millisecond)
+	}
+
+	m, _ = m.Update(PausedMsg{ID: m.id, Paused: true})
+	m, _ = m.Update(PausedMsg{ID: m.id, Paused: false})
+	m, _ = m.Update(tickAt(m, deadline.Add(-200*time.Millisecond)))
+	if m.Timeout != 200*time.Millisecond {
+		t.Fatalf("expected the pause not to postpone the deadline, got %v remaining", m.Timeout)
+	}
+}
+
+// TestFormat 测试内置的显示格式和 FormatFunc
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		format    Format
+		remaining time.Duration
+		want      string
+	}{
+		{FormatDefault, 200*time.Second + 500*time.Millisecond, "3m20.5s"},
+		{FormatMinutes, 200 * time.Second, "03:20"},
+		{FormatMinutes, 2 * time.Hour, "120:00"},
+		{FormatHours, 200 * time.Second, "00:03:20"},
+		{FormatHours, 100 * time.Millisecond, "00:00:01"},
+		{FormatChinese, 200 * time.Second, "3分钟20秒"},
+		{FormatChinese, time.Hour + 5*time.Second, "1小时5秒"},
+		{FormatChinese, 0, "0秒"},
+	}
+
+	for _, tt := range tests {
+		m := New(tt.remaining)
+		m.Format = tt.format
+		if got := m.View(); got != tt.want {
+			t.Errorf("format %d of %v: expected %q, got %q", tt.format, tt.remaining, tt.want, got)
+		}
+	}
+
+	// FormatFunc 优先于 Format。
+	m := New(90 * time.Second)
+	m.Format = FormatMinutes
+	m.FormatFunc = func(d time.Duration) string { return fmt.Sprintf("%.0f seconds left", d.Seconds()) }
+	if got := m.View(); got != "90 seconds left" {
+		t.Fatalf("expected FormatFunc to be used, got %q", got)
+	}
+}
+
+// TestShared 测试共享模式下根据 SharedTickMsg 的时间戳计算剩余时间
+func TestShared(t *testing.T) {
+	m := NewShared(time.Second)
+	if !m.Shared() || !m.Running() {
+		t.Fatal("expected a running shared timer")
+	}
+	if m.Init() != nil {
+		t.Fatal("expected a shared timer not to schedule its own ticks")
+	}
+	start := m.started
+
+	tests := []struct {
+		name      string
+		msg       tea.Msg
+		remaining time.Duration
+	}{
+		{"shared tick", SharedTickMsg{Time: start.Add(300 * time.Millisecond)}, 700 * time.Millisecond},
+		{"own tick ignored", TickMsg{ID: m.id, at: start.Add(400 * time.Millisecond)}, 700 * time.Millisecond},
+		{"long suspension", SharedTickMsg{Time: start.Add(900 * time.Millisecond)}, 100 * time.Millisecond},
+		{"timeout", SharedTickMsg{Time: start.Add(2 * time.Second)}, 0},
+	}
+
+	for _, tt := range tests {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tt.msg)
+		if m.Timeout != tt.remaining {
+			t.Fatalf("%s: remaining = %v, expected %v", tt.name, m.Timeout, tt.remaining)
+		}
+		for _, msg := range collect(cmd) {
+			if _, ok := msg.(TickMsg); ok {
+				t.Fatalf("%s: expected no TickMsg from a shared timer", tt.name)
+			}
+		}
+	}
+	if !m.Timedout() {
+		t.Fatal("expected the shared timer to time out")
+	}
+}
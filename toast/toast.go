@@ -0,0 +1,487 @@
+// Package toast 提供一个弹出通知（toast）组件。它管理一组短暂显示的状态消息：
+// 每条消息有自己的显示时间和级别（信息、成功、警告、错误），以进入和退出动画
+// 显示和消失，同时显示的数量有上限，并堆叠在窗口的某个角落。
+//
+// 与 list 的单条状态消息不同，它适用于应用程序级别的通知；需要保留历史记录时，
+// 请使用 notification 包。
+package toast
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// 默认配置。
+const (
+	defaultLifetime   = 4 * time.Second
+	defaultMaxVisible = 3
+	defaultWidth      = 36
+	defaultFrames     = 6
+	defaultFPS        = time.Second / 30 //nolint:mnd
+)
+
+var lastID int64
+
+// nextID 生成下一个唯一的 ID。
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
+}
+
+// Level 是弹出通知的级别。
+type Level int
+
+// 可用的级别。
+const (
+	Info Level = iota
+	Success
+	Warning
+	Error
+)
+
+// String 返回级别的名称。
+func (l Level) String() string {
+	switch l {
+	case Success:
+		return "success"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Corner 是弹出通知堆叠的角落。
+type Corner int
+
+// 可用的角落。
+const (
+	BottomRight Corner = iota
+	BottomLeft
+	TopRight
+	TopLeft
+)
+
+// right 返回角落是否在右侧。
+func (c Corner) right() bool {
+	return c == BottomRight || c == TopRight
+}
+
+// bottom 返回角落是否在底部。
+func (c Corner) bottom() bool {
+	return c == BottomRight || c == BottomLeft
+}
+
+// Toast 是一条弹出通知。
+type Toast struct {
+	ID      int
+	Level   Level
+	Message string
+
+	// Lifetime 是通知显示的时间，从它出现时开始计算。
+	// 如果为 0 或更小，则使用 Model.Lifetime。
+	Lifetime time.Duration
+
+	// Sticky 使通知一直显示，直到调用 Dismiss。
+	Sticky bool
+}
+
+// Styles 包含弹出通知的样式。
+type Styles struct {
+	// Toast 是每条通知的外框。边框颜色取自级别样式的前景色。
+	Toast lipgloss.Style
+
+	Info    lipgloss.Style // Info 级别标签的样式
+	Success lipgloss.Style // Success 级别标签的样式
+	Warning lipgloss.Style // Warning 级别标签的样式
+	Error   lipgloss.Style // Error 级别标签的样式
+}
+
+// DefaultStyles 返回一组默认样式。
+func DefaultStyles() Styles {
+	return Styles{
+		Toast:   lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1),
+		Info:    lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true),
+		Success: lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true),
+		Warning: lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true),
+		Error:   lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true),
+	}
+}
+
+// level 返回给定级别标签的样式。
+func (s Styles) level(l Level) lipgloss.Style {
+	switch l {
+	case Success:
+		return s.Success
+	case Warning:
+		return s.Warning
+	case Error:
+		return s.Error
+	default:
+		return s.Info
+	}
+}
+
+// phase 是通知的动画阶段。
+type phase int
+
+const (
+	queued   phase = iota // 等待显示
+	entering              // 正在进入
+	shown                 // 完全显示
+	exiting               // 正在退出
+)
+
+// entry 是一条通知及其动画状态。
+type entry struct {
+	Toast
+	phase phase
+	frame int // 进入和退出动画的当前帧，0 为完全隐藏，Frames 为完全显示
+}
+
+// frameMsg 推动动画前进一帧。
+type frameMsg struct {
+	id, tag int
+}
+
+// expireMsg 在通知的显示时间结束时发送。
+type expireMsg struct {
+	id, toast int
+}
+
+// Model 是弹出通知堆叠的 Bubble Tea 模型。
+type Model struct {
+	Styles Styles
+
+	// Lifetime 是通知默认的显示时间。
+	Lifetime time.Duration
+
+	// MaxVisible 是同时显示的通知数量上限。超出的通知排队等待，
+	// 在有通知消失后显示。如果为 0 或更小，则不限制。
+	MaxVisible int
+
+	// Corner 是通知堆叠的角落。最新的通知最靠近窗口边缘。
+	Corner Corner
+
+	// Width 是每条通知的宽度（包括边框）。
+	Width int
+
+	// Frames 是进入和退出动画的帧数。通知从所在角落的一侧滑入和滑出。
+	// 如果为 0 或更小，则禁用动画。
+	Frames int
+
+	// FPS 是动画每一帧的时间间隔。
+	FPS time.Duration
+
+	id        int
+	tag       int
+	animating bool
+	toasts    []entry // 显示中和排队中的通知，最旧的在前
+	width     int
+	height    int
+}
+
+// New 返回一个带有默认配置的弹出通知堆叠。
+func New() Model {
+	return Model{
+		Styles:     DefaultStyles(),
+		Lifetime:   defaultLifetime,
+		MaxVisible: defaultMaxVisible,
+		Width:      defaultWidth,
+		Frames:     defaultFrames,
+		FPS:        defaultFPS,
+		id:         nextID(),
+	}
+}
+
+// SetSize 设置通知堆叠所在区域（通常是整个窗口）的大小。
+// Update 也会根据 tea.WindowSizeMsg 自动设置它。
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Push 添加一条给定级别的通知，返回它的 ID 和显示它的命令。
+func (m *Model) Push(level Level, message string) (int, tea.Cmd) {
+	return m.PushToast(Toast{Level: level, Message: message})
+}
+
+// PushToast 添加一条通知，返回它的 ID 和显示它的命令。通知的 ID 会被重新分配。
+func (m *Model) PushToast(t Toast) (int, tea.Cmd) {
+	t.ID = nextID()
+	if t.Lifetime <= 0 {
+		t.Lifetime = m.Lifetime
+	}
+	m.toasts = append(m.toasts, entry{Toast: t})
+	return t.ID, m.promote()
+}
+
+// Dismiss 使给定 ID 的通知退出。排队中的通知直接移除。
+func (m *Model) Dismiss(id int) tea.Cmd {
+	for i, e := range m.toasts {
+		if e.ID != id {
+			continue
+		}
+		switch e.phase {
+		case queued:
+			m.toasts = append(m.toasts[:i:i], m.toasts[i+1:]...)
+			return nil
+		case exiting:
+			return nil
+		}
+		if m.Frames <= 0 {
+			m.toasts = append(m.toasts[:i:i], m.toasts[i+1:]...)
+			return m.promote()
+		}
+		m.toasts[i].phase = exiting
+		return m.animate()
+	}
+	return nil
+}
+
+// DismissAll 使所有通知退出。
+func (m *Model) DismissAll() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, e := range m.Toasts() {
+		cmds = append(cmds, m.Dismiss(e.ID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Toasts 返回所有显示中和排队中的通知，最旧的在前。
+func (m Model) Toasts() []Toast {
+	toasts := make([]Toast, len(m.toasts))
+	for i, e := range m.toasts {
+		toasts[i] = e.Toast
+	}
+	return toasts
+}
+
+// Len 返回显示中和排队中的通知数量。
+func (m Model) Len() int {
+	return len(m.toasts)
+}
+
+// Update 处理动画和通知过期消息，以及窗口大小变化。
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+
+	case expireMsg:
+		if msg.id == m.id {
+			return m, m.Dismiss(msg.toast)
+		}
+
+	case frameMsg:
+		if msg.id != m.id || msg.tag != m.tag {
+			return m, nil
+		}
+		m.animating = false
+		return m, m.step()
+	}
+	return m, nil
+}
+
+// step 将所有动画前进一帧，移除退出完成的通知并显示排队中的通知。
+func (m *Model) step() tea.Cmd {
+	toasts := m.toasts[:0:0]
+	for _, e := range m.toasts {
+		switch e.phase {
+		case entering:
+			e.frame++
+			if e.frame >= m.Frames {
+				e.phase = shown
+			}
+		case exiting:
+			e.frame--
+			if e.frame <= 0 {
+				continue
+			}
+		}
+		toasts = append(toasts, e)
+	}
+	m.toasts = toasts
+	return m.promote()
+}
+
+// promote 显示排队中的通知，直到达到 MaxVisible，并返回它们的过期命令和动画命令。
+func (m *Model) promote() tea.Cmd {
+	visible := 0
+	for _, e := range m.toasts {
+		if e.phase != queued && e.phase != exiting {
+			visible++
+		}
+	}
+
+	var cmds []tea.Cmd
+	for i := range m.toasts {
+		if m.MaxVisible > 0 && visible >= m.MaxVisible {
+			break
+		}
+		e := &m.toasts[i]
+		if e.phase != queued {
+			continue
+		}
+		e.phase = entering
+		if m.Frames <= 0 {
+			e.phase, e.frame = shown, 0
+		}
+		visible++
+		if !e.Sticky {
+			id, toast := m.id, e.ID
+			cmds = append(cmds, tea.Tick(e.Lifetime, func(time.Time) tea.Msg {
+				return expireMsg{id: id, toast: toast}
+			}))
+		}
+	}
+	return tea.Batch(append(cmds, m.animate())...)
+}
+
+// animate 如果有正在进行的动画并且还没有等待中的帧，则返回下一帧的命令。
+func (m *Model) animate() tea.Cmd {
+	if m.animating || m.Frames <= 0 {
+		return nil
+	}
+	for _, e := range m.toasts {
+		if e.phase == entering || e.phase == exiting {
+			m.animating = true
+			m.tag++
+			id, tag := m.id, m.tag
+			return tea.Tick(m.FPS, func(time.Time) tea.Msg {
+				return frameMsg{id: id, tag: tag}
+			})
+		}
+	}
+	return nil
+}
+
+// View 渲染通知堆叠，将其放置在 SetSize 设置的区域的角落中。
+// 如果没有设置区域大小，则只渲染通知堆叠本身。
+func (m Model) View() string {
+	lines := m.stackLines()
+	if len(lines) == 0 {
+		return ""
+	}
+
+	w, h := m.width, m.height
+	if w <= 0 {
+		for _, l := range lines {
+			w = max(w, ansi.StringWidth(l))
+		}
+	}
+	h = max(h, len(lines))
+
+	area := make([]string, h)
+	top := 0
+	if m.Corner.bottom() {
+		top = h - len(lines)
+	}
+	for i, l := range lines {
+		x := m.lineX(l, w)
+		area[top+i] = strings.Repeat(" ", x) + l + strings.Repeat(" ", max(0, w-x-ansi.StringWidth(l)))
+	}
+	for i := range area {
+		if area[i] == "" {
+			area[i] = strings.Repeat(" ", w)
+		}
+	}
+	return strings.Join(area, "\n")
+}
+
+// Overlay 将通知堆叠绘制在 background（例如应用程序的整个视图）之上，
+// 放置在 background 的角落中。通知没有覆盖的部分保持不变。
+func (m Model) Overlay(background string) string {
+	lines := m.stackLines()
+	if len(lines) == 0 {
+		return background
+	}
+
+	bg := strings.Split(background, "\n")
+	for len(bg) < len(lines) {
+		bg = append(bg, "")
+	}
+	w := 0
+	for _, l := range bg {
+		w = max(w, ansi.StringWidth(l))
+	}
+
+	top := 0
+	if m.Corner.bottom() {
+		top = len(bg) - len(lines)
+	}
+	for i, l := range lines {
+		if l == "" {
+			continue
+		}
+		x := m.lineX(l, w)
+		row := bg[top+i]
+		left := ansi.Truncate(row, x, "")
+		left += strings.Repeat(" ", x-ansi.StringWidth(left))
+		bg[top+i] = left + l + ansi.TruncateLeft(row, x+ansi.StringWidth(l), "")
+	}
+	return strings.Join(bg, "\n")
+}
+
+// lineX 返回宽度为 w 的区域中通知堆叠的一行开始的列。
+func (m Model) lineX(line string, w int) int {
+	if !m.Corner.right() {
+		return 0
+	}
+	return max(0, w-ansi.StringWidth(line))
+}
+
+// stackLines 渲染显示中的通知，最新的通知最靠近所在角落的边缘。
+// 正在进入或退出的通知只渲染已经滑入的部分。
+func (m Model) stackLines() []string {
+	var blocks [][]string
+	for _, e := range m.toasts {
+		if e.phase == queued {
+			continue
+		}
+		blocks = append(blocks, m.toastLines(e))
+	}
+	if !m.Corner.bottom() {
+		// 顶部的角落中最新的通知在最上面。
+		for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+			blocks[i], blocks[j] = blocks[j], blocks[i]
+		}
+	}
+
+	var lines []string
+	for _, b := range blocks {
+		lines = append(lines, b...)
+	}
+	return lines
+}
+
+// toastLines 渲染一条通知。
+func (m Model) toastLines(e entry) []string {
+	label := m.Styles.level(e.Level)
+	box := m.Styles.Toast.
+		BorderForeground(label.GetForeground()).
+		Width(max(1, m.Width-m.Styles.Toast.GetHorizontalBorderSize()))
+	lines := strings.Split(box.Render(label.Render(e.Level.String())+" "+e.Message), "\n")
+
+	if e.phase == shown || m.Frames <= 0 {
+		return lines
+	}
+
+	// 从所在角落的一侧滑入：右侧的角落先显示左边的部分，反之亦然。
+	w := ansi.StringWidth(lines[0])
+	n := (w*e.frame + m.Frames - 1) / m.Frames
+	for i, l := range lines {
+		if m.Corner.right() {
+			lines[i] = ansi.Cut(l, 0, n)
+		} else {
+			lines[i] = ansi.Cut(l, w-n, w)
+		}
+	}
+	return lines
+}
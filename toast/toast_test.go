@@ -0,0 +1,147 @@
+package toast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// plain 返回不带样式的测试模型。
+func plain() Model {
+	m := New()
+	m.Styles = Styles{Toast: lipgloss.NewStyle().Border(lipgloss.NormalBorder())}
+	m.Width = 10
+	return m
+}
+
+// animateAll 推动动画直到所有通知都停止动画。
+func animateAll(t *testing.T, m Model) Model {
+	t.Helper()
+	for i := 0; m.animating; i++ {
+		if i > 100 {
+			t.Fatal("animation did not finish")
+		}
+		m, _ = m.Update(frameMsg{id: m.id, tag: m.tag})
+	}
+	return m
+}
+
+func TestPushAndExpire(t *testing.T) {
+	m := plain()
+	id, cmd := m.Push(Error, "boom")
+	if cmd == nil {
+		t.Fatal("expected Push to return a command")
+	}
+	if m.toasts[0].phase != entering {
+		t.Fatalf("expected toast to be entering, got %v", m.toasts[0].phase)
+	}
+
+	m = animateAll(t, m)
+	want := "┌────────┐\n│error   │\n│boom    │\n└────────┘"
+	if got := m.View(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	// 另一个模型的过期消息被忽略。
+	m, _ = m.Update(expireMsg{id: m.id + 1, toast: id})
+	if m.toasts[0].phase != shown {
+		t.Fatal("expected toast to ignore foreign expiry")
+	}
+
+	m, _ = m.Update(expireMsg{id: m.id, toast: id})
+	if m.toasts[0].phase != exiting {
+		t.Fatal("expected toast to start exiting")
+	}
+	m = animateAll(t, m)
+	if m.Len() != 0 || m.View() != "" {
+		t.Fatalf("expected toast to be removed, got %d", m.Len())
+	}
+}
+
+func TestAnimationFrames(t *testing.T) {
+	m := plain()
+	m.Frames = 2
+	m.Push(Info, "hi")
+
+	m, _ = m.Update(frameMsg{id: m.id, tag: m.tag})
+	if w := ansi.StringWidth(strings.Split(m.View(), "\n")[0]); w != 5 {
+		t.Fatalf("expected half of the toast to be visible, got width %d", w)
+	}
+	if got := strings.Split(m.View(), "\n")[0]; got != "┌────" {
+		t.Fatalf("expected the left part at the right corner, got %q", got)
+	}
+
+	m.Corner = TopLeft
+	if got := strings.Split(m.View(), "\n")[0]; got != "────┐" {
+		t.Fatalf("expected the right part at the left corner, got %q", got)
+	}
+
+	// 过时的帧被忽略。
+	m, _ = m.Update(frameMsg{id: m.id, tag: m.tag - 1})
+	if m.toasts[0].phase != entering {
+		t.Fatal("expected a stale frame to be ignored")
+	}
+}
+
+func TestMaxVisible(t *testing.T) {
+	m := plain()
+	m.Frames = 0
+	m.MaxVisible = 2
+	first, _ := m.Push(Info, "a")
+	m.Push(Warning, "b")
+	third, _ := m.Push(Error, "c")
+
+	if m.toasts[2].phase != queued {
+		t.Fatal("expected the third toast to be queued")
+	}
+	if got := strings.Count(m.View(), "┌"); got != 2 {
+		t.Fatalf("expected 2 visible toasts, got %d", got)
+	}
+
+	m.Dismiss(first)
+	if m.Len() != 2 || m.toasts[1].ID != third || m.toasts[1].phase != shown {
+		t.Fatalf("expected the queued toast to be shown, got %+v", m.toasts)
+	}
+}
+
+func TestCorners(t *testing.T) {
+	m := plain()
+	m.Frames = 0
+	m.Push(Info, "old")
+	m.Push(Info, "new")
+	m.SetSize(14, 10)
+
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("expected 10 lines, got %d", len(lines))
+	}
+	if got := lines[8]; got != "    │info new│" {
+		t.Fatalf("expected newest toast at the bottom right, got %q", got)
+	}
+
+	m.Corner = TopLeft
+	lines = strings.Split(m.View(), "\n")
+	if got := lines[1]; got != "│info new│    " {
+		t.Fatalf("expected newest toast at the top left, got %q", got)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	m := plain()
+	m.Frames = 0
+	m.Push(Info, "x")
+
+	bg := strings.Repeat(strings.Repeat(".", 12)+"\n", 5) + strings.Repeat(".", 12)
+	lines := strings.Split(m.Overlay(bg), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 lines, got %d", len(lines))
+	}
+	if lines[1] != "............" {
+		t.Fatalf("expected background to be untouched, got %q", lines[1])
+	}
+	if lines[4] != "..│info x  │" || lines[5] != "..└────────┘" {
+		t.Fatalf("expected toast at the bottom right, got %q", lines)
+	}
+}
@@ -0,0 +1,124 @@
+package viewport
+
+import (
+	"sort"
+
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+// anchor 是内容中一个命名的行。
+type anchor struct {
+	name string
+	line int
+}
+
+// SetAnchors 设置命名的锚点：锚点名称到内容中行号（从 0 开始）的映射，
+// 例如文档中的各个章节或差异中的各个区块。之后可以通过 GotoAnchor、
+// NextAnchor 和 PrevAnchor 在锚点之间跳转。传入 nil 清除所有锚点。
+func (m *Model) SetAnchors(anchors map[string]int) {
+	m.anchors = m.anchors[:0:0]
+	for name, line := range anchors {
+		m.anchors = append(m.anchors, anchor{name: name, line: max(0, line)})
+	}
+	sort.Slice(m.anchors, func(i, j int) bool {
+		if m.anchors[i].line == m.anchors[j].line {
+			return m.anchors[i].name < m.anchors[j].name
+		}
+		return m.anchors[i].line < m.anchors[j].line
+	})
+	if _, ok := m.anchorLine(m.currentAnchor); !ok {
+		m.currentAnchor = ""
+	}
+}
+
+// Anchors 返回锚点名称到行号的映射。
+func (m Model) Anchors() map[string]int {
+	anchors := make(map[string]int, len(m.anchors))
+	for _, a := range m.anchors {
+		anchors[a.name] = a.line
+	}
+	return anchors
+}
+
+// CurrentAnchor 返回最近一次跳转到的锚点的名称。如果没有，返回空字符串。
+func (m Model) CurrentAnchor() string {
+	return m.currentAnchor
+}
+
+// GotoAnchor 滚动视口，使给定锚点所在的行显示在顶部，并将跳转前的位置
+// 记录到跳转列表中。如果没有该锚点，返回 false。
+func (m *Model) GotoAnchor(name string) bool {
+	line, ok := m.anchorLine(name)
+	if !ok {
+		return false
+	}
+	m.currentAnchor = name
+	m.Jump(line)
+	return true
+}
+
+// NextAnchor 跳转到当前锚点（如果它可见）或视口顶部之后的下一个锚点。
+// 如果没有下一个锚点，返回 false。
+func (m *Model) NextAnchor() bool {
+	ref := m.YOffset - 1
+	if line, ok := m.visibleAnchorLine(); ok {
+		ref = line
+	}
+	for _, a := range m.anchors {
+		if a.line > ref {
+			return m.GotoAnchor(a.name)
+		}
+	}
+	return false
+}
+
+// PrevAnchor 跳转到当前锚点（如果它可见）或视口顶部之前的上一个锚点。
+// 如果没有上一个锚点，返回 false。
+func (m *Model) PrevAnchor() bool {
+	ref := m.YOffset
+	if line, ok := m.visibleAnchorLine(); ok {
+		ref = line
+	}
+	for i := len(m.anchors) - 1; i >= 0; i-- {
+		if m.anchors[i].line < ref {
+			return m.GotoAnchor(m.anchors[i].name)
+		}
+	}
+	return false
+}
+
+// anchorLine 返回给定锚点所在的行。
+func (m Model) anchorLine(name string) (int, bool) {
+	for _, a := range m.anchors {
+		if a.name == name {
+			return a.line, true
+		}
+	}
+	return 0, false
+}
+
+// visibleAnchorLine 返回当前锚点所在的行，如果它在视口中可见。
+// 跳转到内容末尾附近的锚点时，锚点不一定显示在顶部。
+func (m Model) visibleAnchorLine() (int, bool) {
+	line, ok := m.anchorLine(m.currentAnchor)
+	if !ok || line < m.YOffset || line >= m.YOffset+m.Height {
+		return 0, false
+	}
+	return line, true
+}
+
+// withAnchor 在设置了 HighlightAnchor 时，以 AnchorStyle 渲染从第 top 行开始的
+// 可见行中当前锚点所在的行。
+func (m Model) withAnchor(lines []string, top int) []string {
+	if !m.HighlightAnchor {
+		return lines
+	}
+	line, ok := m.anchorLine(m.currentAnchor)
+	if !ok || line < top || line >= top+len(lines) {
+		return lines
+	}
+	styled := make([]string, len(lines))
+	copy(styled, lines)
+	styled[line-top] = m.AnchorStyle.Inline(true).Render(ansi.Strip(lines[line-top]))
+	return styled
+}
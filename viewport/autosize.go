@@ -0,0 +1,64 @@
+package viewport
+
+import tea "github.com/purpose168/bubbletea-cn"
+
+// AutoSize 描述视口如何根据 tea.WindowSizeMsg 自动调整自身大小。
+// 参见 [Model.SetAutoSize]。
+type AutoSize struct {
+	// MarginX 和 MarginY 是从窗口宽度和高度中减去的列数和行数，
+	// 用于为视口周围的其他内容（例如标题和状态栏）留出空间。
+	MarginX int
+	MarginY int
+
+	// MaxWidth 和 MaxHeight 限制视口的最大尺寸。如果为 0 或更小，则不限制。
+	MaxWidth  int
+	MaxHeight int
+
+	// AspectRatio 是宽度与高度（以单元格计）之比的上限。如果为 0 或更小，则不限制。
+	// 当窗口过宽时，视口宽度会被缩小以满足该比例。
+	AspectRatio float64
+}
+
+// SetAutoSize 启用对 tea.WindowSizeMsg 的自动处理：收到消息时，视口按照给定的
+// 约束重新计算宽度和高度，并将滚动位置限制在有效范围内。
+// 这样简单的分页器就不需要仅为转发窗口大小事件而编写父模型。
+func (m *Model) SetAutoSize(s AutoSize) {
+	m.autoSize = s
+	m.autoSizeEnabled = true
+}
+
+// DisableAutoSize 禁用对 tea.WindowSizeMsg 的自动处理。
+func (m *Model) DisableAutoSize() {
+	m.autoSize = AutoSize{}
+	m.autoSizeEnabled = false
+}
+
+// AutoSizeEnabled 返回是否启用了对 tea.WindowSizeMsg 的自动处理。
+func (m Model) AutoSizeEnabled() bool {
+	return m.autoSizeEnabled
+}
+
+// handleWindowSize 根据窗口大小和自动调整约束调整视口大小。
+func (m *Model) handleWindowSize(msg tea.WindowSizeMsg) {
+	s := m.autoSize
+	w := max(0, msg.Width-s.MarginX)
+	h := max(0, msg.Height-s.MarginY)
+	if s.MaxWidth > 0 {
+		w = min(w, s.MaxWidth)
+	}
+	if s.MaxHeight > 0 {
+		h = min(h, s.MaxHeight)
+	}
+	if s.AspectRatio > 0 {
+		w = min(w, int(float64(h)*s.AspectRatio))
+	}
+
+	m.Width = w
+	m.Height = h
+
+	// 保持滚动位置在有效范围内。
+	if m.PastBottom() {
+		m.SetYOffset(m.maxYOffset())
+	}
+	m.SetXOffset(m.xOffset)
+}
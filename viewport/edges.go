@@ -0,0 +1,141 @@
+package viewport
+
+import (
+	"strings"
+	"time"
+
+	"github.com/purpose168/bubbles-cn/key"
+	tea "github.com/purpose168/bubbletea-cn"
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// edge 是用户试图越过的内容边缘。
+type edge int
+
+const (
+	edgeNone edge = iota
+	edgeTop
+	edgeBottom
+)
+
+// EdgeMsg 在 Update 中的滚动或加载使 AtTop 或 AtBottom 发生变化时发送，
+// 例如分页器可以用它显示“已到末尾”的提示或加载更多内容。
+type EdgeMsg struct {
+	ID       int  // 视口的 ID
+	AtTop    bool // 视口现在是否位于顶部
+	AtBottom bool // 视口现在是否位于底部
+}
+
+// indicatorMsg 在边缘指示器显示 IndicatorDuration 之后隐藏它。
+type indicatorMsg struct {
+	id, tag int
+}
+
+// ID 返回视口的唯一 ID。ID 在第一次调用 Update 时分配。
+func (m Model) ID() int {
+	return m.id
+}
+
+// edgeState 记录 Update 之前的位置和消息的滚动方向，用于检测边缘的变化和
+// 越过边缘的滚动。
+type edgeState struct {
+	yOffset         int
+	atTop, atBottom bool
+	up, down        bool
+}
+
+// edgeState 返回视口当前的位置，以及 msg 是否是向上或向下滚动的按键或鼠标滚轮事件。
+func (m Model) edgeState(msg tea.Msg) edgeState {
+	s := edgeState{yOffset: m.YOffset, atTop: m.AtTop(), atBottom: m.AtBottom()}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		s.up = key.Check(msg, m.KeyMap.PageUp, m.KeyMap.HalfPageUp, m.KeyMap.Up)
+		s.down = key.Check(msg, m.KeyMap.PageDown, m.KeyMap.HalfPageDown, m.KeyMap.Down)
+	case tea.MouseMsg:
+		if m.MouseWheelEnabled && msg.Action == tea.MouseActionPress && !msg.Shift {
+			s.up = msg.Button == tea.MouseButtonWheelUp
+			s.down = msg.Button == tea.MouseButtonWheelDown
+		}
+	}
+	return s
+}
+
+// handleEdges 在 Update 结束时调用。如果用户试图越过顶部或底部滚动，
+// 它显示相应的指示器；如果 AtTop 或 AtBottom 发生了变化，它返回发送 EdgeMsg 的命令。
+func (m *Model) handleEdges(before edgeState) tea.Cmd {
+	var cmds []tea.Cmd
+
+	switch {
+	case m.YOffset != before.yOffset:
+		m.edge = edgeNone
+	case before.up && before.atTop:
+		cmds = append(cmds, m.showIndicator(edgeTop))
+	case before.down && before.atBottom:
+		cmds = append(cmds, m.showIndicator(edgeBottom))
+	}
+
+	if atTop, atBottom := m.AtTop(), m.AtBottom(); atTop != before.atTop || atBottom != before.atBottom {
+		msg := EdgeMsg{ID: m.id, AtTop: atTop, AtBottom: atBottom}
+		cmds = append(cmds, func() tea.Msg { return msg })
+	}
+	return tea.Batch(cmds...)
+}
+
+// showIndicator 显示给定边缘的指示器。如果设置了 IndicatorDuration，
+// 返回在该时间之后隐藏指示器的命令。
+func (m *Model) showIndicator(e edge) tea.Cmd {
+	if m.indicator(e) == "" {
+		return nil
+	}
+	m.edge = e
+	m.edgeTag++
+	if m.IndicatorDuration <= 0 {
+		return nil
+	}
+	id, tag := m.id, m.edgeTag
+	return tea.Tick(m.IndicatorDuration, func(time.Time) tea.Msg {
+		return indicatorMsg{id: id, tag: tag}
+	})
+}
+
+// hideIndicator 处理 indicatorMsg。
+func (m *Model) hideIndicator(msg indicatorMsg) {
+	if msg.id == m.id && msg.tag == m.edgeTag {
+		m.edge = edgeNone
+	}
+}
+
+// indicator 返回给定边缘的指示器文本。
+func (m Model) indicator(e edge) string {
+	switch e {
+	case edgeTop:
+		return m.TopIndicator
+	case edgeBottom:
+		return m.BottomIndicator
+	default:
+		return ""
+	}
+}
+
+// withIndicator 将正在显示的边缘指示器居中渲染在视图的第一行或最后一行。
+// 指示器在内容区域中居中，不覆盖行号栏的位置。
+// 底部指示器在内容没有填满视口时渲染在内容之后。
+func (m Model) withIndicator(lines []string, height int) []string {
+	s := m.indicator(m.edge)
+	if s == "" || height <= 0 {
+		return lines
+	}
+	s = strings.Repeat(" ", m.gutterWidth()) +
+		lipgloss.PlaceHorizontal(m.contentWidth(), lipgloss.Center, m.IndicatorStyle.Inline(true).Render(s))
+
+	lines = append([]string(nil), lines...)
+	switch {
+	case m.edge == edgeTop && len(lines) > 0:
+		lines[0] = s
+	case len(lines) < height:
+		lines = append(lines, s)
+	default:
+		lines[len(lines)-1] = s
+	}
+	return lines
+}
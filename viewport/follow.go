@@ -0,0 +1,30 @@
+package viewport
+
+import "strings"
+
+// Following 返回视口是否正在跟随新内容，即启用了 Follow 并且位于底部。
+func (m Model) Following() bool {
+	return m.Follow && m.AtBottom()
+}
+
+// AppendContent 将给定文本作为新行追加到内容末尾。末尾的一个换行符会被忽略，
+// 因此追加 "foo\n" 只会添加一行。
+//
+// 与 SetContent 不同，它不会取消正在进行的 SetContentFromReader 加载。
+func (m *Model) AppendContent(s string) {
+	s = strings.ReplaceAll(s, "\r\n", "\n") // 规范化行尾
+	m.AppendLines(strings.Split(strings.TrimSuffix(s, "\n"), "\n"))
+}
+
+// AppendLines 将给定的行追加到内容末尾，而无需重新拆分全部内容。
+// 如果视口正在跟随新内容（参见 Following），视图保持在底部。
+func (m *Model) AppendLines(lines []string) {
+	if len(lines) == 0 || m.provider != nil {
+		return
+	}
+	following := m.Following()
+	m.appendLines(lines)
+	if following {
+		m.SetYOffset(m.maxYOffset())
+	}
+}
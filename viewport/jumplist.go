@@ -0,0 +1,65 @@
+package viewport
+
+// maxJumps 是跳转列表保留的位置数量上限。
+const maxJumps = 100
+
+// Jump 将视口滚动到给定的 Y 偏移量，并将跳转前的位置记录到跳转列表中，
+// 以便之后通过 JumpBack 返回。GotoTop、GotoBottom 以及搜索和锚点等
+// 大幅跳转都应该使用它，逐行滚动和翻页则不应使用。
+func (m *Model) Jump(yOffset int) (lines []string) {
+	m.recordJump()
+	m.SetYOffset(yOffset)
+	return m.visibleLines()
+}
+
+// JumpBack 返回跳转列表中的上一个位置，类似于编辑器中的 ctrl+o。
+func (m *Model) JumpBack() (lines []string) {
+	if m.jumpIndex == 0 {
+		return nil
+	}
+	if m.jumpIndex == len(m.jumps) {
+		// 第一次返回时记录当前位置，以便之后可以通过 JumpForward 回到这里。
+		m.jumps = append(m.jumps, m.YOffset)
+	}
+	m.jumpIndex--
+	m.SetYOffset(m.jumps[m.jumpIndex])
+	return m.visibleLines()
+}
+
+// JumpForward 前往跳转列表中的下一个位置，撤销 JumpBack，类似于编辑器中的 ctrl+i。
+func (m *Model) JumpForward() (lines []string) {
+	if m.jumpIndex >= len(m.jumps)-1 {
+		return nil
+	}
+	m.jumpIndex++
+	m.SetYOffset(m.jumps[m.jumpIndex])
+	return m.visibleLines()
+}
+
+// CanJumpBack 返回跳转列表中是否有可以返回的位置。
+func (m Model) CanJumpBack() bool {
+	return m.jumpIndex > 0
+}
+
+// CanJumpForward 返回跳转列表中是否有可以前往的位置。
+func (m Model) CanJumpForward() bool {
+	return m.jumpIndex < len(m.jumps)-1
+}
+
+// ClearJumps 清空跳转列表，例如在设置新内容之后。
+func (m *Model) ClearJumps() {
+	m.jumps = nil
+	m.jumpIndex = 0
+}
+
+// recordJump 将当前位置记录到跳转列表中，并丢弃当前位置之后的所有位置。
+func (m *Model) recordJump() {
+	m.jumps = m.jumps[:m.jumpIndex:m.jumpIndex]
+	if n := len(m.jumps); n == 0 || m.jumps[n-1] != m.YOffset {
+		m.jumps = append(m.jumps, m.YOffset)
+	}
+	if len(m.jumps) > maxJumps {
+		m.jumps = m.jumps[len(m.jumps)-maxJumps:]
+	}
+	m.jumpIndex = len(m.jumps)
+}
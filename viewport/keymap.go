@@ -17,6 +17,13 @@ type KeyMap struct {
 	Up           key.Binding // 向上移动一行
 	Left         key.Binding // 向左移动一列
 	Right        key.Binding // 向右移动一列
+	JumpBack     key.Binding // 返回跳转列表中的上一个位置
+	JumpForward  key.Binding // 前往跳转列表中的下一个位置
+	NextAnchor   key.Binding // 跳转到下一个锚点
+	PrevAnchor   key.Binding // 跳转到上一个锚点
+
+	// CopySelection 将鼠标选中的文本复制到系统剪贴板，参见 Model.SelectionEnabled。
+	CopySelection key.Binding
 }
 
 // DefaultKeyMap 返回一组类似分页器的默认按键绑定。
@@ -62,5 +69,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("right", "l"),
 			key.WithHelp("→/l", "向右移动"),
 		),
+		// 返回跳转前的位置：ctrl+o
+		JumpBack: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "跳回"),
+		),
+		// 前往下一个跳转位置：ctrl+i（终端中与 tab 相同）
+		JumpForward: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("ctrl+i", "跳到下一个位置"),
+		),
+		// 跳转到下一个锚点：]
+		NextAnchor: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "下一节"),
+		),
+		// 跳转到上一个锚点：[
+		PrevAnchor: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "上一节"),
+		),
+		// 复制选中的文本：y
+		CopySelection: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "复制"),
+		),
 	}
 }
@@ -0,0 +1,52 @@
+package viewport
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// gutterWidth 返回行号栏的宽度（包括与内容之间的一个空格）。
+// 如果没有启用 ShowLineNumbers，则返回 0。
+func (m Model) gutterWidth() int {
+	if !m.ShowLineNumbers {
+		return 0
+	}
+	return len(strconv.Itoa(max(1, m.lineCount()))) + 1
+}
+
+// withLineNumbers 在从第 top 行开始的行之前添加行号栏。
+func (m Model) withLineNumbers(lines []string, top int) []string {
+	if !m.ShowLineNumbers {
+		return lines
+	}
+	digits := m.gutterWidth() - 1
+	numbered := make([]string, len(lines))
+	for i, line := range lines {
+		numbered[i] = m.LineNumberStyle.Inline(true).Render(fmt.Sprintf("%*d", digits, top+i+1)) + " " + line
+	}
+	return numbered
+}
+
+// GotoLine 滚动视口使第 n 行（从 1 开始，与行号栏一致）可见，并尽量少地滚动：
+// 该行已经可见时不滚动，在视口上方时滚动到顶部，在视口下方时滚动到底部。
+// 跳转前的位置被记录到跳转列表中（参见 Jump）。
+func (m *Model) GotoLine(n int) (lines []string) {
+	i := clamp(n-1, 0, m.lineCount()-1)
+	h := m.Height - m.Style.GetVerticalFrameSize() - m.scrollbarHeight()
+	switch {
+	case i < m.YOffset:
+		return m.Jump(i)
+	case i >= m.YOffset+h:
+		return m.Jump(i - h + 1)
+	default:
+		return m.visibleLines()
+	}
+}
+
+// GotoLineCentered 滚动视口使第 n 行（从 1 开始）位于视口的中间。
+// 靠近内容开头或结尾的行无法居中，此时滚动到顶部或底部。
+func (m *Model) GotoLineCentered(n int) (lines []string) {
+	i := clamp(n-1, 0, m.lineCount()-1)
+	h := m.Height - m.Style.GetVerticalFrameSize() - m.scrollbarHeight()
+	return m.Jump(i - h/2) //nolint:mnd
+}
@@ -0,0 +1,108 @@
+package viewport
+
+import (
+	"strings"
+
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+// lineCache 缓存每一行的测量结果，使渲染时无需反复解析行中的 ANSI 序列。
+// 内容变化时整个缓存被替换；追加行时只测量新的行。
+type lineCache struct {
+	widths []int // 每一行的显示宽度
+
+	// segments 是每一行拆分后的片段，在第一次水平裁剪该行时惰性计算
+	segments [][]segment
+}
+
+// segment 是行中的一个字素簇或一个转义序列（宽度为 0）。
+type segment struct {
+	s     string
+	width int
+}
+
+// setLines 替换内容并测量所有行。
+func (m *Model) setLines(lines []string) {
+	m.lines = lines
+	m.provider = nil
+	m.cache = &lineCache{
+		widths:   make([]int, len(lines)),
+		segments: make([][]segment, len(lines)),
+	}
+	m.longestLineWidth = m.cache.measure(lines, 0)
+}
+
+// appendLines 追加行并测量新的行。
+func (m *Model) appendLines(lines []string) {
+	start := len(m.lines)
+	m.lines = append(m.lines, lines...)
+	c := &lineCache{}
+	if m.cache != nil {
+		c.widths, c.segments = m.cache.widths[:start], m.cache.segments[:start]
+	}
+	c.widths = append(c.widths, make([]int, len(lines))...)
+	c.segments = append(c.segments, make([][]segment, len(lines))...)
+	m.cache = c
+	m.longestLineWidth = max(m.longestLineWidth, c.measure(lines, start))
+}
+
+// measure 测量从 start 开始的行并返回其中最长行的宽度。
+func (c *lineCache) measure(lines []string, start int) int {
+	longest := 0
+	for i, l := range lines {
+		w := ansi.StringWidth(l)
+		c.widths[start+i] = w
+		longest = max(longest, w)
+	}
+	return longest
+}
+
+// cutLine 返回第 i 行中从第 left 列到第 right 列（不含）的部分。与 ansi.Cut
+// 不同，它使用缓存的宽度和片段，因此重复裁剪同一行时无需重新解析。
+// 裁剪范围之外的转义序列被保留，以保持样式状态正确。
+func (m Model) cutLine(i, left, right int) string {
+	c := m.cache
+	if c == nil || i >= len(c.widths) {
+		return ansi.Cut(m.lines[i], left, right)
+	}
+	if left <= 0 && c.widths[i] <= right {
+		return m.lines[i]
+	}
+
+	segs := c.segments[i]
+	if segs == nil {
+		segs = splitSegments(m.lines[i])
+		c.segments[i] = segs
+	}
+
+	var b strings.Builder
+	col := 0
+	for _, s := range segs {
+		if s.width == 0 {
+			b.WriteString(s.s)
+			continue
+		}
+		// 与 ansi.Cut 一致：跨越左边界的宽字符被保留，跨越右边界的被丢弃。
+		if end := col + s.width; end > left && end <= right {
+			b.WriteString(s.s)
+		}
+		col += s.width
+	}
+	return b.String()
+}
+
+// splitSegments 将行拆分为字素簇和转义序列。
+func splitSegments(s string) []segment {
+	segs := []segment{}
+	var state byte
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		if n <= 0 {
+			break
+		}
+		segs = append(segs, segment{s: seq, width: width})
+		state = newState
+		s = s[n:]
+	}
+	return segs
+}
@@ -0,0 +1,101 @@
+package viewport
+
+import (
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+// lineProvider 是按需提供内容的行的来源，参见 SetLineProvider。
+type lineProvider struct {
+	fn    func(start, end int) []string
+	count int
+
+	// longest 是已知最长行的宽度。如果 fixed 为 false，它是目前为止取得的行中
+	// 最长行的宽度，随着滚动惰性增长。
+	longest int
+	fixed   bool
+}
+
+// SetLineProvider 将视口切换到行提供者模式：内容共有 count 行，视口只在需要时
+// 调用 fn 取得第 start 行到第 end 行（不含）。与 SetContent 不同，它不需要保存和
+// 拆分全部内容，因此适用于非常大的内容，例如上百万行的日志。fn 返回的行数应为
+// end-start，多余的行被忽略，缺少的行视为空行。
+//
+// 水平滚动的范围由目前为止取得的最长行惰性决定；如果调用者已经知道最长行的
+// 宽度，可以通过 SetLongestLineWidth 提供。再次调用 SetContent 或
+// SetContentFromReader 会退出行提供者模式。行提供者模式下 AppendContent 和
+// AppendLines 无效，内容增长时使用 SetLineCount 更新行数。
+func (m *Model) SetLineProvider(count int, fn func(start, end int) []string) {
+	following := m.Following()
+	m.cancelRead()
+	m.ClearSelection()
+	m.setLines(nil)
+	m.provider = &lineProvider{fn: fn, count: max(0, count)}
+
+	if following || m.YOffset > m.lineCount()-1 {
+		m.SetYOffset(m.maxYOffset())
+	}
+}
+
+// SetLineCount 更新行提供者模式下内容的行数，例如日志增长之后。如果视口正在
+// 跟随新内容（参见 Following），视图保持在底部。不处于行提供者模式时无效。
+func (m *Model) SetLineCount(count int) {
+	if m.provider == nil {
+		return
+	}
+	following := m.Following()
+	m.provider.count = max(0, count)
+	if following || m.PastBottom() {
+		m.SetYOffset(m.maxYOffset())
+	}
+}
+
+// SetLongestLineWidth 设置行提供者模式下最长行的宽度，用于决定水平滚动的范围。
+// 如果 w 为 0 或更小，则恢复为根据取得的行惰性计算。不处于行提供者模式时无效。
+func (m *Model) SetLongestLineWidth(w int) {
+	if m.provider == nil {
+		return
+	}
+	m.provider.fixed = w > 0
+	m.provider.longest = max(0, w)
+}
+
+// lineCount 返回内容的行数。
+func (m Model) lineCount() int {
+	if m.provider != nil {
+		return m.provider.count
+	}
+	return len(m.lines)
+}
+
+// lineRange 返回第 start 行到第 end 行（不含）。调用者保证范围有效。
+func (m Model) lineRange(start, end int) []string {
+	p := m.provider
+	if p == nil {
+		return m.lines[start:end]
+	}
+	if start >= end {
+		return nil
+	}
+
+	lines := make([]string, end-start)
+	copy(lines, p.fn(start, end))
+	if !p.fixed {
+		for _, l := range lines {
+			p.longest = max(p.longest, ansi.StringWidth(l))
+		}
+	}
+	return lines
+}
+
+// line 返回第 i 行。
+func (m Model) line(i int) string {
+	return m.lineRange(i, i+1)[0]
+}
+
+// longestWidth 返回最长行的宽度。
+func (m Model) longestWidth() int {
+	if m.provider != nil {
+		return m.provider.longest
+	}
+	return m.longestLineWidth
+}
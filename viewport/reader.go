@@ -0,0 +1,117 @@
+package viewport
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	tea "github.com/purpose168/bubbletea-cn"
+)
+
+// defaultReaderChunkSize 是每次从 io.Reader 读取的默认行数
+const defaultReaderChunkSize = 1000
+
+// ReadMsg 包含通过 SetContentFromReader 读取的一块内容。
+// 它应该被传递给视口的 Update 方法。
+type ReadMsg struct {
+	id     int           // 视口 ID
+	tag    int           // 标签，用于丢弃已取消的加载产生的消息
+	reader *bufio.Reader // 剩余内容的读取器
+	lines  []string      // 本次读取的行
+	done   bool          // 是否已读取完毕
+	err    error         // 读取错误（io.EOF 除外）
+}
+
+// SetContentFromReader 清空视口内容，并返回一个从 r 中分块读取内容的命令。
+// 每读取一块，视口就会追加这些行并继续读取下一块，因此在加载期间
+// 已到达的内容可以立即渲染和滚动。读取完成前，LoadingIndicator
+// 会显示在已加载内容之后。
+//
+// 再次调用 SetContentFromReader 或 SetContent 会取消之前的加载。
+func (m *Model) SetContentFromReader(r io.Reader) tea.Cmd {
+	if !m.initialized {
+		m.setInitialValues()
+	}
+	if m.id == 0 {
+		m.id = nextID()
+	}
+	m.cancelRead()
+	m.setLines(nil)
+	m.SetYOffset(0)
+	m.loading = true
+	return m.readChunk(bufio.NewReader(r))
+}
+
+// Loading 返回视口是否仍在从 io.Reader 加载内容。
+func (m Model) Loading() bool {
+	return m.loading
+}
+
+// ReadErr 返回最近一次 SetContentFromReader 加载时遇到的错误（如果有）。
+func (m Model) ReadErr() error {
+	return m.readErr
+}
+
+// cancelRead 使正在进行的加载失效。
+func (m *Model) cancelRead() {
+	m.readTag++
+	m.loading = false
+	m.readErr = nil
+}
+
+// readChunk 返回一个读取下一块内容的命令。
+func (m Model) readChunk(r *bufio.Reader) tea.Cmd {
+	id, tag := m.id, m.readTag
+	size := m.ReaderChunkSize
+	if size <= 0 {
+		size = defaultReaderChunkSize
+	}
+
+	return func() tea.Msg {
+		msg := ReadMsg{id: id, tag: tag, reader: r}
+		for len(msg.lines) < size {
+			line, err := r.ReadString('\n')
+			if line != "" || err == nil {
+				line = strings.TrimSuffix(line, "\n")
+				line = strings.TrimSuffix(line, "\r")
+				msg.lines = append(msg.lines, line)
+			}
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					msg.err = err
+				}
+				msg.done = true
+				break
+			}
+		}
+		return msg
+	}
+}
+
+// handleRead 追加读取到的行，并在需要时继续读取。
+func (m *Model) handleRead(msg ReadMsg) tea.Cmd {
+	if msg.id != m.id || msg.tag != m.readTag || !m.loading {
+		return nil
+	}
+
+	m.AppendLines(msg.lines)
+
+	if msg.done {
+		m.loading = false
+		m.readErr = msg.err
+		return nil
+	}
+	return m.readChunk(msg.reader)
+}
+
+// linesForView 返回要渲染的行。加载期间，如果还有空间，
+// 会在已加载内容之后追加加载指示器；否则显示正在显示的边缘指示器（参见 TopIndicator）。
+func (m Model) linesForView(height int) []string {
+	top := max(0, m.YOffset)
+	lines := m.withLineNumbers(m.withSelection(m.withAnchor(m.visibleLines(), top), top), top)
+	if m.loading && m.LoadingIndicator != "" && len(lines) < height {
+		return append(lines[:len(lines):len(lines)], m.LoadingIndicator)
+	}
+	return m.withIndicator(lines, height)
+}
@@ -0,0 +1,67 @@
+package viewport
+
+import (
+	"math"
+	"strings"
+
+	lipgloss "github.com/purpose168/lipgloss-cn"
+)
+
+// 滚动条的字符。
+const (
+	scrollbarThumb  = "█"
+	scrollbarVTrack = "│"
+	scrollbarHTrack = "─"
+)
+
+// scrollbarWidth 返回垂直滚动条占用的宽度。如果没有启用 ShowScrollbar，则返回 0。
+func (m Model) scrollbarWidth() int {
+	if !m.ShowScrollbar {
+		return 0
+	}
+	return 1
+}
+
+// scrollbarHeight 返回水平滚动条占用的高度。如果没有启用 ShowHorizontalScrollbar，则返回 0。
+func (m Model) scrollbarHeight() int {
+	if !m.ShowHorizontalScrollbar {
+		return 0
+	}
+	return 1
+}
+
+// withScrollbars 在宽为 w、高为 h 的内容的右侧和下方添加滚动条。
+func (m Model) withScrollbars(contents string, w, h int) string {
+	if m.ShowScrollbar && h > 0 {
+		bar := m.scrollbar(h, h, m.lineCount(), m.ScrollPercent(), scrollbarVTrack)
+		contents = lipgloss.JoinHorizontal(lipgloss.Top, contents, strings.Join(bar, "\n"))
+	}
+	if m.ShowHorizontalScrollbar && w > 0 {
+		bar := m.scrollbar(w, w, m.longestWidth(), m.HorizontalScrollPercent(), scrollbarHTrack)
+		// 两个滚动条都显示时，右下角留空。
+		contents += "\n" + strings.Join(bar, "") + strings.Repeat(" ", m.scrollbarWidth())
+	}
+	return contents
+}
+
+// scrollbar 返回长度为 length 的滚动条的各个单元格。滑块的长度与可见部分 visible
+// 占总长度 total 的比例相同，但不小于 MinThumbSize；其位置由滚动百分比 percent 决定。
+// 内容没有超出视口时，滑块占满整个滚动条。
+func (m Model) scrollbar(length, visible, total int, percent float64, track string) []string {
+	size := length
+	if total > visible {
+		size = int(math.Round(float64(length) * float64(visible) / float64(total)))
+	}
+	size = clamp(size, max(1, m.MinThumbSize), length)
+	pos := int(math.Round(percent * float64(length-size)))
+
+	cells := make([]string, length)
+	for i := range cells {
+		if i >= pos && i < pos+size {
+			cells[i] = m.ScrollbarThumbStyle.Inline(true).Render(scrollbarThumb)
+		} else {
+			cells[i] = m.ScrollbarTrackStyle.Inline(true).Render(track)
+		}
+	}
+	return cells
+}
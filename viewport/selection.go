@@ -0,0 +1,152 @@
+package viewport
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+)
+
+// CopiedMsg 在 CopySelection 将选中的文本写入系统剪贴板后发送。
+type CopiedMsg struct {
+	ID   int    // 视口的 ID
+	Text string // 复制的文本
+	Err  error  // 写入剪贴板时发生的错误
+}
+
+// position 是内容中的一个单元格：行索引和显示列。
+type position struct {
+	line, col int
+}
+
+// before 返回 p 是否在 q 之前。
+func (p position) before(q position) bool {
+	return p.line < q.line || p.line == q.line && p.col < q.col
+}
+
+// HasSelection 返回是否有选中的文本。
+func (m Model) HasSelection() bool {
+	return m.selected
+}
+
+// ClearSelection 取消选中。
+func (m *Model) ClearSelection() {
+	m.selecting, m.selected = false, false
+}
+
+// SelectedText 返回选中的文本，不带 ANSI 转义序列。如果没有选中的文本，返回空字符串。
+func (m Model) SelectedText() string {
+	start, end, ok := m.selection()
+	if !ok {
+		return ""
+	}
+	lines := make([]string, 0, end.line-start.line+1)
+	for i := start.line; i <= end.line; i++ {
+		from, to := m.selectedCols(i, start, end)
+		lines = append(lines, ansi.Cut(ansi.Strip(m.line(i)), from, to))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CopySelection 返回将选中的文本写入系统剪贴板并发送 CopiedMsg 的命令。
+// 如果没有选中的文本，返回 nil。
+func (m Model) CopySelection() tea.Cmd {
+	text := m.SelectedText()
+	if text == "" {
+		return nil
+	}
+	id := m.id
+	return func() tea.Msg {
+		return CopiedMsg{ID: id, Text: text, Err: clipboard.WriteAll(text)}
+	}
+}
+
+// selection 返回按顺序排列并限制在内容范围内的选中区域的起点和终点（均包含在内）。
+func (m Model) selection() (start, end position, ok bool) {
+	if !m.selected || m.lineCount() == 0 {
+		return start, end, false
+	}
+	start, end = m.selStart, m.selEnd
+	if end.before(start) {
+		start, end = end, start
+	}
+	start.line = clamp(start.line, 0, m.lineCount()-1)
+	end.line = clamp(end.line, 0, m.lineCount()-1)
+	return start, end, true
+}
+
+// selectedCols 返回第 i 行中选中的列的范围 [from, to)。
+func (m Model) selectedCols(i int, start, end position) (from, to int) {
+	from, to = 0, m.lineWidth(i)
+	if i == start.line {
+		from = start.col
+	}
+	if i == end.line {
+		to = min(to, end.col+1)
+	}
+	return from, max(from, to)
+}
+
+// lineWidth 返回第 i 行的显示宽度。
+func (m Model) lineWidth(i int) int {
+	if m.cache != nil && i < len(m.cache.widths) {
+		return m.cache.widths[i]
+	}
+	return ansi.StringWidth(m.line(i))
+}
+
+// contentPosition 将鼠标事件的屏幕坐标转换为内容中的位置。
+func (m Model) contentPosition(msg tea.MouseMsg) position {
+	top := m.YPosition + m.Style.GetMarginTop() + m.Style.GetBorderTopSize() + m.Style.GetPaddingTop()
+	left := m.XPosition + m.Style.GetMarginLeft() + m.Style.GetBorderLeftSize() + m.Style.GetPaddingLeft() + m.gutterWidth()
+	return position{
+		line: max(0, m.YOffset+msg.Y-top),
+		col:  max(0, m.xOffset+msg.X-left),
+	}
+}
+
+// updateSelection 处理用于选中文本的鼠标事件：按下左键开始选中，拖动扩展选中区域，
+// 松开结束选中。没有拖动的单击取消选中。如果消息被处理，返回 true。
+func (m *Model) updateSelection(msg tea.MouseMsg) bool {
+	switch {
+	case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		m.selStart = m.contentPosition(msg)
+		m.selEnd = m.selStart
+		m.selecting, m.selected = true, false
+	case msg.Action == tea.MouseActionMotion && m.selecting:
+		m.selEnd = m.contentPosition(msg)
+		m.selected = true
+	case msg.Action == tea.MouseActionRelease && m.selecting:
+		m.selecting = false
+	default:
+		return false
+	}
+	return true
+}
+
+// withSelection 以 SelectionStyle 渲染从第 top 行开始的可见行中选中的部分。
+func (m Model) withSelection(lines []string, top int) []string {
+	start, end, ok := m.selection()
+	if !ok || end.line < top || start.line >= top+len(lines) {
+		return lines
+	}
+
+	styled := make([]string, len(lines))
+	copy(styled, lines)
+	style := m.SelectionStyle.Inline(true)
+	for i := max(start.line, top); i <= end.line && i < top+len(lines); i++ {
+		from, to := m.selectedCols(i, start, end)
+		// 可见行已经按水平滚动位置裁剪。
+		from, to = max(0, from-m.xOffset), max(0, to-m.xOffset)
+		if from >= to {
+			continue
+		}
+		line := lines[i-top]
+		w := ansi.StringWidth(line)
+		styled[i-top] = ansi.Cut(line, 0, from) +
+			style.Render(ansi.Strip(ansi.Cut(line, from, to))) +
+			ansi.Cut(line, min(to, w), w)
+	}
+	return styled
+}
@@ -3,6 +3,8 @@ package viewport
 import (
 	"math"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/purpose168/bubbles-cn/key"
 	tea "github.com/purpose168/bubbletea-cn"
@@ -10,6 +12,14 @@ import (
 	lipgloss "github.com/purpose168/lipgloss-cn"
 )
 
+// 内部 ID 管理。用于确保异步消息只能由发送它们的视口接收。
+var lastID int64
+
+// nextID 生成下一个唯一的 ID
+func nextID() int {
+	return int(atomic.AddInt64(&lastID, 1))
+}
+
 // New 创建一个具有给定宽度和高度的视口模型，并设置默认按键映射
 func New(width, height int) (m Model) {
 	m.Width = width
@@ -40,9 +50,13 @@ type Model struct {
 	// horizontalStep 默认水平滚动时左右移动的列数
 	horizontalStep int
 
-	// YPosition 视口相对于终端窗口的位置。仅用于高性能渲染
+	// YPosition 视口相对于终端窗口的垂直位置。用于高性能渲染，
+	// 以及在启用 SelectionEnabled 时将鼠标坐标转换为内容中的位置
 	YPosition int
 
+	// XPosition 视口相对于终端窗口的水平位置。在启用 SelectionEnabled 时使用
+	XPosition int
+
 	// Style 为视口应用 lipgloss 样式。实际上，它最常用于设置边框、边距和内边距
 	Style lipgloss.Style
 
@@ -55,9 +69,99 @@ type Model struct {
 	// 已废弃：高性能渲染现已在 Bubble Tea 中被废弃
 	HighPerformanceRendering bool
 
+	// ReaderChunkSize 是 SetContentFromReader 每次读取的最大行数。
+	// 如果为 0 或更小，则使用默认值 1000。
+	ReaderChunkSize int
+
+	// Follow 启用跟随模式（类似 tail -f）：如果视口位于底部，内容变化后
+	// （SetContent、AppendContent、AppendLines 以及 SetContentFromReader 加载的内容）
+	// 视图保持在底部。向上滚动会暂停跟随，滚动回底部后恢复。
+	Follow bool
+
+	// ShowLineNumbers 在每一行之前显示行号栏，行号从 1 开始。
+	// 行号栏占用视口的宽度，不随水平滚动移动。
+	ShowLineNumbers bool
+
+	// LineNumberStyle 是行号栏的样式。
+	LineNumberStyle lipgloss.Style
+
+	// TopIndicator 和 BottomIndicator 在用户试图越过顶部或底部继续滚动时显示，
+	// 例如 "── END ──"，使分页器不会看起来像卡住了一样。顶部指示器显示在第一行，
+	// 底部指示器显示在内容之后（如果视口中还有空间）或最后一行。为空时不显示。
+	TopIndicator    string
+	BottomIndicator string
+
+	// IndicatorStyle 是边缘指示器的样式。
+	IndicatorStyle lipgloss.Style
+
+	// IndicatorDuration 是边缘指示器显示的时间。如果为 0 或更小，
+	// 指示器一直显示到视口再次滚动。默认为 1 秒。
+	IndicatorDuration time.Duration
+
+	// SelectionEnabled 启用鼠标拖动选中文本，选中的文本可以通过 CopySelection
+	// 按键或方法复制到系统剪贴板。必须在 Bubble Tea 中启用鼠标支持（包括拖动事件，
+	// 例如 tea.WithMouseCellMotion），并设置 XPosition 和 YPosition。
+	SelectionEnabled bool
+
+	// SelectionStyle 是选中文本的样式。
+	SelectionStyle lipgloss.Style
+
+	// HighlightAnchor 以 AnchorStyle 渲染当前锚点（参见 SetAnchors）所在的行。
+	HighlightAnchor bool
+
+	// AnchorStyle 是当前锚点所在的行的样式。
+	AnchorStyle lipgloss.Style
+
+	// ShowScrollbar 在视口右侧显示垂直滚动条，ShowHorizontalScrollbar 在视口底部
+	// 显示水平滚动条，滑块的位置由 ScrollPercent 和 HorizontalScrollPercent 决定。
+	// 滚动条各占用视口的一列或一行。
+	ShowScrollbar           bool
+	ShowHorizontalScrollbar bool
+
+	// ScrollbarThumbStyle 和 ScrollbarTrackStyle 是滚动条的滑块和轨道的样式。
+	ScrollbarThumbStyle lipgloss.Style
+	ScrollbarTrackStyle lipgloss.Style
+
+	// MinThumbSize 是滑块的最小长度，使内容很长时滑块仍然可见。如果为 0 或更小，则为 1。
+	MinThumbSize int
+
+	// LoadingIndicator 在通过 SetContentFromReader 加载内容期间，
+	// 渲染在已加载内容之后（如果视口中还有空间）。
+	LoadingIndicator string
+
 	initialized      bool
+	id               int
 	lines            []string
 	longestLineWidth int
+	cache            *lineCache    // 每一行的测量结果
+	provider         *lineProvider // 行提供者模式下行的来源（参见 SetLineProvider）
+
+	// 从 io.Reader 增量加载内容的状态
+	loading bool
+	readTag int
+	readErr error
+
+	// 根据窗口大小自动调整视口大小的约束
+	autoSize        AutoSize
+	autoSizeEnabled bool
+
+	// 跳转列表：大幅跳转前的 Y 偏移量，以及当前在列表中的位置
+	jumps     []int
+	jumpIndex int
+
+	// 正在显示指示器的边缘，以及用于隐藏指示器的标签
+	edge    edge
+	edgeTag int
+
+	// 鼠标选中的起点和终点（内容中的位置），是否正在拖动以及是否有选中的文本
+	selStart  position
+	selEnd    position
+	selecting bool
+	selected  bool
+
+	// 按行号排序的锚点，以及最近一次跳转到的锚点
+	anchors       []anchor
+	currentAnchor string
 }
 
 // setInitialValues 设置模型的初始默认值
@@ -65,6 +169,13 @@ func (m *Model) setInitialValues() {
 	m.KeyMap = DefaultKeyMap()
 	m.MouseWheelEnabled = true
 	m.MouseWheelDelta = 3
+	m.LoadingIndicator = "…"
+	m.IndicatorStyle = lipgloss.NewStyle().Faint(true)
+	m.IndicatorDuration = time.Second
+	m.SelectionStyle = lipgloss.NewStyle().Reverse(true)
+	m.AnchorStyle = lipgloss.NewStyle().Bold(true)
+	m.LineNumberStyle = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"})
+	m.ScrollbarTrackStyle = lipgloss.NewStyle().Faint(true)
 	m.initialized = true
 }
 
@@ -91,62 +202,73 @@ func (m Model) PastBottom() bool {
 
 // ScrollPercent 返回滚动量作为 0 到 1 之间的浮点数
 func (m Model) ScrollPercent() float64 {
-	if m.Height >= len(m.lines) {
+	if m.Height >= m.lineCount() {
 		return 1.0
 	}
 	y := float64(m.YOffset)
 	h := float64(m.Height)
-	t := float64(len(m.lines))
+	t := float64(m.lineCount())
 	v := y / (t - h)
 	return math.Max(0.0, math.Min(1.0, v))
 }
 
 // HorizontalScrollPercent 返回水平滚动量作为 0 到 1 之间的浮点数
 func (m Model) HorizontalScrollPercent() float64 {
-	if m.xOffset >= m.longestLineWidth-m.Width {
+	if m.xOffset >= m.longestWidth()-m.Width+m.gutterWidth()+m.scrollbarWidth() {
 		return 1.0
 	}
 	y := float64(m.xOffset)
-	h := float64(m.Width)
-	t := float64(m.longestLineWidth)
+	h := float64(m.Width - m.gutterWidth() - m.scrollbarWidth())
+	t := float64(m.longestWidth())
 	v := y / (t - h)
 	return math.Max(0.0, math.Min(1.0, v))
 }
 
-// SetContent 设置分页器的文本内容
+// SetContent 设置分页器的文本内容。正在进行的 SetContentFromReader 加载将被取消。
 func (m *Model) SetContent(s string) {
+	following := m.Following()
+	m.cancelRead()
+	m.ClearSelection()
 	s = strings.ReplaceAll(s, "\r\n", "\n") // 规范化行尾
-	m.lines = strings.Split(s, "\n")
-	m.longestLineWidth = findLongestLineWidth(m.lines)
+	m.setLines(strings.Split(s, "\n"))
 
-	if m.YOffset > len(m.lines)-1 {
-		m.GotoBottom()
+	if following || m.YOffset > m.lineCount()-1 {
+		m.SetYOffset(m.maxYOffset())
 	}
 }
 
 // maxYOffset 根据视口的内容和设置的高度返回 y 偏移量的最大可能值
 func (m Model) maxYOffset() int {
-	return max(0, len(m.lines)-m.Height+m.Style.GetVerticalFrameSize())
+	return max(0, m.lineCount()-m.Height+m.Style.GetVerticalFrameSize()+m.scrollbarHeight())
+}
+
+// contentWidth 返回内容区域的宽度，即视口宽度减去样式的边框和边距、行号栏和滚动条
+func (m Model) contentWidth() int {
+	return m.Width - m.Style.GetHorizontalFrameSize() - m.gutterWidth() - m.scrollbarWidth()
 }
 
 // visibleLines 返回当前应该在视口中可见的行
 func (m Model) visibleLines() (lines []string) {
-	h := m.Height - m.Style.GetVerticalFrameSize()
-	w := m.Width - m.Style.GetHorizontalFrameSize()
+	h := m.Height - m.Style.GetVerticalFrameSize() - m.scrollbarHeight()
+	w := m.contentWidth()
 
-	if len(m.lines) > 0 {
+	if n := m.lineCount(); n > 0 {
 		top := max(0, m.YOffset)
-		bottom := clamp(m.YOffset+h, top, len(m.lines))
-		lines = m.lines[top:bottom]
+		bottom := clamp(m.YOffset+h, top, n)
+		lines = m.lineRange(top, bottom)
 	}
 
-	if (m.xOffset == 0 && m.longestLineWidth <= w) || w == 0 {
+	if (m.xOffset == 0 && m.longestWidth() <= w) || w == 0 {
 		return lines
 	}
 
 	cutLines := make([]string, len(lines))
 	for i := range lines {
-		cutLines[i] = ansi.Cut(lines[i], m.xOffset, m.xOffset+w)
+		if m.provider != nil {
+			cutLines[i] = ansi.Cut(lines[i], m.xOffset, m.xOffset+w)
+			continue
+		}
+		cutLines[i] = m.cutLine(max(0, m.YOffset)+i, m.xOffset, m.xOffset+w)
 	}
 	return cutLines
 }
@@ -241,7 +363,7 @@ func (m *Model) LineDown(n int) (lines []string) {
 
 // ScrollDown 将视图向下移动指定的行数
 func (m *Model) ScrollDown(n int) (lines []string) {
-	if m.AtBottom() || n == 0 || len(m.lines) == 0 {
+	if m.AtBottom() || n == 0 || m.lineCount() == 0 {
 		return nil
 	}
 
@@ -251,9 +373,9 @@ func (m *Model) ScrollDown(n int) (lines []string) {
 	// 收集用于性能滚动的行
 	//
 	// XXX：高性能渲染已在 Bubble Tea 中被废弃
-	bottom := clamp(m.YOffset+m.Height, 0, len(m.lines))
+	bottom := clamp(m.YOffset+m.Height, 0, m.lineCount())
 	top := clamp(m.YOffset+m.Height-n, 0, bottom)
-	return m.lines[top:bottom]
+	return m.lineRange(top, bottom)
 }
 
 // LineUp 将视图向下移动指定的行数。返回要显示的新行
@@ -265,7 +387,7 @@ func (m *Model) LineUp(n int) (lines []string) {
 
 // ScrollUp 将视图向下移动指定的行数。返回要显示的新行
 func (m *Model) ScrollUp(n int) (lines []string) {
-	if m.AtTop() || n == 0 || len(m.lines) == 0 {
+	if m.AtTop() || n == 0 || m.lineCount() == 0 {
 		return nil
 	}
 
@@ -277,7 +399,7 @@ func (m *Model) ScrollUp(n int) (lines []string) {
 	// XXX：高性能渲染已在 Bubble Tea 中被废弃
 	top := max(0, m.YOffset)
 	bottom := clamp(m.YOffset+n, 0, m.maxYOffset())
-	return m.lines[top:bottom]
+	return m.lineRange(top, bottom)
 }
 
 // SetHorizontalStep 设置使用默认视口按键映射时左右滚动的默认列数
@@ -291,7 +413,7 @@ func (m *Model) SetHorizontalStep(n int) {
 
 // SetXOffset 设置 X 偏移量
 func (m *Model) SetXOffset(n int) {
-	m.xOffset = clamp(n, 0, m.longestLineWidth-m.Width)
+	m.xOffset = clamp(n, 0, m.longestWidth()-m.Width+m.gutterWidth()+m.scrollbarWidth())
 }
 
 // ScrollLeft 将视口向左移动指定的列数
@@ -306,7 +428,7 @@ func (m *Model) ScrollRight(n int) {
 
 // TotalLineCount 返回视口内行的总数（包括隐藏和可见的行）
 func (m Model) TotalLineCount() int {
-	return len(m.lines)
+	return m.lineCount()
 }
 
 // VisibleLineCount 返回视口内可见行的数量
@@ -314,20 +436,21 @@ func (m Model) VisibleLineCount() int {
 	return len(m.visibleLines())
 }
 
-// GotoTop 将视口设置到顶部位置
+// GotoTop 将视口设置到顶部位置。跳转前的位置会被记录到跳转列表中
 func (m *Model) GotoTop() (lines []string) {
 	if m.AtTop() {
 		return nil
 	}
 
-	m.SetYOffset(0)
-	return m.visibleLines()
+	return m.Jump(0)
 }
 
-// GotoBottom 将视口设置到底部位置
+// GotoBottom 将视口设置到底部位置。跳转前的位置会被记录到跳转列表中
 func (m *Model) GotoBottom() (lines []string) {
-	m.SetYOffset(m.maxYOffset())
-	return m.visibleLines()
+	if m.YOffset == m.maxYOffset() {
+		return m.visibleLines()
+	}
+	return m.Jump(m.maxYOffset())
 }
 
 // Sync 告诉渲染器视口将位于何处，并请求渲染视口的当前状态。
@@ -337,7 +460,7 @@ func (m *Model) GotoBottom() (lines []string) {
 //
 // 已废弃：高性能渲染已在 Bubble Tea 中被废弃
 func Sync(m Model) tea.Cmd {
-	if len(m.lines) == 0 {
+	if m.lineCount() == 0 {
 		return nil
 	}
 	top, bottom := m.scrollArea()
@@ -390,7 +513,12 @@ func (m Model) updateAsModel(msg tea.Msg) (Model, tea.Cmd) {
 		m.setInitialValues()
 	}
 
+	if m.id == 0 {
+		m.id = nextID()
+	}
+
 	var cmd tea.Cmd
+	before := m.edgeState(msg)
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -436,9 +564,27 @@ func (m Model) updateAsModel(msg tea.Msg) (Model, tea.Cmd) {
 
 		case key.Matches(msg, m.KeyMap.Right):
 			m.ScrollRight(m.horizontalStep)
+
+		case key.Matches(msg, m.KeyMap.JumpBack):
+			m.JumpBack()
+
+		case key.Matches(msg, m.KeyMap.JumpForward):
+			m.JumpForward()
+
+		case key.Matches(msg, m.KeyMap.NextAnchor):
+			m.NextAnchor()
+
+		case key.Matches(msg, m.KeyMap.PrevAnchor):
+			m.PrevAnchor()
+
+		case m.selected && key.Matches(msg, m.KeyMap.CopySelection):
+			cmd = m.CopySelection()
 		}
 
 	case tea.MouseMsg:
+		if m.SelectionEnabled && m.updateSelection(msg) {
+			break
+		}
 		if !m.MouseWheelEnabled || msg.Action != tea.MouseActionPress {
 			break
 		}
@@ -469,9 +615,20 @@ func (m Model) updateAsModel(msg tea.Msg) (Model, tea.Cmd) {
 		case tea.MouseButtonWheelRight:
 			m.ScrollRight(m.horizontalStep)
 		}
+
+	case tea.WindowSizeMsg:
+		if m.autoSizeEnabled {
+			m.handleWindowSize(msg)
+		}
+
+	case ReadMsg:
+		cmd = m.handleRead(msg)
+
+	case indicatorMsg:
+		m.hideIndicator(msg)
 	}
 
-	return m, cmd
+	return m, tea.Batch(cmd, m.handleEdges(before))
 }
 
 // View 将视口渲染为字符串
@@ -490,17 +647,17 @@ func (m Model) View() string {
 	if sh := m.Style.GetHeight(); sh != 0 {
 		h = min(h, sh)
 	}
-	contentWidth := w - m.Style.GetHorizontalFrameSize()
-	contentHeight := h - m.Style.GetVerticalFrameSize()
+	contentWidth := w - m.Style.GetHorizontalFrameSize() - m.scrollbarWidth()
+	contentHeight := h - m.Style.GetVerticalFrameSize() - m.scrollbarHeight()
 	contents := lipgloss.NewStyle().
 		Width(contentWidth).      // 填充到宽度
 		Height(contentHeight).    // 填充到高度
 		MaxHeight(contentHeight). // 如果更高则截断高度
 		MaxWidth(contentWidth).   // 如果更宽则截断宽度
-		Render(strings.Join(m.visibleLines(), "\n"))
+		Render(strings.Join(m.linesForView(contentHeight), "\n"))
 	return m.Style.
 		UnsetWidth().UnsetHeight(). // 样式大小已在 contents 中应用
-		Render(contents)
+		Render(m.withScrollbars(contents, contentWidth, contentHeight))
 }
 
 // clamp 将值限制在指定的最小值和最大值之间
@@ -510,14 +667,3 @@ func clamp(v, low, high int) int {
 	}
 	return min(high, max(low, v))
 }
-
-// findLongestLineWidth 查找所有行中最长行的宽度
-func findLongestLineWidth(lines []string) int {
-	w := 0
-	for _, l := range lines {
-		if ww := ansi.StringWidth(l); ww > w {
-			w = ww
-		}
-	}
-	return w
-}
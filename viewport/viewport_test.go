@@ -1,8 +1,15 @@
 package viewport
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/purpose168/bubbles-cn/key"
+	tea "github.com/purpose168/bubbletea-cn"
+	"github.com/purpose168/charm-experimental-packages-cn/ansi"
+	lipgloss "github.com/purpose168/lipgloss-cn"
 )
 
 const defaultHorizontalStep = 6 // 默认水平滚动步长
@@ -401,3 +408,529 @@ func TestRightOverscroll(t *testing.T) {
 		}
 	})
 }
+
+// TestSetContentFromReader 测试从 io.Reader 分块加载内容
+func TestSetContentFromReader(t *testing.T) {
+	t.Parallel()
+
+	m := New(10, 5)
+	m.ReaderChunkSize = 2
+
+	cmd := m.SetContentFromReader(strings.NewReader("一\r\n二\n三\n四\n五"))
+	if !m.Loading() {
+		t.Fatal("开始加载后 Loading 应为 true")
+	}
+
+	reads := 0
+	for cmd != nil {
+		msg, ok := cmd().(ReadMsg)
+		if !ok {
+			t.Fatal("期望得到 ReadMsg")
+		}
+		reads++
+		m, cmd = m.Update(msg)
+		if cmd != nil && m.TotalLineCount() != reads*2 {
+			t.Fatalf("第 %d 次读取后应有 %d 行，实际为 %d", reads, reads*2, m.TotalLineCount())
+		}
+	}
+
+	if m.Loading() {
+		t.Error("读取完成后 Loading 应为 false")
+	}
+	if reads != 3 {
+		t.Errorf("应读取 3 次，实际为 %d", reads)
+	}
+	want := []string{"一", "二", "三", "四", "五"}
+	if got := m.visibleLines(); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("内容应为 %q，实际为 %q", want, got)
+	}
+}
+
+// TestSetContentFromReaderCancel 测试 SetContent 会取消正在进行的加载
+func TestSetContentFromReaderCancel(t *testing.T) {
+	t.Parallel()
+
+	m := New(10, 5)
+	cmd := m.SetContentFromReader(strings.NewReader("旧内容"))
+	if !strings.Contains(m.View(), m.LoadingIndicator) {
+		t.Error("加载期间应渲染加载指示器")
+	}
+
+	m.SetContent("新内容")
+	m, _ = m.Update(cmd())
+
+	if got := m.visibleLines(); len(got) != 1 || got[0] != "新内容" {
+		t.Errorf("已取消的加载不应修改内容，实际为 %q", got)
+	}
+}
+
+// TestAutoSize 测试根据窗口大小自动调整视口大小
+func TestAutoSize(t *testing.T) {
+	t.Parallel()
+
+	m := New(10, 10)
+	m.SetContent(strings.Repeat("line\n", 29) + "line")
+	m.GotoBottom()
+
+	// 未启用时忽略窗口大小消息
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if m.Width != 10 || m.Height != 10 {
+		t.Fatalf("expected size to be unchanged, got %dx%d", m.Width, m.Height)
+	}
+
+	m.SetAutoSize(AutoSize{MarginX: 2, MarginY: 4, MaxWidth: 60})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if m.Width != 60 || m.Height != 20 {
+		t.Fatalf("expected 60x20, got %dx%d", m.Width, m.Height)
+	}
+	if m.PastBottom() || !m.AtBottom() {
+		t.Fatalf("expected offset to be clamped to the bottom, got %d", m.YOffset)
+	}
+
+	m.SetAutoSize(AutoSize{AspectRatio: 2})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if m.Width != 48 || m.Height != 24 {
+		t.Fatalf("expected 48x24, got %dx%d", m.Width, m.Height)
+	}
+}
+
+func TestJumpList(t *testing.T) {
+	t.Parallel()
+
+	m := New(10, 10)
+	m.SetContent(strings.Repeat("line\n", 99) + "line")
+	m.SetYOffset(20)
+
+	m.GotoBottom()
+	if m.YOffset != 90 {
+		t.Fatalf("expected offset 90, got %d", m.YOffset)
+	}
+	m.GotoTop()
+
+	back := tea.KeyMsg{Type: tea.KeyCtrlO}
+	forward := tea.KeyMsg{Type: tea.KeyTab}
+
+	for _, want := range []int{90, 20, 20} {
+		m, _ = m.Update(back)
+		if m.YOffset != want {
+			t.Fatalf("expected offset %d after jumping back, got %d", want, m.YOffset)
+		}
+	}
+	if m.CanJumpBack() || !m.CanJumpForward() {
+		t.Fatal("expected to be at the start of the jump list")
+	}
+	for _, want := range []int{90, 0, 0} {
+		m, _ = m.Update(forward)
+		if m.YOffset != want {
+			t.Fatalf("expected offset %d after jumping forward, got %d", want, m.YOffset)
+		}
+	}
+
+	// 新的跳转会丢弃当前位置之后的所有位置
+	m.JumpBack()
+	m.JumpBack()
+	m.Jump(50)
+	if m.CanJumpForward() {
+		t.Fatal("expected a new jump to truncate the forward history")
+	}
+	m.JumpBack()
+	if m.YOffset != 20 {
+		t.Fatalf("expected to jump back to offset 20, got %d", m.YOffset)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	m := New(10, 5)
+	m.Follow = true
+	m.AppendContent("1\n2\n3\n4\n5\n6\n7\n")
+	if m.TotalLineCount() != 7 || m.YOffset != 2 || !m.Following() {
+		t.Fatalf("expected 7 lines pinned to the bottom, got %d lines at offset %d", m.TotalLineCount(), m.YOffset)
+	}
+
+	m.AppendLines([]string{"8", "9"})
+	if m.YOffset != 4 {
+		t.Fatalf("expected to follow appended lines to offset 4, got %d", m.YOffset)
+	}
+
+	// 向上滚动会暂停跟随
+	m.ScrollUp(1)
+	m.AppendLines([]string{"10"})
+	if m.YOffset != 3 || m.Following() {
+		t.Fatalf("expected to stay at offset 3 after scrolling up, got %d", m.YOffset)
+	}
+
+	// 滚动回底部后恢复跟随
+	m.GotoBottom()
+	m.SetContent(strings.Repeat("x\n", 20))
+	if !m.AtBottom() || m.YOffset != 16 {
+		t.Fatalf("expected SetContent to keep the view at the bottom, got offset %d", m.YOffset)
+	}
+
+	m.Follow = false
+	m.AppendLines([]string{"y"})
+	if m.AtBottom() {
+		t.Fatal("expected the view not to follow when Follow is disabled")
+	}
+}
+
+func TestCutLineCache(t *testing.T) {
+	style := lipgloss.NewStyle().Bold(true)
+	lines := []string{
+		style.Render("hello") + " world " + style.Render("你好世界"),
+		"plain",
+		"",
+	}
+	m := New(6, 3)
+	m.SetHorizontalStep(1)
+	m.SetContent(strings.Join(lines, "\n"))
+
+	for x := 0; x < 20; x++ {
+		m.SetXOffset(x)
+		for i, got := range m.visibleLines() {
+			want := ansi.Cut(lines[i], m.xOffset, m.xOffset+6)
+			if ansi.Strip(got) != ansi.Strip(want) {
+				t.Fatalf("offset %d, line %d: expected %q, got %q", m.xOffset, i, ansi.Strip(want), ansi.Strip(got))
+			}
+		}
+	}
+	if m.cache.segments[0] == nil || m.cache.widths[0] != 20 {
+		t.Fatalf("expected the first line to be measured and split, got width %d", m.cache.widths[0])
+	}
+
+	// 追加的行被测量，已有行的缓存保留
+	m.AppendLines([]string{strings.Repeat("x", 30)})
+	if m.longestLineWidth != 30 || m.cache.segments[0] == nil {
+		t.Fatalf("expected appended line to be measured, got longest width %d", m.longestLineWidth)
+	}
+
+	// 替换内容使缓存失效
+	m.SetContent("short")
+	if len(m.cache.widths) != 1 || m.longestLineWidth != 5 {
+		t.Fatal("expected the cache to be replaced on SetContent")
+	}
+}
+
+func TestLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	m := New(10, 4)
+	m.ShowLineNumbers = true
+	m.LineNumberStyle = lipgloss.NewStyle()
+	m.SetContent(strings.Join(lines, "\n"))
+
+	m.GotoLine(10)
+	if m.YOffset != 6 {
+		t.Fatalf("expected line 10 at the bottom (offset 6), got offset %d", m.YOffset)
+	}
+	m.GotoLine(8)
+	if m.YOffset != 6 {
+		t.Fatalf("expected no scroll for a visible line, got offset %d", m.YOffset)
+	}
+	m.GotoLine(3)
+	if m.YOffset != 2 {
+		t.Fatalf("expected line 3 at the top (offset 2), got offset %d", m.YOffset)
+	}
+	m.GotoLineCentered(12)
+	if m.YOffset != 9 {
+		t.Fatalf("expected line 12 centered (offset 9), got offset %d", m.YOffset)
+	}
+
+	got := strings.Split(m.View(), "\n")
+	want := []string{"10 line 10", "11 line 11", "12 line 12", "13 line 13"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestEdges(t *testing.T) {
+	t.Parallel()
+
+	m := New(9, 3)
+	m.BottomIndicator = "END"
+	m.TopIndicator = "TOP"
+	m.IndicatorDuration = time.Millisecond
+	m.SetContent("1\n2\n3\n4")
+
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	up := tea.KeyMsg{Type: tea.KeyUp}
+
+	// 滚动到底部时发送 EdgeMsg
+	m, cmd := m.Update(down)
+	if cmd == nil {
+		t.Fatal("expected an EdgeMsg when reaching the bottom")
+	}
+	if msg, ok := cmd().(EdgeMsg); !ok || msg.ID != m.ID() || msg.AtTop || !msg.AtBottom {
+		t.Fatalf("expected EdgeMsg{AtBottom: true}, got %#v", cmd())
+	}
+
+	// 越过底部继续滚动时显示底部指示器，直到它过期
+	m, cmd = m.Update(down)
+	if got := strings.Split(m.View(), "\n")[2]; got != "   END   " {
+		t.Fatalf("expected the bottom indicator on the last line, got %q", got)
+	}
+	m, _ = m.Update(cmd())
+	if got := strings.Split(m.View(), "\n")[2]; got != "4        " {
+		t.Fatalf("expected the indicator to expire, got %q", got)
+	}
+
+	// 滚动离开底部时发送 EdgeMsg，越过顶部时显示顶部指示器
+	m, cmd = m.Update(up)
+	if msg, ok := cmd().(EdgeMsg); !ok || !msg.AtTop || msg.AtBottom {
+		t.Fatalf("expected EdgeMsg{AtTop: true}, got %#v", cmd())
+	}
+	m.IndicatorDuration = 0
+	m, _ = m.Update(up)
+	if got := strings.Split(m.View(), "\n")[0]; got != "   TOP   " {
+		t.Fatalf("expected the top indicator on the first line, got %q", got)
+	}
+	m, _ = m.Update(down)
+	if got := strings.Split(m.View(), "\n")[0]; got != "2        " {
+		t.Fatalf("expected scrolling to hide the indicator, got %q", got)
+	}
+
+	// 指示器在行号栏和滚动条之间的内容区域中居中
+	m.Width = 10
+	m.ShowLineNumbers = true
+	m.ShowScrollbar = true
+	m, _ = m.Update(up)
+	m, _ = m.Update(up)
+	if got := strings.Split(m.View(), "\n")[0]; got != "    TOP  █" {
+		t.Fatalf("expected the indicator centered in the content area, got %q", got)
+	}
+}
+
+// TestEdgesObserved 测试判断滚动方向不会让按键观察者多记录一次按键。
+// 观察者是全局的，因此这个测试不与其他测试并行运行。
+func TestEdgesObserved(t *testing.T) {
+	m := New(9, 3)
+	m.SetContent("1\n2\n3\n4")
+
+	var got []string
+	prev := key.SetObserver(func(k string, _ key.Binding) {
+		got = append(got, k)
+	})
+	defer key.SetObserver(prev)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if len(got) != 1 || got[0] != "down" {
+		t.Fatalf("expected a single observed key press, got %v", got)
+	}
+}
+
+func TestSelection(t *testing.T) {
+	m := New(10, 3)
+	m.SelectionEnabled = true
+	m.SelectionStyle = lipgloss.NewStyle()
+	m.XPosition, m.YPosition = 2, 1
+	m.SetContent("first line\nsecond line\nthird line\nfourth")
+	m.SetYOffset(1)
+
+	mouse := func(action tea.MouseAction, x, y int) {
+		m, _ = m.Update(tea.MouseMsg{X: x, Y: y, Action: action, Button: tea.MouseButtonLeft})
+	}
+
+	// 从 "second" 的 "c" 拖动到 "third" 的 "i"。
+	mouse(tea.MouseActionPress, 4, 1)
+	if m.HasSelection() {
+		t.Fatal("expected a press alone not to select")
+	}
+	mouse(tea.MouseActionMotion, 5, 2)
+	mouse(tea.MouseActionRelease, 5, 2)
+	if got := m.SelectedText(); got != "cond line\nthir" {
+		t.Fatalf("expected selected text, got %q", got)
+	}
+
+	// 向上拖动时起点和终点交换。
+	mouse(tea.MouseActionPress, 3, 2)
+	mouse(tea.MouseActionMotion, 9, 1)
+	if got := m.SelectedText(); got != "line\nth" {
+		t.Fatalf("expected reversed selection, got %q", got)
+	}
+	if got := ansi.Strip(m.View()); got != "second lin\nthird line\nfourth    " {
+		t.Fatalf("expected the view text to be unchanged, got %q", got)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+
+	// 单击取消选中。
+	mouse(tea.MouseActionPress, 3, 2)
+	mouse(tea.MouseActionRelease, 3, 2)
+	if m.HasSelection() || m.SelectedText() != "" {
+		t.Fatal("expected a click to clear the selection")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}); cmd != nil {
+		t.Fatal("expected no copy command without a selection")
+	}
+}
+
+func TestSelectionStyle(t *testing.T) {
+	m := New(20, 2)
+	m.SetContent("abcdef\nghijkl")
+	m.selStart, m.selEnd, m.selected = position{0, 4}, position{1, 1}, true
+	m.SelectionStyle = lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+
+	lines := m.withSelection(m.visibleLines(), 0)
+	if lines[0] != "abcd[ef]" || lines[1] != "[gh]ijkl" {
+		t.Fatalf("expected the selected ranges to be styled, got %q", lines)
+	}
+}
+
+func TestAnchors(t *testing.T) {
+	m := New(10, 3)
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+	m.SetContent(strings.Join(lines, "\n"))
+	m.SetAnchors(map[string]int{"intro": 0, "usage": 5, "faq": 18})
+
+	if !m.GotoAnchor("usage") || m.YOffset != 5 || m.CurrentAnchor() != "usage" {
+		t.Fatalf("expected to jump to usage, got offset %d", m.YOffset)
+	}
+	if m.GotoAnchor("missing") {
+		t.Fatal("expected unknown anchor to fail")
+	}
+
+	// 跳转到内容末尾附近的锚点时，锚点不在顶部，但下一次仍从它继续。
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	if m.CurrentAnchor() != "faq" || m.YOffset != 17 {
+		t.Fatalf("expected to jump to faq, got %q at %d", m.CurrentAnchor(), m.YOffset)
+	}
+	if m.NextAnchor() {
+		t.Fatal("expected no anchor after faq")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")})
+	if m.CurrentAnchor() != "usage" {
+		t.Fatalf("expected to jump back to usage, got %q", m.CurrentAnchor())
+	}
+	m.PrevAnchor()
+	if m.CurrentAnchor() != "intro" || m.YOffset != 0 {
+		t.Fatalf("expected to jump to intro, got %q at %d", m.CurrentAnchor(), m.YOffset)
+	}
+
+	// 锚点跳转记录在跳转列表中。
+	m.JumpBack()
+	if m.YOffset != 5 {
+		t.Fatalf("expected to jump back to usage, got %d", m.YOffset)
+	}
+
+	m.HighlightAnchor = true
+	m.AnchorStyle = lipgloss.NewStyle().Transform(strings.ToUpper)
+	m.GotoAnchor("usage")
+	if got := strings.Split(m.View(), "\n")[0]; !strings.HasPrefix(got, "LINE 5") {
+		t.Fatalf("expected the anchor line to be highlighted, got %q", got)
+	}
+}
+
+func TestLineProvider(t *testing.T) {
+	const count = 1_000_000
+	var fetched int
+	provider := func(start, end int) []string {
+		fetched += end - start
+		lines := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			lines = append(lines, fmt.Sprintf("line %d", i))
+		}
+		return lines
+	}
+
+	m := New(12, 3)
+	m.SetHorizontalStep(2)
+	m.SetLineProvider(count, provider)
+	if m.TotalLineCount() != count {
+		t.Fatalf("expected %d lines, got %d", count, m.TotalLineCount())
+	}
+
+	m.GotoBottom()
+	if got := strings.Split(m.View(), "\n")[2]; strings.TrimSpace(got) != "line 999999" {
+		t.Fatalf("expected the last line at the bottom, got %q", got)
+	}
+	if fetched > 10 {
+		t.Fatalf("expected only the visible lines to be fetched, got %d", fetched)
+	}
+
+	// 水平滚动的范围由取得的行惰性决定，也可以由调用者提供
+	m.SetXOffset(100)
+	if m.xOffset != 0 {
+		t.Fatalf("expected no horizontal scrolling for short lines, got %d", m.xOffset)
+	}
+	m.SetLongestLineWidth(40)
+	m.SetXOffset(100)
+	if m.xOffset != 28 {
+		t.Fatalf("expected the supplied width to bound scrolling, got %d", m.xOffset)
+	}
+	m.SetXOffset(0)
+
+	// 跟随时增加行数保持在底部
+	m.Follow = true
+	m.SetLineCount(count + 5)
+	if !m.AtBottom() || m.YOffset != count+5-3 {
+		t.Fatalf("expected to follow new lines, got offset %d", m.YOffset)
+	}
+	m.AppendLines([]string{"ignored"})
+	if m.TotalLineCount() != count+5 {
+		t.Fatalf("expected AppendLines to be ignored in provider mode, got %d lines", m.TotalLineCount())
+	}
+
+	m.SetContent("a\nb")
+	if m.provider != nil || m.TotalLineCount() != 2 {
+		t.Fatal("expected SetContent to leave provider mode")
+	}
+}
+
+func TestScrollbar(t *testing.T) {
+	m := New(6, 4)
+	m.ShowScrollbar = true
+	m.SetContent("0\n1\n2\n3\n4\n5\n6\n7")
+
+	bar := func() string {
+		var b strings.Builder
+		for _, line := range strings.Split(m.View(), "\n") {
+			r := []rune(line)
+			b.WriteRune(r[len(r)-1])
+		}
+		return b.String()
+	}
+	if got, want := bar(), "██││"; got != want {
+		t.Fatalf("expected thumb at the top %q, got %q", want, got)
+	}
+	m.GotoBottom()
+	if got, want := bar(), "││██"; got != want {
+		t.Fatalf("expected thumb at the bottom %q, got %q", want, got)
+	}
+
+	// 滑块不小于 MinThumbSize
+	m.MinThumbSize = 3
+	if got, want := bar(), "│███"; got != want {
+		t.Fatalf("expected a larger thumb %q, got %q", want, got)
+	}
+
+	// 水平滚动条占用最后一行，右下角留空
+	m = New(6, 3)
+	m.ShowScrollbar = true
+	m.ShowHorizontalScrollbar = true
+	m.SetContent("0123456789\nb")
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if got, want := lines[2], "███── "; got != want {
+		t.Fatalf("expected horizontal scrollbar %q, got %q", want, got)
+	}
+	if got, want := lines[0], "01234█"; got != want {
+		t.Fatalf("expected content next to the scrollbar %q, got %q", want, got)
+	}
+	m.SetXOffset(100)
+	if m.xOffset != 5 || strings.Split(m.View(), "\n")[2] != "──███ " {
+		t.Fatalf("expected to scroll to the right end, got offset %d", m.xOffset)
+	}
+}